@@ -0,0 +1,38 @@
+package access
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/task"
+)
+
+// Allow checks action on the workspace for the user of ctx,
+// core.ErrForbidden is returned when the policy does not allow it
+func Allow(ctx context.Context, au Usecase, action string) error {
+	p, err := au.Policy(ctx)
+	if err != nil {
+		return err
+	}
+	if !p.Can(action, "", 0) {
+		return core.ErrForbidden
+	}
+	return nil
+}
+
+// AllowTask checks action on the task of taskID for the user of ctx,
+// core.ErrRecordNotFound is returned when there is no such task
+func AllowTask(ctx context.Context, au Usecase, tr task.Repository, action string, taskID int) error {
+	p, err := au.Policy(ctx)
+	if err != nil {
+		return err
+	}
+	t, err := tr.Get(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if !p.Can(action, t.Project, t.ID) {
+		return core.ErrForbidden
+	}
+	return nil
+}
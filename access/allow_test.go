@@ -0,0 +1,70 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	taskmocks "github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+// grants is the Usecase of a fixed policy
+type grants struct {
+	Usecase
+	grants []*models.Grant
+	err    error
+}
+
+func (g grants) Policy(context.Context) (*Policy, error) {
+	return NewPolicy(g.grants), g.err
+}
+
+func TestAllow(t *testing.T) {
+	tests := []struct {
+		name    string
+		au      Usecase
+		action  string
+		wantErr error
+	}{
+		{"Normal Case1: member writes", grants{grants: []*models.Grant{{Role: models.RoleMember}}}, ActionWrite, nil},
+		{"viewer can not write", grants{grants: []*models.Grant{{Role: models.RoleViewer}}}, ActionWrite, core.ErrForbidden},
+		{"project grant is not enough", grants{grants: []*models.Grant{{Role: models.RoleAdmin, Project: "web"}}}, ActionRead, core.ErrForbidden},
+		{"policy error", grants{err: errors.New("db error")}, ActionRead, errors.New("db error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Allow(context.Background(), tt.au, tt.action)
+			if err != tt.wantErr && (err == nil || tt.wantErr == nil || err.Error() != tt.wantErr.Error()) {
+				t.Errorf("Allow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAllowTask(t *testing.T) {
+	tr := &taskmocks.MockRepository{Tasks: []*models.Task{{ID: 1, Project: "web"}, {ID: 2, Project: "ops"}}}
+	web := grants{grants: []*models.Grant{{Role: models.RoleMember, Project: "web"}}}
+	tests := []struct {
+		name    string
+		au      Usecase
+		action  string
+		taskID  int
+		wantErr error
+	}{
+		{"Normal Case1: member writes a task of the project", web, ActionWrite, 1, nil},
+		{"task of another project", web, ActionRead, 2, core.ErrForbidden},
+		{"member can not delete", web, ActionDelete, 1, core.ErrForbidden},
+		{"no such task", web, ActionRead, 3, core.ErrRecordNotFound},
+		{"policy error", grants{err: errors.New("db error")}, ActionRead, 1, errors.New("db error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AllowTask(context.Background(), tt.au, tr, tt.action, tt.taskID)
+			if err != tt.wantErr && (err == nil || tt.wantErr == nil || err.Error() != tt.wantErr.Error()) {
+				t.Errorf("AllowTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
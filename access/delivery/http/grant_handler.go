@@ -0,0 +1,113 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+//GrantHandler represents http handler for role grants
+type GrantHandler struct {
+	AccessUsecase access.Usecase
+}
+
+// NewGrantHandler will initialize the grants/ resources endpoint
+func NewGrantHandler(au access.Usecase) nethttp.Handler {
+	r := chi.NewMux()
+	grantHandler := &GrantHandler{
+		AccessUsecase: au,
+	}
+	r.Get("/", grantHandler.List)
+	r.Post("/", grantHandler.Grant)
+	r.Delete("/{id:[0-9]+}", grantHandler.Revoke)
+	return r
+}
+
+func writeJSON(w nethttp.ResponseWriter, status int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	res, _ := json.Marshal(body)
+	w.Write(res)
+}
+
+func writeForbidden(w nethttp.ResponseWriter) {
+	w.WriteHeader(nethttp.StatusForbidden)
+	w.Write([]byte("forbidden"))
+}
+
+// writeError answers the errors shared by the grant endpoints
+func writeError(w nethttp.ResponseWriter, err error) {
+	switch err {
+	case core.ErrForbidden:
+		writeForbidden(w)
+	case core.ErrRecordNotFound:
+		w.WriteHeader(nethttp.StatusNotFound)
+		w.Write([]byte("not found"))
+	case core.ErrNotMember:
+		w.WriteHeader(nethttp.StatusUnprocessableEntity)
+		w.Write([]byte("user is not a member of the workspace"))
+	case core.ErrLastAdmin:
+		w.WriteHeader(nethttp.StatusConflict)
+		w.Write([]byte("workspace needs an admin"))
+	default:
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+	}
+}
+
+//List handler returns the grants of the workspace
+func (h *GrantHandler) List(w nethttp.ResponseWriter, r *nethttp.Request) {
+	grants, err := h.AccessUsecase.List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"grants":  grants,
+	})
+}
+
+//Grant handler gives a role on the workspace, a project or a task,
+//the role a user already has on the same one is replaced
+func (h *GrantHandler) Grant(w nethttp.ResponseWriter, r *nethttp.Request) {
+	g := &models.Grant{}
+	if err := json.NewDecoder(r.Body).Decode(g); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return
+	}
+	validate := validator.New()
+	if err := validate.Struct(g); err != nil || g.Project != "" && g.TaskID != 0 {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return
+	}
+	if err := h.AccessUsecase.Grant(r.Context(), g); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusCreated, map[string]interface{}{
+		"message": "success",
+		"grant":   g,
+	})
+}
+
+//Revoke handler removes a grant
+func (h *GrantHandler) Revoke(w nethttp.ResponseWriter, r *nethttp.Request) {
+	id, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	if err := h.AccessUsecase.Revoke(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	w.Write([]byte("success"))
+}
@@ -0,0 +1,151 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func TestNewGrantHandler(t *testing.T) {
+	h := NewGrantHandler(&mocks.MockUsecase{Grants: []*models.Grant{{ID: 1, UserID: 1, Role: models.RoleAdmin}}})
+	tests := []struct {
+		method     string
+		url        string
+		statusCode int
+	}{
+		{"GET", "/", 200},
+		{"DELETE", "/1", 200},
+		{"DELETE", "/abc", 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, nil))
+			if rec.Code != tt.statusCode {
+				t.Errorf("got statuscode %d but expected %d", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestGrantHandler_Grant(t *testing.T) {
+	body := `{"id_user":2,"role":"member","id_task":9}`
+	tests := []struct {
+		name       string
+		usecase    access.Usecase
+		body       string
+		statusCode int
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{},
+		body:       body,
+		statusCode: 201,
+	}, {
+		name:       "not an admin",
+		usecase:    &mocks.MockUsecase{Error: core.ErrForbidden},
+		body:       body,
+		statusCode: 403,
+	}, {
+		name:       "user out of the workspace",
+		usecase:    &mocks.MockUsecase{Error: core.ErrNotMember},
+		body:       body,
+		statusCode: 422,
+	}, {
+		name:       "last admin",
+		usecase:    &mocks.MockUsecase{Error: core.ErrLastAdmin},
+		body:       `{"id_user":1,"role":"viewer"}`,
+		statusCode: 409,
+	}, {
+		name:       "unknown task",
+		usecase:    &mocks.MockUsecase{Error: core.ErrRecordNotFound},
+		body:       body,
+		statusCode: 404,
+	}, {
+		name:       "unknown role",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{"id_user":2,"role":"owner"}`,
+		statusCode: 400,
+	}, {
+		name:       "project and task",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{"id_user":2,"role":"member","project":"web","id_task":9}`,
+		statusCode: 400,
+	}, {
+		name:       "body parse error",
+		usecase:    &mocks.MockUsecase{},
+		body:       `[]`,
+		statusCode: 400,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("Usecase.Error()")},
+		body:       body,
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h := &GrantHandler{AccessUsecase: tt.usecase}
+			h.Grant(rec, httptest.NewRequest("POST", "/", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if rec.Code == 201 {
+				res := struct{ Grant *models.Grant }{}
+				if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil || res.Grant == nil || res.Grant.ID == 0 {
+					t.Errorf("expected the stored grant, got %s", rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestGrantHandler_List(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    access.Usecase
+		statusCode int
+	}{
+		{"Success case", &mocks.MockUsecase{}, 200},
+		{"not an admin", &mocks.MockUsecase{Error: core.ErrForbidden}, 403},
+		{"usecase error", &mocks.MockUsecase{Error: errors.New("Usecase.Error()")}, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h := &GrantHandler{AccessUsecase: tt.usecase}
+			h.List(rec, httptest.NewRequest("GET", "/", nil))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestGrantHandler_Revoke(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    access.Usecase
+		statusCode int
+	}{
+		{"Success case", &mocks.MockUsecase{}, 200},
+		{"not an admin", &mocks.MockUsecase{Error: core.ErrForbidden}, 403},
+		{"last admin", &mocks.MockUsecase{Error: core.ErrLastAdmin}, 409},
+		{"unknown grant", &mocks.MockUsecase{Error: core.ErrRecordNotFound}, 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewGrantHandler(tt.usecase).ServeHTTP(rec, httptest.NewRequest("DELETE", "/1", nil))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	nethttp "net/http"
+	"strconv"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/sirupsen/logrus"
+)
+
+// WorkspaceHeader selects the workspace a request acts on, the workspace of the user by default
+const WorkspaceHeader = "X-Workspace-ID"
+
+// NewWorkspaceMiddleware will create a middleware scoping the request context to the
+// workspace of the X-Workspace-ID header, it answers 403 unless the user of the context
+// has a role on that workspace. Requests without the header keep their workspace and
+// requests authenticated by an API key can not leave the workspace of the key
+func NewWorkspaceMiddleware(au access.Usecase) func(nethttp.Handler) nethttp.Handler {
+	return func(next nethttp.Handler) nethttp.Handler {
+		return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			header := r.Header.Get(WorkspaceHeader)
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			workspace, err := strconv.Atoi(header)
+			if err != nil || workspace <= 0 {
+				w.WriteHeader(nethttp.StatusBadRequest)
+				w.Write([]byte("invalid workspace"))
+				return
+			}
+			if u, ok := core.UserFromContext(r.Context()); ok && u.Scope != "" && u.WorkspaceID != workspace {
+				// an API key only opens the workspace it was created for
+				writeForbidden(w)
+				return
+			}
+			ctx := core.WithTenant(r.Context(), workspace)
+			p, err := au.Policy(ctx)
+			if err != nil && err != core.ErrForbidden {
+				logrus.Error(err)
+				w.WriteHeader(nethttp.StatusInternalServerError)
+				w.Write([]byte("internal server error"))
+				return
+			}
+			if err == core.ErrForbidden || !p.Member() {
+				writeForbidden(w)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
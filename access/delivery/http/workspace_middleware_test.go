@@ -0,0 +1,99 @@
+package http
+
+import (
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func TestNewWorkspaceMiddleware(t *testing.T) {
+	member := &mocks.MockUsecase{Grants: []*models.Grant{{Role: models.RoleViewer}}}
+	tests := []struct {
+		name       string
+		usecase    access.Usecase
+		header     string
+		user       *models.User
+		statusCode int
+		tenant     int
+	}{{
+		name:       "Success case",
+		usecase:    member,
+		header:     "9",
+		statusCode: 200,
+		tenant:     9,
+	}, {
+		name:       "no header keeps the workspace of the user",
+		usecase:    &mocks.MockUsecase{},
+		statusCode: 200,
+		tenant:     4,
+	}, {
+		name:       "only a task shared",
+		usecase:    &mocks.MockUsecase{Grants: []*models.Grant{{Role: models.RoleAdmin, TaskID: 3}}},
+		header:     "9",
+		statusCode: 403,
+	}, {
+		name:       "not a member",
+		usecase:    &mocks.MockUsecase{},
+		header:     "9",
+		statusCode: 403,
+	}, {
+		name:       "no user",
+		usecase:    &mocks.MockUsecase{Error: core.ErrForbidden},
+		header:     "9",
+		statusCode: 403,
+	}, {
+		name:       "API key on its workspace",
+		usecase:    member,
+		header:     "9",
+		user:       &models.User{ID: 1, WorkspaceID: 9, Scope: models.ScopeReadOnly},
+		statusCode: 200,
+		tenant:     9,
+	}, {
+		name:       "API key on another workspace",
+		usecase:    member,
+		header:     "9",
+		user:       &models.User{ID: 1, WorkspaceID: 4, Scope: models.ScopeAdmin},
+		statusCode: 403,
+	}, {
+		name:       "invalid workspace",
+		usecase:    member,
+		header:     "nine",
+		statusCode: 400,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("db error")},
+		header:     "9",
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant := 0
+			h := NewWorkspaceMiddleware(tt.usecase)(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+				tenant, _ = core.TenantFromContext(r.Context())
+			}))
+			req := httptest.NewRequest("GET", "/", nil)
+			ctx := core.WithTenant(req.Context(), 4)
+			if tt.user != nil {
+				ctx = core.WithUser(ctx, tt.user)
+			}
+			req = req.WithContext(ctx)
+			if tt.header != "" {
+				req.Header.Set(WorkspaceHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if tenant != tt.tenant {
+				t.Errorf("tenant = %d, want %d", tenant, tt.tenant)
+			}
+		})
+	}
+}
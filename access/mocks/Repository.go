@@ -0,0 +1,78 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockRepository implements inerface access.Repository
+type MockRepository struct {
+	Error  error
+	Grants []*models.Grant
+}
+
+func (m *MockRepository) find(id int) int {
+	for i, g := range m.Grants {
+		if g.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+//Grant replaces the grant of Grants with the same user and scope or appends it
+func (m *MockRepository) Grant(ctx context.Context, g *models.Grant) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for i, old := range m.Grants {
+		if old.UserID == g.UserID && old.Project == g.Project && old.TaskID == g.TaskID {
+			g.ID = old.ID
+			m.Grants[i] = g
+			return nil
+		}
+	}
+	g.ID = len(m.Grants) + 1
+	m.Grants = append(m.Grants, g)
+	return nil
+}
+
+//Get returns the grant of Grants with the id
+func (m *MockRepository) Get(ctx context.Context, id int) (*models.Grant, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if i := m.find(id); i >= 0 {
+		return m.Grants[i], nil
+	}
+	return nil, core.ErrRecordNotFound
+}
+
+//List returns the grants of Grants of the user, all of them when userID is 0
+func (m *MockRepository) List(ctx context.Context, userID int) ([]*models.Grant, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	grants := []*models.Grant{}
+	for _, g := range m.Grants {
+		if userID == 0 || g.UserID == userID {
+			grants = append(grants, g)
+		}
+	}
+	return grants, nil
+}
+
+//Revoke removes the grant of Grants with the id
+func (m *MockRepository) Revoke(ctx context.Context, id int) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	i := m.find(id)
+	if i < 0 {
+		return core.ErrRecordNotFound
+	}
+	m.Grants = append(m.Grants[:i], m.Grants[i+1:]...)
+	return nil
+}
@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+// Workspace and UserID are the workspace of UserContext and the user RoleUsecase grants a role to
+const (
+	Workspace = 7
+	UserID    = 3
+)
+
+//UserContext returns a context acting for the user id in Workspace
+func UserContext(id int) context.Context {
+	return core.WithUser(core.WithTenant(context.Background(), Workspace), &models.User{ID: id})
+}
+
+//GrantedContext returns the context of UserID
+func GrantedContext() context.Context {
+	return UserContext(UserID)
+}
+
+//RoleUsecase returns a MockUsecase giving role on the workspace to UserID
+func RoleUsecase(role string) *MockUsecase {
+	return &MockUsecase{Grants: []*models.Grant{{UserID: UserID, Role: role}}}
+}
+
+//MockUsecase implements inerface access.Usecase
+type MockUsecase struct {
+	Error  error
+	Grants []*models.Grant
+}
+
+//Policy returns the policy of Grants
+func (m *MockUsecase) Policy(ctx context.Context) (*access.Policy, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	return access.NewPolicy(m.Grants), nil
+}
+
+//List returns Grants
+func (m *MockUsecase) List(ctx context.Context) ([]*models.Grant, error) {
+	return m.Grants, m.Error
+}
+
+//Grant appends the grant to Grants
+func (m *MockUsecase) Grant(ctx context.Context, g *models.Grant) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	g.ID = len(m.Grants) + 1
+	m.Grants = append(m.Grants, g)
+	return nil
+}
+
+//Revoke grant
+func (m *MockUsecase) Revoke(ctx context.Context, id int) error {
+	return m.Error
+}
@@ -0,0 +1,82 @@
+package access
+
+import "github.com/pratheeshm/todo-golang/models"
+
+// Actions checked by a Policy
+const (
+	// ActionRead reads tasks and their history
+	ActionRead = "read"
+	// ActionWrite adds and edits tasks
+	ActionWrite = "write"
+	// ActionDelete deletes tasks
+	ActionDelete = "delete"
+	// ActionManage manages the grants of the workspace
+	ActionManage = "manage"
+)
+
+// required is the weakest role allowed to do each action
+var required = map[string]string{
+	ActionRead:   models.RoleViewer,
+	ActionWrite:  models.RoleMember,
+	ActionDelete: models.RoleAdmin,
+	ActionManage: models.RoleAdmin,
+}
+
+// ranks orders the roles, a missing role ranks 0
+var ranks = map[string]int{
+	models.RoleViewer: 1,
+	models.RoleMember: 2,
+	models.RoleAdmin:  3,
+}
+
+//Policy decides what a user can do from the grants it holds in a workspace
+type Policy struct {
+	grants []*models.Grant
+	// limit caps the roles when limited is set
+	limit   string
+	limited bool
+}
+
+// NewPolicy will create a Policy of the grants of a user
+func NewPolicy(grants []*models.Grant) *Policy {
+	return &Policy{grants: grants}
+}
+
+// Role returns the strongest role the grants give on the task of project,
+// taskID 0 stands for a task not created yet. An empty project and a taskID 0
+// ask for the role on the workspace. The empty string means no role at all
+func (p *Policy) Role(project string, taskID int) string {
+	role := ""
+	for _, g := range p.grants {
+		applies := g.Workspace() ||
+			g.TaskID == 0 && g.Project == project ||
+			g.TaskID != 0 && g.TaskID == taskID
+		if applies && ranks[g.Role] > ranks[role] {
+			role = g.Role
+		}
+	}
+	if p.limited && ranks[p.limit] < ranks[role] {
+		role = p.limit
+	}
+	return role
+}
+
+// Limit returns a copy of the policy whose roles are capped at role, an empty
+// or unknown role allows nothing. It applies the scope of API keys
+func (p *Policy) Limit(role string) *Policy {
+	if p.limited && ranks[p.limit] < ranks[role] {
+		role = p.limit
+	}
+	return &Policy{grants: p.grants, limit: role, limited: true}
+}
+
+// Member tells whether the user has a role on the whole workspace
+func (p *Policy) Member() bool {
+	return p.Role("", 0) != ""
+}
+
+// Can tells whether the user can do action on the task of project
+func (p *Policy) Can(action, project string, taskID int) bool {
+	need, ok := required[action]
+	return ok && ranks[p.Role(project, taskID)] >= ranks[need]
+}
@@ -0,0 +1,98 @@
+package access
+
+import (
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func TestPolicy_Role(t *testing.T) {
+	p := NewPolicy([]*models.Grant{
+		{Role: models.RoleViewer},
+		{Role: models.RoleMember, Project: "web"},
+		{Role: models.RoleAdmin, TaskID: 9},
+		{Role: models.RoleViewer, Project: "api"},
+	})
+	tests := []struct {
+		name    string
+		project string
+		taskID  int
+		want    string
+	}{
+		{"Normal Case1: workspace role", "", 0, models.RoleViewer},
+		{"project grant adds to the workspace role", "web", 0, models.RoleMember},
+		{"weaker project grant keeps the workspace role", "api", 0, models.RoleViewer},
+		{"task grant", "", 9, models.RoleAdmin},
+		{"task grant beats the project grant", "web", 9, models.RoleAdmin},
+		{"other task of the project", "web", 8, models.RoleMember},
+		{"other project", "mobile", 8, models.RoleViewer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Role(tt.project, tt.taskID); got != tt.want {
+				t.Errorf("Policy.Role() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicy_Can(t *testing.T) {
+	tests := []struct {
+		name   string
+		grants []*models.Grant
+		action string
+		want   bool
+	}{
+		{"Normal Case1: viewer reads", []*models.Grant{{Role: models.RoleViewer}}, ActionRead, true},
+		{"viewer can not write", []*models.Grant{{Role: models.RoleViewer}}, ActionWrite, false},
+		{"member writes", []*models.Grant{{Role: models.RoleMember}}, ActionWrite, true},
+		{"member can not delete", []*models.Grant{{Role: models.RoleMember}}, ActionDelete, false},
+		{"admin deletes", []*models.Grant{{Role: models.RoleAdmin}}, ActionDelete, true},
+		{"admin manages", []*models.Grant{{Role: models.RoleAdmin}}, ActionManage, true},
+		{"member can not manage", []*models.Grant{{Role: models.RoleMember}}, ActionManage, false},
+		{"no grant", nil, ActionRead, false},
+		{"unknown action", []*models.Grant{{Role: models.RoleAdmin}}, "launch", false},
+		{"unknown role", []*models.Grant{{Role: "owner"}}, ActionRead, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPolicy(tt.grants).Can(tt.action, "", 0); got != tt.want {
+				t.Errorf("Policy.Can() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicy_Member(t *testing.T) {
+	shared := NewPolicy([]*models.Grant{{Role: models.RoleAdmin, Project: "web"}, {Role: models.RoleAdmin, TaskID: 9}})
+	if shared.Member() {
+		t.Errorf("project and task grants should not make a member")
+	}
+	if !NewPolicy([]*models.Grant{{Role: models.RoleViewer}}).Member() {
+		t.Errorf("a workspace grant should make a member")
+	}
+}
+
+func TestPolicy_Limit(t *testing.T) {
+	admin := NewPolicy([]*models.Grant{{Role: models.RoleAdmin}})
+	tests := []struct {
+		name   string
+		policy *Policy
+		want   string
+	}{
+		{"Normal Case1: capped at viewer", admin.Limit(models.RoleViewer), models.RoleViewer},
+		{"cap above the grants", NewPolicy([]*models.Grant{{Role: models.RoleMember}}).Limit(models.RoleAdmin), models.RoleMember},
+		{"limits add up", admin.Limit(models.RoleMember).Limit(models.RoleAdmin), models.RoleMember},
+		{"unknown role allows nothing", admin.Limit(""), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Role("", 0); got != tt.want {
+				t.Errorf("Policy.Role() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if got := admin.Role("", 0); got != models.RoleAdmin {
+		t.Errorf("Limit should not change the policy it is called on, got %q", got)
+	}
+}
@@ -0,0 +1,19 @@
+package access
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Repository represents role grant's interface
+type Repository interface {
+	// Grant stores the grant, replacing the role of the user on the same
+	// workspace, project or task. core.ErrRecordNotFound is returned when
+	// the user or the task do not exist
+	Grant(context.Context, *models.Grant) error
+	Get(context.Context, int) (*models.Grant, error)
+	// List returns the grants of the workspace, only the ones of the user when userID is not 0
+	List(ctx context.Context, userID int) ([]*models.Grant, error)
+	Revoke(context.Context, int) error
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+// foreignKeyViolation is the postgres error code of a reference to a missing row
+const foreignKeyViolation = "23503"
+
+const grantColumns = "id_grant, id_user, role, project, COALESCE(id_task, 0), created_at"
+
+type postgresGrantRepository struct {
+	*sql.DB
+}
+
+// NewPostgresGrantRepository will create an object that represent the access.Repository interface
+func NewPostgresGrantRepository(db *sql.DB) access.Repository {
+	return &postgresGrantRepository{db}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanGrant(s scanner) (*models.Grant, error) {
+	g := &models.Grant{}
+	err := s.Scan(&g.ID, &g.UserID, &g.Role, &g.Project, &g.TaskID, &g.CreatedAt)
+	return g, err
+}
+
+// Grant inserts nothing when the task is not one of the workspace
+func (p *postgresGrantRepository) Grant(ctx context.Context, g *models.Grant) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	err = transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "INSERT INTO role_grant(id_workspace, id_user, role, project, id_task) SELECT $1, $2, $3, $4, NULLIF($5, 0) WHERE $5 = 0 OR EXISTS (SELECT 1 FROM task WHERE id_task = $5 AND id_workspace = $1 AND NOT deleted) ON CONFLICT (id_workspace, id_user, project, COALESCE(id_task, 0)) DO UPDATE SET role = EXCLUDED.role RETURNING id_grant, created_at",
+		workspace, g.UserID, g.Role, g.Project, g.TaskID).Scan(&g.ID, &g.CreatedAt)
+	if err == sql.ErrNoRows {
+		return core.ErrRecordNotFound
+	}
+	if perr, ok := err.(*pq.Error); ok && perr.Code == foreignKeyViolation {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresGrantRepository) Get(ctx context.Context, id int) (*models.Grant, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "SELECT "+grantColumns+" FROM role_grant WHERE id_grant = $1 AND id_workspace = $2", id, workspace)
+	g, err := scanGrant(row)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (p *postgresGrantRepository) List(ctx context.Context, userID int) ([]*models.Grant, error) {
+	grants := make([]*models.Grant, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return grants, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT "+grantColumns+" FROM role_grant WHERE id_workspace = $1 AND ($2 = 0 OR id_user = $2) ORDER BY id_user, project, id_task NULLS FIRST",
+		workspace, userID)
+	if err != nil {
+		return grants, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		g, err := scanGrant(rows)
+		if err != nil {
+			return []*models.Grant{}, err
+		}
+		grants = append(grants, g)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.Grant{}, err
+	}
+	return grants, nil
+}
+
+func (p *postgresGrantRepository) Revoke(ctx context.Context, id int) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "DELETE FROM role_grant WHERE id_grant = $1 AND id_workspace = $2", id, workspace)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return core.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+var grantRowColumns = []string{"id_grant", "id_user", "role", "project", "id_task", "created_at"}
+
+func Test_postgresGrantRepository_Grant(t *testing.T) {
+	query := "INSERT INTO role_grant(id_workspace, id_user, role, project, id_task) SELECT $1, $2, $3, $4, NULLIF($5, 0) WHERE $5 = 0 OR EXISTS (SELECT 1 FROM task WHERE id_task = $5 AND id_workspace = $1 AND NOT deleted) ON CONFLICT (id_workspace, id_user, project, COALESCE(id_task, 0)) DO UPDATE SET role = EXCLUDED.role RETURNING id_grant, created_at"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		err     error
+		wantID  int
+		wantErr error
+	}{{
+		name:   "Normal Case 1: grant stored",
+		rows:   sqlmock.NewRows([]string{"id_grant", "created_at"}).AddRow(5, time.Time{}),
+		wantID: 5,
+	}, {
+		name:    "task out of the workspace",
+		rows:    sqlmock.NewRows([]string{"id_grant", "created_at"}),
+		wantErr: core.ErrRecordNotFound,
+	}, {
+		name:    "unknown user",
+		err:     &pq.Error{Code: foreignKeyViolation},
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			expect := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant, 3, models.RoleMember, "", 9)
+			if tt.err != nil {
+				expect.WillReturnError(tt.err)
+			} else {
+				expect.WillReturnRows(tt.rows)
+			}
+			g := &models.Grant{UserID: 3, Role: models.RoleMember, TaskID: 9}
+			if err := NewPostgresGrantRepository(db).Grant(coremocks.TenantContext(), g); err != tt.wantErr {
+				t.Fatalf("postgresGrantRepository.Grant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if g.ID != tt.wantID {
+				t.Errorf("postgresGrantRepository.Grant() id = %d, want %d", g.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func Test_postgresGrantRepository_Get(t *testing.T) {
+	query := "SELECT id_grant, id_user, role, project, COALESCE(id_task, 0), created_at FROM role_grant WHERE id_grant = $1 AND id_workspace = $2"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *models.Grant
+		wantErr error
+	}{{
+		name: "Normal Case 1: grant found",
+		rows: sqlmock.NewRows(grantRowColumns).AddRow(1, 3, "viewer", "web", 0, time.Time{}),
+		want: &models.Grant{ID: 1, UserID: 3, Role: models.RoleViewer, Project: "web"},
+	}, {
+		name:    "grant not found",
+		rows:    sqlmock.NewRows(grantRowColumns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant).WillReturnRows(tt.rows)
+			got, err := NewPostgresGrantRepository(db).Get(coremocks.TenantContext(), 1)
+			if err != tt.wantErr {
+				t.Fatalf("postgresGrantRepository.Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postgresGrantRepository.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresGrantRepository_List(t *testing.T) {
+	query := "SELECT id_grant, id_user, role, project, COALESCE(id_task, 0), created_at FROM role_grant WHERE id_workspace = $1 AND ($2 = 0 OR id_user = $2) ORDER BY id_user, project, id_task NULLS FIRST"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbErr   error
+		want    int
+		wantErr bool
+	}{{
+		name: "Normal Case 1: grants of the user",
+		rows: sqlmock.NewRows(grantRowColumns).
+			AddRow(1, 3, "viewer", "", 0, time.Time{}).
+			AddRow(2, 3, "member", "", 9, time.Time{}),
+		want: 2,
+	}, {
+		name:    "db error",
+		dbErr:   errors.New("db error"),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			exp := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant, 3)
+			if tt.dbErr != nil {
+				exp.WillReturnError(tt.dbErr)
+			} else {
+				exp.WillReturnRows(tt.rows)
+			}
+			got, err := NewPostgresGrantRepository(db).List(coremocks.TenantContext(), 3)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("postgresGrantRepository.List() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("postgresGrantRepository.List() = %d grants, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresGrantRepository_Revoke(t *testing.T) {
+	query := "DELETE FROM role_grant WHERE id_grant = $1 AND id_workspace = $2"
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{{
+		name:     "Normal Case 1: grant revoked",
+		affected: 1,
+	}, {
+		name:    "grant not found",
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if err := NewPostgresGrantRepository(db).Revoke(coremocks.TenantContext(), 1); err != tt.wantErr {
+				t.Errorf("postgresGrantRepository.Revoke() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresGrantRepository_withoutTenant(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	r := NewPostgresGrantRepository(db)
+	ctx := context.Background()
+	if err := r.Grant(ctx, &models.Grant{UserID: 3, Role: models.RoleAdmin}); err != core.ErrNoTenant {
+		t.Errorf("Grant() error = %v, want %v", err, core.ErrNoTenant)
+	}
+	if _, err := r.List(ctx, 0); err != core.ErrNoTenant {
+		t.Errorf("List() error = %v, want %v", err, core.ErrNoTenant)
+	}
+}
@@ -0,0 +1,21 @@
+package access
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Usecase represents access control's usecases, they act for the user of the context
+type Usecase interface {
+	// Policy returns the policy of the user in the workspace
+	Policy(context.Context) (*Policy, error)
+	// List returns the grants of the workspace, only admins can
+	List(context.Context) ([]*models.Grant, error)
+	// Grant gives a role, only admins can. Projects and tasks are only
+	// shared with members of the workspace, core.ErrNotMember otherwise
+	Grant(context.Context, *models.Grant) error
+	// Revoke removes a grant, only admins can. The last admin of the
+	// workspace can not be removed, core.ErrLastAdmin is returned
+	Revoke(context.Context, int) error
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+type accessUsecase struct {
+	grantRepo access.Repository
+}
+
+// NewAccessUsecase will create new an accessUsecase object representation of access.Usecase interface
+func NewAccessUsecase(gr access.Repository) access.Usecase {
+	return &accessUsecase{
+		grantRepo: gr,
+	}
+}
+
+func (au *accessUsecase) Policy(ctx context.Context) (*access.Policy, error) {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := au.grantRepo.List(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	p := access.NewPolicy(grants)
+	if u.Scope != "" {
+		p = p.Limit(models.ScopeRole(u.Scope))
+	}
+	return p, nil
+}
+
+// admins returns the ids of the grants making admins of the workspace
+func admins(grants []*models.Grant) map[int]bool {
+	ids := map[int]bool{}
+	for _, g := range grants {
+		if g.Workspace() && g.Role == models.RoleAdmin {
+			ids[g.ID] = true
+		}
+	}
+	return ids
+}
+
+func (au *accessUsecase) List(ctx context.Context) ([]*models.Grant, error) {
+	if err := access.Allow(ctx, au, access.ActionManage); err != nil {
+		return nil, err
+	}
+	return au.grantRepo.List(ctx, 0)
+}
+
+func (au *accessUsecase) Grant(ctx context.Context, g *models.Grant) error {
+	if err := access.Allow(ctx, au, access.ActionManage); err != nil {
+		return err
+	}
+	grants, err := au.grantRepo.List(ctx, g.UserID)
+	if err != nil {
+		return err
+	}
+	if !g.Workspace() {
+		if !access.NewPolicy(grants).Member() {
+			return core.ErrNotMember
+		}
+		return au.grantRepo.Grant(ctx, g)
+	}
+	if g.Role != models.RoleAdmin {
+		// the grant replaces the workspace role of the user, which may be the last admin one
+		for id := range admins(grants) {
+			if err := au.keepAdmin(ctx, id); err != nil {
+				return err
+			}
+		}
+	}
+	return au.grantRepo.Grant(ctx, g)
+}
+
+func (au *accessUsecase) Revoke(ctx context.Context, id int) error {
+	if err := access.Allow(ctx, au, access.ActionManage); err != nil {
+		return err
+	}
+	if err := au.keepAdmin(ctx, id); err != nil {
+		return err
+	}
+	return au.grantRepo.Revoke(ctx, id)
+}
+
+// keepAdmin fails when the grant is the only one making an admin of the workspace
+func (au *accessUsecase) keepAdmin(ctx context.Context, id int) error {
+	grants, err := au.grantRepo.List(ctx, 0)
+	if err != nil {
+		return err
+	}
+	ids := admins(grants)
+	if ids[id] && len(ids) == 1 {
+		return core.ErrLastAdmin
+	}
+	return nil
+}
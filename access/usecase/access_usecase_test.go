@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+// the admin is user 1, user 2 is a viewer
+func grants() []*models.Grant {
+	return []*models.Grant{
+		{ID: 1, UserID: 1, Role: models.RoleAdmin},
+		{ID: 2, UserID: 2, Role: models.RoleViewer},
+	}
+}
+
+func as(userID int) context.Context {
+	return core.WithUser(context.Background(), &models.User{ID: userID})
+}
+
+func TestNewAccessUsecase(t *testing.T) {
+	gr := &mocks.MockRepository{}
+	want := &accessUsecase{grantRepo: gr}
+	if got := NewAccessUsecase(gr); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAccessUsecase() = %v, want %v", got, want)
+	}
+}
+
+func Test_accessUsecase_Policy(t *testing.T) {
+	au := NewAccessUsecase(&mocks.MockRepository{Grants: grants()})
+	p, err := au.Policy(as(2))
+	if err != nil {
+		t.Fatalf("accessUsecase.Policy() error = %v", err)
+	}
+	if got := p.Role("", 0); got != models.RoleViewer {
+		t.Errorf("accessUsecase.Policy() role = %q, want %q", got, models.RoleViewer)
+	}
+	if _, err := au.Policy(context.Background()); err != core.ErrForbidden {
+		t.Errorf("expected %v without user, got %v", core.ErrForbidden, err)
+	}
+	// the scope of an API key caps the role of its user
+	key := core.WithUser(context.Background(), &models.User{ID: 1, Scope: models.ScopeReadOnly})
+	if p, err := au.Policy(key); err != nil || p.Can(access.ActionWrite, "", 0) || !p.Can(access.ActionRead, "", 0) {
+		t.Errorf("accessUsecase.Policy() of a read-only key should only read, error = %v", err)
+	}
+	failing := NewAccessUsecase(&mocks.MockRepository{Error: errors.New("db error")})
+	if _, err := failing.Policy(as(1)); err == nil {
+		t.Errorf("expected the repository error")
+	}
+}
+
+func Test_accessUsecase_List(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    int
+		wantErr error
+	}{
+		{"Normal Case1: admin lists the grants", as(1), 2, nil},
+		{"viewer can not", as(2), 0, core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAccessUsecase(&mocks.MockRepository{Grants: grants()}).List(tt.ctx)
+			if err != tt.wantErr {
+				t.Fatalf("accessUsecase.List() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("accessUsecase.List() = %d grants, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_accessUsecase_Grant(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		grant   *models.Grant
+		wantErr error
+	}{
+		{"Normal Case1: admin promotes a viewer", as(1), &models.Grant{UserID: 2, Role: models.RoleMember}, nil},
+		{"admin shares a task with a member", as(1), &models.Grant{UserID: 2, Role: models.RoleAdmin, TaskID: 9}, nil},
+		{"admin shares a project with a member", as(1), &models.Grant{UserID: 2, Role: models.RoleMember, Project: "web"}, nil},
+		{"task of a user out of the workspace", as(1), &models.Grant{UserID: 3, Role: models.RoleMember, TaskID: 9}, core.ErrNotMember},
+		{"user out of the workspace joins it", as(1), &models.Grant{UserID: 3, Role: models.RoleViewer}, nil},
+		{"viewer can not grant", as(2), &models.Grant{UserID: 2, Role: models.RoleAdmin}, core.ErrForbidden},
+		{"last admin can not step down", as(1), &models.Grant{UserID: 1, Role: models.RoleMember}, core.ErrLastAdmin},
+		{"last admin keeps the role", as(1), &models.Grant{UserID: 1, Role: models.RoleAdmin}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gr := &mocks.MockRepository{Grants: grants()}
+			err := NewAccessUsecase(gr).Grant(tt.ctx, tt.grant)
+			if err != tt.wantErr {
+				t.Fatalf("accessUsecase.Grant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !reflect.DeepEqual(gr.Grants, grants()) {
+					t.Errorf("grants changed on error: %v", gr.Grants)
+				}
+				return
+			}
+			mine, _ := gr.List(tt.ctx, tt.grant.UserID)
+			if got := access.NewPolicy(mine).Role(tt.grant.Project, tt.grant.TaskID); got != tt.grant.Role {
+				t.Errorf("role = %q, want %q", got, tt.grant.Role)
+			}
+		})
+	}
+}
+
+func Test_accessUsecase_Grant_secondAdmin(t *testing.T) {
+	gr := &mocks.MockRepository{Grants: grants()}
+	au := NewAccessUsecase(gr)
+	if err := au.Grant(as(1), &models.Grant{UserID: 2, Role: models.RoleAdmin}); err != nil {
+		t.Fatalf("accessUsecase.Grant() error = %v", err)
+	}
+	if err := au.Grant(as(1), &models.Grant{UserID: 1, Role: models.RoleMember}); err != nil {
+		t.Errorf("an admin should step down when another one is left, got %v", err)
+	}
+}
+
+func Test_accessUsecase_Revoke(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		id      int
+		wantErr error
+	}{
+		{"Normal Case1: admin revokes a viewer", as(1), 2, nil},
+		{"last admin", as(1), 1, core.ErrLastAdmin},
+		{"unknown grant", as(1), 42, core.ErrRecordNotFound},
+		{"viewer can not revoke", as(2), 2, core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewAccessUsecase(&mocks.MockRepository{Grants: grants()}).Revoke(tt.ctx, tt.id); err != tt.wantErr {
+				t.Errorf("accessUsecase.Revoke() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/attachment"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+// errUnsatisfiable is returned for a range starting past the end of the attachment
+var errUnsatisfiable = errors.New("range not satisfiable")
+
+//AttachmentHandler represents http handler for task attachments
+type AttachmentHandler struct {
+	AttachmentUsecase attachment.Usecase
+}
+
+// NewAttachmentHandler will initialize the attachments resources endpoint of a task
+func NewAttachmentHandler(au attachment.Usecase) nethttp.Handler {
+	r := chi.NewMux()
+	attachmentHandler := &AttachmentHandler{
+		AttachmentUsecase: au,
+	}
+	r.Get("/", attachmentHandler.List)
+	r.Post("/", attachmentHandler.Add)
+	r.Get("/{attachment:[0-9]+}", attachmentHandler.Download)
+	r.Delete("/{attachment:[0-9]+}", attachmentHandler.Delete)
+	return r
+}
+
+// NewPurgeHandler will initialize the endpoint purging the attachments of the deleted tasks
+func NewPurgeHandler(au attachment.Usecase) nethttp.Handler {
+	r := chi.NewMux()
+	attachmentHandler := &AttachmentHandler{
+		AttachmentUsecase: au,
+	}
+	r.Post("/purge", attachmentHandler.Purge)
+	return r
+}
+
+func writeJSON(w nethttp.ResponseWriter, status int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	res, _ := json.Marshal(body)
+	w.Write(res)
+}
+
+// writeError answers the errors shared by the attachment endpoints
+func writeError(w nethttp.ResponseWriter, err error) {
+	switch err {
+	case core.ErrForbidden:
+		w.WriteHeader(nethttp.StatusForbidden)
+		w.Write([]byte("forbidden"))
+	case core.ErrRecordNotFound:
+		w.WriteHeader(nethttp.StatusNotFound)
+		w.Write([]byte("not found"))
+	case core.ErrTooLarge:
+		w.WriteHeader(nethttp.StatusRequestEntityTooLarge)
+		w.Write([]byte("attachment is too large"))
+	case core.ErrUnsupportedType:
+		w.WriteHeader(nethttp.StatusUnsupportedMediaType)
+		w.Write([]byte("unsupported media type"))
+	default:
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+	}
+}
+
+// ids returns the ids of the task and of the attachment of the url, the routes only match digits
+func ids(r *nethttp.Request) (int, int) {
+	taskID, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	id, _ := strconv.Atoi(chi.URLParam(r, "attachment"))
+	return taskID, id
+}
+
+//List handler returns the attachments of the task, oldest first
+func (h *AttachmentHandler) List(w nethttp.ResponseWriter, r *nethttp.Request) {
+	taskID, _ := ids(r)
+	attachments, err := h.AttachmentUsecase.List(r.Context(), taskID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message":     "success",
+		"attachments": attachments,
+	})
+}
+
+//Add handler uploads the "file" part of a multipart form, the part is streamed to the usecase
+func (h *AttachmentHandler) Add(w nethttp.ResponseWriter, r *nethttp.Request) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			w.WriteHeader(nethttp.StatusBadRequest)
+			w.Write([]byte("validation error"))
+			return
+		}
+		if err != nil {
+			w.WriteHeader(nethttp.StatusBadRequest)
+			w.Write([]byte("Can not decode body"))
+			return
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+		taskID, _ := ids(r)
+		a := &models.Attachment{TaskID: taskID, Name: part.FileName()}
+		validate := validator.New()
+		if err := validate.Struct(a); err != nil {
+			w.WriteHeader(nethttp.StatusBadRequest)
+			w.Write([]byte("validation error"))
+			return
+		}
+		if err := h.AttachmentUsecase.Add(r.Context(), a, part); err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, nethttp.StatusCreated, map[string]interface{}{
+			"message":    "success",
+			"attachment": a,
+		})
+		return
+	}
+}
+
+// parseRange returns the offset and the length of a single "bytes" range of a content of size,
+// ok is false when the header is to be ignored and the whole content sent
+func parseRange(header string, size int64) (offset, length int64, ok bool, err error) {
+	spec := strings.TrimPrefix(header, "bytes=")
+	if spec == header || strings.Contains(spec, ",") {
+		return 0, 0, false, nil
+	}
+	dash := strings.Index(spec, "-")
+	if dash < 0 {
+		return 0, 0, false, nil
+	}
+	first, last := strings.TrimSpace(spec[:dash]), strings.TrimSpace(spec[dash+1:])
+	if first == "" {
+		// the last n bytes
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n < 0 {
+			return 0, 0, false, nil
+		}
+		if n == 0 || size == 0 {
+			return 0, 0, false, errUnsatisfiable
+		}
+		if n > size {
+			n = size
+		}
+		return size - n, n, true, nil
+	}
+	offset, err = strconv.ParseInt(first, 10, 64)
+	if err != nil || offset < 0 {
+		return 0, 0, false, nil
+	}
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < offset {
+			return 0, 0, false, nil
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+	if offset >= size {
+		return 0, 0, false, errUnsatisfiable
+	}
+	return offset, end - offset + 1, true, nil
+}
+
+//Download handler streams the content of an attachment, a single byte range is
+//answered with 206 Partial Content
+func (h *AttachmentHandler) Download(w nethttp.ResponseWriter, r *nethttp.Request) {
+	taskID, id := ids(r)
+	a, err := h.AttachmentUsecase.Get(r.Context(), taskID, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	offset, length, status := int64(0), a.Size, nethttp.StatusOK
+	if header := r.Header.Get("Range"); header != "" {
+		o, l, ok, err := parseRange(header, a.Size)
+		if err == errUnsatisfiable {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", a.Size))
+			w.WriteHeader(nethttp.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		if ok {
+			offset, length, status = o, l, nethttp.StatusPartialContent
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", o, o+l-1, a.Size))
+		}
+	}
+	content, err := h.AttachmentUsecase.Open(r.Context(), taskID, id, offset, length)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer content.Close()
+	w.Header().Set("Content-Type", a.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(length, 10))
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": a.Name})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.Header().Set("ETag", `"`+a.Hash+`"`)
+	w.WriteHeader(status)
+	if _, err := io.Copy(w, content); err != nil {
+		logrus.Error(err)
+	}
+}
+
+//Delete handler deletes an attachment
+func (h *AttachmentHandler) Delete(w nethttp.ResponseWriter, r *nethttp.Request) {
+	taskID, id := ids(r)
+	if err := h.AttachmentUsecase.Delete(r.Context(), taskID, id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	w.Write([]byte("success"))
+}
+
+//Purge handler deletes the attachments of the deleted tasks of the workspace
+func (h *AttachmentHandler) Purge(w nethttp.ResponseWriter, r *nethttp.Request) {
+	purged, err := h.AttachmentUsecase.Purge(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"purged":  purged,
+	})
+}
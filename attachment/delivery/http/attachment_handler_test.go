@@ -0,0 +1,224 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/pratheeshm/todo-golang/attachment"
+	"github.com/pratheeshm/todo-golang/attachment/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func withIDs(r *nethttp.Request, taskID, id string) *nethttp.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", taskID)
+	rctx.URLParams.Add("attachment", id)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+// upload returns a multipart request of the fields and of a file part named field
+func upload(field, filename, content string) *nethttp.Request {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	mw.WriteField("comment", "screenshot of the bug")
+	fw, _ := mw.CreateFormFile(field, filename)
+	fw.Write([]byte(content))
+	mw.Close()
+	r := httptest.NewRequest("POST", "/task/1/attachments", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return withIDs(r, "1", "")
+}
+
+func newUsecase() *mocks.MockUsecase {
+	return &mocks.MockUsecase{
+		Attachments: []*models.Attachment{{ID: 2, TaskID: 1, Name: "notes.txt", ContentType: "text/plain; charset=utf-8", Size: 11, Hash: "abc"}},
+		Content:     "hello world",
+	}
+}
+
+func TestNewAttachmentHandler(t *testing.T) {
+	h := NewAttachmentHandler(newUsecase())
+	tests := []struct {
+		method     string
+		url        string
+		statusCode int
+	}{
+		{"GET", "/", 200},
+		{"GET", "/2", 200},
+		{"DELETE", "/2", 200},
+		{"GET", "/abc", 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, nil))
+			if rec.Code != tt.statusCode {
+				t.Errorf("got statuscode %d but expected %d", rec.Code, tt.statusCode)
+			}
+		})
+	}
+	rec := httptest.NewRecorder()
+	NewPurgeHandler(newUsecase()).ServeHTTP(rec, httptest.NewRequest("POST", "/purge", nil))
+	if rec.Code != 200 {
+		t.Errorf("POST /purge got statuscode %d but expected 200", rec.Code)
+	}
+}
+
+func TestAttachmentHandler_Add(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    attachment.Usecase
+		request    *nethttp.Request
+		statusCode int
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{},
+		request:    upload("file", "notes.txt", "hello world"),
+		statusCode: 201,
+	}, {
+		name:       "no file part",
+		usecase:    &mocks.MockUsecase{},
+		request:    upload("other", "notes.txt", "hello world"),
+		statusCode: 400,
+	}, {
+		name:       "missing filename",
+		usecase:    &mocks.MockUsecase{},
+		request:    upload("file", "", "hello world"),
+		statusCode: 400,
+	}, {
+		name:       "not multipart",
+		usecase:    &mocks.MockUsecase{},
+		request:    withIDs(httptest.NewRequest("POST", "/task/1/attachments", bytes.NewBufferString("hello world")), "1", ""),
+		statusCode: 400,
+	}, {
+		name:       "too large",
+		usecase:    &mocks.MockUsecase{Error: core.ErrTooLarge},
+		request:    upload("file", "notes.txt", "hello world"),
+		statusCode: 413,
+	}, {
+		name:       "unsupported type",
+		usecase:    &mocks.MockUsecase{Error: core.ErrUnsupportedType},
+		request:    upload("file", "notes.exe", "MZ"),
+		statusCode: 415,
+	}, {
+		name:       "forbidden",
+		usecase:    &mocks.MockUsecase{Error: core.ErrForbidden},
+		request:    upload("file", "notes.txt", "hello world"),
+		statusCode: 403,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("Usecase.Error()")},
+		request:    upload("file", "notes.txt", "hello world"),
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &AttachmentHandler{AttachmentUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Add(rec, tt.request)
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestAttachmentHandler_Download(t *testing.T) {
+	tests := []struct {
+		name         string
+		usecase      attachment.Usecase
+		rangeHeader  string
+		statusCode   int
+		contentRange string
+		body         string
+	}{
+		{name: "Success case", usecase: newUsecase(), statusCode: 200, body: "hello world"},
+		{name: "range", usecase: newUsecase(), rangeHeader: "bytes=6-", statusCode: 206, contentRange: "bytes 6-10/11", body: "world"},
+		{name: "bounded range", usecase: newUsecase(), rangeHeader: "bytes=0-4", statusCode: 206, contentRange: "bytes 0-4/11", body: "hello"},
+		{name: "suffix range", usecase: newUsecase(), rangeHeader: "bytes=-3", statusCode: 206, contentRange: "bytes 8-10/11", body: "rld"},
+		{name: "range past the end is cut", usecase: newUsecase(), rangeHeader: "bytes=6-100", statusCode: 206, contentRange: "bytes 6-10/11", body: "world"},
+		{name: "several ranges are ignored", usecase: newUsecase(), rangeHeader: "bytes=0-1,3-4", statusCode: 200, body: "hello world"},
+		{name: "malformed range is ignored", usecase: newUsecase(), rangeHeader: "bytes=abc", statusCode: 200, body: "hello world"},
+		{name: "unsatisfiable range", usecase: newUsecase(), rangeHeader: "bytes=11-", statusCode: 416, contentRange: "bytes */11"},
+		{name: "attachment not found", usecase: &mocks.MockUsecase{Error: core.ErrRecordNotFound}, statusCode: 404, body: "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &AttachmentHandler{AttachmentUsecase: tt.usecase}
+			req := withIDs(httptest.NewRequest("GET", "/task/1/attachments/2", nil), "1", "2")
+			if tt.rangeHeader != "" {
+				req.Header.Set("Range", tt.rangeHeader)
+			}
+			rec := httptest.NewRecorder()
+			h.Download(rec, req)
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if got := rec.Header().Get("Content-Range"); got != tt.contentRange {
+				t.Errorf("Test - %s , got Content-Range %q but expected %q", tt.name, got, tt.contentRange)
+			}
+			if rec.Body.String() != tt.body {
+				t.Errorf("Test - %s , got body %q but expected %q", tt.name, rec.Body.String(), tt.body)
+			}
+		})
+	}
+	rec := httptest.NewRecorder()
+	(&AttachmentHandler{AttachmentUsecase: newUsecase()}).Download(rec, withIDs(httptest.NewRequest("GET", "/task/1/attachments/2", nil), "1", "2"))
+	if got := rec.Header().Get("Content-Disposition"); got != "attachment; filename=notes.txt" {
+		t.Errorf("got Content-Disposition %q", got)
+	}
+	if got := rec.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+		t.Errorf("got X-Content-Type-Options %q", got)
+	}
+}
+
+func TestAttachmentHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    attachment.Usecase
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, statusCode: 200},
+		{name: "forbidden", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, statusCode: 403},
+		{name: "attachment not found", usecase: &mocks.MockUsecase{Error: core.ErrRecordNotFound}, statusCode: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &AttachmentHandler{AttachmentUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Delete(rec, withIDs(httptest.NewRequest("DELETE", "/task/1/attachments/2", nil), "1", "2"))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestAttachmentHandler_Purge(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    attachment.Usecase
+		statusCode int
+		body       string
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{Purged: 3}, statusCode: 200, body: `{"message":"success","purged":3}`},
+		{name: "forbidden", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, statusCode: 403, body: "forbidden"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &AttachmentHandler{AttachmentUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Purge(rec, httptest.NewRequest("POST", "/attachments/purge", nil))
+			if rec.Code != tt.statusCode || rec.Body.String() != tt.body {
+				t.Errorf("Test - %s , got %d %s but expected %d %s", tt.name, rec.Code, rec.Body.String(), tt.statusCode, tt.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+//MockRepository implements inerface attachment.Repository
+type MockRepository struct {
+	Error       error
+	Attachments []*models.Attachment
+	// Blobs holds the size of the blobs claimed by hash
+	Blobs map[string]int64
+	// Deleted holds the ids of the deleted tasks
+	Deleted []int
+}
+
+//Add appends a copy of the attachment to Attachments
+func (m *MockRepository) Add(ctx context.Context, a *models.Attachment) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	a.ID = len(m.Attachments) + 1
+	a.CreatedAt = time.Now()
+	stored := *a
+	m.Attachments = append(m.Attachments, &stored)
+	return nil
+}
+
+//Get returns a copy of the attachment of Attachments with the id
+func (m *MockRepository) Get(ctx context.Context, id int) (*models.Attachment, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	for _, a := range m.Attachments {
+		if a.ID == id {
+			found := *a
+			return &found, nil
+		}
+	}
+	return nil, core.ErrRecordNotFound
+}
+
+//List returns copies of the attachments of the task
+func (m *MockRepository) List(ctx context.Context, taskID int) ([]*models.Attachment, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	attachments := []*models.Attachment{}
+	for _, a := range m.Attachments {
+		if a.TaskID == taskID {
+			found := *a
+			attachments = append(attachments, &found)
+		}
+	}
+	return attachments, nil
+}
+
+//Delete removes the attachment from Attachments
+func (m *MockRepository) Delete(ctx context.Context, id int) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for i, a := range m.Attachments {
+		if a.ID == id {
+			m.Attachments = append(m.Attachments[:i], m.Attachments[i+1:]...)
+			return nil
+		}
+	}
+	return core.ErrRecordNotFound
+}
+
+//Purge removes the attachments of the tasks of Deleted
+func (m *MockRepository) Purge(ctx context.Context) (int64, error) {
+	if m.Error != nil {
+		return 0, m.Error
+	}
+	kept := []*models.Attachment{}
+	for _, a := range m.Attachments {
+		if !m.deleted(a.TaskID) {
+			kept = append(kept, a)
+		}
+	}
+	purged := int64(len(m.Attachments) - len(kept))
+	m.Attachments = kept
+	return purged, nil
+}
+
+func (m *MockRepository) deleted(taskID int) bool {
+	for _, id := range m.Deleted {
+		if id == taskID {
+			return true
+		}
+	}
+	return false
+}
+
+//Claim adds the hash to Blobs, the blob is forgotten when the transaction of ctx rolls back
+func (m *MockRepository) Claim(ctx context.Context, hash string, size int64) (bool, error) {
+	if m.Error != nil {
+		return false, m.Error
+	}
+	if m.Blobs == nil {
+		m.Blobs = map[string]int64{}
+	}
+	if _, ok := m.Blobs[hash]; ok {
+		return false, nil
+	}
+	m.Blobs[hash] = size
+	transaction.OnRollback(ctx, func() {
+		delete(m.Blobs, hash)
+	})
+	return true, nil
+}
+
+//Release removes the hash from Blobs when no attachment of Attachments refers to it
+func (m *MockRepository) Release(ctx context.Context, hash string) (bool, error) {
+	if m.Error != nil {
+		return false, m.Error
+	}
+	if _, ok := m.Blobs[hash]; !ok || m.referenced(hash) {
+		return false, nil
+	}
+	size := m.Blobs[hash]
+	delete(m.Blobs, hash)
+	transaction.OnRollback(ctx, func() {
+		m.Blobs[hash] = size
+	})
+	return true, nil
+}
+
+//Orphans returns the hashes of Blobs no attachment of Attachments refers to
+func (m *MockRepository) Orphans(ctx context.Context) ([]string, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	hashes := []string{}
+	for hash := range m.Blobs {
+		if !m.referenced(hash) {
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes, nil
+}
+
+func (m *MockRepository) referenced(hash string) bool {
+	for _, a := range m.Attachments {
+		if a.Hash == hash {
+			return true
+		}
+	}
+	return false
+}
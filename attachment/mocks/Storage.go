@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+//MockStorage implements inerface attachment.Storage
+type MockStorage struct {
+	Error error
+	Blobs map[string][]byte
+	// Puts counts the calls to Put
+	Puts int
+}
+
+//Put stores the content of r in Blobs
+func (m *MockStorage) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if m.Blobs == nil {
+		m.Blobs = map[string][]byte{}
+	}
+	m.Blobs[key] = content
+	m.Puts++
+	return nil
+}
+
+//Get returns the section of the blob of Blobs
+func (m *MockStorage) Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	content, ok := m.Blobs[key]
+	if !ok {
+		return nil, core.ErrRecordNotFound
+	}
+	return ioutil.NopCloser(bytes.NewReader(content[offset : offset+length])), nil
+}
+
+//Delete removes the blob from Blobs
+func (m *MockStorage) Delete(ctx context.Context, key string) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	delete(m.Blobs, key)
+	return nil
+}
@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockUsecase implements inerface attachment.Usecase
+type MockUsecase struct {
+	Error       error
+	Attachments []*models.Attachment
+	// Content is the content of every attachment
+	Content string
+	Purged  int64
+}
+
+//List returns Attachments
+func (m *MockUsecase) List(ctx context.Context, taskID int) ([]*models.Attachment, error) {
+	return m.Attachments, m.Error
+}
+
+//Add reads the content and sets the size of the attachment
+func (m *MockUsecase) Add(ctx context.Context, a *models.Attachment, r io.Reader) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	content, err := ioutil.ReadAll(r)
+	a.Size = int64(len(content))
+	return err
+}
+
+//Get returns the first attachment of Attachments
+func (m *MockUsecase) Get(ctx context.Context, taskID, id int) (*models.Attachment, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	return m.Attachments[0], nil
+}
+
+//Open returns the section of Content
+func (m *MockUsecase) Open(ctx context.Context, taskID, id int, offset, length int64) (io.ReadCloser, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	return ioutil.NopCloser(strings.NewReader(m.Content[offset : offset+length])), nil
+}
+
+//Delete attachment
+func (m *MockUsecase) Delete(ctx context.Context, taskID, id int) error {
+	return m.Error
+}
+
+//Purge returns Purged
+func (m *MockUsecase) Purge(ctx context.Context) (int64, error) {
+	return m.Purged, m.Error
+}
@@ -0,0 +1,27 @@
+package attachment
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Repository represents task attachment's interface. The content of the attachments
+//is kept in the Storage, the repository tracks which blobs of the workspace are stored
+type Repository interface {
+	Add(context.Context, *models.Attachment) error
+	Get(ctx context.Context, id int) (*models.Attachment, error)
+	// List returns the attachments of a task, oldest first
+	List(ctx context.Context, taskID int) ([]*models.Attachment, error)
+	Delete(ctx context.Context, id int) error
+	// Purge deletes the attachments of the deleted tasks and returns how many it deleted
+	Purge(ctx context.Context) (int64, error)
+	// Claim locks the blob of hash until the end of the transaction of ctx,
+	// it returns true when the blob is new and its content has to be stored
+	Claim(ctx context.Context, hash string, size int64) (bool, error)
+	// Release locks the blob of hash until the end of the transaction of ctx and forgets it
+	// when no attachment refers to it anymore, it returns true when its content has to be removed
+	Release(ctx context.Context, hash string) (bool, error)
+	// Orphans returns the blobs no attachment refers to
+	Orphans(ctx context.Context) ([]string, error)
+}
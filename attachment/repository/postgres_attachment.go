@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pratheeshm/todo-golang/attachment"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+const attachmentColumns = "id_attachment, id_task, name, content_type, size, hash, uploaded_by, created_at"
+
+type postgresAttachmentRepository struct {
+	*sql.DB
+}
+
+// NewPostgresAttachmentRepository will create an object that represent the attachment.Repository interface
+func NewPostgresAttachmentRepository(db *sql.DB) attachment.Repository {
+	return &postgresAttachmentRepository{db}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAttachment(s scanner) (*models.Attachment, error) {
+	a := &models.Attachment{}
+	err := s.Scan(&a.ID, &a.TaskID, &a.Name, &a.ContentType, &a.Size, &a.Hash, &a.UploadedBy, &a.CreatedAt)
+	return a, err
+}
+
+// Add only attaches to the tasks of the workspace that are not deleted
+func (p *postgresAttachmentRepository) Add(ctx context.Context, a *models.Attachment) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	err = transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "INSERT INTO attachment(id_task, id_workspace, name, content_type, size, hash, uploaded_by) SELECT $1, $2, $3, $4, $5, $6, $7 "+
+		"WHERE EXISTS (SELECT 1 FROM task WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted) RETURNING id_attachment, created_at",
+		a.TaskID, workspace, a.Name, a.ContentType, a.Size, a.Hash, a.UploadedBy).Scan(&a.ID, &a.CreatedAt)
+	if err == sql.ErrNoRows {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresAttachmentRepository) Get(ctx context.Context, id int) (*models.Attachment, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "SELECT "+attachmentColumns+" FROM attachment WHERE id_attachment = $1 AND id_workspace = $2", id, workspace)
+	a, err := scanAttachment(row)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	return a, err
+}
+
+func (p *postgresAttachmentRepository) List(ctx context.Context, taskID int) ([]*models.Attachment, error) {
+	attachments := make([]*models.Attachment, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return attachments, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT "+attachmentColumns+" FROM attachment WHERE id_task = $1 AND id_workspace = $2 ORDER BY created_at, id_attachment",
+		taskID, workspace)
+	if err != nil {
+		return attachments, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return []*models.Attachment{}, err
+		}
+		attachments = append(attachments, a)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.Attachment{}, err
+	}
+	return attachments, nil
+}
+
+func (p *postgresAttachmentRepository) Delete(ctx context.Context, id int) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "DELETE FROM attachment WHERE id_attachment = $1 AND id_workspace = $2", id, workspace)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresAttachmentRepository) Purge(ctx context.Context) (int64, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "DELETE FROM attachment a USING task t WHERE a.id_task = t.id_task AND a.id_workspace = $1 AND t.deleted", workspace)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Claim upserts the blob, the update takes the row lock that Release waits for.
+// xmax is 0 on the row version of an insert
+func (p *postgresAttachmentRepository) Claim(ctx context.Context, hash string, size int64) (bool, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	var created bool
+	err = transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "INSERT INTO attachment_blob(id_workspace, hash, size) VALUES ($1, $2, $3) "+
+		"ON CONFLICT (id_workspace, hash) DO UPDATE SET size = EXCLUDED.size RETURNING xmax = 0",
+		workspace, hash, size).Scan(&created)
+	return created, err
+}
+
+// Release locks the blob before looking for attachments in a statement of its own:
+// an upload holding the lock has committed its attachment by the time the lookup runs
+func (p *postgresAttachmentRepository) Release(ctx context.Context, hash string) (bool, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	conn := transaction.Conn(ctx, p.DB)
+	var locked string
+	err = conn.QueryRowContext(ctx, "SELECT hash FROM attachment_blob WHERE id_workspace = $1 AND hash = $2 FOR UPDATE", workspace, hash).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	result, err := conn.ExecContext(ctx, "DELETE FROM attachment_blob b WHERE b.id_workspace = $1 AND b.hash = $2 "+
+		"AND NOT EXISTS (SELECT 1 FROM attachment a WHERE a.id_workspace = b.id_workspace AND a.hash = b.hash)", workspace, hash)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+func (p *postgresAttachmentRepository) Orphans(ctx context.Context) ([]string, error) {
+	hashes := make([]string, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return hashes, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT b.hash FROM attachment_blob b WHERE b.id_workspace = $1 "+
+		"AND NOT EXISTS (SELECT 1 FROM attachment a WHERE a.id_workspace = b.id_workspace AND a.hash = b.hash) ORDER BY b.hash", workspace)
+	if err != nil {
+		return hashes, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return []string{}, err
+		}
+		hashes = append(hashes, hash)
+	}
+	if err = rows.Err(); err != nil {
+		return []string{}, err
+	}
+	return hashes, nil
+}
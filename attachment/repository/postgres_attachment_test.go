@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+const hash = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+
+var attachmentRowColumns = []string{"id_attachment", "id_task", "name", "content_type", "size", "hash", "uploaded_by", "created_at"}
+
+func Test_postgresAttachmentRepository_Add(t *testing.T) {
+	query := "INSERT INTO attachment(id_task, id_workspace, name, content_type, size, hash, uploaded_by) SELECT $1, $2, $3, $4, $5, $6, $7 " +
+		"WHERE EXISTS (SELECT 1 FROM task WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted) RETURNING id_attachment, created_at"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantID  int
+		wantErr error
+	}{{
+		name:   "Normal Case 1: attachment added",
+		rows:   sqlmock.NewRows([]string{"id_attachment", "created_at"}).AddRow(3, time.Time{}),
+		wantID: 3,
+	}, {
+		name:    "task not found",
+		rows:    sqlmock.NewRows([]string{"id_attachment", "created_at"}),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant, "notes.txt", "text/plain; charset=utf-8", 11, hash, 5).WillReturnRows(tt.rows)
+			a := &models.Attachment{TaskID: 1, Name: "notes.txt", ContentType: "text/plain; charset=utf-8", Size: 11, Hash: hash, UploadedBy: 5}
+			err = NewPostgresAttachmentRepository(db).Add(coremocks.TenantContext(), a)
+			if err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if a.ID != tt.wantID {
+				t.Errorf("Test %s - id = %d, want %d", tt.name, a.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func Test_postgresAttachmentRepository_Get(t *testing.T) {
+	query := "SELECT id_attachment, id_task, name, content_type, size, hash, uploaded_by, created_at FROM attachment WHERE id_attachment = $1 AND id_workspace = $2"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *models.Attachment
+		wantErr error
+	}{{
+		name: "Normal Case 1: attachment found",
+		rows: sqlmock.NewRows(attachmentRowColumns).AddRow(2, 1, "notes.txt", "text/plain", 11, hash, 5, time.Time{}),
+		want: &models.Attachment{ID: 2, TaskID: 1, Name: "notes.txt", ContentType: "text/plain", Size: 11, Hash: hash, UploadedBy: 5},
+	}, {
+		name:    "attachment not found",
+		rows:    sqlmock.NewRows(attachmentRowColumns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(2, coremocks.Tenant).WillReturnRows(tt.rows)
+			got, err := NewPostgresAttachmentRepository(db).Get(coremocks.TenantContext(), 2)
+			if err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresAttachmentRepository_List(t *testing.T) {
+	query := "SELECT id_attachment, id_task, name, content_type, size, hash, uploaded_by, created_at FROM attachment WHERE id_task = $1 AND id_workspace = $2 ORDER BY created_at, id_attachment"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbError error
+		want    []*models.Attachment
+		wantErr bool
+	}{{
+		name: "Normal Case 1: attachments of the task",
+		rows: sqlmock.NewRows(attachmentRowColumns).
+			AddRow(1, 1, "notes.txt", "text/plain", 11, hash, 5, time.Time{}).
+			AddRow(2, 1, "copy.txt", "text/plain", 11, hash, 6, time.Time{}),
+		want: []*models.Attachment{
+			{ID: 1, TaskID: 1, Name: "notes.txt", ContentType: "text/plain", Size: 11, Hash: hash, UploadedBy: 5},
+			{ID: 2, TaskID: 1, Name: "copy.txt", ContentType: "text/plain", Size: 11, Hash: hash, UploadedBy: 6},
+		},
+	}, {
+		name:    "db error",
+		rows:    sqlmock.NewRows(attachmentRowColumns),
+		dbError: errors.New("db error"),
+		want:    []*models.Attachment{},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant).WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			got, err := NewPostgresAttachmentRepository(db).List(coremocks.TenantContext(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresAttachmentRepository_Delete(t *testing.T) {
+	query := "DELETE FROM attachment WHERE id_attachment = $1 AND id_workspace = $2"
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "Normal Case 1: attachment deleted", affected: 1},
+		{name: "attachment not found", affected: 0, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(2, coremocks.Tenant).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if err := NewPostgresAttachmentRepository(db).Delete(coremocks.TenantContext(), 2); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresAttachmentRepository_Purge(t *testing.T) {
+	query := "DELETE FROM attachment a USING task t WHERE a.id_task = t.id_task AND a.id_workspace = $1 AND t.deleted"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant).WillReturnResult(sqlmock.NewResult(0, 3))
+	if got, err := NewPostgresAttachmentRepository(db).Purge(coremocks.TenantContext()); err != nil || got != 3 {
+		t.Errorf("postgresAttachmentRepository.Purge() = %d, %v, want 3", got, err)
+	}
+}
+
+func Test_postgresAttachmentRepository_Claim(t *testing.T) {
+	query := "INSERT INTO attachment_blob(id_workspace, hash, size) VALUES ($1, $2, $3) " +
+		"ON CONFLICT (id_workspace, hash) DO UPDATE SET size = EXCLUDED.size RETURNING xmax = 0"
+	tests := []struct {
+		name    string
+		created bool
+	}{
+		{name: "Normal Case 1: new blob", created: true},
+		{name: "blob already stored", created: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant, hash, 11).
+				WillReturnRows(sqlmock.NewRows([]string{"created"}).AddRow(tt.created))
+			got, err := NewPostgresAttachmentRepository(db).Claim(coremocks.TenantContext(), hash, 11)
+			if err != nil || got != tt.created {
+				t.Errorf("Test %s - got = %v, %v, want %v", tt.name, got, err, tt.created)
+			}
+		})
+	}
+}
+
+func Test_postgresAttachmentRepository_Release(t *testing.T) {
+	lock := "SELECT hash FROM attachment_blob WHERE id_workspace = $1 AND hash = $2 FOR UPDATE"
+	release := "DELETE FROM attachment_blob b WHERE b.id_workspace = $1 AND b.hash = $2 " +
+		"AND NOT EXISTS (SELECT 1 FROM attachment a WHERE a.id_workspace = b.id_workspace AND a.hash = b.hash)"
+	tests := []struct {
+		name     string
+		stored   bool
+		affected int64
+		want     bool
+	}{
+		{name: "Normal Case 1: blob released", stored: true, affected: 1, want: true},
+		{name: "blob still referred to", stored: true, affected: 0, want: false},
+		{name: "blob already released", stored: false, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			rows := sqlmock.NewRows([]string{"hash"})
+			if tt.stored {
+				rows.AddRow(hash)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(lock)).WithArgs(coremocks.Tenant, hash).WillReturnRows(rows)
+			if tt.stored {
+				mock.ExpectExec(regexp.QuoteMeta(release)).WithArgs(coremocks.Tenant, hash).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			}
+			got, err := NewPostgresAttachmentRepository(db).Release(coremocks.TenantContext(), hash)
+			if err != nil || got != tt.want {
+				t.Errorf("Test %s - got = %v, %v, want %v", tt.name, got, err, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_postgresAttachmentRepository_Orphans(t *testing.T) {
+	query := "SELECT b.hash FROM attachment_blob b WHERE b.id_workspace = $1 " +
+		"AND NOT EXISTS (SELECT 1 FROM attachment a WHERE a.id_workspace = b.id_workspace AND a.hash = b.hash) ORDER BY b.hash"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant).WillReturnRows(sqlmock.NewRows([]string{"hash"}).AddRow(hash))
+	got, err := NewPostgresAttachmentRepository(db).Orphans(coremocks.TenantContext())
+	if err != nil || !reflect.DeepEqual(got, []string{hash}) {
+		t.Errorf("postgresAttachmentRepository.Orphans() = %v, %v, want [%s]", got, err, hash)
+	}
+}
+
+func Test_postgresAttachmentRepository_withoutTenant(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	ctx := context.Background()
+	p := NewPostgresAttachmentRepository(db)
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Add", func() error { return p.Add(ctx, &models.Attachment{TaskID: 1}) }},
+		{"Get", func() error { _, err := p.Get(ctx, 1); return err }},
+		{"List", func() error { _, err := p.List(ctx, 1); return err }},
+		{"Delete", func() error { return p.Delete(ctx, 1) }},
+		{"Purge", func() error { _, err := p.Purge(ctx); return err }},
+		{"Claim", func() error { _, err := p.Claim(ctx, hash, 1); return err }},
+		{"Release", func() error { _, err := p.Release(ctx, hash); return err }},
+		{"Orphans", func() error { _, err := p.Orphans(ctx); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != core.ErrNoTenant {
+				t.Errorf("expected %v, got %v", core.ErrNoTenant, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package attachment
+
+import (
+	"context"
+	"io"
+)
+
+//Storage represents the blob store keeping the content of the attachments
+type Storage interface {
+	// Put stores size bytes of r under key, replacing what was there
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Get returns length bytes of key starting at offset,
+	// core.ErrRecordNotFound is returned when there is no such key
+	Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	// Delete removes key, removing a missing key is not an error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pratheeshm/todo-golang/attachment"
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+type localStorage struct {
+	dir string
+}
+
+// NewLocalStorage will create an attachment.Storage keeping the blobs as files under dir,
+// the keys are paths relative to dir
+func NewLocalStorage(dir string) attachment.Storage {
+	return &localStorage{dir: dir}
+}
+
+// path returns the file of key, keys must not leave dir
+func (s *localStorage) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if clean == "/" || clean != "/"+key || strings.Contains(key, "\\") {
+		return "", errors.New("invalid blob key " + key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
+
+// Put writes to a temporary file next to the blob and renames it, a reader
+// never sees a blob being written
+func (s *localStorage) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(path), ".upload-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	n, err := io.Copy(f, r)
+	if err == nil && n != size {
+		err = io.ErrUnexpectedEOF
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// readCloser reads a section of a file and closes the file
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func (s *localStorage) Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, core.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return readCloser{io.NewSectionReader(f, offset, length), f}, nil
+}
+
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
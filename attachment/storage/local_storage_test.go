@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+func Test_localStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "attachments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := NewLocalStorage(dir)
+	ctx := context.Background()
+	if err := s.Put(ctx, "7/abc", strings.NewReader("hello world"), 11); err != nil {
+		t.Fatalf("localStorage.Put() error = %v", err)
+	}
+	tests := []struct {
+		name   string
+		offset int64
+		length int64
+		want   string
+	}{
+		{name: "Normal Case 1: whole blob", offset: 0, length: 11, want: "hello world"},
+		{name: "range", offset: 6, length: 3, want: "wor"},
+		{name: "empty range", offset: 0, length: 0, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := s.Get(ctx, "7/abc", tt.offset, tt.length)
+			if err != nil {
+				t.Fatalf("localStorage.Get() error = %v", err)
+			}
+			defer r.Close()
+			got, _ := ioutil.ReadAll(r)
+			if string(got) != tt.want {
+				t.Errorf("localStorage.Get() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if err := s.Put(ctx, "7/short", strings.NewReader("hello"), 11); err == nil {
+		t.Errorf("localStorage.Put() of a short content, expected an error")
+	}
+	if _, err := s.Get(ctx, "7/short", 0, 5); err != core.ErrRecordNotFound {
+		t.Errorf("localStorage.Get() of a failed Put, expected %v, got %v", core.ErrRecordNotFound, err)
+	}
+	if err := s.Delete(ctx, "7/abc"); err != nil {
+		t.Fatalf("localStorage.Delete() error = %v", err)
+	}
+	if _, err := s.Get(ctx, "7/abc", 0, 11); err != core.ErrRecordNotFound {
+		t.Errorf("localStorage.Get() of a deleted blob, expected %v, got %v", core.ErrRecordNotFound, err)
+	}
+	if err := s.Delete(ctx, "7/abc"); err != nil {
+		t.Errorf("localStorage.Delete() of a missing blob, error = %v", err)
+	}
+	for _, key := range []string{"../secret", "7/../../secret", "", "7//abc"} {
+		if err := s.Put(ctx, key, strings.NewReader("x"), 1); err == nil {
+			t.Errorf("localStorage.Put(%q), expected an error", key)
+		}
+	}
+}
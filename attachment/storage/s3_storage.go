@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	nethttp "net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/attachment"
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+// unsignedPayload lets the uploads stream, the content is not hashed for the signature
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// emptyPayload is the sha256 of an empty body
+const emptyPayload = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Config locates the bucket of an S3-compatible storage and the credentials to use it
+type S3Config struct {
+	// Endpoint is the base url of the service, e.g. https://s3.eu-west-1.amazonaws.com
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+type s3Storage struct {
+	config S3Config
+	client *nethttp.Client
+	now    func() time.Time
+}
+
+// NewS3Storage will create an attachment.Storage keeping the blobs as objects of the bucket,
+// the requests use path-style urls and are signed with AWS Signature Version 4
+func NewS3Storage(c S3Config, client *nethttp.Client) attachment.Storage {
+	return &s3Storage{config: c, client: client, now: time.Now}
+}
+
+// escape encodes the key the way the canonical request of a signature does
+func escape(key string) string {
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-_.~/", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func (s *s3Storage) request(ctx context.Context, method, key string, body io.Reader) (*nethttp.Request, error) {
+	url := strings.TrimRight(s.config.Endpoint, "/") + "/" + escape(s.config.Bucket) + "/" + escape(key)
+	req, err := nethttp.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	return req.WithContext(ctx), nil
+}
+
+// do signs and sends req, the body of the response is closed unless it is a success
+func (s *s3Storage) do(req *nethttp.Request, payload string) (*nethttp.Response, error) {
+	sign(req, payload, s.config, s.now())
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		res.Body.Close()
+		if res.StatusCode == nethttp.StatusNotFound {
+			return res, core.ErrRecordNotFound
+		}
+		return res, fmt.Errorf("s3: %s %s: %s %s", req.Method, req.URL.Path, res.Status, msg)
+	}
+	return res, nil
+}
+
+func (s *s3Storage) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	req, err := s.request(ctx, "PUT", key, ioutil.NopCloser(r))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	res, err := s.do(req, unsignedPayload)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func (s *s3Storage) Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	req, err := s.request(ctx, "GET", key, nil)
+	if err != nil {
+		return nil, err
+	}
+	if length == 0 {
+		// an empty range can not be asked for, the object still has to exist
+		req.Method = "HEAD"
+	} else {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	}
+	res, err := s.do(req, emptyPayload)
+	if err != nil {
+		return nil, err
+	}
+	if length == 0 {
+		res.Body.Close()
+		return ioutil.NopCloser(strings.NewReader("")), nil
+	}
+	return res.Body, nil
+}
+
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.request(ctx, "DELETE", key, nil)
+	if err != nil {
+		return err
+	}
+	res, err := s.do(req, emptyPayload)
+	if err == core.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// sign adds the Authorization header of AWS Signature Version 4 to req. The host,
+// the range and the x-amz-* headers are signed, payload is the hex sha256 of the body
+// or UNSIGNED-PAYLOAD
+func sign(req *nethttp.Request, payload string, c S3Config, now time.Time) {
+	now = now.UTC()
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	req.Header.Set("X-Amz-Content-Sha256", payload)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "range" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(req.Header.Get(name))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signed := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonical := strings.Join([]string{req.Method, path, req.URL.RawQuery, canonicalHeaders.String(), signed, payload}, "\n")
+	sum := sha256.Sum256([]byte(canonical))
+	scope := date + "/" + c.Region + "/s3/aws4_request"
+	toSign := "AWS4-HMAC-SHA256\n" + now.Format("20060102T150405Z") + "\n" + scope + "\n" + hex.EncodeToString(sum[:])
+
+	key := hmacSHA256([]byte("AWS4"+c.SecretKey), date)
+	key = hmacSHA256(key, c.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, toSign))
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+c.AccessKey+"/"+scope+", SignedHeaders="+signed+", Signature="+signature)
+}
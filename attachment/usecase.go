@@ -0,0 +1,27 @@
+package attachment
+
+import (
+	"context"
+	"io"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Usecase represents task attachment's usecases, the uploader of an attachment
+//is the user of the context
+type Usecase interface {
+	// List returns the attachments of a task
+	List(ctx context.Context, taskID int) ([]*models.Attachment, error)
+	// Add stores the content read from r as an attachment of the task of a,
+	// core.ErrTooLarge and core.ErrUnsupportedType are returned when it breaks the limits
+	Add(ctx context.Context, a *models.Attachment, r io.Reader) error
+	Get(ctx context.Context, taskID, id int) (*models.Attachment, error)
+	// Open returns length bytes of the content of an attachment starting at offset
+	Open(ctx context.Context, taskID, id int, offset, length int64) (io.ReadCloser, error)
+	// Delete deletes an attachment of the user, the admins of the task delete any attachment
+	Delete(ctx context.Context, taskID, id int) error
+	// Purge deletes the attachments of the deleted tasks of the workspace along with
+	// the blobs no attachment refers to anymore, it returns how many attachments it deleted.
+	// Deleting a task already does so, Purge picks up what a failing cleanup left behind
+	Purge(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	nethttp "net/http"
+	"os"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/attachment"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/sirupsen/logrus"
+)
+
+type attachmentUsecase struct {
+	attachmentRepo attachment.Repository
+	taskRepo       task.Repository
+	accessUsecase  access.Usecase
+	storage        attachment.Storage
+	transactor     core.Transactor
+	maxSize        int64
+	types          map[string]bool
+}
+
+// NewAttachmentUsecase will create new an attachmentUsecase object representation of attachment.Usecase interface.
+// Attachments are limited to maxSize bytes of one of the media types, they follow the role of the user
+// on their task like the comments do
+func NewAttachmentUsecase(ar attachment.Repository, tr task.Repository, au access.Usecase, s attachment.Storage, t core.Transactor, maxSize int64, types []string) attachment.Usecase {
+	allowed := map[string]bool{}
+	for _, mediaType := range types {
+		allowed[mediaType] = true
+	}
+	return &attachmentUsecase{
+		attachmentRepo: ar,
+		taskRepo:       tr,
+		accessUsecase:  au,
+		storage:        s,
+		transactor:     t,
+		maxSize:        maxSize,
+		types:          allowed,
+	}
+}
+
+// key returns the key of the blob of hash in the storage, blobs are not shared between workspaces
+func key(ctx context.Context, hash string) (string, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d/%s", workspace, hash), nil
+}
+
+// get returns the attachment when it belongs to the task
+func (au *attachmentUsecase) get(ctx context.Context, taskID, id int) (*models.Attachment, error) {
+	a, err := au.attachmentRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.TaskID != taskID {
+		return nil, core.ErrRecordNotFound
+	}
+	return a, nil
+}
+
+func (au *attachmentUsecase) List(ctx context.Context, taskID int) ([]*models.Attachment, error) {
+	if err := access.AllowTask(ctx, au.accessUsecase, au.taskRepo, access.ActionRead, taskID); err != nil {
+		return nil, err
+	}
+	return au.attachmentRepo.List(ctx, taskID)
+}
+
+// Add spools the content to a temporary file to learn its size, hash and type
+// before anything is stored
+func (au *attachmentUsecase) Add(ctx context.Context, a *models.Attachment, r io.Reader) error {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return err
+	}
+	if err := access.AllowTask(ctx, au.accessUsecase, au.taskRepo, access.ActionWrite, a.TaskID); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile("", "attachment-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(r, au.maxSize+1))
+	if err != nil {
+		return err
+	}
+	if size > au.maxSize {
+		return core.ErrTooLarge
+	}
+	head := make([]byte, 512)
+	n, err := f.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	a.ContentType = nethttp.DetectContentType(head[:n])
+	mediaType, _, err := mime.ParseMediaType(a.ContentType)
+	if err != nil || !au.types[mediaType] {
+		return core.ErrUnsupportedType
+	}
+	a.Size = size
+	a.Hash = hex.EncodeToString(h.Sum(nil))
+	a.UploadedBy = u.ID
+	blob, err := key(ctx, a.Hash)
+	if err != nil {
+		return err
+	}
+	return au.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		created, err := au.attachmentRepo.Claim(ctx, a.Hash, a.Size)
+		if err != nil {
+			return err
+		}
+		if created {
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			if err := au.storage.Put(ctx, blob, f, size); err != nil {
+				return err
+			}
+		}
+		if err := au.attachmentRepo.Add(ctx, a); err != nil {
+			if created {
+				// the blob is still locked, no other upload relies on it yet
+				au.storage.Delete(ctx, blob)
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+func (au *attachmentUsecase) Get(ctx context.Context, taskID, id int) (*models.Attachment, error) {
+	if err := access.AllowTask(ctx, au.accessUsecase, au.taskRepo, access.ActionRead, taskID); err != nil {
+		return nil, err
+	}
+	return au.get(ctx, taskID, id)
+}
+
+func (au *attachmentUsecase) Open(ctx context.Context, taskID, id int, offset, length int64) (io.ReadCloser, error) {
+	a, err := au.Get(ctx, taskID, id)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := key(ctx, a.Hash)
+	if err != nil {
+		return nil, err
+	}
+	return au.storage.Get(ctx, blob, offset, length)
+}
+
+func (au *attachmentUsecase) Delete(ctx context.Context, taskID, id int) error {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return err
+	}
+	a, err := au.get(ctx, taskID, id)
+	if err != nil {
+		return err
+	}
+	action := access.ActionDelete
+	if a.UploadedBy == u.ID {
+		action = access.ActionWrite
+	}
+	if err := access.AllowTask(ctx, au.accessUsecase, au.taskRepo, action, taskID); err != nil {
+		return err
+	}
+	if err := au.attachmentRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	// the attachment is gone, a blob failing to go is left for Purge
+	if err := au.release(ctx, a.Hash); err != nil {
+		logrus.Error(err)
+	}
+	return nil
+}
+
+// Purge also releases the blobs left behind by a failed release
+func (au *attachmentUsecase) Purge(ctx context.Context) (int64, error) {
+	if err := access.Allow(ctx, au.accessUsecase, access.ActionManage); err != nil {
+		return 0, err
+	}
+	return au.purge(ctx)
+}
+
+// purge deletes the attachments of the deleted tasks of the workspace and releases their blobs
+func (au *attachmentUsecase) purge(ctx context.Context) (int64, error) {
+	purged, err := au.attachmentRepo.Purge(ctx)
+	if err != nil {
+		return 0, err
+	}
+	orphans, err := au.attachmentRepo.Orphans(ctx)
+	if err != nil {
+		return purged, err
+	}
+	for _, hash := range orphans {
+		if err := au.release(ctx, hash); err != nil {
+			return purged, err
+		}
+	}
+	return purged, nil
+}
+
+// release removes the blob of hash from the storage once no attachment refers to it.
+// The content goes while the blob is locked, an upload of the same content waits for it
+func (au *attachmentUsecase) release(ctx context.Context, hash string) error {
+	blob, err := key(ctx, hash)
+	if err != nil {
+		return err
+	}
+	return au.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		released, err := au.attachmentRepo.Release(ctx, hash)
+		if err != nil || !released {
+			return err
+		}
+		return au.storage.Delete(ctx, blob)
+	})
+}
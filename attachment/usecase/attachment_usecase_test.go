@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	accessmocks "github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/attachment/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	taskmocks "github.com/pratheeshm/todo-golang/task/mocks"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+// sha256 of "hello world"
+const hash = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+
+// alice (id 3) has role on workspace 7, bob (id 5) uploaded some of the attachments
+type fixture struct {
+	attachments *mocks.MockRepository
+	storage     *mocks.MockStorage
+	usecase     *attachmentUsecase
+}
+
+func newFixture(role string) *fixture {
+	f := &fixture{
+		attachments: &mocks.MockRepository{},
+		storage:     &mocks.MockStorage{},
+	}
+	tasks := &taskmocks.MockRepository{Tasks: []*models.Task{
+		{ID: 1, Title: "Take math notes", Project: "school"},
+		{ID: 2, Title: "Read chapter 3", Project: "school"},
+	}}
+	access := accessmocks.RoleUsecase(role)
+	f.usecase = NewAttachmentUsecase(f.attachments, tasks, access, f.storage, transaction.NewMemoryTransactor(), 16, []string{"text/plain", "image/png"}).(*attachmentUsecase)
+	return f
+}
+
+// stored adds an attachment of "hello world" uploaded by the user
+func (f *fixture) stored(taskID, user int) {
+	f.attachments.Attachments = append(f.attachments.Attachments, &models.Attachment{ID: len(f.attachments.Attachments) + 1,
+		TaskID: taskID, Name: "notes.txt", ContentType: "text/plain; charset=utf-8", Size: 11, Hash: hash, UploadedBy: user})
+	f.attachments.Blobs = map[string]int64{hash: 11}
+	f.storage.Blobs = map[string][]byte{"7/" + hash: []byte("hello world")}
+}
+
+func TestNewAttachmentUsecase(t *testing.T) {
+	ar := &mocks.MockRepository{}
+	tr := &taskmocks.MockRepository{}
+	au := &accessmocks.MockUsecase{}
+	s := &mocks.MockStorage{}
+	tx := transaction.NewMemoryTransactor()
+	want := &attachmentUsecase{attachmentRepo: ar, taskRepo: tr, accessUsecase: au, storage: s, transactor: tx,
+		maxSize: 1024, types: map[string]bool{"image/png": true}}
+	if got := NewAttachmentUsecase(ar, tr, au, s, tx, 1024, []string{"image/png"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAttachmentUsecase() = %v, want %v", got, want)
+	}
+}
+
+func Test_attachmentUsecase_Add(t *testing.T) {
+	f := newFixture(models.RoleMember)
+	a := &models.Attachment{TaskID: 1, Name: "notes.txt"}
+	if err := f.usecase.Add(accessmocks.GrantedContext(), a, strings.NewReader("hello world")); err != nil {
+		t.Fatalf("attachmentUsecase.Add() error = %v", err)
+	}
+	want := &models.Attachment{ID: 1, TaskID: 1, Name: "notes.txt", ContentType: "text/plain; charset=utf-8", Size: 11, Hash: hash, UploadedBy: 3, CreatedAt: a.CreatedAt}
+	if !reflect.DeepEqual(a, want) {
+		t.Errorf("attachmentUsecase.Add() attachment = %+v, want %+v", a, want)
+	}
+	if string(f.storage.Blobs["7/"+hash]) != "hello world" {
+		t.Errorf("blobs = %v", f.storage.Blobs)
+	}
+	// the same content is stored once
+	if err := f.usecase.Add(accessmocks.GrantedContext(), &models.Attachment{TaskID: 1, Name: "copy.txt"}, strings.NewReader("hello world")); err != nil {
+		t.Fatalf("attachmentUsecase.Add() error = %v", err)
+	}
+	if f.storage.Puts != 1 || len(f.attachments.Attachments) != 2 {
+		t.Errorf("got %d puts for %d attachments, want 1 put for 2 attachments", f.storage.Puts, len(f.attachments.Attachments))
+	}
+}
+
+func Test_attachmentUsecase_Add_errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		ctx        context.Context
+		taskID     int
+		content    string
+		storageErr error
+		wantErr    error
+	}{
+		{name: "too large", role: models.RoleMember, ctx: accessmocks.GrantedContext(), taskID: 1, content: "hello world, hello world", wantErr: core.ErrTooLarge},
+		{name: "unsupported type", role: models.RoleMember, ctx: accessmocks.GrantedContext(), taskID: 1, content: "%PDF-1.4 hello", wantErr: core.ErrUnsupportedType},
+		{name: "viewer can not upload", role: models.RoleViewer, ctx: accessmocks.GrantedContext(), taskID: 1, content: "hello world", wantErr: core.ErrForbidden},
+		{name: "no user", role: models.RoleMember, ctx: core.WithTenant(context.Background(), accessmocks.Workspace), taskID: 1, content: "hello world", wantErr: core.ErrForbidden},
+		{name: "task not found", role: models.RoleMember, ctx: accessmocks.GrantedContext(), taskID: 42, content: "hello world", wantErr: core.ErrRecordNotFound},
+		{name: "storage error", role: models.RoleMember, ctx: accessmocks.GrantedContext(), taskID: 1, content: "hello world", storageErr: errors.New("storage error"), wantErr: errors.New("storage error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.role)
+			f.storage.Error = tt.storageErr
+			err := f.usecase.Add(tt.ctx, &models.Attachment{TaskID: tt.taskID, Name: "notes.txt"}, strings.NewReader(tt.content))
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("attachmentUsecase.Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// a failed upload leaves nothing behind
+			if len(f.attachments.Attachments) != 0 || len(f.attachments.Blobs) != 0 {
+				t.Errorf("attachments = %v, blobs = %v", f.attachments.Attachments, f.attachments.Blobs)
+			}
+		})
+	}
+}
+
+func Test_attachmentUsecase_Open(t *testing.T) {
+	f := newFixture(models.RoleViewer)
+	f.stored(1, 5)
+	r, err := f.usecase.Open(accessmocks.GrantedContext(), 1, 1, 6, 5)
+	if err != nil {
+		t.Fatalf("attachmentUsecase.Open() error = %v", err)
+	}
+	defer r.Close()
+	if got, _ := ioutil.ReadAll(r); string(got) != "world" {
+		t.Errorf("attachmentUsecase.Open() = %q, want %q", got, "world")
+	}
+	// the attachment belongs to task 1
+	if _, err := f.usecase.Open(accessmocks.GrantedContext(), 2, 1, 0, 11); err != core.ErrRecordNotFound {
+		t.Errorf("attachmentUsecase.Open() of another task, expected %v, got %v", core.ErrRecordNotFound, err)
+	}
+}
+
+func Test_attachmentUsecase_Delete(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		uploader int
+		copies   int
+		wantErr  error
+		wantBlob bool
+	}{
+		{name: "member deletes their own attachment", role: models.RoleMember, uploader: 3, copies: 1},
+		{name: "blob kept for another attachment", role: models.RoleMember, uploader: 3, copies: 2, wantBlob: true},
+		{name: "member can not delete an attachment of someone else", role: models.RoleMember, uploader: 5, copies: 1, wantErr: core.ErrForbidden, wantBlob: true},
+		{name: "admin deletes an attachment of someone else", role: models.RoleAdmin, uploader: 5, copies: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.role)
+			for i := 0; i < tt.copies; i++ {
+				f.stored(1, tt.uploader)
+			}
+			if err := f.usecase.Delete(accessmocks.GrantedContext(), 1, 1); err != tt.wantErr {
+				t.Fatalf("attachmentUsecase.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			_, stored := f.storage.Blobs["7/"+hash]
+			_, claimed := f.attachments.Blobs[hash]
+			if stored != tt.wantBlob || claimed != tt.wantBlob {
+				t.Errorf("blob stored %v, claimed %v, want %v", stored, claimed, tt.wantBlob)
+			}
+		})
+	}
+}
+
+func Test_attachmentUsecase_Purge(t *testing.T) {
+	f := newFixture(models.RoleAdmin)
+	f.stored(1, 5)
+	f.stored(2, 5)
+	f.attachments.Deleted = []int{1, 2}
+	got, err := f.usecase.Purge(accessmocks.GrantedContext())
+	if err != nil || got != 2 {
+		t.Fatalf("attachmentUsecase.Purge() = %d, %v, want 2", got, err)
+	}
+	if len(f.storage.Blobs) != 0 || len(f.attachments.Blobs) != 0 {
+		t.Errorf("blobs left: %v, %v", f.storage.Blobs, f.attachments.Blobs)
+	}
+
+	f = newFixture(models.RoleMember)
+	if _, err := f.usecase.Purge(accessmocks.GrantedContext()); err != core.ErrForbidden {
+		t.Errorf("attachmentUsecase.Purge() by a member, expected %v, got %v", core.ErrForbidden, err)
+	}
+}
+
+func Test_attachmentUsecase_List(t *testing.T) {
+	f := newFixture(models.RoleViewer)
+	f.stored(1, 5)
+	got, err := f.usecase.List(accessmocks.GrantedContext(), 1)
+	if err != nil || len(got) != 1 {
+		t.Errorf("attachmentUsecase.List() = %v, %v", got, err)
+	}
+	if _, err := f.usecase.List(accessmocks.GrantedContext(), 42); err != core.ErrRecordNotFound {
+		t.Errorf("attachmentUsecase.List() of a missing task, expected %v, got %v", core.ErrRecordNotFound, err)
+	}
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/attachment"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/sirupsen/logrus"
+)
+
+type cleanupUsecase struct {
+	task.Usecase
+	attachments *attachmentUsecase
+}
+
+// NewCleanupUsecase will create a task.Usecase deleting the attachments of the workspace
+// along with their blobs once tu deleted a task, through Delete, Bulk or Push.
+// The task is deleted by then, a failing cleanup is logged and left for Purge
+func NewCleanupUsecase(tu task.Usecase, ar attachment.Repository, s attachment.Storage, t core.Transactor) task.Usecase {
+	return &cleanupUsecase{
+		Usecase:     tu,
+		attachments: &attachmentUsecase{attachmentRepo: ar, storage: s, transactor: t},
+	}
+}
+
+func (c *cleanupUsecase) cleanup(ctx context.Context) {
+	if _, err := c.attachments.purge(ctx); err != nil {
+		logrus.Error(err)
+	}
+}
+
+func (c *cleanupUsecase) Delete(ctx context.Context, id int) error {
+	if err := c.Usecase.Delete(ctx, id); err != nil {
+		return err
+	}
+	c.cleanup(ctx)
+	return nil
+}
+
+func (c *cleanupUsecase) Bulk(ctx context.Context, req *models.BulkRequest) (*models.BulkResponse, error) {
+	res, err := c.Usecase.Bulk(ctx, req)
+	if err != nil {
+		return res, err
+	}
+	for _, r := range res.Results {
+		if r.Op == models.BulkDelete && r.Status == models.BulkOK {
+			c.cleanup(ctx)
+			break
+		}
+	}
+	return res, nil
+}
+
+func (c *cleanupUsecase) Push(ctx context.Context, changes []*models.SyncChange) ([]*models.SyncResult, error) {
+	results, err := c.Usecase.Push(ctx, changes)
+	if err != nil {
+		return results, err
+	}
+	for i, r := range results {
+		if i < len(changes) && changes[i].Deleted && r.Status == models.SyncApplied {
+			c.cleanup(ctx)
+			break
+		}
+	}
+	return results, nil
+}
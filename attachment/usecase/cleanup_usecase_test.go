@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	accessmocks "github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+	taskmocks "github.com/pratheeshm/todo-golang/task/mocks"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+func Test_cleanupUsecase(t *testing.T) {
+	deleted := &models.BulkResponse{Results: []*models.BulkResult{{Op: models.BulkDelete, Status: models.BulkOK}}}
+	failed := &models.BulkResponse{Results: []*models.BulkResult{{Op: models.BulkDelete, Status: models.BulkNotFound}}}
+	tests := []struct {
+		name    string
+		tasks   *taskmocks.MockUsecase
+		call    func(ctx context.Context, tu *cleanupUsecase) error
+		wantErr bool
+		want    int
+	}{{
+		name:  "Normal Case 1: delete",
+		tasks: &taskmocks.MockUsecase{},
+		call:  func(ctx context.Context, tu *cleanupUsecase) error { return tu.Delete(ctx, 1) },
+		want:  0,
+	}, {
+		name:    "delete fails",
+		tasks:   &taskmocks.MockUsecase{Error: errors.New("db error")},
+		call:    func(ctx context.Context, tu *cleanupUsecase) error { return tu.Delete(ctx, 1) },
+		wantErr: true,
+		want:    1,
+	}, {
+		name:  "bulk delete",
+		tasks: &taskmocks.MockUsecase{BulkResponse: deleted},
+		call: func(ctx context.Context, tu *cleanupUsecase) error {
+			_, err := tu.Bulk(ctx, &models.BulkRequest{})
+			return err
+		},
+		want: 0,
+	}, {
+		name:  "bulk without deletion",
+		tasks: &taskmocks.MockUsecase{BulkResponse: failed},
+		call: func(ctx context.Context, tu *cleanupUsecase) error {
+			_, err := tu.Bulk(ctx, &models.BulkRequest{})
+			return err
+		},
+		want: 1,
+	}, {
+		name:  "sync push deletion",
+		tasks: &taskmocks.MockUsecase{SyncResults: []*models.SyncResult{{ID: 1, Status: models.SyncApplied}}},
+		call: func(ctx context.Context, tu *cleanupUsecase) error {
+			_, err := tu.Push(ctx, []*models.SyncChange{{ID: 1, Deleted: true}})
+			return err
+		},
+		want: 0,
+	}, {
+		name:  "sync push conflict",
+		tasks: &taskmocks.MockUsecase{SyncResults: []*models.SyncResult{{ID: 1, Status: models.SyncConflict}}},
+		call: func(ctx context.Context, tu *cleanupUsecase) error {
+			_, err := tu.Push(ctx, []*models.SyncChange{{ID: 1, Deleted: true}})
+			return err
+		},
+		want: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(models.RoleAdmin)
+			f.stored(1, 5)
+			// the repository sees the task as deleted whatever the usecase answered
+			f.attachments.Deleted = []int{1}
+			tu := NewCleanupUsecase(tt.tasks, f.attachments, f.storage, transaction.NewMemoryTransactor()).(*cleanupUsecase)
+			if err := tt.call(accessmocks.GrantedContext(), tu); (err != nil) != tt.wantErr {
+				t.Fatalf("cleanupUsecase error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(f.attachments.Attachments); got != tt.want {
+				t.Errorf("%d attachments left, want %d", got, tt.want)
+			}
+			if got := len(f.storage.Blobs); got != tt.want {
+				t.Errorf("%d blobs left, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/comment"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+//CommentHandler represents http handler for task comments
+type CommentHandler struct {
+	CommentUsecase comment.Usecase
+}
+
+// NewCommentHandler will initialize the comments resources endpoint of a task
+func NewCommentHandler(cu comment.Usecase) nethttp.Handler {
+	r := chi.NewMux()
+	commentHandler := &CommentHandler{
+		CommentUsecase: cu,
+	}
+	r.Get("/", commentHandler.List)
+	r.Post("/", commentHandler.Add)
+	r.Put("/{comment:[0-9]+}", commentHandler.Edit)
+	r.Delete("/{comment:[0-9]+}", commentHandler.Delete)
+	r.Get("/{comment:[0-9]+}/history", commentHandler.History)
+	return r
+}
+
+func writeJSON(w nethttp.ResponseWriter, status int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	res, _ := json.Marshal(body)
+	w.Write(res)
+}
+
+// writeError answers the errors shared by the comment endpoints
+func writeError(w nethttp.ResponseWriter, err error) {
+	switch err {
+	case core.ErrForbidden:
+		w.WriteHeader(nethttp.StatusForbidden)
+		w.Write([]byte("forbidden"))
+	case core.ErrRecordNotFound:
+		w.WriteHeader(nethttp.StatusNotFound)
+		w.Write([]byte("not found"))
+	default:
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+	}
+}
+
+// ids returns the ids of the task and of the comment of the url, the routes only match digits
+func ids(r *nethttp.Request) (int, int) {
+	taskID, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	id, _ := strconv.Atoi(chi.URLParam(r, "comment"))
+	return taskID, id
+}
+
+// decodeComment reads and validates the comment of the request body
+func decodeComment(w nethttp.ResponseWriter, r *nethttp.Request) (*models.Comment, bool) {
+	c := &models.Comment{}
+	if err := json.NewDecoder(r.Body).Decode(c); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return nil, false
+	}
+	validate := validator.New()
+	if err := validate.Struct(c); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return nil, false
+	}
+	c.TaskID, c.ID = ids(r)
+	return c, true
+}
+
+//List handler returns the comments of the task, oldest first
+func (h *CommentHandler) List(w nethttp.ResponseWriter, r *nethttp.Request) {
+	taskID, _ := ids(r)
+	comments, err := h.CommentUsecase.List(r.Context(), taskID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message":  "success",
+		"comments": comments,
+	})
+}
+
+//Add handler comments the task as the authenticated user
+func (h *CommentHandler) Add(w nethttp.ResponseWriter, r *nethttp.Request) {
+	c, ok := decodeComment(w, r)
+	if !ok {
+		return
+	}
+	if err := h.CommentUsecase.Add(r.Context(), c); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusCreated, map[string]interface{}{
+		"message": "success",
+		"comment": c,
+	})
+}
+
+//Edit handler replaces the body of a comment of the authenticated user
+func (h *CommentHandler) Edit(w nethttp.ResponseWriter, r *nethttp.Request) {
+	c, ok := decodeComment(w, r)
+	if !ok {
+		return
+	}
+	if err := h.CommentUsecase.Edit(r.Context(), c); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"comment": c,
+	})
+}
+
+//Delete handler deletes a comment
+func (h *CommentHandler) Delete(w nethttp.ResponseWriter, r *nethttp.Request) {
+	taskID, id := ids(r)
+	if err := h.CommentUsecase.Delete(r.Context(), taskID, id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	w.Write([]byte("success"))
+}
+
+//History handler returns the previous bodies of a comment, oldest first
+func (h *CommentHandler) History(w nethttp.ResponseWriter, r *nethttp.Request) {
+	taskID, id := ids(r)
+	revisions, err := h.CommentUsecase.History(r.Context(), taskID, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message":   "success",
+		"revisions": revisions,
+	})
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/pratheeshm/todo-golang/comment"
+	"github.com/pratheeshm/todo-golang/comment/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func withIDs(r *nethttp.Request, taskID, id string) *nethttp.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", taskID)
+	rctx.URLParams.Add("comment", id)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestNewCommentHandler(t *testing.T) {
+	h := NewCommentHandler(&mocks.MockUsecase{})
+	tests := []struct {
+		method     string
+		url        string
+		body       string
+		statusCode int
+	}{
+		{"GET", "/", "", 200},
+		{"POST", "/", `{"body":"hi"}`, 201},
+		{"PUT", "/2", `{"body":"hi"}`, 200},
+		{"DELETE", "/2", "", 200},
+		{"GET", "/2/history", "", 200},
+		{"DELETE", "/abc", "", 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Errorf("got statuscode %d but expected %d", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestCommentHandler_Add(t *testing.T) {
+	body := `{"body":"ping @bob"}`
+	tests := []struct {
+		name       string
+		usecase    comment.Usecase
+		body       string
+		statusCode int
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{},
+		body:       body,
+		statusCode: 201,
+	}, {
+		name:       "missing body",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{}`,
+		statusCode: 400,
+	}, {
+		name:       "body parse error",
+		usecase:    &mocks.MockUsecase{},
+		body:       `[]`,
+		statusCode: 400,
+	}, {
+		name:       "forbidden",
+		usecase:    &mocks.MockUsecase{Error: core.ErrForbidden},
+		body:       body,
+		statusCode: 403,
+	}, {
+		name:       "task not found",
+		usecase:    &mocks.MockUsecase{Error: core.ErrRecordNotFound},
+		body:       body,
+		statusCode: 404,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("Usecase.Error()")},
+		body:       body,
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &CommentHandler{CommentUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Add(rec, withIDs(httptest.NewRequest("POST", "/task/1/comments", bytes.NewBufferString(tt.body)), "1", ""))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if rec.Code != 201 {
+				return
+			}
+			res := struct {
+				Comment *models.Comment `json:"comment"`
+			}{}
+			if err := json.NewDecoder(rec.Body).Decode(&res); err != nil || res.Comment.TaskID != 1 {
+				t.Errorf("Test - %s , got comment %+v, %v", tt.name, res.Comment, err)
+			}
+		})
+	}
+}
+
+func TestCommentHandler_Edit(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    comment.Usecase
+		body       string
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, body: `{"body":"edited"}`, statusCode: 200},
+		{name: "missing body", usecase: &mocks.MockUsecase{}, body: `{"body":""}`, statusCode: 400},
+		{name: "not the author", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, body: `{"body":"edited"}`, statusCode: 403},
+		{name: "comment not found", usecase: &mocks.MockUsecase{Error: core.ErrRecordNotFound}, body: `{"body":"edited"}`, statusCode: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &CommentHandler{CommentUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Edit(rec, withIDs(httptest.NewRequest("PUT", "/task/1/comments/2", bytes.NewBufferString(tt.body)), "1", "2"))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestCommentHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    comment.Usecase
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, statusCode: 200},
+		{name: "forbidden", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, statusCode: 403},
+		{name: "comment not found", usecase: &mocks.MockUsecase{Error: core.ErrRecordNotFound}, statusCode: 404},
+		{name: "usecase error", usecase: &mocks.MockUsecase{Error: errors.New("Usecase.Error()")}, statusCode: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &CommentHandler{CommentUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Delete(rec, withIDs(httptest.NewRequest("DELETE", "/task/1/comments/2", nil), "1", "2"))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestCommentHandler_History(t *testing.T) {
+	uc := &mocks.MockUsecase{Revisions: []*models.CommentRevision{{ID: 1, CommentID: 2, Body: "first"}}}
+	h := &CommentHandler{CommentUsecase: uc}
+	rec := httptest.NewRecorder()
+	h.History(rec, withIDs(httptest.NewRequest("GET", "/task/1/comments/2/history", nil), "1", "2"))
+	if rec.Code != 200 {
+		t.Fatalf("got statuscode %d but expected 200", rec.Code)
+	}
+	res := struct {
+		Revisions []*models.CommentRevision `json:"revisions"`
+	}{}
+	if err := json.NewDecoder(rec.Body).Decode(&res); err != nil || len(res.Revisions) != 1 {
+		t.Errorf("got revisions %v, %v", res.Revisions, err)
+	}
+}
@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockNotifier implements inerface comment.Notifier
+type MockNotifier struct {
+	Error         error
+	Notifications []*models.Notification
+}
+
+//Notify appends the notification to Notifications
+func (m *MockNotifier) Notify(ctx context.Context, n *models.Notification) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	m.Notifications = append(m.Notifications, n)
+	return nil
+}
@@ -0,0 +1,117 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockRepository implements inerface comment.Repository
+type MockRepository struct {
+	Error    error
+	Comments []*models.Comment
+	History  map[int][]*models.CommentRevision
+	// Users maps the usernames to the ids of the users who can read every task
+	Users map[string]int
+}
+
+//Add appends a copy of the comment to Comments
+func (m *MockRepository) Add(ctx context.Context, c *models.Comment) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	c.ID = len(m.Comments) + 1
+	c.CreatedAt = time.Now()
+	c.UpdatedAt = c.CreatedAt
+	stored := *c
+	m.Comments = append(m.Comments, &stored)
+	return nil
+}
+
+//Get returns a copy of the comment of Comments with the id
+func (m *MockRepository) Get(ctx context.Context, id int) (*models.Comment, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	for _, c := range m.Comments {
+		if c.ID == id {
+			found := *c
+			return &found, nil
+		}
+	}
+	return nil, core.ErrRecordNotFound
+}
+
+//List returns copies of the comments of the task
+func (m *MockRepository) List(ctx context.Context, taskID int) ([]*models.Comment, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	comments := []*models.Comment{}
+	for _, c := range m.Comments {
+		if c.TaskID == taskID {
+			found := *c
+			comments = append(comments, &found)
+		}
+	}
+	return comments, nil
+}
+
+//Edit replaces the body of the stored comment and keeps the previous one in History
+func (m *MockRepository) Edit(ctx context.Context, c *models.Comment) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for _, stored := range m.Comments {
+		if stored.ID == c.ID {
+			if m.History == nil {
+				m.History = map[int][]*models.CommentRevision{}
+			}
+			m.History[c.ID] = append(m.History[c.ID], &models.CommentRevision{CommentID: c.ID, Body: stored.Body})
+			stored.Body = c.Body
+			stored.UpdatedAt = time.Now()
+			c.TaskID, c.AuthorID, c.CreatedAt, c.UpdatedAt = stored.TaskID, stored.AuthorID, stored.CreatedAt, stored.UpdatedAt
+			return nil
+		}
+	}
+	return core.ErrRecordNotFound
+}
+
+//Delete removes the comment from Comments
+func (m *MockRepository) Delete(ctx context.Context, id int) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for i, c := range m.Comments {
+		if c.ID == id {
+			m.Comments = append(m.Comments[:i], m.Comments[i+1:]...)
+			return nil
+		}
+	}
+	return core.ErrRecordNotFound
+}
+
+//Revisions returns the revisions of the comment kept in History
+func (m *MockRepository) Revisions(ctx context.Context, id int) ([]*models.CommentRevision, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	revisions := []*models.CommentRevision{}
+	return append(revisions, m.History[id]...), nil
+}
+
+//Mentioned returns the ids of Users of the usernames
+func (m *MockRepository) Mentioned(ctx context.Context, taskID int, usernames []string) ([]int, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	ids := []int{}
+	for _, name := range usernames {
+		if id, ok := m.Users[name]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockUsecase implements inerface comment.Usecase
+type MockUsecase struct {
+	Error     error
+	Comments  []*models.Comment
+	Revisions []*models.CommentRevision
+}
+
+//List returns Comments
+func (m *MockUsecase) List(ctx context.Context, taskID int) ([]*models.Comment, error) {
+	return m.Comments, m.Error
+}
+
+//Add comment
+func (m *MockUsecase) Add(ctx context.Context, c *models.Comment) error {
+	return m.Error
+}
+
+//Edit comment
+func (m *MockUsecase) Edit(ctx context.Context, c *models.Comment) error {
+	return m.Error
+}
+
+//Delete comment
+func (m *MockUsecase) Delete(ctx context.Context, taskID, id int) error {
+	return m.Error
+}
+
+//History returns Revisions
+func (m *MockUsecase) History(ctx context.Context, taskID, id int) ([]*models.CommentRevision, error) {
+	return m.Revisions, m.Error
+}
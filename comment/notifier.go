@@ -0,0 +1,13 @@
+package comment
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Notifier represents the way notifications reach the users,
+//a notification is sent once the comment is stored
+type Notifier interface {
+	Notify(context.Context, *models.Notification) error
+}
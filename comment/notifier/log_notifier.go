@@ -0,0 +1,31 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/comment"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+type logNotifier struct {
+	logger logrus.FieldLogger
+}
+
+// NewLogNotifier will create a comment.Notifier writing the notifications to logger,
+// it stands in until the notifications are delivered by mail or push
+func NewLogNotifier(logger logrus.FieldLogger) comment.Notifier {
+	return &logNotifier{logger: logger}
+}
+
+func (l *logNotifier) Notify(ctx context.Context, n *models.Notification) error {
+	l.logger.WithFields(logrus.Fields{
+		"type":         n.Type,
+		"id_user":      n.UserID,
+		"id_workspace": n.WorkspaceID,
+		"id_task":      n.TaskID,
+		"id_comment":   n.CommentID,
+		"id_author":    n.AuthorID,
+	}).Info("notification")
+	return nil
+}
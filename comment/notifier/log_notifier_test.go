@@ -0,0 +1,23 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func Test_logNotifier_Notify(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	n := NewLogNotifier(logger)
+	err := n.Notify(context.Background(), &models.Notification{Type: models.NotificationMention, UserID: 5, TaskID: 1, CommentID: 2, AuthorID: 3})
+	if err != nil {
+		t.Fatalf("logNotifier.Notify() error = %v", err)
+	}
+	entry := hook.LastEntry()
+	if entry == nil || entry.Level != logrus.InfoLevel || entry.Data["id_user"] != 5 || entry.Data["type"] != models.NotificationMention {
+		t.Errorf("unexpected log entry %+v", entry)
+	}
+}
@@ -0,0 +1,24 @@
+package comment
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Repository represents task comment's interface
+type Repository interface {
+	Add(context.Context, *models.Comment) error
+	Get(ctx context.Context, id int) (*models.Comment, error)
+	// List returns the comments of a task, oldest first
+	List(ctx context.Context, taskID int) ([]*models.Comment, error)
+	// Edit replaces the body of the comment and keeps the previous one as a revision
+	Edit(context.Context, *models.Comment) error
+	Delete(ctx context.Context, id int) error
+	// Revisions returns the previous bodies of a comment, oldest first
+	Revisions(ctx context.Context, id int) ([]*models.CommentRevision, error)
+	// Mentioned returns the ids of the users named by the usernames who can read
+	// the task. The username of a user is the part of their email before the @,
+	// a username shared by several users of the workspace names none of them
+	Mentioned(ctx context.Context, taskID int, usernames []string) ([]int, error)
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pratheeshm/todo-golang/comment"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+const commentColumns = "id_comment, id_task, id_author, body, created_at, updated_at"
+
+type postgresCommentRepository struct {
+	*sql.DB
+}
+
+// NewPostgresCommentRepository will create an object that represent the comment.Repository interface
+func NewPostgresCommentRepository(db *sql.DB) comment.Repository {
+	return &postgresCommentRepository{db}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanComment(s scanner) (*models.Comment, error) {
+	c := &models.Comment{}
+	err := s.Scan(&c.ID, &c.TaskID, &c.AuthorID, &c.Body, &c.CreatedAt, &c.UpdatedAt)
+	return c, err
+}
+
+// Add only comments the tasks of the workspace that are not deleted
+func (p *postgresCommentRepository) Add(ctx context.Context, c *models.Comment) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	err = transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "INSERT INTO task_comment(id_task, id_author, body, id_workspace) SELECT $1, $2, $3, $4 WHERE EXISTS (SELECT 1 FROM task WHERE id_task = $1 AND id_workspace = $4 AND NOT deleted) RETURNING id_comment, created_at, updated_at",
+		c.TaskID, c.AuthorID, c.Body, workspace).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresCommentRepository) Get(ctx context.Context, id int) (*models.Comment, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "SELECT "+commentColumns+" FROM task_comment WHERE id_comment = $1 AND id_workspace = $2 AND NOT deleted", id, workspace)
+	c, err := scanComment(row)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	return c, err
+}
+
+func (p *postgresCommentRepository) List(ctx context.Context, taskID int) ([]*models.Comment, error) {
+	comments := make([]*models.Comment, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return comments, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT "+commentColumns+" FROM task_comment WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted ORDER BY created_at, id_comment",
+		taskID, workspace)
+	if err != nil {
+		return comments, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		c, err := scanComment(rows)
+		if err != nil {
+			return []*models.Comment{}, err
+		}
+		comments = append(comments, c)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.Comment{}, err
+	}
+	return comments, nil
+}
+
+// Edit keeps the previous body in the same statement, the CTE reads it before the update
+func (p *postgresCommentRepository) Edit(ctx context.Context, c *models.Comment) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	err = transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "WITH old AS (SELECT id_comment, body FROM task_comment WHERE id_comment = $2 AND id_workspace = $3 AND NOT deleted FOR UPDATE), "+
+		"revision AS (INSERT INTO task_comment_revision(id_comment, body) SELECT id_comment, body FROM old) "+
+		"UPDATE task_comment c SET body = $1, updated_at = now() FROM old WHERE c.id_comment = old.id_comment AND c.id_workspace = $3 RETURNING c.id_task, c.id_author, c.created_at, c.updated_at",
+		c.Body, c.ID, workspace).Scan(&c.TaskID, &c.AuthorID, &c.CreatedAt, &c.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+// Delete keeps the row and its revisions, the comment is hidden
+func (p *postgresCommentRepository) Delete(ctx context.Context, id int) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "UPDATE task_comment SET deleted = true WHERE id_comment = $1 AND id_workspace = $2 AND NOT deleted", id, workspace)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresCommentRepository) Revisions(ctx context.Context, id int) ([]*models.CommentRevision, error) {
+	revisions := make([]*models.CommentRevision, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return revisions, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT r.id_revision, r.id_comment, r.body, r.created_at FROM task_comment_revision r JOIN task_comment c ON c.id_comment = r.id_comment WHERE r.id_comment = $1 AND c.id_workspace = $2 AND NOT c.deleted ORDER BY r.created_at, r.id_revision",
+		id, workspace)
+	if err != nil {
+		return revisions, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		r := &models.CommentRevision{}
+		if err := rows.Scan(&r.ID, &r.CommentID, &r.Body, &r.CreatedAt); err != nil {
+			return []*models.CommentRevision{}, err
+		}
+		revisions = append(revisions, r)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.CommentRevision{}, err
+	}
+	return revisions, nil
+}
+
+// Mentioned keeps the users whose grants reach the task: workspace grants,
+// grants on the project of the task and grants on the task itself. A username
+// shared by several users of the workspace, alice@a.com and alice@b.com, is
+// ambiguous and names none of them
+func (p *postgresCommentRepository) Mentioned(ctx context.Context, taskID int, usernames []string) ([]int, error) {
+	ids := make([]int, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return ids, err
+	}
+	if len(usernames) == 0 {
+		return ids, nil
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "WITH named AS (SELECT DISTINCT u.id_user, lower(split_part(u.email, '@', 1)) AS username FROM app_user u JOIN role_grant g ON g.id_user = u.id_user "+
+		"WHERE g.id_workspace = $2 AND lower(split_part(u.email, '@', 1)) = ANY($3::varchar[])), "+
+		"single AS (SELECT min(id_user) AS id_user FROM named GROUP BY username HAVING count(*) = 1) "+
+		"SELECT DISTINCT g.id_user FROM single s JOIN role_grant g ON g.id_user = s.id_user JOIN task t ON t.id_workspace = g.id_workspace "+
+		"WHERE t.id_task = $1 AND t.id_workspace = $2 AND (g.id_task = t.id_task OR g.id_task IS NULL AND g.project IN ('', t.project)) ORDER BY g.id_user",
+		taskID, workspace, pq.Array(usernames))
+	if err != nil {
+		return ids, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return []int{}, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return []int{}, err
+	}
+	return ids, nil
+}
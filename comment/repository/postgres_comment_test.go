@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+var commentRowColumns = []string{"id_comment", "id_task", "id_author", "body", "created_at", "updated_at"}
+
+func Test_postgresCommentRepository_Add(t *testing.T) {
+	query := "INSERT INTO task_comment(id_task, id_author, body, id_workspace) SELECT $1, $2, $3, $4 WHERE EXISTS (SELECT 1 FROM task WHERE id_task = $1 AND id_workspace = $4 AND NOT deleted) RETURNING id_comment, created_at, updated_at"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantID  int
+		wantErr error
+	}{{
+		name:   "Normal Case 1: comment added",
+		rows:   sqlmock.NewRows([]string{"id_comment", "created_at", "updated_at"}).AddRow(3, time.Time{}, time.Time{}),
+		wantID: 3,
+	}, {
+		name:    "task not found",
+		rows:    sqlmock.NewRows([]string{"id_comment", "created_at", "updated_at"}),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, 5, "ping @bob", coremocks.Tenant).WillReturnRows(tt.rows)
+			c := &models.Comment{TaskID: 1, AuthorID: 5, Body: "ping @bob"}
+			if err := NewPostgresCommentRepository(db).Add(coremocks.TenantContext(), c); err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if c.ID != tt.wantID {
+				t.Errorf("Test %s - id = %d, want %d", tt.name, c.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func Test_postgresCommentRepository_Get(t *testing.T) {
+	query := "SELECT id_comment, id_task, id_author, body, created_at, updated_at FROM task_comment WHERE id_comment = $1 AND id_workspace = $2 AND NOT deleted"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *models.Comment
+		wantErr error
+	}{{
+		name: "Normal Case 1: comment found",
+		rows: sqlmock.NewRows(commentRowColumns).AddRow(2, 1, 5, "ping @bob", time.Time{}, time.Time{}),
+		want: &models.Comment{ID: 2, TaskID: 1, AuthorID: 5, Body: "ping @bob"},
+	}, {
+		name:    "comment not found",
+		rows:    sqlmock.NewRows(commentRowColumns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(2, coremocks.Tenant).WillReturnRows(tt.rows)
+			got, err := NewPostgresCommentRepository(db).Get(coremocks.TenantContext(), 2)
+			if err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresCommentRepository_List(t *testing.T) {
+	query := "SELECT id_comment, id_task, id_author, body, created_at, updated_at FROM task_comment WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted ORDER BY created_at, id_comment"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbError error
+		want    []*models.Comment
+		wantErr bool
+	}{{
+		name: "Normal Case 1: comments of the task",
+		rows: sqlmock.NewRows(commentRowColumns).
+			AddRow(1, 1, 5, "first", time.Time{}, time.Time{}).
+			AddRow(2, 1, 6, "second", time.Time{}, time.Time{}),
+		want: []*models.Comment{
+			{ID: 1, TaskID: 1, AuthorID: 5, Body: "first"},
+			{ID: 2, TaskID: 1, AuthorID: 6, Body: "second"},
+		},
+	}, {
+		name:    "db error",
+		rows:    sqlmock.NewRows(commentRowColumns),
+		dbError: errors.New("db error"),
+		want:    []*models.Comment{},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant).WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			got, err := NewPostgresCommentRepository(db).List(coremocks.TenantContext(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresCommentRepository_Edit(t *testing.T) {
+	query := "WITH old AS (SELECT id_comment, body FROM task_comment WHERE id_comment = $2 AND id_workspace = $3 AND NOT deleted FOR UPDATE), " +
+		"revision AS (INSERT INTO task_comment_revision(id_comment, body) SELECT id_comment, body FROM old) " +
+		"UPDATE task_comment c SET body = $1, updated_at = now() FROM old WHERE c.id_comment = old.id_comment AND c.id_workspace = $3 RETURNING c.id_task, c.id_author, c.created_at, c.updated_at"
+	columns := []string{"id_task", "id_author", "created_at", "updated_at"}
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr error
+	}{{
+		name: "Normal Case 1: comment edited",
+		rows: sqlmock.NewRows(columns).AddRow(1, 5, time.Time{}, time.Time{}),
+	}, {
+		name:    "comment not found",
+		rows:    sqlmock.NewRows(columns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("ping @carol", 2, coremocks.Tenant).WillReturnRows(tt.rows)
+			c := &models.Comment{ID: 2, Body: "ping @carol"}
+			err = NewPostgresCommentRepository(db).Edit(coremocks.TenantContext(), c)
+			if err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if err == nil && (c.TaskID != 1 || c.AuthorID != 5) {
+				t.Errorf("Test %s - got = %+v", tt.name, c)
+			}
+		})
+	}
+}
+
+func Test_postgresCommentRepository_Delete(t *testing.T) {
+	query := "UPDATE task_comment SET deleted = true WHERE id_comment = $1 AND id_workspace = $2 AND NOT deleted"
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "Normal Case 1: comment deleted", affected: 1},
+		{name: "comment not found", affected: 0, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(2, coremocks.Tenant).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if err := NewPostgresCommentRepository(db).Delete(coremocks.TenantContext(), 2); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresCommentRepository_Revisions(t *testing.T) {
+	query := "SELECT r.id_revision, r.id_comment, r.body, r.created_at FROM task_comment_revision r JOIN task_comment c ON c.id_comment = r.id_comment WHERE r.id_comment = $1 AND c.id_workspace = $2 AND NOT c.deleted ORDER BY r.created_at, r.id_revision"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(2, coremocks.Tenant).
+		WillReturnRows(sqlmock.NewRows([]string{"id_revision", "id_comment", "body", "created_at"}).
+			AddRow(1, 2, "ping @bob", time.Time{}))
+	got, err := NewPostgresCommentRepository(db).Revisions(coremocks.TenantContext(), 2)
+	if err != nil {
+		t.Fatalf("postgresCommentRepository.Revisions() error = %v", err)
+	}
+	want := []*models.CommentRevision{{ID: 1, CommentID: 2, Body: "ping @bob"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("postgresCommentRepository.Revisions() = %v, want %v", got, want)
+	}
+}
+
+func Test_postgresCommentRepository_Mentioned(t *testing.T) {
+	query := "WITH named AS (SELECT DISTINCT u.id_user, lower(split_part(u.email, '@', 1)) AS username FROM app_user u JOIN role_grant g ON g.id_user = u.id_user " +
+		"WHERE g.id_workspace = $2 AND lower(split_part(u.email, '@', 1)) = ANY($3::varchar[])), " +
+		"single AS (SELECT min(id_user) AS id_user FROM named GROUP BY username HAVING count(*) = 1) " +
+		"SELECT DISTINCT g.id_user FROM single s JOIN role_grant g ON g.id_user = s.id_user JOIN task t ON t.id_workspace = g.id_workspace " +
+		"WHERE t.id_task = $1 AND t.id_workspace = $2 AND (g.id_task = t.id_task OR g.id_task IS NULL AND g.project IN ('', t.project)) ORDER BY g.id_user"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant, `{"bob","carol"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"id_user"}).AddRow(5).AddRow(6))
+	p := NewPostgresCommentRepository(db)
+	got, err := p.Mentioned(coremocks.TenantContext(), 1, []string{"bob", "carol"})
+	if err != nil || !reflect.DeepEqual(got, []int{5, 6}) {
+		t.Errorf("postgresCommentRepository.Mentioned() = %v, %v, want [5 6]", got, err)
+	}
+	// no username, no query
+	if got, err := p.Mentioned(coremocks.TenantContext(), 1, nil); err != nil || len(got) != 0 {
+		t.Errorf("postgresCommentRepository.Mentioned() = %v, %v, want none", got, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func Test_postgresCommentRepository_withoutTenant(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	ctx := context.Background()
+	p := NewPostgresCommentRepository(db)
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Add", func() error { return p.Add(ctx, &models.Comment{TaskID: 1, Body: "a"}) }},
+		{"Get", func() error { _, err := p.Get(ctx, 1); return err }},
+		{"List", func() error { _, err := p.List(ctx, 1); return err }},
+		{"Edit", func() error { return p.Edit(ctx, &models.Comment{ID: 1, Body: "a"}) }},
+		{"Delete", func() error { return p.Delete(ctx, 1) }},
+		{"Revisions", func() error { _, err := p.Revisions(ctx, 1); return err }},
+		{"Mentioned", func() error { _, err := p.Mentioned(ctx, 1, []string{"bob"}); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != core.ErrNoTenant {
+				t.Errorf("expected %v, got %v", core.ErrNoTenant, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package comment
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Usecase represents task comment's usecases, the author of a comment
+//is the user of the context
+type Usecase interface {
+	// List returns the comments of a task
+	List(ctx context.Context, taskID int) ([]*models.Comment, error)
+	// Add stores a comment and notifies the users it mentions
+	Add(context.Context, *models.Comment) error
+	// Edit replaces the body of a comment of the user and notifies the users newly mentioned
+	Edit(context.Context, *models.Comment) error
+	// Delete deletes a comment of the user, the admins of the task delete any comment
+	Delete(ctx context.Context, taskID, id int) error
+	// History returns the previous bodies of a comment
+	History(ctx context.Context, taskID, id int) ([]*models.CommentRevision, error)
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/comment"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/markdown"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/sirupsen/logrus"
+)
+
+type commentUsecase struct {
+	commentRepo   comment.Repository
+	taskRepo      task.Repository
+	accessUsecase access.Usecase
+	notifier      comment.Notifier
+}
+
+// NewCommentUsecase will create new a commentUsecase object representation of comment.Usecase interface.
+// Comments follow the role of the user on their task: viewers read them, members write them
+// and admins delete the comments of others
+func NewCommentUsecase(cr comment.Repository, tr task.Repository, au access.Usecase, n comment.Notifier) comment.Usecase {
+	return &commentUsecase{
+		commentRepo:   cr,
+		taskRepo:      tr,
+		accessUsecase: au,
+		notifier:      n,
+	}
+}
+
+// get returns the comment when it belongs to the task
+func (cu *commentUsecase) get(ctx context.Context, taskID, id int) (*models.Comment, error) {
+	c, err := cu.commentRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.TaskID != taskID {
+		return nil, core.ErrRecordNotFound
+	}
+	return c, nil
+}
+
+func render(c *models.Comment) {
+	c.BodyHTML = markdown.Render(c.Body)
+}
+
+func (cu *commentUsecase) List(ctx context.Context, taskID int) ([]*models.Comment, error) {
+	if err := access.AllowTask(ctx, cu.accessUsecase, cu.taskRepo, access.ActionRead, taskID); err != nil {
+		return nil, err
+	}
+	comments, err := cu.commentRepo.List(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range comments {
+		render(c)
+	}
+	return comments, nil
+}
+
+func (cu *commentUsecase) Add(ctx context.Context, c *models.Comment) error {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return err
+	}
+	if err := access.AllowTask(ctx, cu.accessUsecase, cu.taskRepo, access.ActionWrite, c.TaskID); err != nil {
+		return err
+	}
+	c.AuthorID = u.ID
+	if err := cu.commentRepo.Add(ctx, c); err != nil {
+		return err
+	}
+	render(c)
+	cu.notify(ctx, c, markdown.Mentions(c.Body))
+	return nil
+}
+
+func (cu *commentUsecase) Edit(ctx context.Context, c *models.Comment) error {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return err
+	}
+	if err := access.AllowTask(ctx, cu.accessUsecase, cu.taskRepo, access.ActionWrite, c.TaskID); err != nil {
+		return err
+	}
+	old, err := cu.get(ctx, c.TaskID, c.ID)
+	if err != nil {
+		return err
+	}
+	if old.AuthorID != u.ID {
+		return core.ErrForbidden
+	}
+	if err := cu.commentRepo.Edit(ctx, c); err != nil {
+		return err
+	}
+	render(c)
+	cu.notify(ctx, c, added(markdown.Mentions(old.Body), markdown.Mentions(c.Body)))
+	return nil
+}
+
+// added returns the names of now missing from before
+func added(before, now []string) []string {
+	known := map[string]bool{}
+	for _, name := range before {
+		known[name] = true
+	}
+	names := []string{}
+	for _, name := range now {
+		if !known[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Delete checks the access to the task before looking the comment up, so that
+// a user who can not write on the task does not learn which comments exist
+func (cu *commentUsecase) Delete(ctx context.Context, taskID, id int) error {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return err
+	}
+	if err := access.AllowTask(ctx, cu.accessUsecase, cu.taskRepo, access.ActionWrite, taskID); err != nil {
+		return err
+	}
+	c, err := cu.get(ctx, taskID, id)
+	if err != nil {
+		return err
+	}
+	if c.AuthorID != u.ID {
+		if err := access.AllowTask(ctx, cu.accessUsecase, cu.taskRepo, access.ActionDelete, taskID); err != nil {
+			return err
+		}
+	}
+	return cu.commentRepo.Delete(ctx, id)
+}
+
+func (cu *commentUsecase) History(ctx context.Context, taskID, id int) ([]*models.CommentRevision, error) {
+	if err := access.AllowTask(ctx, cu.accessUsecase, cu.taskRepo, access.ActionRead, taskID); err != nil {
+		return nil, err
+	}
+	if _, err := cu.get(ctx, taskID, id); err != nil {
+		return nil, err
+	}
+	revisions, err := cu.commentRepo.Revisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range revisions {
+		r.BodyHTML = markdown.Render(r.Body)
+	}
+	return revisions, nil
+}
+
+// notify sends a mention to the users named who can read the task, the author
+// aside. The comment is stored by then, failures are logged and not returned
+func (cu *commentUsecase) notify(ctx context.Context, c *models.Comment, usernames []string) {
+	if len(usernames) == 0 {
+		return
+	}
+	users, err := cu.commentRepo.Mentioned(ctx, c.TaskID, usernames)
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	workspace, _ := core.TenantFromContext(ctx)
+	for _, id := range users {
+		if id == c.AuthorID {
+			continue
+		}
+		n := &models.Notification{Type: models.NotificationMention, UserID: id, WorkspaceID: workspace,
+			TaskID: c.TaskID, CommentID: c.ID, AuthorID: c.AuthorID}
+		if err := cu.notifier.Notify(ctx, n); err != nil {
+			logrus.Error(err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	accessmocks "github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/comment/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	taskmocks "github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+// alice (id 3) and bob (id 5) are members of workspace 7, carol (id 6) only reads it
+type fixture struct {
+	comments *mocks.MockRepository
+	notifier *mocks.MockNotifier
+	usecase  *commentUsecase
+}
+
+func newFixture(role string) *fixture {
+	f := &fixture{
+		comments: &mocks.MockRepository{Users: map[string]int{"alice": 3, "bob": 5, "carol": 6}},
+		notifier: &mocks.MockNotifier{},
+	}
+	tasks := &taskmocks.MockRepository{Tasks: []*models.Task{{ID: 1, Title: "Take math notes", Project: "school"}}}
+	access := accessmocks.RoleUsecase(role)
+	f.usecase = NewCommentUsecase(f.comments, tasks, access, f.notifier).(*commentUsecase)
+	return f
+}
+
+func TestNewCommentUsecase(t *testing.T) {
+	cr := &mocks.MockRepository{}
+	tr := &taskmocks.MockRepository{}
+	au := &accessmocks.MockUsecase{}
+	n := &mocks.MockNotifier{}
+	want := &commentUsecase{commentRepo: cr, taskRepo: tr, accessUsecase: au, notifier: n}
+	if got := NewCommentUsecase(cr, tr, au, n); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewCommentUsecase() = %v, want %v", got, want)
+	}
+}
+
+func Test_commentUsecase_Add(t *testing.T) {
+	f := newFixture(models.RoleMember)
+	c := &models.Comment{TaskID: 1, Body: "**done**, @bob @alice @dave please check"}
+	if err := f.usecase.Add(accessmocks.GrantedContext(), c); err != nil {
+		t.Fatalf("commentUsecase.Add() error = %v", err)
+	}
+	if c.AuthorID != 3 || c.BodyHTML != "<p><strong>done</strong>, @bob @alice @dave please check</p>\n" {
+		t.Errorf("commentUsecase.Add() comment = %+v", c)
+	}
+	// the author is not told about their own mention, unknown users are ignored
+	want := []*models.Notification{{Type: models.NotificationMention, UserID: 5, WorkspaceID: 7, TaskID: 1, CommentID: 1, AuthorID: 3}}
+	if !reflect.DeepEqual(f.notifier.Notifications, want) {
+		t.Errorf("notifications = %v, want %v", f.notifier.Notifications, want)
+	}
+}
+
+func Test_commentUsecase_Add_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		ctx     context.Context
+		taskID  int
+		wantErr error
+	}{
+		{name: "viewer can not comment", role: models.RoleViewer, ctx: accessmocks.GrantedContext(), taskID: 1, wantErr: core.ErrForbidden},
+		{name: "no user", role: models.RoleMember, ctx: core.WithTenant(context.Background(), accessmocks.Workspace), taskID: 1, wantErr: core.ErrForbidden},
+		{name: "task not found", role: models.RoleMember, ctx: accessmocks.GrantedContext(), taskID: 42, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.role)
+			if err := f.usecase.Add(tt.ctx, &models.Comment{TaskID: tt.taskID, Body: "hi"}); err != tt.wantErr {
+				t.Errorf("commentUsecase.Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(f.comments.Comments) != 0 {
+				t.Errorf("comment stored: %v", f.comments.Comments)
+			}
+		})
+	}
+}
+
+func Test_commentUsecase_Edit(t *testing.T) {
+	f := newFixture(models.RoleMember)
+	f.comments.Comments = []*models.Comment{{ID: 1, TaskID: 1, AuthorID: 3, Body: "ping @bob"}}
+	c := &models.Comment{ID: 1, TaskID: 1, Body: "ping @bob and @carol"}
+	if err := f.usecase.Edit(accessmocks.GrantedContext(), c); err != nil {
+		t.Fatalf("commentUsecase.Edit() error = %v", err)
+	}
+	// bob was mentioned already
+	if len(f.notifier.Notifications) != 1 || f.notifier.Notifications[0].UserID != 6 {
+		t.Errorf("notifications = %v, want carol only", f.notifier.Notifications)
+	}
+	history, err := f.usecase.History(accessmocks.GrantedContext(), 1, 1)
+	if err != nil {
+		t.Fatalf("commentUsecase.History() error = %v", err)
+	}
+	want := []*models.CommentRevision{{CommentID: 1, Body: "ping @bob", BodyHTML: "<p>ping @bob</p>\n"}}
+	if !reflect.DeepEqual(history, want) {
+		t.Errorf("commentUsecase.History() = %v, want %v", history, want)
+	}
+}
+
+func Test_commentUsecase_Edit_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment *models.Comment
+		wantErr error
+	}{
+		{name: "comment of someone else", comment: &models.Comment{ID: 1, TaskID: 1, Body: "mine now"}, wantErr: core.ErrForbidden},
+		{name: "comment of another task", comment: &models.Comment{ID: 2, TaskID: 1, Body: "moved"}, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(models.RoleAdmin)
+			f.comments.Comments = []*models.Comment{
+				{ID: 1, TaskID: 1, AuthorID: 5, Body: "ping"},
+				{ID: 2, TaskID: 2, AuthorID: 3, Body: "ping"},
+			}
+			if err := f.usecase.Edit(accessmocks.GrantedContext(), tt.comment); err != tt.wantErr {
+				t.Errorf("commentUsecase.Edit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_commentUsecase_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		id      int
+		wantErr error
+	}{
+		{name: "member deletes their own comment", role: models.RoleMember, id: 1},
+		{name: "member can not delete a comment of someone else", role: models.RoleMember, id: 2, wantErr: core.ErrForbidden},
+		{name: "admin deletes a comment of someone else", role: models.RoleAdmin, id: 2},
+		{name: "comment not found", role: models.RoleAdmin, id: 42, wantErr: core.ErrRecordNotFound},
+		{name: "viewer is forbidden before the lookup", role: models.RoleViewer, id: 42, wantErr: core.ErrForbidden},
+		{name: "viewer can not delete an existing comment", role: models.RoleViewer, id: 2, wantErr: core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.role)
+			f.comments.Comments = []*models.Comment{
+				{ID: 1, TaskID: 1, AuthorID: 3, Body: "mine"},
+				{ID: 2, TaskID: 1, AuthorID: 5, Body: "theirs"},
+			}
+			if err := f.usecase.Delete(accessmocks.GrantedContext(), 1, tt.id); err != tt.wantErr {
+				t.Errorf("commentUsecase.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_commentUsecase_List(t *testing.T) {
+	f := newFixture(models.RoleViewer)
+	f.comments.Comments = []*models.Comment{{ID: 1, TaskID: 1, AuthorID: 5, Body: "see `notes.md`"}}
+	got, err := f.usecase.List(accessmocks.GrantedContext(), 1)
+	if err != nil {
+		t.Fatalf("commentUsecase.List() error = %v", err)
+	}
+	if len(got) != 1 || got[0].BodyHTML != "<p>see <code>notes.md</code></p>\n" {
+		t.Errorf("commentUsecase.List() = %v", got)
+	}
+}
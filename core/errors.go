@@ -5,4 +5,44 @@ import "errors"
 var (
 	//ErrRecordNotFound :=
 	ErrRecordNotFound = errors.New("record not found")
+	//ErrConflict is returned when a record was changed since the given version
+	ErrConflict = errors.New("version conflict")
+	//ErrInvalidSyncToken is returned when a sync token can not be decoded
+	ErrInvalidSyncToken = errors.New("invalid sync token")
+	//ErrInvalidView is returned when the filter, sort or columns of a view do not compile
+	ErrInvalidView = errors.New("invalid view")
+	//ErrAlreadyExists is returned when a unique field of the record is taken
+	ErrAlreadyExists = errors.New("record already exists")
+	//ErrInvalidCredentials is returned when the email or the password of a login is wrong
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	//ErrInvalidToken is returned when a token is malformed, expired or revoked
+	ErrInvalidToken = errors.New("invalid token")
+	//ErrNoTenant is returned when a repository is called without a workspace in the context
+	ErrNoTenant = errors.New("no tenant in context")
+	//ErrForbidden is returned when the role of the user does not allow the action
+	ErrForbidden = errors.New("forbidden")
+	//ErrNotMember is returned when a project or a task is shared with, or a task assigned to, a user out of the workspace
+	ErrNotMember = errors.New("user is not a member of the workspace")
+	//ErrLastAdmin is returned when a change would leave the workspace without an admin
+	ErrLastAdmin = errors.New("workspace needs an admin")
+	//ErrTooLarge is returned when an attachment is over the size limit
+	ErrTooLarge = errors.New("attachment is too large")
+	//ErrUnsupportedType is returned when the content of an attachment is not of an allowed media type
+	ErrUnsupportedType = errors.New("unsupported media type")
+	//ErrUnranked is returned when a task is moved next to a task that has no rank yet
+	ErrUnranked = errors.New("task has no rank")
+	//ErrInvalidMove is returned when the task a task is moved before comes before the one it is moved after
+	ErrInvalidMove = errors.New("invalid move")
+	//ErrWIPLimit is returned when a task would go over the WIP limit of the column of its status
+	ErrWIPLimit = errors.New("wip limit reached")
+	//ErrOverlap is returned when a time entry overlaps another entry, or a running timer, of the same user
+	ErrOverlap = errors.New("time entry overlaps another one")
+	//ErrSprintClosed is returned when a closed sprint is changed or tasks are carried over to it
+	ErrSprintClosed = errors.New("sprint is closed")
+	//ErrInvalidTemplate is returned when a placeholder of a template is malformed or a task it renders is invalid
+	ErrInvalidTemplate = errors.New("invalid template")
+	//ErrTemplateValue is returned when a template is instantiated without the value of one of its placeholders
+	ErrTemplateValue = errors.New("template value missing")
+	//ErrInvalidRule is returned when the condition of a rule does not compile or the value of one of its actions is invalid
+	ErrInvalidRule = errors.New("invalid rule")
 )
@@ -0,0 +1,15 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+// Tenant is the workspace the repositories are called for in the tests
+const Tenant = 7
+
+//TenantContext returns a context acting in Tenant
+func TenantContext() context.Context {
+	return core.WithTenant(context.Background(), Tenant)
+}
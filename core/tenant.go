@@ -0,0 +1,19 @@
+package core
+
+import "context"
+
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx scoped to the workspace
+func WithTenant(ctx context.Context, workspaceID int) context.Context {
+	return context.WithValue(ctx, tenantKey{}, workspaceID)
+}
+
+// TenantFromContext returns the workspace ctx is scoped to. ErrNoTenant is returned
+// for an unscoped ctx, repositories fail on it rather than reading every workspace
+func TenantFromContext(ctx context.Context) (int, error) {
+	if id, ok := ctx.Value(tenantKey{}).(int); ok {
+		return id, nil
+	}
+	return 0, ErrNoTenant
+}
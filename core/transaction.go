@@ -0,0 +1,12 @@
+package core
+
+import "context"
+
+// Transactor runs a unit of work spanning several repositories atomically
+type Transactor interface {
+	// WithinTransaction calls fn with a context carrying the transaction, repositories
+	// called with that context take part in it. The transaction is rolled back when fn
+	// returns an error or panics and committed otherwise. Nested calls join the
+	// transaction already carried by ctx.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
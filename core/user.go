@@ -0,0 +1,29 @@
+package core
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx carrying the authenticated user
+func WithUser(ctx context.Context, u *models.User) context.Context {
+	return context.WithValue(ctx, userKey{}, u)
+}
+
+// UserFromContext returns the user authenticated for the request of ctx
+func UserFromContext(ctx context.Context) (*models.User, bool) {
+	u, ok := ctx.Value(userKey{}).(*models.User)
+	return u, ok
+}
+
+// RequireUser returns the user of ctx, ErrForbidden is returned when the request is not authenticated
+func RequireUser(ctx context.Context) (*models.User, error) {
+	u, ok := UserFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+	return u, nil
+}
@@ -1,5 +1,305 @@
+CREATE TABLE workspace(
+    id_workspace serial primary key,
+    name varchar(254) not null,
+    created_at timestamptz not null default now()
+);
+
+CREATE TABLE app_user(
+    id_user serial primary key,
+    email varchar(254) not null unique,
+    password_hash varchar(100) not null,
+    id_workspace integer not null references workspace(id_workspace),
+    -- signs the calendar feed urls of the user, empty until the first one
+    feed_key varchar(64) not null default '',
+    created_at timestamptz not null default now()
+);
+
+CREATE SEQUENCE task_change_seq;
+
 CREATE TABLE task(
-    id_task serial not null,
+    id_task serial primary key,
     title varchar(50) not null,
-    status varchar(10) not null
-);
\ No newline at end of file
+    status varchar(10) not null,
+    description text not null default '',
+    priority smallint not null default 0,
+    project varchar(50) not null default '',
+    tags varchar(30)[] not null default '{}',
+    due_date timestamptz,
+    id_workspace integer not null references workspace(id_workspace),
+    created_by integer references app_user(id_user),
+    assignees integer[] not null default '{}',
+    -- manual order, see the rank package. New tasks have none and come last
+    rank text COLLATE "C",
+    created_seq bigint not null default 0,
+    change_seq bigint not null default 0,
+    -- the transactions of the writes, see task_bump_change_seq
+    created_txid bigint not null default 0,
+    change_txid bigint not null default 0,
+    deleted boolean not null default false,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+CREATE INDEX task_change_seq_idx ON task(id_workspace, change_txid, change_seq);
+CREATE INDEX task_workspace_idx ON task(id_workspace);
+-- GET /me/tasks looks the tasks up by assignee
+CREATE INDEX task_assignees_idx ON task USING GIN (assignees);
+CREATE INDEX task_rank_idx ON task(id_workspace, rank, id_task);
+
+-- the full-text index over title and description, task_search_<language>_idx,
+-- is created on start for the text search configuration set as search.language
+-- in config/app.json
+
+-- every write takes the next value of task_change_seq along with the id of
+-- its transaction. The sequence is taken before commit, so a later value can
+-- commit first: sync clients page by (change_txid, change_seq) and only read
+-- the transactions below the oldest one still in flight
+CREATE FUNCTION task_bump_change_seq() RETURNS trigger AS $$
+BEGIN
+    -- the rank is not synced, moving a task is not a change
+    IF TG_OP = 'UPDATE' AND to_jsonb(NEW) - 'rank' = to_jsonb(OLD) - 'rank' THEN
+        RETURN NEW;
+    END IF;
+    NEW.change_seq := nextval('task_change_seq');
+    NEW.change_txid := txid_current();
+    IF TG_OP = 'INSERT' THEN
+        NEW.created_seq := NEW.change_seq;
+        NEW.created_txid := NEW.change_txid;
+    END IF;
+    NEW.updated_at := now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_change_seq BEFORE INSERT OR UPDATE ON task
+    FOR EACH ROW EXECUTE PROCEDURE task_bump_change_seq();
+
+CREATE TABLE task_event(
+    id_event serial primary key,
+    id_task integer not null references task(id_task),
+    type varchar(10) not null,
+    status varchar(10) not null,
+    -- the user (un)assigned by the event
+    id_user integer references app_user(id_user),
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX task_event_task_idx ON task_event(id_task, created_at);
+-- GET /stats counts the tasks created over a range
+CREATE INDEX task_created_idx ON task(id_workspace, created_at);
+
+-- keys are scoped by user, see idempotency/delivery/http
+CREATE TABLE idempotency_key(
+    key varchar(300) primary key,
+    fingerprint char(64) not null,
+    status_code integer not null default 0,
+    content_type varchar(100) not null default '',
+    body bytea,
+    expires_at timestamptz not null
+);
+
+CREATE TABLE task_view(
+    id_view serial primary key,
+    id_workspace integer not null references workspace(id_workspace),
+    name varchar(50) not null,
+    id_owner integer not null references app_user(id_user),
+    project varchar(50) not null default '',
+    filter text not null default '',
+    sort varchar(200) not null default '',
+    columns varchar(30)[] not null default '{}',
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+CREATE INDEX task_view_owner_idx ON task_view(id_workspace, id_owner);
+CREATE INDEX task_view_project_idx ON task_view(project) WHERE project <> '';
+
+-- a grant gives a role on the workspace, on one of its projects when project
+-- is set or on one task when id_task is set, see access.Policy
+CREATE TABLE role_grant(
+    id_grant serial primary key,
+    id_workspace integer not null references workspace(id_workspace),
+    id_user integer not null references app_user(id_user),
+    role varchar(10) not null,
+    project varchar(50) not null default '',
+    id_task integer references task(id_task),
+    created_at timestamptz not null default now()
+);
+
+CREATE UNIQUE INDEX role_grant_scope_idx ON role_grant(id_workspace, id_user, project, COALESCE(id_task, 0));
+
+CREATE TABLE user_session(
+    id_session char(32) primary key,
+    id_user integer not null references app_user(id_user),
+    refresh_hash char(64) not null,
+    expires_at timestamptz not null,
+    revoked_at timestamptz,
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX user_session_user_idx ON user_session(id_user);
+
+-- an API key is shown once, only the sha256 of its secret is stored
+CREATE TABLE api_key(
+    id_key char(32) primary key,
+    id_user integer not null references app_user(id_user),
+    id_workspace integer not null references workspace(id_workspace),
+    name varchar(50) not null,
+    scope varchar(10) not null,
+    secret_hash char(64) not null,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX api_key_user_idx ON api_key(id_user);
+
+-- comments keep their previous bodies in task_comment_revision, a deleted
+-- comment is hidden along with its revisions
+CREATE TABLE task_comment(
+    id_comment serial primary key,
+    id_task integer not null references task(id_task),
+    id_workspace integer not null references workspace(id_workspace),
+    id_author integer not null references app_user(id_user),
+    body text not null,
+    deleted boolean not null default false,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+CREATE INDEX task_comment_task_idx ON task_comment(id_task, created_at);
+
+CREATE TABLE task_comment_revision(
+    id_revision serial primary key,
+    id_comment integer not null references task_comment(id_comment),
+    body text not null,
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX task_comment_revision_comment_idx ON task_comment_revision(id_comment, created_at);
+
+-- the content of an attachment is kept in the blob storage under
+-- <id_workspace>/<hash>, attachment_blob tracks the blobs stored per workspace
+CREATE TABLE attachment_blob(
+    id_workspace integer not null references workspace(id_workspace),
+    hash char(64) not null,
+    size bigint not null,
+    created_at timestamptz not null default now(),
+    primary key (id_workspace, hash)
+);
+
+CREATE TABLE attachment(
+    id_attachment serial primary key,
+    id_task integer not null references task(id_task),
+    id_workspace integer not null references workspace(id_workspace),
+    name varchar(255) not null,
+    content_type varchar(100) not null,
+    size bigint not null,
+    hash char(64) not null,
+    uploaded_by integer not null references app_user(id_user),
+    created_at timestamptz not null default now(),
+    foreign key (id_workspace, hash) references attachment_blob(id_workspace, hash)
+);
+
+CREATE INDEX attachment_task_idx ON attachment(id_task, created_at);
+CREATE INDEX attachment_hash_idx ON attachment(id_workspace, hash);
+
+-- the items of a checklist are ordered by position, gaps are left by deletes
+CREATE TABLE checklist_item(
+    id_item serial primary key,
+    id_task integer not null references task(id_task),
+    id_workspace integer not null references workspace(id_workspace),
+    text varchar(200) not null,
+    checked boolean not null default false,
+    position integer not null,
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX checklist_item_task_idx ON checklist_item(id_task, position);
+
+-- the entries of a user can not overlap, a running timer (no stopped_at) runs
+-- until infinity so that a user has one at most
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE time_entry(
+    id_entry serial primary key,
+    id_task integer not null references task(id_task),
+    id_workspace integer not null references workspace(id_workspace),
+    id_user integer not null references app_user(id_user),
+    started_at timestamptz not null,
+    stopped_at timestamptz check (stopped_at >= started_at),
+    note varchar(200) not null default '',
+    created_at timestamptz not null default now(),
+    EXCLUDE USING gist (id_user WITH =, tstzrange(started_at, COALESCE(stopped_at, 'infinity')) WITH &&)
+);
+
+CREATE INDEX time_entry_task_idx ON time_entry(id_task, started_at);
+CREATE INDEX time_entry_workspace_idx ON time_entry(id_workspace, started_at);
+
+CREATE TABLE sprint(
+    id_sprint serial primary key,
+    id_workspace integer not null references workspace(id_workspace),
+    name varchar(50) not null,
+    goal varchar(200) not null default '',
+    start_date date not null,
+    end_date date not null,
+    -- set once the sprint is closed and its unfinished tasks carried over
+    closed_at timestamptz,
+    created_at timestamptz not null default now(),
+    CHECK (end_date >= start_date)
+);
+
+CREATE INDEX sprint_workspace_idx ON sprint(id_workspace, start_date);
+
+-- the tasks planned in a sprint, a task is in one open sprint at most.
+-- A task carried over stays in the closed sprint for its burndown
+CREATE TABLE sprint_task(
+    id_sprint integer not null references sprint(id_sprint) ON DELETE CASCADE,
+    id_task integer not null references task(id_task),
+    carried boolean not null default false,
+    added_at timestamptz not null default now(),
+    primary key (id_sprint, id_task)
+);
+
+CREATE INDEX sprint_task_task_idx ON sprint_task(id_task);
+
+-- the tasks of a template are stored as they are written, placeholders included
+CREATE TABLE task_template(
+    id_template serial primary key,
+    id_workspace integer not null references workspace(id_workspace),
+    name varchar(50) not null,
+    tasks jsonb not null,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+CREATE INDEX task_template_workspace_idx ON task_template(id_workspace);
+
+-- a rule runs its actions, stored as json, on the tasks matching its condition
+CREATE TABLE task_rule(
+    id_rule serial primary key,
+    id_workspace integer not null references workspace(id_workspace),
+    name varchar(50) not null,
+    trigger varchar(20) not null,
+    condition text not null default '',
+    actions jsonb not null,
+    enabled boolean not null default true,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+CREATE INDEX task_rule_workspace_idx ON task_rule(id_workspace, trigger) WHERE enabled;
+
+-- the execution log of the rules, the entries go with their rule
+CREATE TABLE task_rule_execution(
+    id_execution serial primary key,
+    id_rule integer not null references task_rule(id_rule) ON DELETE CASCADE,
+    id_workspace integer not null references workspace(id_workspace),
+    id_task integer not null references task(id_task),
+    trigger varchar(20) not null,
+    status varchar(10) not null,
+    error text not null default '',
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX task_rule_execution_rule_idx ON task_rule_execution(id_rule, created_at);
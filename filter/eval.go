@@ -0,0 +1,176 @@
+package filter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+// Match reports whether the task matches e, a nil expression matches every task.
+// It gives the same answers as the SQL the repositories compile e to: a condition
+// on the due date of a task without one is unknown, as the comparison with NULL,
+// so that the task only matches due:none and due!=none, even under NOT
+func Match(e Expr, t *models.Task) bool {
+	return eval(e, t) == yes
+}
+
+// truth is a value of the three-valued logic of SQL
+type truth int
+
+const (
+	no truth = iota
+	yes
+	unknown
+)
+
+func truthOf(b bool) truth {
+	if b {
+		return yes
+	}
+	return no
+}
+
+func eval(e Expr, t *models.Task) truth {
+	switch e := e.(type) {
+	case nil:
+		return yes
+	case *And:
+		l, r := eval(e.Left, t), eval(e.Right, t)
+		switch {
+		case l == no || r == no:
+			return no
+		case l == yes && r == yes:
+			return yes
+		}
+		return unknown
+	case *Or:
+		l, r := eval(e.Left, t), eval(e.Right, t)
+		switch {
+		case l == yes || r == yes:
+			return yes
+		case l == no && r == no:
+			return no
+		}
+		return unknown
+	case *Not:
+		switch eval(e.Expr, t) {
+		case yes:
+			return no
+		case no:
+			return yes
+		}
+		return unknown
+	case *Cond:
+		if e.Field == "due" && !e.None && t.DueDate == nil {
+			return unknown
+		}
+		return truthOf(matchCond(e, t))
+	}
+	return no
+}
+
+func matchCond(c *Cond, t *models.Task) bool {
+	switch c.Field {
+	case "id":
+		return compareInt(c, t.ID)
+	case "status":
+		return compareText(c, t.Status)
+	case "title":
+		return compareText(c, t.Title)
+	case "description":
+		return compareText(c, t.Description)
+	case "project":
+		return compareText(c, t.Project)
+	case "priority":
+		return compareInt(c, t.Priority)
+	case "tag":
+		has := false
+		for _, tag := range t.Tags {
+			if tag == c.Value {
+				has = true
+			}
+		}
+		return has == (c.Op == Has)
+	case "due":
+		if c.None {
+			return (t.DueDate == nil) == (c.Op != Ne)
+		}
+		return compareTime(c, *t.DueDate)
+	case "created":
+		return compareTime(c, t.CreatedAt)
+	case "updated":
+		return compareTime(c, t.UpdatedAt)
+	}
+	return false
+}
+
+func compareText(c *Cond, v string) bool {
+	switch c.Op {
+	case Has, Eq:
+		return v == c.Value
+	case Ne:
+		return v != c.Value
+	case Contains:
+		return strings.Contains(strings.ToLower(v), strings.ToLower(c.Value))
+	}
+	return false
+}
+
+func compareInt(c *Cond, v int) bool {
+	switch c.Op {
+	case Has, Eq:
+		return v == c.Int
+	case Ne:
+		return v != c.Int
+	case Lt:
+		return v < c.Int
+	case Le:
+		return v <= c.Int
+	case Gt:
+		return v > c.Int
+	case Ge:
+		return v >= c.Int
+	}
+	return false
+}
+
+// compareTime compares v with the range [Time, Until) of a date, or with the instant Time
+func compareTime(c *Cond, v time.Time) bool {
+	if c.Until.Equal(c.Time) {
+		return compareInstant(c.Op, v, c.Time)
+	}
+	switch c.Op {
+	case Has, Eq:
+		return !v.Before(c.Time) && v.Before(c.Until)
+	case Ne:
+		return v.Before(c.Time) || !v.Before(c.Until)
+	case Lt:
+		return v.Before(c.Time)
+	case Le:
+		return v.Before(c.Until)
+	case Gt:
+		return !v.Before(c.Until)
+	case Ge:
+		return !v.Before(c.Time)
+	}
+	return false
+}
+
+func compareInstant(op string, v, at time.Time) bool {
+	switch op {
+	case Has, Eq:
+		return v.Equal(at)
+	case Ne:
+		return !v.Equal(at)
+	case Lt:
+		return v.Before(at)
+	case Le:
+		return !v.After(at)
+	case Gt:
+		return v.After(at)
+	case Ge:
+		return !v.Before(at)
+	}
+	return false
+}
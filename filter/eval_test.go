@@ -0,0 +1,57 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func TestMatch(t *testing.T) {
+	due := time.Date(2026, 10, 31, 18, 0, 0, 0, time.UTC)
+	login := &models.Task{ID: 1, Title: "Fix Login page", Status: "inprogress", Priority: 2,
+		Project: "web", Tags: []string{"bug"}, DueDate: &due}
+	milk := &models.Task{ID: 2, Title: "buy milk", Status: "todo"}
+	tests := []struct {
+		query string
+		task  *models.Task
+		want  bool
+	}{
+		{`status:inprogress AND (tag:bug OR title:~"login") AND due<2026-11-01`, login, true},
+		{`status:inprogress AND (tag:bug OR title:~"login") AND due<2026-11-01`, milk, false},
+		{`due:2026-10-31`, login, true},
+		{`due<=2026-10-31`, login, true},
+		{`due<2026-10-31`, login, false},
+		{`due>2026-10-30`, login, true},
+		{`due>2026-10-31`, login, false},
+		{`due!=2026-10-31`, login, false},
+		{`due=2026-10-31T18:00:00Z`, login, true},
+		{`due!=2026-11-01`, milk, false},
+		{`due:none`, milk, true},
+		{`due!=none`, login, true},
+		{`NOT tag:bug`, milk, true},
+		{`NOT due<2026-01-01`, milk, false},
+		{`NOT (due<2026-01-01 AND tag:bug)`, milk, true},
+		{`NOT (due<2026-01-01 OR tag:bug)`, milk, false},
+		{`due<2026-01-01 OR NOT tag:bug`, milk, true},
+		{`NOT (due<2026-01-01 OR due>=2026-01-01)`, milk, false},
+		{`NOT NOT due<2026-01-01`, milk, false},
+		{`tag!=bug`, login, false},
+		{`priority<=2 AND priority>0`, login, true},
+		{`project:web OR id:2`, milk, true},
+		{`title="buy milk"`, milk, true},
+		{`title:~MILK`, milk, true},
+		{``, milk, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			e, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := Match(e, tt.task); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
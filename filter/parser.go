@@ -0,0 +1,402 @@
+// Package filter implements the query language used to filter tasks, such as
+//
+//	status:inprogress AND (tag:bug OR title:~"login") AND due<2026-11-01
+//
+// A condition is a field, an operator and a value. Conditions are combined
+// with AND, OR, NOT and parentheses; AND binds tighter than OR.
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Operators of a condition. Has matches a tag for the tag field and
+// means Eq for the others, Contains matches a substring ignoring case
+const (
+	Has      = ":"
+	Contains = ":~"
+	Eq       = "="
+	Ne       = "!="
+	Lt       = "<"
+	Le       = "<="
+	Gt       = ">"
+	Ge       = ">="
+)
+
+// Kinds of field
+const (
+	KindEnum = iota
+	KindText
+	KindTags
+	KindInt
+	KindTime
+)
+
+// Fields maps the field names of the language to their kind
+var Fields = map[string]int{
+	"id":          KindInt,
+	"status":      KindEnum,
+	"title":       KindText,
+	"description": KindText,
+	"project":     KindText,
+	"tag":         KindTags,
+	"priority":    KindInt,
+	"due":         KindTime,
+	"created":     KindTime,
+	"updated":     KindTime,
+}
+
+var kindOperators = map[int][]string{
+	KindEnum: {Has, Eq, Ne},
+	KindText: {Has, Eq, Ne, Contains},
+	KindTags: {Has, Ne},
+	KindInt:  {Has, Eq, Ne, Lt, Le, Gt, Ge},
+	KindTime: {Has, Eq, Ne, Lt, Le, Gt, Ge},
+}
+
+var statuses = []string{"todo", "inprogress", "done"}
+
+// None is the value matching a due date that is not set
+const None = "none"
+
+// Today is the value of the current day in UTC, it is resolved when the query is parsed
+const Today = "today"
+
+// now returns the current time, the day Today stands for
+var now = time.Now
+
+// Error is a syntax or validation error, Pos is the 1-based position
+// of the offending character in the query
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+// Expr is a node of a parsed query
+type Expr interface {
+	// Position returns the 1-based position of the node in the query
+	Position() int
+}
+
+// And matches when both sides match
+type And struct {
+	Left, Right Expr
+}
+
+// Or matches when either side matches
+type Or struct {
+	Left, Right Expr
+}
+
+// Not matches when Expr does not
+type Not struct {
+	Expr Expr
+	Pos  int
+}
+
+// Cond is a condition on a field. Int holds the value of an int field.
+// A time field matches from Time until Until, which is the next day for
+// a date and Time itself for an instant. None is set for due:none
+type Cond struct {
+	Field string
+	Op    string
+	Value string
+	Pos   int
+	Int   int
+	Time  time.Time
+	Until time.Time
+	None  bool
+}
+
+// Position implements Expr
+func (a *And) Position() int { return a.Left.Position() }
+
+// Position implements Expr
+func (o *Or) Position() int { return o.Left.Position() }
+
+// Position implements Expr
+func (n *Not) Position() int { return n.Pos }
+
+// Position implements Expr
+func (c *Cond) Position() int { return c.Pos }
+
+type parser struct {
+	src string
+	pos int // byte offset in src
+}
+
+// Parse parses and validates a query. An empty query parses to nil, which matches every task
+func Parse(query string) (Expr, error) {
+	p := &parser{src: query}
+	p.skipSpace()
+	if p.eof() {
+		return nil, nil
+	}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.errorf(p.pos, "expected AND or OR before %q", p.peekWord())
+	}
+	return e, nil
+}
+
+// column returns the 1-based rune position of a byte offset
+func (p *parser) column(offset int) int {
+	return utf8.RuneCountInString(p.src[:offset]) + 1
+}
+
+func (p *parser) errorf(offset int, format string, args ...interface{}) error {
+	return &Error{Pos: p.column(offset), Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+		p.pos += size
+	}
+}
+
+// peekWord returns the text up to the next space, for error messages
+func (p *parser) peekWord() string {
+	end := strings.IndexFunc(p.src[p.pos:], unicode.IsSpace)
+	if end < 0 {
+		return p.src[p.pos:]
+	}
+	return p.src[p.pos : p.pos+end]
+}
+
+// keyword consumes kw, ignoring case, when it is the next word
+func (p *parser) keyword(kw string) bool {
+	end := p.pos + len(kw)
+	if end > len(p.src) || !strings.EqualFold(p.src[p.pos:end], kw) {
+		return false
+	}
+	if end < len(p.src) {
+		r, _ := utf8.DecodeRuneInString(p.src[end:])
+		if !unicode.IsSpace(r) && r != '(' {
+			return false
+		}
+	}
+	p.pos = end
+	return true
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if !p.keyword("OR") {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if !p.keyword("AND") {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	p.skipSpace()
+	start := p.pos
+	if p.eof() {
+		return nil, p.errorf(p.pos, "expected a condition")
+	}
+	if p.keyword("NOT") {
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: e, Pos: p.column(start)}, nil
+	}
+	if p.src[p.pos] == '(' {
+		p.pos++
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.eof() || p.src[p.pos] != ')' {
+			return nil, p.errorf(start, "unclosed parenthesis")
+		}
+		p.pos++
+		return e, nil
+	}
+	return p.parseCond()
+}
+
+func (p *parser) parseCond() (Expr, error) {
+	start := p.pos
+	for !p.eof() {
+		c := p.src[p.pos]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_') {
+			break
+		}
+		p.pos++
+	}
+	if p.pos == start {
+		return nil, p.errorf(start, "expected a field name, got %q", p.peekWord())
+	}
+	field := strings.ToLower(p.src[start:p.pos])
+	kind, ok := Fields[field]
+	if !ok {
+		return nil, p.errorf(start, "unknown field %q", field)
+	}
+	opStart := p.pos
+	op := p.parseOperator()
+	if op == "" {
+		return nil, p.errorf(opStart, "expected an operator after %s", field)
+	}
+	if !hasOperator(kindOperators[kind], op) {
+		return nil, p.errorf(opStart, "operator %s is not supported by %s", op, field)
+	}
+	valueStart := p.pos
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	c := &Cond{Field: field, Op: op, Value: value, Pos: p.column(start)}
+	if err := p.checkValue(c, kind, valueStart); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func hasOperator(ops []string, op string) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseOperator() string {
+	for _, op := range []string{Contains, Ne, Le, Ge, Has, Eq, Lt, Gt} {
+		if strings.HasPrefix(p.src[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+// parseValue reads a quoted string or a bare word ending at a space or a parenthesis
+func (p *parser) parseValue() (string, error) {
+	start := p.pos
+	if p.eof() {
+		return "", p.errorf(start, "expected a value")
+	}
+	if p.src[p.pos] == '"' {
+		var b strings.Builder
+		p.pos++
+		for !p.eof() {
+			c := p.src[p.pos]
+			switch {
+			case c == '"':
+				p.pos++
+				return b.String(), nil
+			case c == '\\' && p.pos+1 < len(p.src):
+				b.WriteByte(p.src[p.pos+1])
+				p.pos += 2
+			default:
+				b.WriteByte(c)
+				p.pos++
+			}
+		}
+		return "", p.errorf(start, "unterminated string")
+	}
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		if unicode.IsSpace(r) || r == '(' || r == ')' {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return "", p.errorf(start, "expected a value")
+	}
+	return p.src[start:p.pos], nil
+}
+
+// checkValue validates the value of c against the kind of its field and parses it
+func (p *parser) checkValue(c *Cond, kind int, offset int) error {
+	switch kind {
+	case KindEnum:
+		for _, s := range statuses {
+			if c.Value == s {
+				return nil
+			}
+		}
+		return p.errorf(offset, "invalid status %q, expected one of %s", c.Value, strings.Join(statuses, ", "))
+	case KindInt:
+		n, err := strconv.Atoi(c.Value)
+		if err != nil {
+			return p.errorf(offset, "invalid number %q", c.Value)
+		}
+		c.Int = n
+	case KindTime:
+		if strings.EqualFold(c.Value, None) {
+			if c.Field != "due" || (c.Op != Has && c.Op != Eq && c.Op != Ne) {
+				return p.errorf(offset, "%s can not be compared to none", c.Field)
+			}
+			c.None = true
+			return nil
+		}
+		if strings.EqualFold(c.Value, Today) {
+			t := now().UTC().Truncate(24 * time.Hour)
+			c.Time, c.Until = t, t.AddDate(0, 0, 1)
+			return nil
+		}
+		if t, err := time.Parse("2006-01-02", c.Value); err == nil {
+			c.Time, c.Until = t, t.AddDate(0, 0, 1)
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, c.Value)
+		if err != nil {
+			return p.errorf(offset, "invalid date %q, expected YYYY-MM-DD or RFC 3339", c.Value)
+		}
+		c.Time, c.Until = t, t
+	}
+	return nil
+}
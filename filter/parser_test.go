@@ -0,0 +1,118 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	day := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	// the evening of October 31st in New York is November 1st in UTC
+	now = func() time.Time { return time.Date(2026, 10, 31, 22, 0, 0, 0, time.FixedZone("EDT", -4*60*60)) }
+	defer func() { now = time.Now }()
+	tests := []struct {
+		name    string
+		query   string
+		want    Expr
+		wantErr *Error
+	}{{
+		name:  "Normal Case1: example of the documentation",
+		query: `status:inprogress AND (tag:bug OR title:~"login") AND due<2026-11-01`,
+		want: &And{
+			Left: &And{
+				Left: &Cond{Field: "status", Op: Has, Value: "inprogress", Pos: 1},
+				Right: &Or{
+					Left:  &Cond{Field: "tag", Op: Has, Value: "bug", Pos: 24},
+					Right: &Cond{Field: "title", Op: Contains, Value: "login", Pos: 35},
+				},
+			},
+			Right: &Cond{Field: "due", Op: Lt, Value: "2026-11-01", Pos: 55, Time: day, Until: day.AddDate(0, 0, 1)},
+		},
+	}, {
+		name:  "AND binds tighter than OR",
+		query: "priority>=3 or not project=home and tag!=x",
+		want: &Or{
+			Left: &Cond{Field: "priority", Op: Ge, Value: "3", Pos: 1, Int: 3},
+			Right: &And{
+				Left:  &Not{Expr: &Cond{Field: "project", Op: Eq, Value: "home", Pos: 20}, Pos: 16},
+				Right: &Cond{Field: "tag", Op: Ne, Value: "x", Pos: 37},
+			},
+		},
+	}, {
+		name:  "escaped quote and instant",
+		query: `title:"say \"hi\"" AND updated>2026-11-01T00:00:00Z`,
+		want: &And{
+			Left:  &Cond{Field: "title", Op: Has, Value: `say "hi"`, Pos: 1},
+			Right: &Cond{Field: "updated", Op: Gt, Value: "2026-11-01T00:00:00Z", Pos: 24, Time: day, Until: day},
+		},
+	}, {
+		name:  "due none",
+		query: "due:none",
+		want:  &Cond{Field: "due", Op: Has, Value: "none", Pos: 1, None: true},
+	}, {
+		name:  "today",
+		query: "due<today",
+		want:  &Cond{Field: "due", Op: Lt, Value: "today", Pos: 1, Time: day, Until: day.AddDate(0, 0, 1)},
+	}, {
+		name:  "empty query",
+		query: "  ",
+	}, {
+		name:    "unknown field",
+		query:   "status:todo AND colour:red",
+		wantErr: &Error{Pos: 17, Msg: `unknown field "colour"`},
+	}, {
+		name:    "invalid status",
+		query:   "status:later",
+		wantErr: &Error{Pos: 8, Msg: `invalid status "later", expected one of todo, inprogress, done`},
+	}, {
+		name:    "unsupported operator",
+		query:   "tag<bug",
+		wantErr: &Error{Pos: 4, Msg: "operator < is not supported by tag"},
+	}, {
+		name:    "missing operator",
+		query:   "status todo",
+		wantErr: &Error{Pos: 7, Msg: "expected an operator after status"},
+	}, {
+		name:    "missing AND",
+		query:   "status:todo tag:bug",
+		wantErr: &Error{Pos: 13, Msg: `expected AND or OR before "tag:bug"`},
+	}, {
+		name:    "unclosed parenthesis",
+		query:   "(tag:bug OR tag:ui",
+		wantErr: &Error{Pos: 1, Msg: "unclosed parenthesis"},
+	}, {
+		name:    "unterminated string",
+		query:   `title:~"login`,
+		wantErr: &Error{Pos: 8, Msg: "unterminated string"},
+	}, {
+		name:    "dangling AND",
+		query:   "tag:bug AND",
+		wantErr: &Error{Pos: 12, Msg: "expected a condition"},
+	}, {
+		name:    "positions count characters",
+		query:   `title:"é" AND id:x`,
+		wantErr: &Error{Pos: 18, Msg: `invalid number "x"`},
+	}, {
+		name:    "none only applies to due",
+		query:   "created:none",
+		wantErr: &Error{Pos: 9, Msg: "created can not be compared to none"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.query)
+			if tt.wantErr != nil {
+				if !reflect.DeepEqual(err, tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package filter
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+// SortKey orders tasks by a field, Desc reverses the order
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort parses a comma separated list of fields, a leading - sorts
+// the field in descending order: "priority,-due". Tags can not be sorted on
+func ParseSort(s string) ([]SortKey, error) {
+	keys := []SortKey{}
+	if strings.TrimSpace(s) == "" {
+		return keys, nil
+	}
+	offset := 0
+	for _, part := range strings.Split(s, ",") {
+		field := strings.TrimSpace(part)
+		pos := offset + strings.Index(part, field)
+		offset += len(part) + 1
+		key := SortKey{}
+		if strings.HasPrefix(field, "-") {
+			key.Desc = true
+			field = field[1:]
+		}
+		key.Field = strings.ToLower(field)
+		kind, ok := Fields[key.Field]
+		if field == "" || !ok || kind == KindTags {
+			p := &parser{src: s}
+			return nil, p.errorf(pos, "can not sort on %q", part)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// SortTasks sorts tasks in place the way the repositories do: by the keys,
+// tasks without a due date last, then by id. Text is compared byte-wise
+// where the database follows its collation
+func SortTasks(tasks []*models.Task, keys []SortKey) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		for _, k := range keys {
+			c := compareField(k.Field, tasks[i], tasks[j])
+			if c == 0 {
+				continue
+			}
+			if k.Field == "due" && (tasks[i].DueDate == nil || tasks[j].DueDate == nil) {
+				// missing due dates stay last whatever the direction
+				return tasks[j].DueDate == nil
+			}
+			if k.Desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+func compareField(field string, a, b *models.Task) int {
+	switch field {
+	case "id":
+		return compareInts(a.ID, b.ID)
+	case "status":
+		return strings.Compare(a.Status, b.Status)
+	case "title":
+		return strings.Compare(a.Title, b.Title)
+	case "description":
+		return strings.Compare(a.Description, b.Description)
+	case "project":
+		return strings.Compare(a.Project, b.Project)
+	case "priority":
+		return compareInts(a.Priority, b.Priority)
+	case "due":
+		switch {
+		case a.DueDate == nil && b.DueDate == nil:
+			return 0
+		case a.DueDate == nil:
+			return 1
+		case b.DueDate == nil:
+			return -1
+		}
+		return compareTimes(*a.DueDate, *b.DueDate)
+	case "created":
+		return compareTimes(a.CreatedAt, b.CreatedAt)
+	case "updated":
+		return compareTimes(a.UpdatedAt, b.UpdatedAt)
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		sort    string
+		want    []SortKey
+		wantErr *Error
+	}{{
+		name: "Normal Case1: fields and directions",
+		sort: "priority, -due,Title",
+		want: []SortKey{{Field: "priority"}, {Field: "due", Desc: true}, {Field: "title"}},
+	}, {
+		name: "empty",
+		sort: "",
+		want: []SortKey{},
+	}, {
+		name:    "tags can not be sorted",
+		sort:    "due,tag",
+		wantErr: &Error{Pos: 5, Msg: `can not sort on "tag"`},
+	}, {
+		name:    "empty field",
+		sort:    "due,,title",
+		wantErr: &Error{Pos: 5, Msg: `can not sort on ""`},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSort(tt.sort)
+			if tt.wantErr != nil {
+				if !reflect.DeepEqual(err, tt.wantErr) {
+					t.Fatalf("ParseSort() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSort() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortTasks(t *testing.T) {
+	early := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	late := early.AddDate(0, 1, 0)
+	tasks := func() []*models.Task {
+		return []*models.Task{
+			{ID: 1, Priority: 2},
+			{ID: 2, Priority: 1, DueDate: &late},
+			{ID: 3, Priority: 2, DueDate: &early},
+			{ID: 4, Priority: 1, DueDate: &early},
+		}
+	}
+	tests := []struct {
+		sort string
+		want []int
+	}{
+		{"", []int{1, 2, 3, 4}},
+		{"priority", []int{2, 4, 1, 3}},
+		{"priority,due", []int{4, 2, 3, 1}},
+		{"-due", []int{2, 3, 4, 1}},
+		{"due", []int{3, 4, 2, 1}},
+		{"-priority,-id", []int{3, 1, 4, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			keys, err := ParseSort(tt.sort)
+			if err != nil {
+				t.Fatalf("ParseSort() error = %v", err)
+			}
+			got := tasks()
+			SortTasks(got, keys)
+			ids := make([]int, len(got))
+			for i, task := range got {
+				ids[i] = task.ID
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("SortTasks() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
@@ -9,4 +9,5 @@ require (
 	github.com/lib/pq v1.3.0
 	github.com/sirupsen/logrus v1.4.2
 	github.com/spf13/viper v1.6.1
+	golang.org/x/crypto v0.0.0-20191206172530-e9b2fee46413
 )
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	nethttp "net/http"
+	"strconv"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/idempotency"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// HeaderKey is the request header carrying the idempotency key
+	HeaderKey = "Idempotency-Key"
+	// HeaderReplayed is set on responses replayed from a stored record
+	HeaderReplayed = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+	maxBodySize  = 1 << 20
+	// finishTimeout bounds storing or releasing the key once the request is served
+	finishTimeout = 5 * time.Second
+)
+
+// detached keeps the values of its parent context but neither its deadline
+// nor its cancellation, the key has to be settled after the client left
+type detached struct {
+	context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detached) Done() <-chan struct{} { return nil }
+
+func (detached) Err() error { return nil }
+
+// responseRecorder keeps a copy of what the wrapped handler writes
+type responseRecorder struct {
+	nethttp.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if r.statusCode == 0 {
+		r.statusCode = statusCode
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.statusCode = nethttp.StatusOK
+	}
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// NewIdempotencyMiddleware will create a middleware replaying the stored response of
+// requests repeated with the same Idempotency-Key header by the same user in the same workspace for ttl.
+// Requests without the header are passed through untouched
+func NewIdempotencyMiddleware(ir idempotency.Repository, ttl time.Duration) func(nethttp.Handler) nethttp.Handler {
+	return func(next nethttp.Handler) nethttp.Handler {
+		return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			key := r.Header.Get(HeaderKey)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxKeyLength {
+				w.WriteHeader(nethttp.StatusBadRequest)
+				w.Write([]byte("invalid idempotency key"))
+				return
+			}
+			// keys are scoped by user and workspace, a key sent by someone else or for another
+			// workspace must not replay their response
+			if u, ok := core.UserFromContext(r.Context()); ok {
+				key = strconv.Itoa(u.ID) + ":" + key
+			}
+			if workspace, err := core.TenantFromContext(r.Context()); err == nil {
+				key = strconv.Itoa(workspace) + ":" + key
+			}
+			// the body is read once more than the limit, a truncated body would be replayed for another request
+			body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+			if err != nil {
+				w.WriteHeader(nethttp.StatusBadRequest)
+				w.Write([]byte("Can not read body"))
+				return
+			}
+			if len(body) > maxBodySize {
+				w.WriteHeader(nethttp.StatusRequestEntityTooLarge)
+				w.Write([]byte("request body too large"))
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+			fingerprint := sha256.New()
+			fingerprint.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+			fingerprint.Write(body)
+			rec := &models.IdempotencyRecord{
+				Key:         key,
+				Fingerprint: hex.EncodeToString(fingerprint.Sum(nil)),
+				ExpiresAt:   time.Now().Add(ttl),
+			}
+			existing, err := ir.Reserve(r.Context(), rec)
+			if err != nil {
+				logrus.Error(err)
+				w.WriteHeader(nethttp.StatusInternalServerError)
+				w.Write([]byte("internal server error"))
+				return
+			}
+			if existing != nil {
+				replay(w, rec, existing)
+				return
+			}
+			recorder := &responseRecorder{ResponseWriter: w}
+			defer func() {
+				// a client gone before the end must not leave the key in flight
+				ctx, cancel := context.WithTimeout(detached{r.Context()}, finishTimeout)
+				defer cancel()
+				// a failed request must not keep the key locked, it has to be retryable
+				if p := recover(); p != nil {
+					ir.Release(ctx, key)
+					panic(p)
+				}
+				if recorder.statusCode >= nethttp.StatusInternalServerError || recorder.statusCode == 0 {
+					err = ir.Release(ctx, key)
+				} else {
+					err = ir.Complete(ctx, key, recorder.statusCode,
+						recorder.Header().Get("Content-Type"), recorder.body.Bytes())
+				}
+				if err != nil {
+					logrus.Error(err)
+				}
+			}()
+			next.ServeHTTP(recorder, r)
+		})
+	}
+}
+
+// replay answers a repeated request from the stored record
+func replay(w nethttp.ResponseWriter, rec, existing *models.IdempotencyRecord) {
+	switch {
+	case existing.Fingerprint != rec.Fingerprint:
+		w.WriteHeader(nethttp.StatusUnprocessableEntity)
+		w.Write([]byte("idempotency key reused with a different request"))
+	case existing.StatusCode == 0:
+		w.WriteHeader(nethttp.StatusConflict)
+		w.Write([]byte("request with this idempotency key is in progress"))
+	default:
+		if existing.ContentType != "" {
+			w.Header().Set("Content-Type", existing.ContentType)
+		}
+		w.Header().Set(HeaderReplayed, "true")
+		w.WriteHeader(existing.StatusCode)
+		w.Write(existing.Body)
+	}
+}
@@ -0,0 +1,214 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/idempotency"
+	"github.com/pratheeshm/todo-golang/idempotency/repository"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func TestNewIdempotencyMiddleware(t *testing.T) {
+	calls := 0
+	fail := false
+	next := nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		calls++
+		if fail {
+			w.WriteHeader(nethttp.StatusInternalServerError)
+			w.Write([]byte("internal server error"))
+			return
+		}
+		w.WriteHeader(nethttp.StatusOK)
+		w.Write([]byte("success"))
+	})
+	h := NewIdempotencyMiddleware(repository.NewMemoryIdempotencyRepository(), time.Hour)(next)
+	send := func(key, body string, user, workspace int) *nethttp.Response {
+		req := httptest.NewRequest("POST", "/add", bytes.NewBufferString(body))
+		if key != "" {
+			req.Header.Set(HeaderKey, key)
+		}
+		if user != 0 {
+			req = req.WithContext(core.WithUser(req.Context(), &models.User{ID: user}))
+		}
+		if workspace != 0 {
+			req = req.WithContext(core.WithTenant(req.Context(), workspace))
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+	body := `{"title":"Take math notes","status":"todo"}`
+	tests := []struct {
+		name       string
+		key        string
+		body       string
+		user       int
+		workspace  int
+		fail       bool
+		statusCode int
+		message    string
+		wantCalls  int
+		replayed   bool
+	}{{
+		name:       "Normal Case1: first request",
+		key:        "k1",
+		body:       body,
+		statusCode: 200,
+		message:    "success",
+		wantCalls:  1,
+	}, {
+		name:       "repeated request is replayed",
+		key:        "k1",
+		body:       body,
+		statusCode: 200,
+		message:    "success",
+		wantCalls:  1,
+		replayed:   true,
+	}, {
+		name:       "same key with another body",
+		key:        "k1",
+		body:       `{"title":"do physics homework","status":"todo"}`,
+		statusCode: 422,
+		message:    "idempotency key reused with a different request",
+		wantCalls:  1,
+	}, {
+		name:       "failed request is not stored",
+		key:        "k2",
+		body:       body,
+		fail:       true,
+		statusCode: 500,
+		message:    "internal server error",
+		wantCalls:  2,
+	}, {
+		name:       "failed request can be retried",
+		key:        "k2",
+		body:       body,
+		statusCode: 200,
+		message:    "success",
+		wantCalls:  3,
+	}, {
+		name:       "no key",
+		body:       body,
+		statusCode: 200,
+		message:    "success",
+		wantCalls:  4,
+	}, {
+		name:       "same key sent by another user",
+		key:        "k1",
+		body:       body,
+		user:       2,
+		statusCode: 200,
+		message:    "success",
+		wantCalls:  5,
+	}, {
+		name:       "repeated request of the other user is replayed",
+		key:        "k1",
+		body:       body,
+		user:       2,
+		statusCode: 200,
+		message:    "success",
+		wantCalls:  5,
+		replayed:   true,
+	}, {
+		name:       "key sent in a workspace",
+		key:        "k4",
+		body:       body,
+		user:       2,
+		workspace:  7,
+		statusCode: 200,
+		message:    "success",
+		wantCalls:  6,
+	}, {
+		name:       "same key sent for another workspace",
+		key:        "k4",
+		body:       body,
+		user:       2,
+		workspace:  8,
+		statusCode: 200,
+		message:    "success",
+		wantCalls:  7,
+	}, {
+		name:       "repeated request of the other workspace is replayed",
+		key:        "k4",
+		body:       body,
+		user:       2,
+		workspace:  8,
+		statusCode: 200,
+		message:    "success",
+		wantCalls:  7,
+		replayed:   true,
+	}, {
+		name:       "body over the limit",
+		key:        "k3",
+		body:       string(make([]byte, maxBodySize+1)),
+		statusCode: 413,
+		message:    "request body too large",
+		wantCalls:  7,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fail = tt.fail
+			res := send(tt.key, tt.body, tt.user, tt.workspace)
+			if res.StatusCode != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, res.StatusCode, tt.statusCode)
+			}
+			msg, _ := ioutil.ReadAll(res.Body)
+			if string(msg) != tt.message {
+				t.Errorf("Test - %s , got message %s but expected %s", tt.name, msg, tt.message)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("Test - %s , handler called %d times but expected %d", tt.name, calls, tt.wantCalls)
+			}
+			if replayed := res.Header.Get(HeaderReplayed) == "true"; replayed != tt.replayed {
+				t.Errorf("Test - %s , replayed = %v but expected %v", tt.name, replayed, tt.replayed)
+			}
+		})
+	}
+}
+
+// cancelRepository fails like a database driver once the context is done
+type cancelRepository struct {
+	idempotency.Repository
+}
+
+func (c cancelRepository) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.Repository.Complete(ctx, key, statusCode, contentType, body)
+}
+
+func (c cancelRepository) Release(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.Repository.Release(ctx, key)
+}
+
+func TestNewIdempotencyMiddleware_clientGone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	next := nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		// the client disconnects while the request is served
+		cancel()
+		w.WriteHeader(nethttp.StatusOK)
+		w.Write([]byte("success"))
+	})
+	h := NewIdempotencyMiddleware(cancelRepository{repository.NewMemoryIdempotencyRepository()}, time.Hour)(next)
+	req := httptest.NewRequest("POST", "/add", bytes.NewBufferString("{}")).WithContext(ctx)
+	req.Header.Set(HeaderKey, "k1")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	req = httptest.NewRequest("POST", "/add", bytes.NewBufferString("{}"))
+	req.Header.Set(HeaderKey, "k1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != nethttp.StatusOK || rec.Header().Get(HeaderReplayed) != "true" {
+		t.Errorf("got statuscode %d, replayed %q but expected a replayed 200", rec.Code, rec.Header().Get(HeaderReplayed))
+	}
+}
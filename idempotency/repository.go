@@ -0,0 +1,18 @@
+package idempotency
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Repository represents idempotency key's interface
+type Repository interface {
+	// Reserve stores rec as in flight. When a record with the same key that has
+	// not expired exists, nothing is stored and that record is returned
+	Reserve(context.Context, *models.IdempotencyRecord) (*models.IdempotencyRecord, error)
+	// Complete stores the response of the reserved key
+	Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error
+	// Release forgets the reserved key so that the request can be retried
+	Release(ctx context.Context, key string) error
+}
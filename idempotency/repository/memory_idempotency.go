@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/idempotency"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+type memoryIdempotencyRepository struct {
+	mu      sync.Mutex
+	records map[string]*models.IdempotencyRecord
+	now     func() time.Time
+}
+
+// NewMemoryIdempotencyRepository will create an in-memory idempotency.Repository,
+// suitable for tests and single instance deployments
+func NewMemoryIdempotencyRepository() idempotency.Repository {
+	return &memoryIdempotencyRepository{
+		records: map[string]*models.IdempotencyRecord{},
+		now:     time.Now,
+	}
+}
+
+func (m *memoryIdempotencyRepository) Reserve(ctx context.Context, rec *models.IdempotencyRecord) (*models.IdempotencyRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	// drop expired records while we hold the lock so the map does not grow forever
+	for k, r := range m.records {
+		if r.ExpiresAt.Before(now) {
+			delete(m.records, k)
+		}
+	}
+	if existing, ok := m.records[rec.Key]; ok {
+		copied := *existing
+		return &copied, nil
+	}
+	stored := *rec
+	stored.StatusCode = 0
+	stored.Body = nil
+	m.records[rec.Key] = &stored
+	return nil, nil
+}
+
+func (m *memoryIdempotencyRepository) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if r, ok := m.records[key]; ok {
+		r.StatusCode = statusCode
+		r.ContentType = contentType
+		r.Body = append([]byte{}, body...)
+	}
+	return nil
+}
+
+func (m *memoryIdempotencyRepository) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if r, ok := m.records[key]; ok && r.StatusCode == 0 {
+		delete(m.records, key)
+	}
+	return nil
+}
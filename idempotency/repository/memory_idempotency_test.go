@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func Test_memoryIdempotencyRepository(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	m := NewMemoryIdempotencyRepository().(*memoryIdempotencyRepository)
+	m.now = func() time.Time { return now }
+	rec := &models.IdempotencyRecord{Key: "k1", Fingerprint: "f1", ExpiresAt: now.Add(time.Hour)}
+
+	if existing, err := m.Reserve(ctx, rec); err != nil || existing != nil {
+		t.Fatalf("first Reserve() = %v, %v, want the key reserved", existing, err)
+	}
+	existing, err := m.Reserve(ctx, rec)
+	if err != nil || existing == nil || existing.StatusCode != 0 {
+		t.Fatalf("second Reserve() = %v, %v, want the in flight record", existing, err)
+	}
+	if err := m.Complete(ctx, "k1", 200, "text/plain", []byte("success")); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	existing, _ = m.Reserve(ctx, rec)
+	if existing.StatusCode != 200 || string(existing.Body) != "success" {
+		t.Errorf("Reserve() after Complete() = %+v, want the stored response", existing)
+	}
+	if m.Release(ctx, "k1"); len(m.records) != 1 {
+		t.Errorf("Release() must keep completed records")
+	}
+
+	now = now.Add(2 * time.Hour)
+	rec.ExpiresAt = now.Add(time.Hour)
+	if existing, _ := m.Reserve(ctx, rec); existing != nil {
+		t.Errorf("expired record should be taken over, got %+v", existing)
+	}
+	if m.Release(ctx, "k1"); len(m.records) != 0 {
+		t.Errorf("Release() should forget an in flight record")
+	}
+}
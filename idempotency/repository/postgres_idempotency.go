@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pratheeshm/todo-golang/idempotency"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+type postgresIdempotencyRepository struct {
+	*sql.DB
+}
+
+// NewPostgresIdempotencyRepository will create an object that represent the idempotency.Repository interface
+func NewPostgresIdempotencyRepository(db *sql.DB) idempotency.Repository {
+	return &postgresIdempotencyRepository{db}
+}
+
+func (p *postgresIdempotencyRepository) Reserve(ctx context.Context, rec *models.IdempotencyRecord) (*models.IdempotencyRecord, error) {
+	conn := transaction.Conn(ctx, p.DB)
+	// an expired record is taken over as if it did not exist
+	var key string
+	err := conn.QueryRowContext(ctx, "INSERT INTO idempotency_key(key, fingerprint, expires_at) VALUES ($1, $2, $3) ON CONFLICT (key) DO UPDATE SET fingerprint = EXCLUDED.fingerprint, status_code = 0, content_type = '', body = NULL, expires_at = EXCLUDED.expires_at WHERE idempotency_key.expires_at < now() RETURNING key",
+		rec.Key, rec.Fingerprint, rec.ExpiresAt).Scan(&key)
+	if err == nil {
+		return nil, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+	existing := &models.IdempotencyRecord{}
+	err = conn.QueryRowContext(ctx, "SELECT key, fingerprint, status_code, content_type, body, expires_at FROM idempotency_key WHERE key = $1",
+		rec.Key).Scan(&existing.Key, &existing.Fingerprint, &existing.StatusCode,
+		&existing.ContentType, &existing.Body, &existing.ExpiresAt)
+	if err == sql.ErrNoRows {
+		// released in between, try again
+		return p.Reserve(ctx, rec)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (p *postgresIdempotencyRepository) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	_, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "UPDATE idempotency_key SET status_code = $1, content_type = $2, body = $3 WHERE key = $4",
+		statusCode, contentType, body, key)
+	return err
+}
+
+func (p *postgresIdempotencyRepository) Release(ctx context.Context, key string) error {
+	_, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "DELETE FROM idempotency_key WHERE key = $1 AND status_code = 0", key)
+	return err
+}
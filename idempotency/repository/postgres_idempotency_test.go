@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_postgresIdempotencyRepository_Reserve(t *testing.T) {
+	insert := "INSERT INTO idempotency_key(key, fingerprint, expires_at) VALUES ($1, $2, $3) ON CONFLICT (key) DO UPDATE"
+	selectQuery := "SELECT key, fingerprint, status_code, content_type, body, expires_at FROM idempotency_key WHERE key = $1"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	expires := time.Date(2026, 10, 2, 9, 0, 0, 0, time.UTC)
+	rec := &models.IdempotencyRecord{Key: "k1", Fingerprint: "f1", ExpiresAt: expires}
+	tests := []struct {
+		name     string
+		reserved bool
+		want     *models.IdempotencyRecord
+	}{{
+		name:     "Normal Case 1: new key",
+		reserved: true,
+	}, {
+		name: "live key",
+		want: &models.IdempotencyRecord{Key: "k1", Fingerprint: "f1", StatusCode: 200,
+			ContentType: "text/plain", Body: []byte("success"), ExpiresAt: expires},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := mock.NewRows([]string{"key"})
+			if tt.reserved {
+				rows.AddRow("k1")
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(insert)).WithArgs("k1", "f1", expires).WillReturnRows(rows)
+			if !tt.reserved {
+				mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).WithArgs("k1").
+					WillReturnRows(mock.NewRows([]string{"key", "fingerprint", "status_code", "content_type", "body", "expires_at"}).
+						AddRow("k1", "f1", 200, "text/plain", []byte("success"), expires))
+			}
+			p := NewPostgresIdempotencyRepository(db)
+			got, err := p.Reserve(context.Background(), rec)
+			if err != nil {
+				t.Fatalf("Test %s - error = %v", tt.name, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %+v, want %+v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresIdempotencyRepository_CompleteRelease(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE idempotency_key SET status_code = $1, content_type = $2, body = $3 WHERE key = $4")).
+		WithArgs(200, "text/plain", []byte("success"), "k1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM idempotency_key WHERE key = $1 AND status_code = 0")).
+		WithArgs("k2").WillReturnResult(sqlmock.NewResult(0, 1))
+	p := NewPostgresIdempotencyRepository(db)
+	if err := p.Complete(context.Background(), "k1", 200, "text/plain", []byte("success")); err != nil {
+		t.Errorf("Complete() error = %v", err)
+	}
+	if err := p.Release(context.Background(), "k2"); err != nil {
+		t.Errorf("Release() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,17 +1,64 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/pratheeshm/todo-golang/task/usecase"
+	"github.com/pratheeshm/todo-golang/transaction"
 
 	"github.com/pratheeshm/todo-golang/task/repository"
 
 	taskdeliver "github.com/pratheeshm/todo-golang/task/delivery/http"
 
+	idemdeliver "github.com/pratheeshm/todo-golang/idempotency/delivery/http"
+	idemrepo "github.com/pratheeshm/todo-golang/idempotency/repository"
+
+	accessdeliver "github.com/pratheeshm/todo-golang/access/delivery/http"
+	accessrepo "github.com/pratheeshm/todo-golang/access/repository"
+	accessusecase "github.com/pratheeshm/todo-golang/access/usecase"
+
+	attachmentdeliver "github.com/pratheeshm/todo-golang/attachment/delivery/http"
+	attachmentrepo "github.com/pratheeshm/todo-golang/attachment/repository"
+	attachmentstorage "github.com/pratheeshm/todo-golang/attachment/storage"
+	attachmentusecase "github.com/pratheeshm/todo-golang/attachment/usecase"
+
+	commentdeliver "github.com/pratheeshm/todo-golang/comment/delivery/http"
+	commentnotifier "github.com/pratheeshm/todo-golang/comment/notifier"
+	commentrepo "github.com/pratheeshm/todo-golang/comment/repository"
+	commentusecase "github.com/pratheeshm/todo-golang/comment/usecase"
+
+	ruledeliver "github.com/pratheeshm/todo-golang/rule/delivery/http"
+	rulerepo "github.com/pratheeshm/todo-golang/rule/repository"
+	ruleusecase "github.com/pratheeshm/todo-golang/rule/usecase"
+	sprintdeliver "github.com/pratheeshm/todo-golang/sprint/delivery/http"
+	sprintrepo "github.com/pratheeshm/todo-golang/sprint/repository"
+	sprintusecase "github.com/pratheeshm/todo-golang/sprint/usecase"
+	statsdeliver "github.com/pratheeshm/todo-golang/stats/delivery/http"
+	statsrepo "github.com/pratheeshm/todo-golang/stats/repository"
+	statsusecase "github.com/pratheeshm/todo-golang/stats/usecase"
+	templatedeliver "github.com/pratheeshm/todo-golang/template/delivery/http"
+	templaterepo "github.com/pratheeshm/todo-golang/template/repository"
+	templateusecase "github.com/pratheeshm/todo-golang/template/usecase"
+	timedeliver "github.com/pratheeshm/todo-golang/timetrack/delivery/http"
+	timerepo "github.com/pratheeshm/todo-golang/timetrack/repository"
+	timeusecase "github.com/pratheeshm/todo-golang/timetrack/usecase"
+
+	viewdeliver "github.com/pratheeshm/todo-golang/view/delivery/http"
+	viewrepo "github.com/pratheeshm/todo-golang/view/repository"
+	viewusecase "github.com/pratheeshm/todo-golang/view/usecase"
+
+	"github.com/pratheeshm/todo-golang/attachment"
+	"github.com/pratheeshm/todo-golang/user"
+	userdeliver "github.com/pratheeshm/todo-golang/user/delivery/http"
+	userrepo "github.com/pratheeshm/todo-golang/user/repository"
+	userusecase "github.com/pratheeshm/todo-golang/user/usecase"
+
 	"database/sql"
+	"time"
 
+	"github.com/go-chi/chi"
 	_ "github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -32,14 +79,89 @@ func main() {
 	}
 	defer db.Close()
 	log.Info("Connected to DB successfully")
-	tr := repository.NewPostgresTaskRepository(db)
-	tu := usecase.NewTaskUsecase(tr)
-	h := taskdeliver.NewTaskHandler(tu)
-	err = http.ListenAndServe(fmt.Sprintf(":%s", viper.GetString("server.port")), h)
+	err = repository.EnsureSearchIndex(context.Background(), db, viper.GetString("search.language"))
+	if err != nil {
+		log.Panic(err)
+	}
+	uu := userusecase.NewUserUsecase(userrepo.NewPostgresUserRepository(db), userrepo.NewPostgresSessionRepository(db),
+		userrepo.NewPostgresAPIKeyRepository(db), []byte(viper.GetString("auth.secret")), viper.GetDuration("auth.access_ttl"), viper.GetDuration("auth.refresh_ttl"))
+	err = http.ListenAndServe(fmt.Sprintf(":%s", viper.GetString("server.port")), newHandler(db, uu))
 	if err != nil {
 		log.Panic(err)
 	}
 }
+
+// newHandler wires the repositories, usecases and http handlers of the api on db,
+// the requests are authenticated by uu and the task usecases are checked against
+// the role of the user
+func newHandler(db *sql.DB, uu user.Usecase) http.Handler {
+	tr := repository.NewPostgresTaskRepository(db)
+	er := repository.NewPostgresEventRepository(db)
+	sr := repository.NewPostgresSearchRepository(db, viper.GetString("search.language"))
+	au := accessusecase.NewAccessUsecase(accessrepo.NewPostgresGrantRepository(db))
+	tx := transaction.NewPostgresTransactor(db)
+	cr := repository.NewPostgresChecklistRepository(db)
+	ter := timerepo.NewPostgresTimeEntryRepository(db)
+	opts := []usecase.Option{usecase.WithTimers(ter)}
+	if viper.GetBool("tasks.checklist_auto_complete") {
+		opts = append(opts, usecase.WithAutoComplete())
+	}
+	limits := map[string]int{}
+	for status := range viper.GetStringMap("board.wip_limits") {
+		limits[status] = viper.GetInt("board.wip_limits." + status)
+	}
+	opts = append(opts, usecase.WithWIPLimits(limits, viper.GetString("board.wip_mode")))
+	rr := rulerepo.NewPostgresRuleRepository(db)
+	ar := attachmentrepo.NewPostgresAttachmentRepository(db)
+	storage := newStorage()
+	tu := attachmentusecase.NewCleanupUsecase(ruleusecase.NewAutomatedUsecase(usecase.NewAuthorizedUsecase(usecase.NewTaskUsecase(tr, er, sr, cr, tx, opts...), tr, au), rr), ar, storage, tx)
+	ir := idemrepo.NewPostgresIdempotencyRepository(db)
+	idempotent := idemdeliver.NewIdempotencyMiddleware(ir, viper.GetDuration("idempotency.ttl"))
+	vu := viewusecase.NewViewUsecase(viewrepo.NewPostgresViewRepository(db), tu, au)
+	cu := commentusecase.NewCommentUsecase(commentrepo.NewPostgresCommentRepository(db), tr, au, commentnotifier.NewLogNotifier(log.StandardLogger()))
+	atu := attachmentusecase.NewAttachmentUsecase(ar, tr, au, storage, tx,
+		viper.GetInt64("attachments.max_size"), viper.GetStringSlice("attachments.types"))
+	ttu := timeusecase.NewTimetrackUsecase(ter, tr, au)
+	su := statsusecase.NewStatsUsecase(statsrepo.NewPostgresStatsRepository(db), au)
+	spu := sprintusecase.NewSprintUsecase(sprintrepo.NewPostgresSprintRepository(db), au, tx)
+	ru := ruleusecase.NewRuleUsecase(rr, tu, au)
+	tpu := templateusecase.NewTemplateUsecase(templaterepo.NewPostgresTemplateRepository(db), tu, au, tx)
+	selectWorkspace := accessdeliver.NewWorkspaceMiddleware(au)
+	authenticate := func(next http.Handler) http.Handler {
+		return userdeliver.NewAuthMiddleware(uu)(selectWorkspace(next))
+	}
+	h := chi.NewMux()
+	h.Mount("/auth", userdeliver.NewUserHandler(uu))
+	h.With(authenticate).Mount("/views", viewdeliver.NewViewHandler(vu))
+	h.With(authenticate).Mount("/grants", accessdeliver.NewGrantHandler(au))
+	h.With(authenticate).Mount("/apikeys", userdeliver.NewAPIKeyHandler(uu))
+	h.With(authenticate).Mount("/task/{id:[0-9]+}/comments", commentdeliver.NewCommentHandler(cu))
+	h.With(authenticate).Mount("/task/{id:[0-9]+}/attachments", attachmentdeliver.NewAttachmentHandler(atu))
+	h.With(authenticate).Mount("/attachments", attachmentdeliver.NewPurgeHandler(atu))
+	h.With(authenticate).Mount("/task/{id:[0-9]+}/time", timedeliver.NewTimeHandler(ttu))
+	h.With(authenticate).Mount("/time", timedeliver.NewReportHandler(ttu))
+	h.With(authenticate).Mount("/stats", statsdeliver.NewStatsHandler(su))
+	h.With(authenticate).Mount("/sprints", sprintdeliver.NewSprintHandler(spu))
+	h.With(authenticate).Mount("/templates", templatedeliver.NewTemplateHandler(tpu))
+	h.With(authenticate).Mount("/rules", ruledeliver.NewRuleHandler(ru))
+	h.Mount("/", taskdeliver.NewTaskHandler(tu, uu, idempotent, authenticate, []byte(viper.GetString("calendar.secret"))))
+	return h
+}
+
+// newStorage returns the blob storage of the attachments set by attachments.storage
+func newStorage() attachment.Storage {
+	if viper.GetString("attachments.storage") == "s3" {
+		return attachmentstorage.NewS3Storage(attachmentstorage.S3Config{
+			Endpoint:  viper.GetString("attachments.s3.endpoint"),
+			Region:    viper.GetString("attachments.s3.region"),
+			Bucket:    viper.GetString("attachments.s3.bucket"),
+			AccessKey: viper.GetString("attachments.s3.access_key"),
+			SecretKey: viper.GetString("attachments.s3.secret_key"),
+		}, &http.Client{Timeout: 5 * time.Minute})
+	}
+	return attachmentstorage.NewLocalStorage(viper.GetString("attachments.dir"))
+}
+
 func mustInitDB() (*sql.DB, error) {
 	host := viper.GetString("database.host")
 	user := viper.GetString("database.username")
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+	usermocks "github.com/pratheeshm/todo-golang/user/mocks"
+)
+
+// statement is a query sent to the database with its arguments
+type statement struct {
+	query string
+	args  []driver.Value
+}
+
+// recorder is a database driver that records the statements it is sent
+// and answers them with no rows, but for the user being the admin of workspace admin
+type recorder struct {
+	mu         sync.Mutex
+	statements []statement
+	admin      int
+}
+
+func (r *recorder) Connect(context.Context) (driver.Conn, error) { return &recordConn{r}, nil }
+func (r *recorder) Driver() driver.Driver                        { return nil }
+
+func (r *recorder) record(query string, args []driver.Value) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statements = append(r.statements, statement{query, args})
+}
+
+// take returns the statements recorded since the last call
+func (r *recorder) take() []statement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.statements
+	r.statements = nil
+	return s
+}
+
+type recordConn struct{ r *recorder }
+
+func (c *recordConn) Prepare(query string) (driver.Stmt, error) { return &recordStmt{c.r, query}, nil }
+func (c *recordConn) Close() error                              { return nil }
+func (c *recordConn) Begin() (driver.Tx, error)                 { return c, nil }
+func (c *recordConn) Commit() error                             { return nil }
+func (c *recordConn) Rollback() error                           { return nil }
+
+type recordStmt struct {
+	r     *recorder
+	query string
+}
+
+func (s *recordStmt) Close() error  { return nil }
+func (s *recordStmt) NumInput() int { return -1 }
+func (s *recordStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.r.record(s.query, args)
+	return driver.RowsAffected(0), nil
+}
+func (s *recordStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.r.record(s.query, args)
+	if strings.Contains(s.query, "FROM role_grant") && len(args) > 0 && args[0] == int64(s.r.admin) {
+		return &rows{values: [][]driver.Value{{int64(1), int64(3), models.RoleAdmin, "", int64(0), time.Time{}}}}, nil
+	}
+	return &rows{}, nil
+}
+
+// rows answers values one row after the other
+type rows struct {
+	values [][]driver.Value
+}
+
+func (r *rows) Columns() []string {
+	if len(r.values) == 0 {
+		return nil
+	}
+	return make([]string, len(r.values[0]))
+}
+func (r *rows) Close() error { return nil }
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// scoped tells whether the statement is restricted to the workspace
+func (s statement) scoped(workspace int64) bool {
+	if !strings.Contains(s.query, "id_workspace") {
+		return false
+	}
+	for _, a := range s.args {
+		if a == workspace {
+			return true
+		}
+	}
+	return false
+}
+
+// policyLookup tells whether the statement reads the grants of the user making the requests
+func (s statement) policyLookup() bool {
+	return strings.Contains(s.query, "FROM role_grant") && len(s.args) == 2 && s.args[1] == int64(3)
+}
+
+func Test_newHandler_tenantIsolation(t *testing.T) {
+	const workspace = 42
+	rec := &recorder{admin: workspace}
+	db := sql.OpenDB(rec)
+	defer db.Close()
+	h := newHandler(db, &usermocks.MockUsecase{User: &models.User{ID: 3, Email: "alice@example.com", WorkspaceID: workspace}})
+	serve := func(method, target, body string, auth bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if auth {
+			req.Header.Set("Authorization", "Bearer access")
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+	check := func(t *testing.T) {
+		statements := rec.take()
+		reached := false
+		for _, s := range statements {
+			reached = reached || !s.policyLookup()
+		}
+		if !reached {
+			t.Fatalf("no statement reached the database past the role check")
+		}
+		for _, s := range statements {
+			if strings.Contains(s.query, "idempotency_key") {
+				// idempotency keys are scoped to the user, not the workspace
+				continue
+			}
+			if !s.scoped(workspace) {
+				t.Errorf("statement not scoped to workspace %d: %s %v", workspace, s.query, s.args)
+			}
+		}
+	}
+	tests := []struct {
+		method string
+		target string
+		body   string
+	}{
+		{"POST", "/add", `{"title":"Take math notes","status":"todo"}`},
+		{"GET", "/list", ""},
+		{"GET", "/list?q=status:todo", ""},
+		{"GET", "/export?format=csv", ""},
+		{"GET", "/search?q=math", ""},
+		{"POST", "/import?format=json", `[{"title":"Take math notes","status":"todo"}]`},
+		{"PUT", "/task/1", `{"title":"Take math notes","status":"done"}`},
+		{"DELETE", "/task/1", ""},
+		{"GET", "/task/1/history", ""},
+		{"POST", "/task/1/assignees", `{"id_users":[3]}`},
+		{"DELETE", "/task/1/assignees", `{"id_users":[3]}`},
+		{"GET", "/me/tasks", ""},
+		{"GET", "/task/1", ""},
+		{"POST", "/task/1/checklist", `{"text":"Read chapter 3"}`},
+		{"POST", "/task/1/checklist/2/toggle", ""},
+		{"POST", "/task/1/checklist/2/move", `{"position":0}`},
+		{"DELETE", "/task/1/checklist/2", ""},
+		{"POST", "/task/1/move", `{"after":2}`},
+		{"GET", "/board", ""},
+		{"GET", "/task/1/comments/", ""},
+		{"POST", "/task/1/comments/", `{"body":"ping @bob"}`},
+		{"PUT", "/task/1/comments/2", `{"body":"ping @carol"}`},
+		{"DELETE", "/task/1/comments/2", ""},
+		{"GET", "/task/1/comments/2/history", ""},
+		{"GET", "/task/1/attachments/", ""},
+		{"GET", "/task/1/attachments/2", ""},
+		{"DELETE", "/task/1/attachments/2", ""},
+		{"POST", "/attachments/purge", ""},
+		{"GET", "/task/1/time/", ""},
+		{"POST", "/task/1/time/", `{"started_at":"2026-10-01T09:00:00Z","stopped_at":"2026-10-01T10:00:00Z"}`},
+		{"POST", "/task/1/time/start", ""},
+		{"POST", "/task/1/time/stop", ""},
+		{"DELETE", "/task/1/time/2", ""},
+		{"GET", "/time/report?from=2026-10-01&to=2026-11-01&group=project", ""},
+		{"GET", "/stats/?from=2026-10-01&to=2026-11-01&interval=week", ""},
+		{"GET", "/sprints/", ""},
+		{"POST", "/sprints/", `{"name":"Sprint 1","start_date":"2026-10-19T00:00:00Z"}`},
+		{"GET", "/sprints/1", ""},
+		{"PUT", "/sprints/1", `{"name":"Sprint 1","start_date":"2026-10-19T00:00:00Z"}`},
+		{"DELETE", "/sprints/1", ""},
+		{"POST", "/sprints/1/tasks", `{"tasks":[1,2]}`},
+		{"DELETE", "/sprints/1/tasks/2", ""},
+		{"POST", "/sprints/1/close", `{"carry_to":2}`},
+		{"GET", "/sprints/1/burndown", ""},
+		{"GET", "/templates/", ""},
+		{"POST", "/templates/", `{"name":"Release","tasks":[{"title":"Release {{version}}","checklist":["Tag {{version}}"]}]}`},
+		{"GET", "/templates/1", ""},
+		{"PUT", "/templates/1", `{"name":"Release","tasks":[{"title":"Release {{version}}","checklist":["Tag {{version}}"]}]}`},
+		{"DELETE", "/templates/1", ""},
+		{"POST", "/templates/1/instantiate", `{"date":"2026-10-19","values":{"version":"1.2"}}`},
+		{"GET", "/rules/", ""},
+		{"POST", "/rules/", `{"name":"Done is not urgent","trigger":"status_changed","condition":"status:done","actions":[{"type":"remove_tag","value":"urgent"}]}`},
+		{"GET", "/rules/1", ""},
+		{"PUT", "/rules/1", `{"name":"Done is not urgent","trigger":"status_changed","condition":"status:done","actions":[{"type":"remove_tag","value":"urgent"}]}`},
+		{"DELETE", "/rules/1", ""},
+		{"GET", "/rules/1/executions", ""},
+		{"POST", "/rules/run", ""},
+		{"GET", "/sync", ""},
+		{"POST", "/sync", `{"changes":[{"id_task":1,"base_version":1,"title":"Take math notes","status":"done"},{"id_task":2,"base_version":1,"deleted":true}]}`},
+		{"POST", "/tasks/bulk", `{"mode":"best_effort","operations":[{"op":"create","title":"Take math notes","status":"todo"},{"op":"update","id_task":1,"title":"Take math notes","status":"done"},{"op":"status","id_task":1,"status":"done"},{"op":"delete","id_task":1}]}`},
+		{"GET", "/views/", ""},
+		{"POST", "/views/", `{"name":"mine"}`},
+		{"GET", "/views/1", ""},
+		{"PUT", "/views/1", `{"name":"mine"}`},
+		{"DELETE", "/views/1", ""},
+		{"GET", "/views/1/tasks", ""},
+		{"GET", "/grants/", ""},
+		{"POST", "/grants/", `{"id_user":5,"role":"viewer"}`},
+		{"DELETE", "/grants/2", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			serve(tt.method, tt.target, tt.body, true)
+			check(t)
+		})
+	}
+
+	w := serve("GET", "/calendar/url", "", true)
+	res := map[string]string{}
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("can not decode the calendar url: %v", err)
+	}
+	feed, err := url.Parse(res["url"])
+	if err != nil {
+		t.Fatalf("invalid calendar url %q: %v", res["url"], err)
+	}
+	t.Run("GET /calendar.ics", func(t *testing.T) {
+		serve("GET", feed.RequestURI(), "", false)
+		check(t)
+	})
+	t.Run("GET /list of a workspace without role", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/list", nil)
+		req.Header.Set("Authorization", "Bearer access")
+		req.Header.Set("X-Workspace-ID", "43")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("got statuscode %d but expected %d", w.Code, http.StatusForbidden)
+		}
+		for _, s := range rec.take() {
+			if !s.scoped(43) || !s.policyLookup() {
+				t.Errorf("unexpected statement %s %v", s.query, s.args)
+			}
+		}
+	})
+	t.Run("GET /calendar.ics of another workspace", func(t *testing.T) {
+		q := feed.Query()
+		q.Set("workspace", "43")
+		w := serve("GET", "/calendar.ics?"+q.Encode(), "", false)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("got statuscode %d but expected %d", w.Code, http.StatusForbidden)
+		}
+		if s := rec.take(); len(s) != 0 {
+			t.Errorf("expected no statement, got %v", s)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+// Package markdown renders the small subset of Markdown accepted in comments:
+// paragraphs, line breaks, bullet lists, fenced code blocks, `code`, **strong**,
+// *emphasis* and [links](https://example.com). Raw HTML is not supported, every
+// character of the source is escaped so the output is safe to embed in a page
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const fence = "```"
+
+// schemes are the link targets rendered as links, other links are rendered as their text
+var schemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+var (
+	inlineRe = regexp.MustCompile("`([^`]+)`|\\[([^\\]]+)\\]\\(([^()\\s]+)\\)")
+	strongRe = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	emRe     = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	itemRe   = regexp.MustCompile(`^\s*[-*+]\s+`)
+)
+
+// Render returns the HTML of src
+func Render(src string) string {
+	b := &strings.Builder{}
+	var para, items []string
+	flush := func() {
+		if len(para) > 0 {
+			b.WriteString("<p>" + strings.Join(para, "<br>\n") + "</p>\n")
+			para = nil
+		}
+		if len(items) > 0 {
+			b.WriteString("<ul>\n")
+			for _, item := range items {
+				b.WriteString("<li>" + item + "</li>\n")
+			}
+			b.WriteString("</ul>\n")
+			items = nil
+		}
+	}
+	lines := strings.Split(strings.Replace(src, "\r\n", "\n", -1), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(strings.TrimSpace(line), fence):
+			flush()
+			code := []string{}
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, html.EscapeString(lines[i]))
+			}
+			// an unclosed fence runs to the end of the source
+			b.WriteString("<pre><code>" + strings.Join(code, "\n") + "</code></pre>\n")
+		case strings.TrimSpace(line) == "":
+			flush()
+		case itemRe.MatchString(line):
+			if len(para) > 0 {
+				flush()
+			}
+			items = append(items, inline(itemRe.ReplaceAllString(line, "")))
+		default:
+			if len(items) > 0 {
+				flush()
+			}
+			para = append(para, inline(strings.TrimSpace(line)))
+		}
+	}
+	flush()
+	return b.String()
+}
+
+// inline renders the markup of a line, code spans and links are rendered
+// apart so that their content is not taken for emphasis
+func inline(s string) string {
+	b := &strings.Builder{}
+	last := 0
+	for _, m := range inlineRe.FindAllStringSubmatchIndex(s, -1) {
+		b.WriteString(emphasis(s[last:m[0]]))
+		if m[2] >= 0 {
+			b.WriteString("<code>" + html.EscapeString(s[m[2]:m[3]]) + "</code>")
+		} else {
+			b.WriteString(link(emphasis(s[m[4]:m[5]]), s[m[6]:m[7]]))
+		}
+		last = m[1]
+	}
+	b.WriteString(emphasis(s[last:]))
+	return b.String()
+}
+
+func emphasis(s string) string {
+	s = html.EscapeString(s)
+	s = strongRe.ReplaceAllString(s, "<strong>$1</strong>")
+	return emRe.ReplaceAllString(s, "<em>$1</em>")
+}
+
+// link returns text linked to target, or text alone when the scheme of target is not allowed
+func link(text, target string) string {
+	u, err := url.Parse(target)
+	if err != nil || !schemes[strings.ToLower(u.Scheme)] {
+		return text
+	}
+	return `<a href="` + html.EscapeString(target) + `" rel="nofollow noopener">` + text + "</a>"
+}
@@ -0,0 +1,34 @@
+package markdown
+
+import (
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"Normal Case1: paragraphs and line breaks", "first line\nsecond line\n\nnext", "<p>first line<br>\nsecond line</p>\n<p>next</p>\n"},
+		{"emphasis", "**done** and *soon*", "<p><strong>done</strong> and <em>soon</em></p>\n"},
+		{"code span is not marked up", "run `a*b*c <x>`", "<p>run <code>a*b*c &lt;x&gt;</code></p>\n"},
+		{"link", "see [the docs](https://example.com/a?b=1&c=2)", `<p>see <a href="https://example.com/a?b=1&amp;c=2" rel="nofollow noopener">the docs</a></p>` + "\n"},
+		{"javascript link is dropped", "[click](javascript:alert(1))", "<p>[click](javascript:alert(1))</p>\n"},
+		{"javascript scheme", "[click](JavaScript:alert)", "<p>click</p>\n"},
+		{"raw html is escaped", `<script>alert("x")</script>`, "<p>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;</p>\n"},
+		{"attribute injection", `[x](https://a.b/"onmouseover=alert)`, `<p><a href="https://a.b/&#34;onmouseover=alert" rel="nofollow noopener">x</a></p>` + "\n"},
+		{"list", "todo:\n- one\n- *two*\n\nafter", "<p>todo:</p>\n<ul>\n<li>one</li>\n<li><em>two</em></li>\n</ul>\n<p>after</p>\n"},
+		{"fenced code", "```\n<b>**x**</b>\n```\ntext", "<pre><code>&lt;b&gt;**x**&lt;/b&gt;</code></pre>\n<p>text</p>\n"},
+		{"unclosed fence", "```\ncode", "<pre><code>code</code></pre>\n"},
+		{"windows line endings", "a\r\nb", "<p>a<br>\nb</p>\n"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.src); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionRe matches @name where the @ does not follow a word, which leaves email addresses out
+var mentionRe = regexp.MustCompile(`(^|[^\w@.])@([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+var codeSpanRe = regexp.MustCompile("`[^`]+`")
+
+// Mentions returns the names mentioned as @name in src, lower cased and in
+// their first order. Mentions in code spans and code blocks do not count
+func Mentions(src string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	inCode := false
+	for _, line := range strings.Split(src, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), fence) {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+		line = codeSpanRe.ReplaceAllString(line, " ")
+		for _, m := range mentionRe.FindAllStringSubmatch(line, -1) {
+			// a sentence may end right after the name
+			name := strings.ToLower(strings.TrimRight(m[2], "._-"))
+			if name != "" && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,30 @@
+package markdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"Normal Case1: mentions", "@Alice can you review? cc @bob.", []string{"alice", "bob"}},
+		{"duplicates", "@bob @alice @Bob", []string{"bob", "alice"}},
+		{"punctuation around", "(@alice), @bob: ok", []string{"alice", "bob"}},
+		{"dotted name", "@jean.dupont thanks", []string{"jean.dupont"}},
+		{"email is not a mention", "write to alice@example.com", []string{}},
+		{"code span", "run `@alice` then ping @bob", []string{"bob"}},
+		{"code block", "```\n@alice\n```\n@bob", []string{"bob"}},
+		{"lone at", "meet @ noon", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mentions(tt.src); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Mentions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
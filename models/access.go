@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Roles, each one can do everything the previous one can
+const (
+	// RoleViewer reads tasks
+	RoleViewer = "viewer"
+	// RoleMember also adds and edits tasks
+	RoleMember = "member"
+	// RoleAdmin also deletes tasks and manages the grants of the workspace
+	RoleAdmin = "admin"
+)
+
+// Grant gives a role to a user on the whole workspace, on one of its projects
+// when Project is set or on a single task when TaskID is set. Grants add up,
+// the role of a user on a task is the strongest one that applies
+type Grant struct {
+	ID        int       `json:"id_grant"`
+	UserID    int       `json:"id_user" validate:"required,min=1"`
+	Role      string    `json:"role" validate:"oneof=viewer member admin"`
+	Project   string    `json:"project,omitempty" validate:"max=50"`
+	TaskID    int       `json:"id_task,omitempty" validate:"min=0"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Workspace tells whether the grant applies to the whole workspace
+func (g *Grant) Workspace() bool {
+	return g.Project == "" && g.TaskID == 0
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// API key scopes, each one caps the roles of the user to the role of the same rank
+const (
+	ScopeReadOnly  = "read-only"
+	ScopeReadWrite = "read-write"
+	ScopeAdmin     = "admin"
+)
+
+// ScopeRole returns the strongest role allowed by an API key scope
+func ScopeRole(scope string) string {
+	switch scope {
+	case ScopeReadOnly:
+		return RoleViewer
+	case ScopeReadWrite:
+		return RoleMember
+	case ScopeAdmin:
+		return RoleAdmin
+	}
+	return ""
+}
+
+// APIKey lets scripts act for a user on one workspace without logging in.
+// Only the hash of the secret is stored, Key is only set when the key is created
+type APIKey struct {
+	ID          string     `json:"id_key"`
+	UserID      int        `json:"id_user"`
+	WorkspaceID int        `json:"id_workspace"`
+	Name        string     `json:"name" validate:"required,max=50"`
+	Scope       string     `json:"scope" validate:"oneof=read-only read-write admin"`
+	SecretHash  string     `json:"-"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	Key         string     `json:"key,omitempty"`
+}
@@ -0,0 +1,6 @@
+package models
+
+// AssigneeChange represents the users added to or removed from the assignees of a task
+type AssigneeChange struct {
+	UserIDs []int `json:"id_users" validate:"required,min=1,max=20,dive,min=1"`
+}
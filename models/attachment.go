@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Attachment is a file uploaded to a task. The content is kept in the blob
+// storage under its sha256, files with the same content share one blob
+type Attachment struct {
+	ID     int    `json:"id_attachment"`
+	TaskID int    `json:"id_task"`
+	Name   string `json:"name" validate:"required,max=255"`
+	// ContentType is sniffed from the content, the one sent by the client is not trusted
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Hash        string    `json:"sha256"`
+	UploadedBy  int       `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,28 @@
+package models
+
+// BoardStatuses are the columns of the board, in order
+var BoardStatuses = []string{"todo", "inprogress", "done"}
+
+// WIP limit modes, a transition over the limit is either refused or let
+// through and flagged in the history of the task
+const (
+	WIPReject = "reject"
+	WIPFlag   = "flag"
+)
+
+// Board represents the tasks of the workspace grouped by status
+type Board struct {
+	Columns []*BoardColumn `json:"columns"`
+}
+
+// BoardColumn holds the tasks of a status in the manual order
+type BoardColumn struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+	// Limit is the WIP limit of the column, 0 when there is none
+	Limit int `json:"wip_limit,omitempty"`
+	// Exceeded is set when the column holds more tasks than its limit,
+	// which the flag mode lets happen
+	Exceeded bool    `json:"exceeded"`
+	Tasks    []*Task `json:"tasks"`
+}
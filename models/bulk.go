@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// Bulk operation types
+const (
+	BulkCreate = "create"
+	BulkUpdate = "update"
+	BulkDelete = "delete"
+	BulkStatus = "status"
+)
+
+// Bulk execution modes
+const (
+	// BulkAtomic applies every operation or none of them
+	BulkAtomic = "atomic"
+	// BulkBestEffort applies every operation it can
+	BulkBestEffort = "best_effort"
+)
+
+// Bulk result statuses
+const (
+	BulkOK       = "ok"
+	BulkInvalid  = "invalid"
+	BulkNotFound = "not_found"
+	BulkFailed   = "failed"
+	// BulkAborted is reported for valid operations of a rolled back atomic request
+	BulkAborted = "aborted"
+)
+
+// BulkRequest represents a set of operations applied in one request
+type BulkRequest struct {
+	Mode       string           `json:"mode" validate:"oneof=atomic best_effort"`
+	Operations []*BulkOperation `json:"operations" validate:"required,min=1,max=1000"`
+}
+
+// BulkOperation represents a single operation of a BulkRequest.
+// Description, Priority, Project, Tags and DueDate are only used when creating a task
+type BulkOperation struct {
+	Op          string     `json:"op"`
+	ID          int        `json:"id_task"`
+	Title       string     `json:"title"`
+	Status      string     `json:"status"`
+	Description string     `json:"description"`
+	Priority    int        `json:"priority"`
+	Project     string     `json:"project"`
+	Tags        []string   `json:"tags"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+}
+
+// BulkResult represents the outcome of a BulkOperation
+type BulkResult struct {
+	Index   int    `json:"index"`
+	Op      string `json:"op"`
+	ID      int    `json:"id_task,omitempty"`
+	Status  string `json:"status"`
+	Version int64  `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkResponse represents the outcome of a BulkRequest
+type BulkResponse struct {
+	Committed bool          `json:"committed"`
+	Results   []*BulkResult `json:"results"`
+}
+
+// TaskBatch groups changes so that each kind is applied with a single statement
+type TaskBatch struct {
+	Create []*Task
+	Update []*Task
+	Delete []int
+	// Status holds the task ids to move to each status
+	Status map[string][]int
+}
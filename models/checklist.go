@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// ChecklistItem is a small step of a task, the items of a task are ordered by Position
+type ChecklistItem struct {
+	ID        int       `json:"id_item"`
+	TaskID    int       `json:"id_task"`
+	Text      string    `json:"text" validate:"required,max=200"`
+	Checked   bool      `json:"checked"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ChecklistMove moves a checklist item to Position, the items after it shift down
+type ChecklistMove struct {
+	Position int `json:"position" validate:"min=0"`
+}
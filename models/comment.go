@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Comment represents a comment on a task. Body is Markdown, it is only ever
+// sent back rendered and sanitized in BodyHTML next to the source
+type Comment struct {
+	ID       int    `json:"id_comment"`
+	TaskID   int    `json:"id_task"`
+	AuthorID int    `json:"id_author"`
+	Body     string `json:"body" validate:"required,max=10000"`
+	// BodyHTML is rendered from Body by the markdown package, it is not stored
+	BodyHTML  string    `json:"body_html"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CommentRevision is a body a comment had before an edit
+type CommentRevision struct {
+	ID        int       `json:"id_revision"`
+	CommentID int       `json:"id_comment"`
+	Body      string    `json:"body"`
+	BodyHTML  string    `json:"body_html"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NotificationMention is sent to the users mentioned as @username in a comment
+const NotificationMention = "mention"
+
+// Notification tells a user about a comment
+type Notification struct {
+	Type        string `json:"type"`
+	UserID      int    `json:"id_user"`
+	WorkspaceID int    `json:"id_workspace"`
+	TaskID      int    `json:"id_task"`
+	CommentID   int    `json:"id_comment"`
+	AuthorID    int    `json:"id_author"`
+}
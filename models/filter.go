@@ -0,0 +1,15 @@
+package models
+
+// TaskFilter represents the filters accepted when listing tasks
+type TaskFilter struct {
+	Status  string `json:"status" validate:"omitempty,oneof=todo inprogress done"`
+	Project string `json:"project" validate:"max=50"`
+	// Tag keeps the tasks carrying the tag
+	Tag string `json:"tag" validate:"max=30"`
+	// Query is written in the language of the filter package
+	Query string `json:"q" validate:"max=1000"`
+	// Sort lists the fields to sort on, see filter.ParseSort
+	Sort string `json:"sort" validate:"max=200"`
+	// Assignee keeps the tasks assigned to the user, 0 keeps them all
+	Assignee int `json:"assignee" validate:"min=0"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// IdempotencyRecord represents the stored response of a request sent with an Idempotency-Key.
+// StatusCode is zero while the first request is still being handled
+type IdempotencyRecord struct {
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,34 @@
+package models
+
+// Import row statuses
+const (
+	ImportValid   = "valid"
+	ImportInvalid = "invalid"
+	ImportCreated = "created"
+)
+
+// ImportRow represents a task read from an import file, Error is set
+// when the row could not be read at all
+type ImportRow struct {
+	Line  int
+	Task  *Task
+	Error string
+}
+
+// ImportRowResult represents the outcome of importing an ImportRow
+type ImportRowResult struct {
+	Line   int      `json:"line"`
+	ID     int      `json:"id_task,omitempty"`
+	Title  string   `json:"title"`
+	Status string   `json:"status"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ImportResult represents the outcome of an import. Nothing is committed
+// on a dry run or when a row is invalid
+type ImportResult struct {
+	DryRun    bool               `json:"dry_run"`
+	Committed bool               `json:"committed"`
+	Imported  int                `json:"imported"`
+	Rows      []*ImportRowResult `json:"rows"`
+}
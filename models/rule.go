@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// Triggers of a rule. A rule on TriggerStatusChanged runs when the status of a
+// task changes, TriggerUpdated on every edit and TriggerScheduled when the
+// scheduled rules of the workspace are run, for every task matching its condition
+const (
+	TriggerCreated       = "task_created"
+	TriggerUpdated       = "task_updated"
+	TriggerStatusChanged = "status_changed"
+	TriggerScheduled     = "scheduled"
+)
+
+// Actions of a rule, they change the task through task.Usecase
+const (
+	ActionSetStatus   = "set_status"
+	ActionSetPriority = "set_priority"
+	ActionSetProject  = "set_project"
+	ActionAddTag      = "add_tag"
+	ActionRemoveTag   = "remove_tag"
+)
+
+// Statuses of a rule execution. An execution stopped by the loop protection is
+// skipped, a rule that leaves the task as it is is not recorded
+const (
+	ExecutionApplied = "applied"
+	ExecutionFailed  = "failed"
+	ExecutionSkipped = "skipped"
+)
+
+// Rule represents an automation of the workspace: when Trigger happens to a
+// task matching Condition, a query of the filter package, its Actions run.
+// "when a task moves to done, remove the urgent tag" is a rule on
+// status_changed with the condition status:done
+type Rule struct {
+	ID        int           `json:"id_rule"`
+	Name      string        `json:"name" validate:"required,max=50"`
+	Trigger   string        `json:"trigger" validate:"required,oneof=task_created task_updated status_changed scheduled"`
+	Condition string        `json:"condition" validate:"max=1000"`
+	Actions   []*RuleAction `json:"actions" validate:"required,min=1,max=10,dive,required"`
+	Enabled   bool          `json:"enabled"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// RuleAction is a change made to the task, Value is a status, a priority, a project or a tag
+type RuleAction struct {
+	Type  string `json:"type" validate:"required,oneof=set_status set_priority set_project add_tag remove_tag"`
+	Value string `json:"value" validate:"max=50"`
+}
+
+// RuleExecution is an entry of the execution log of a rule
+type RuleExecution struct {
+	ID      int    `json:"id_execution"`
+	RuleID  int    `json:"id_rule"`
+	TaskID  int    `json:"id_task"`
+	Trigger string `json:"trigger"`
+	Status  string `json:"status"`
+	// Error tells why the execution failed or was skipped
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,23 @@
+package models
+
+// SearchQuery represents a full-text search over title and description,
+// every word of Query has to match the start of a word of the task
+type SearchQuery struct {
+	Query string `json:"q" validate:"required,max=200"`
+	Limit int    `json:"limit" validate:"min=1,max=100"`
+}
+
+// SearchResult represents a task matching a SearchQuery. The snippets are
+// html escaped and wrap the matching words between SearchMarkStart and SearchMarkStop
+type SearchResult struct {
+	Task        *Task   `json:"task"`
+	Rank        float64 `json:"rank"`
+	Title       string  `json:"title_snippet"`
+	Description string  `json:"description_snippet"`
+}
+
+// Search snippet markers
+const (
+	SearchMarkStart = "<mark>"
+	SearchMarkStop  = "</mark>"
+)
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// SprintDays is the length of a sprint added without an end date
+const SprintDays = 14
+
+// Sprint represents a milestone of the workspace running from StartDate to
+// EndDate, both days included. A closed sprint has ClosedAt set and can not
+// change anymore
+type Sprint struct {
+	ID        int        `json:"id_sprint"`
+	Name      string     `json:"name" validate:"required,max=50"`
+	Goal      string     `json:"goal" validate:"max=200"`
+	StartDate time.Time  `json:"start_date" validate:"required"`
+	EndDate   time.Time  `json:"end_date" validate:"omitempty,gtefield=StartDate"`
+	ClosedAt  *time.Time `json:"closed_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	// Tasks are the ids of the tasks planned in the sprint, carried over ones included
+	Tasks []int `json:"tasks,omitempty"`
+}
+
+// SprintTasks represents the tasks added to a sprint
+type SprintTasks struct {
+	Tasks []int `json:"tasks" validate:"required,min=1,max=100,dive,min=1"`
+}
+
+// SprintClose represents the closing of a sprint, the unfinished tasks are
+// carried over to the sprint CarryTo or back to the backlog when it is 0
+type SprintClose struct {
+	CarryTo int `json:"carry_to" validate:"min=0"`
+}
+
+// SprintClosure is a closed sprint along with the tasks it carried over
+type SprintClosure struct {
+	Sprint  *Sprint `json:"sprint"`
+	Carried []int   `json:"carried"`
+}
+
+// BurndownPoint is the number of tasks of a sprint left at the end of a day
+// and the number an even pace would leave
+type BurndownPoint struct {
+	Day       time.Time `json:"day"`
+	Remaining int       `json:"remaining"`
+	Ideal     float64   `json:"ideal"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Stats intervals, the buckets of the series start at midnight UTC, on Mondays for the weeks
+const (
+	StatsByDay  = "day"
+	StatsByWeek = "week"
+)
+
+// StatsQuery computes the series of the statistics over the buckets of Interval
+// from From, which starts a bucket, up to To
+type StatsQuery struct {
+	From     time.Time `validate:"required"`
+	To       time.Time `validate:"required,gtfield=From"`
+	Interval string    `validate:"oneof=day week"`
+	Project  string    `validate:"max=50"`
+}
+
+// Stats represents the statistics of the tasks of the workspace
+type Stats struct {
+	// Statuses counts the tasks by their current status
+	Statuses   []*StatusCount     `json:"statuses"`
+	Throughput []*ThroughputPoint `json:"throughput"`
+	LeadTimes  *LeadTimes         `json:"lead_times"`
+	Flow       []*FlowPoint       `json:"flow"`
+}
+
+// StatusCount is the number of tasks in a status
+type StatusCount struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+// ThroughputPoint counts the tasks created and completed within the bucket
+// starting at Start. A task is completed whenever it moves to done
+type ThroughputPoint struct {
+	Start     time.Time `json:"start"`
+	Created   int       `json:"created"`
+	Completed int       `json:"completed"`
+}
+
+// LeadTimes averages the times of the tasks completed within the range. The
+// cycle time runs from the first time a task left todo and the lead time from
+// its creation, both up to its last completion within the range
+type LeadTimes struct {
+	Completed    int   `json:"completed"`
+	CycleSeconds int64 `json:"avg_cycle_seconds"`
+	LeadSeconds  int64 `json:"avg_lead_seconds"`
+}
+
+// FlowPoint counts the tasks by status at the end of the bucket starting at
+// Start, the cumulative flow. Open is the burndown, the tasks not done
+type FlowPoint struct {
+	Start    time.Time      `json:"start"`
+	Statuses map[string]int `json:"statuses"`
+	Open     int            `json:"open"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// Sync result statuses
+const (
+	SyncApplied  = "applied"
+	SyncConflict = "conflict"
+	SyncNotFound = "not_found"
+	SyncInvalid  = "invalid"
+)
+
+// SyncDelta represents the changes made to tasks after a sync token
+type SyncDelta struct {
+	Created []*Task `json:"created"`
+	Updated []*Task `json:"updated"`
+	Deleted []int   `json:"deleted"`
+	Token   string  `json:"token"`
+	HasMore bool    `json:"has_more"`
+}
+
+// SyncCursor is the position of a sync client in the changes of a
+// workspace, changes are ordered by transaction and then by sequence
+type SyncCursor struct {
+	TxID int64
+	Seq  int64
+}
+
+// Before reports whether c comes before o
+func (c SyncCursor) Before(o SyncCursor) bool {
+	return c.TxID < o.TxID || c.TxID == o.TxID && c.Seq < o.Seq
+}
+
+// SyncChange represents a change made by an offline client.
+// A change without ID creates a task, BaseVersion is the version
+// of the task the client last saw.
+type SyncChange struct {
+	ClientID    string     `json:"client_id"`
+	ID          int        `json:"id_task"`
+	BaseVersion int64      `json:"base_version"`
+	Deleted     bool       `json:"deleted"`
+	Title       string     `json:"title"`
+	Status      string     `json:"status"`
+	Description string     `json:"description"`
+	Priority    int        `json:"priority"`
+	Project     string     `json:"project"`
+	Tags        []string   `json:"tags"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+}
+
+// SyncResult represents the outcome of applying a SyncChange.
+// On conflict Task holds the server copy of the task.
+type SyncResult struct {
+	ClientID string `json:"client_id,omitempty"`
+	ID       int    `json:"id_task"`
+	Status   string `json:"status"`
+	Version  int64  `json:"version,omitempty"`
+	Task     *Task  `json:"task,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
@@ -1,8 +1,44 @@
 package models
 
+import "time"
+
 // Task represents the task model
 type Task struct {
-	ID     int    `json:"id_task"`
-	Title  string `json:"title" validate:"required"`
-	Status string `json:"status" validate:"oneof=todo inprogress done"`
+	ID          int    `json:"id_task"`
+	Title       string `json:"title" validate:"required,max=50"`
+	Status      string `json:"status" validate:"oneof=todo inprogress done"`
+	Description string `json:"description" validate:"max=2000"`
+	// Priority goes from 1 (highest) to 9 (lowest), 0 means undefined
+	Priority int    `json:"priority" validate:"min=0,max=9"`
+	Project  string `json:"project" validate:"max=50"`
+	// Tags can not hold the unit separator, the repositories join them with it
+	Tags    []string   `json:"tags" validate:"max=20,dive,required,max=30,excludesall=\x1f"`
+	DueDate *time.Time `json:"due_date,omitempty"`
+	// CreatedBy is the id of the user who created the task, it is taken from
+	// the context when the task is stored and never changes afterwards
+	CreatedBy int `json:"created_by,omitempty"`
+	// Assignees are the ids of the users the task is assigned to, they
+	// only change through the assignees endpoints
+	Assignees []int `json:"assignees,omitempty"`
+	// Checklist is only loaded when a single task is read, it changes
+	// through the checklist endpoints
+	Checklist []*ChecklistItem `json:"checklist,omitempty"`
+	Version   int64            `json:"version"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	// CreatedVersion is the change sequence the task was created at
+	CreatedVersion int64 `json:"-"`
+	// ChangeTxID and CreatedTxID are the transactions of the last write and
+	// of the insert, they are only loaded for sync
+	ChangeTxID  int64 `json:"-"`
+	CreatedTxID int64 `json:"-"`
+	// Deleted marks a tombstone kept for sync clients
+	Deleted bool `json:"-"`
+}
+
+// TaskMove places a task right after the task After and right before the task
+// Before in the manual order, one of them is enough
+type TaskMove struct {
+	After  int `json:"after" validate:"min=0,required_without=Before"`
+	Before int `json:"before" validate:"min=0"`
 }
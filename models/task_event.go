@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Task event types
+const (
+	EventCreated = "created"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+	// EventAssigned and EventUnassigned carry the user in UserID
+	EventAssigned   = "assigned"
+	EventUnassigned = "unassigned"
+	// EventWIPLimit flags a task that went over the WIP limit of the column of its status
+	EventWIPLimit = "wip_limit"
+)
+
+// TaskEvent represents a change in the history of a task,
+// Status is the status of the task after the change, empty once deleted
+type TaskEvent struct {
+	ID     int    `json:"id_event"`
+	TaskID int    `json:"id_task"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	// UserID is the user assigned or unassigned by the change
+	UserID    int       `json:"id_user,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Template represents tasks created again and again, a release checklist for
+// instance. The titles, descriptions and checklist items of its tasks are
+// patterns with placeholders such as {{date}}, see TemplateInstance
+type Template struct {
+	ID        int             `json:"id_template"`
+	Name      string          `json:"name" validate:"required,max=50"`
+	Tasks     []*TemplateTask `json:"tasks" validate:"required,min=1,max=50,dive,required"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// TemplateTask is a task of a template, the title is validated once rendered
+type TemplateTask struct {
+	Title       string `json:"title" validate:"required,max=200"`
+	Description string `json:"description" validate:"max=2000"`
+	// Status is the status of the task created, todo when it is empty
+	Status   string   `json:"status" validate:"omitempty,oneof=todo inprogress done"`
+	Priority int      `json:"priority" validate:"min=0,max=9"`
+	Project  string   `json:"project" validate:"max=50"`
+	Tags     []string `json:"tags" validate:"max=20,dive,required,max=30,excludesall=\x1f"`
+	// DueInDays sets the due date of the task created that many days after the date of the instance
+	DueInDays *int     `json:"due_in_days,omitempty" validate:"omitempty,min=0,max=3650"`
+	Checklist []string `json:"checklist" validate:"max=50,dive,required,max=200"`
+}
+
+// TemplateInstance represents the tasks of a template created on Date,
+// a day, today when it is empty. The placeholders {{date}}, {{year}},
+// {{month}} and {{week}} come from Date, Values set the others
+type TemplateInstance struct {
+	Date   string            `json:"date"`
+	Values map[string]string `json:"values" validate:"max=20"`
+}
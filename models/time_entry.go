@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// TimeEntry represents time a user spent on a task. A running timer has no
+// StoppedAt, the entries of a user never overlap
+type TimeEntry struct {
+	ID        int        `json:"id_entry"`
+	TaskID    int        `json:"id_task"`
+	UserID    int        `json:"id_user"`
+	StartedAt time.Time  `json:"started_at" validate:"required"`
+	StoppedAt *time.Time `json:"stopped_at" validate:"required"`
+	Note      string     `json:"note" validate:"max=200"`
+	// Seconds is the length of the entry, up to now while the timer runs
+	Seconds   int64     `json:"seconds"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Time report groupings
+const (
+	TimeByTask    = "task"
+	TimeByProject = "project"
+	TimeByUser    = "user"
+	TimeByDay     = "day"
+)
+
+// TimeReportQuery sums the time spent between From and To, the entries
+// running over the bounds only count for their part within the range
+type TimeReportQuery struct {
+	From    time.Time `validate:"required"`
+	To      time.Time `validate:"required,gtfield=From"`
+	GroupBy string    `validate:"oneof=task project user day"`
+	Project string    `validate:"max=50"`
+	TaskID  int       `validate:"min=0"`
+	UserID  int       `validate:"min=0"`
+}
+
+// TimeReportRow is the time spent on a group of the report, only the fields
+// of the grouping are set. Day is the UTC day the entries started on
+type TimeReportRow struct {
+	TaskID  int    `json:"id_task,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Project string `json:"project,omitempty"`
+	UserID  int    `json:"id_user,omitempty"`
+	Day     string `json:"day,omitempty"`
+	Entries int    `json:"entries"`
+	Seconds int64  `json:"seconds"`
+}
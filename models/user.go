@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// User represents an account of the api, the password is only kept hashed.
+// Every user gets a workspace of their own when registering
+type User struct {
+	ID           int       `json:"id_user"`
+	Email        string    `json:"email"`
+	WorkspaceID  int       `json:"id_workspace"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	// Scope caps the roles of a user authenticated by an API key, it is empty for access tokens
+	Scope string `json:"-"`
+	// FeedKey signs the calendar feed urls of the user, it is empty until the first one
+	FeedKey string `json:"-"`
+}
+
+// Credentials are sent to register and to log in.
+// bcrypt ignores what follows the 72nd byte of a password
+type Credentials struct {
+	Email    string `json:"email" validate:"required,email,max=254"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+// Session is a login of a user, it lives as long as its refresh token.
+// Only the hash of the refresh token is stored
+type Session struct {
+	ID          string
+	UserID      int
+	RefreshHash string
+	ExpiresAt   time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+}
+
+// TokenPair is returned on login and on refresh
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	// ExpiresIn is the lifetime of the access token in seconds
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// TokenTypeBearer is the type of the access tokens
+const TokenTypeBearer = "Bearer"
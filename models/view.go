@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// View represents a saved list of tasks: a filter in the language of the
+// filter package, a sort and the task fields to show. A view belongs to the
+// user who saved it and is shared with the readers of Project when it is set
+type View struct {
+	ID   int    `json:"id_view"`
+	Name string `json:"name" validate:"required,max=50"`
+	// OwnerID is the id of the user who saved the view, it is taken from the
+	// context and never changes afterwards
+	OwnerID   int       `json:"id_owner"`
+	Project   string    `json:"project" validate:"max=50"`
+	Filter    string    `json:"filter" validate:"max=1000"`
+	Sort      string    `json:"sort" validate:"max=200"`
+	Columns   []string  `json:"columns" validate:"max=20,dive,required,max=30"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Problem explains why a stored view no longer compiles, it is not stored
+	Problem string `json:"problem,omitempty"`
+}
+
+// ViewFilter represents the views of the user OwnerID, those shared with Project included
+type ViewFilter struct {
+	OwnerID int    `json:"-"`
+	Project string `json:"project" validate:"max=50"`
+}
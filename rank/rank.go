@@ -0,0 +1,110 @@
+// Package rank generates the keys used to order tasks by hand. A key is a
+// base 62 fraction written with the digits 0-9A-Za-z, keys compare byte-wise
+// so the column holding them has to use the "C" collation. A key never ends
+// with the digit 0, which always leaves room for a key before it
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+// MaxLength is the length past which the keys of a workspace are rebalanced
+const MaxLength = 24
+
+// ErrOrder is returned when the lower key does not come before the upper one
+var ErrOrder = errors.New("rank: keys out of order")
+
+// ErrInvalid is returned when a key has a digit out of the alphabet or ends with 0
+var ErrInvalid = errors.New("rank: invalid key")
+
+// Between returns the shortest key between lower and upper, an empty lower
+// means the start and an empty upper the end of the order
+func Between(lower, upper string) (string, error) {
+	if !valid(lower) || !valid(upper) {
+		return "", ErrInvalid
+	}
+	if upper != "" && lower >= upper {
+		return "", ErrOrder
+	}
+	return midpoint(lower, upper), nil
+}
+
+// Spread returns n keys evenly spaced over the whole order, all of the same
+// length before their trailing zeros are dropped
+func Spread(n int) []string {
+	keys := make([]string, n)
+	length, space := 1, base
+	for space <= n {
+		length++
+		space *= base
+	}
+	for i := range keys {
+		keys[i] = encode((i+1)*space/(n+1), length)
+	}
+	return keys
+}
+
+func valid(key string) bool {
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(key, "0")
+}
+
+// digit returns the value of the digit of key at i, keys are padded with zeros
+func digit(key string, i int) int {
+	if i >= len(key) {
+		return 0
+	}
+	return strings.IndexByte(digits, key[i])
+}
+
+// tail returns key past its first n digits
+func tail(key string, n int) string {
+	if n >= len(key) {
+		return ""
+	}
+	return key[n:]
+}
+
+func midpoint(lower, upper string) string {
+	if upper != "" {
+		// the common prefix stays, the keys are compared past it
+		n := 0
+		for n < len(upper) && digit(lower, n) == digit(upper, n) {
+			n++
+		}
+		if n > 0 {
+			return upper[:n] + midpoint(tail(lower, n), upper[n:])
+		}
+	}
+	low, high := digit(lower, 0), base
+	if upper != "" {
+		high = digit(upper, 0)
+	}
+	if high-low > 1 {
+		return string(digits[(low+high)/2])
+	}
+	// the first digits are consecutive, the first digit of upper alone is
+	// enough when upper goes on, otherwise the key goes on past lower
+	if len(upper) > 1 {
+		return upper[:1]
+	}
+	return string(digits[low]) + midpoint(tail(lower, 1), "")
+}
+
+func encode(n, length int) string {
+	b := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		b[i] = digits[n%base]
+		n /= base
+	}
+	return strings.TrimRight(string(b), "0")
+}
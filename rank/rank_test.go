@@ -0,0 +1,80 @@
+package rank
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		lower   string
+		upper   string
+		want    string
+		wantErr error
+	}{
+		{name: "Normal Case1: empty order", want: "V"},
+		{name: "after a key", lower: "V", want: "k"},
+		{name: "before a key", upper: "V", want: "F"},
+		{name: "between keys", lower: "F", upper: "V", want: "N"},
+		{name: "consecutive digits", lower: "F", upper: "G", want: "FV"},
+		{name: "common prefix", lower: "FV", upper: "FW", want: "FVV"},
+		{name: "upper goes on", lower: "F", upper: "GV", want: "G"},
+		{name: "before the first digit", upper: "1", want: "0V"},
+		{name: "after the last digit", lower: "z", want: "zV"},
+		{name: "lower is a prefix of upper", lower: "F", upper: "F1", want: "F0V"},
+		{name: "same keys", lower: "F", upper: "F", wantErr: ErrOrder},
+		{name: "reversed keys", lower: "V", upper: "F", wantErr: ErrOrder},
+		{name: "trailing zero", lower: "F0", wantErr: ErrInvalid},
+		{name: "digit out of the alphabet", upper: "F-", wantErr: ErrInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.lower, tt.upper)
+			if err != tt.wantErr {
+				t.Fatalf("Between() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Between() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBetweenRepeated(t *testing.T) {
+	// moving tasks to the top again and again makes the keys grow slowly
+	keys := []string{}
+	upper := ""
+	for i := 0; i < 100; i++ {
+		key, err := Between("", upper)
+		if err != nil {
+			t.Fatalf("Between() error = %v", err)
+		}
+		keys = append(keys, key)
+		upper = key
+	}
+	if !sort.SliceIsSorted(keys, func(i, j int) bool { return keys[i] > keys[j] }) {
+		t.Errorf("keys out of order: %v", keys)
+	}
+	if len(upper) > MaxLength {
+		t.Errorf("key %q is longer than %d", upper, MaxLength)
+	}
+}
+
+func TestSpread(t *testing.T) {
+	if got, want := Spread(3), []string{"F", "V", "k"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Spread() = %v, want %v", got, want)
+	}
+	if got := Spread(0); len(got) != 0 {
+		t.Errorf("Spread() = %v, want none", got)
+	}
+	keys := Spread(5000)
+	previous := ""
+	for i, key := range keys {
+		if len(key) > 3 || !valid(key) || key <= previous {
+			t.Fatalf("Spread() key %d = %q after %q", i, key, previous)
+		}
+		previous = key
+	}
+}
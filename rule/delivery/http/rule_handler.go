@@ -0,0 +1,172 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/rule"
+	"github.com/sirupsen/logrus"
+)
+
+//RuleHandler represents http handler for rules
+type RuleHandler struct {
+	RuleUsecase rule.Usecase
+}
+
+// NewRuleHandler will initialize the rules/ resources endpoint
+func NewRuleHandler(ru rule.Usecase) nethttp.Handler {
+	r := chi.NewMux()
+	ruleHandler := &RuleHandler{
+		RuleUsecase: ru,
+	}
+	r.Get("/", ruleHandler.List)
+	r.Post("/", ruleHandler.Add)
+	r.Post("/run", ruleHandler.Run)
+	r.Get("/{id:[0-9]+}", ruleHandler.Get)
+	r.Put("/{id:[0-9]+}", ruleHandler.Edit)
+	r.Delete("/{id:[0-9]+}", ruleHandler.Delete)
+	r.Get("/{id:[0-9]+}/executions", ruleHandler.Executions)
+	return r
+}
+
+func writeJSON(w nethttp.ResponseWriter, status int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	res, _ := json.Marshal(body)
+	w.Write(res)
+}
+
+// writeError answers the errors shared by the rule endpoints
+func writeError(w nethttp.ResponseWriter, err error) {
+	switch err {
+	case core.ErrForbidden:
+		w.WriteHeader(nethttp.StatusForbidden)
+		w.Write([]byte("forbidden"))
+	case core.ErrRecordNotFound:
+		w.WriteHeader(nethttp.StatusNotFound)
+		w.Write([]byte("not found"))
+	case core.ErrInvalidRule:
+		w.WriteHeader(nethttp.StatusUnprocessableEntity)
+		w.Write([]byte("invalid rule"))
+	default:
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+	}
+}
+
+// writeRule answers the rule, or the error of the call that returned it
+func writeRule(w nethttp.ResponseWriter, status int, r *models.Rule, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, map[string]interface{}{
+		"message": "success",
+		"rule":    r,
+	})
+}
+
+// decode reads and validates a rule from the request body, a rule is enabled unless it says otherwise
+func decode(w nethttp.ResponseWriter, r *nethttp.Request) (*models.Rule, bool) {
+	rl := &models.Rule{Enabled: true}
+	if err := json.NewDecoder(r.Body).Decode(rl); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return nil, false
+	}
+	validate := validator.New()
+	if err := validate.Struct(rl); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return nil, false
+	}
+	return rl, true
+}
+
+func id(r *nethttp.Request) int {
+	id, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	return id
+}
+
+//List handler returns the rules of the workspace ordered by name
+func (h *RuleHandler) List(w nethttp.ResponseWriter, r *nethttp.Request) {
+	rules, err := h.RuleUsecase.List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"rules":   rules,
+	})
+}
+
+//Add rule handler
+func (h *RuleHandler) Add(w nethttp.ResponseWriter, r *nethttp.Request) {
+	rl, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	err := h.RuleUsecase.Add(r.Context(), rl)
+	writeRule(w, nethttp.StatusCreated, rl, err)
+}
+
+//Get rule handler
+func (h *RuleHandler) Get(w nethttp.ResponseWriter, r *nethttp.Request) {
+	rl, err := h.RuleUsecase.Get(r.Context(), id(r))
+	writeRule(w, nethttp.StatusOK, rl, err)
+}
+
+//Edit rule handler
+func (h *RuleHandler) Edit(w nethttp.ResponseWriter, r *nethttp.Request) {
+	rl, ok := decode(w, r)
+	if !ok {
+		return
+	}
+	rl.ID = id(r)
+	err := h.RuleUsecase.Edit(r.Context(), rl)
+	writeRule(w, nethttp.StatusOK, rl, err)
+}
+
+//Delete rule handler, the execution log of the rule goes with it
+func (h *RuleHandler) Delete(w nethttp.ResponseWriter, r *nethttp.Request) {
+	if err := h.RuleUsecase.Delete(r.Context(), id(r)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	w.Write([]byte("success"))
+}
+
+//Executions handler returns the latest entries of the execution log of a rule
+func (h *RuleHandler) Executions(w nethttp.ResponseWriter, r *nethttp.Request) {
+	executions, err := h.RuleUsecase.Executions(r.Context(), id(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message":    "success",
+		"executions": executions,
+	})
+}
+
+//Run handler runs the scheduled rules of the workspace, it is meant to be
+//called by a scheduler such as cron
+func (h *RuleHandler) Run(w nethttp.ResponseWriter, r *nethttp.Request) {
+	executions, err := h.RuleUsecase.Run(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message":    "success",
+		"executions": executions,
+	})
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/rule/mocks"
+)
+
+const notUrgent = `{"name":"Done is not urgent","trigger":"status_changed","condition":"status:done","actions":[{"type":"remove_tag","value":"urgent"}]}`
+
+func TestNewRuleHandler(t *testing.T) {
+	h := NewRuleHandler(&mocks.MockUsecase{})
+	tests := []struct {
+		method     string
+		url        string
+		body       string
+		statusCode int
+	}{
+		{"GET", "/", "", 200},
+		{"POST", "/", notUrgent, 201},
+		{"GET", "/1", "", 200},
+		{"PUT", "/1", notUrgent, 200},
+		{"DELETE", "/1", "", 200},
+		{"GET", "/1/executions", "", 200},
+		{"POST", "/run", "", 200},
+		{"GET", "/abc", "", 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Errorf("got statuscode %d but expected %d", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestRuleHandler_Add(t *testing.T) {
+	tests := []struct {
+		name        string
+		usecase     *mocks.MockUsecase
+		body        string
+		statusCode  int
+		wantEnabled bool
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, body: notUrgent, statusCode: 201, wantEnabled: true},
+		{name: "disabled", usecase: &mocks.MockUsecase{},
+			body: `{"name":"Done","trigger":"status_changed","actions":[{"type":"remove_tag","value":"urgent"}],"enabled":false}`, statusCode: 201},
+		{name: "unknown trigger", usecase: &mocks.MockUsecase{},
+			body: `{"name":"Done","trigger":"task_deleted","actions":[{"type":"remove_tag","value":"urgent"}]}`, statusCode: 400},
+		{name: "no actions", usecase: &mocks.MockUsecase{}, body: `{"name":"Done","trigger":"status_changed","actions":[]}`, statusCode: 400},
+		{name: "unknown action", usecase: &mocks.MockUsecase{},
+			body: `{"name":"Done","trigger":"status_changed","actions":[{"type":"notify","value":"alice"}]}`, statusCode: 400},
+		{name: "body parse error", usecase: &mocks.MockUsecase{}, body: `[]`, statusCode: 400},
+		{name: "invalid rule", usecase: &mocks.MockUsecase{Error: core.ErrInvalidRule}, body: notUrgent, statusCode: 422},
+		{name: "forbidden", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, body: notUrgent, statusCode: 403},
+		{name: "usecase error", usecase: &mocks.MockUsecase{Error: errors.New("Usecase.Error()")}, body: notUrgent, statusCode: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewRuleHandler(tt.usecase).ServeHTTP(rec, httptest.NewRequest("POST", "/", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if tt.statusCode != 201 {
+				return
+			}
+			res := struct {
+				Rule *models.Rule `json:"rule"`
+			}{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil || res.Rule.Enabled != tt.wantEnabled {
+				t.Errorf("Test - %s , got body %s", tt.name, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRuleHandler_Edit(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    *mocks.MockUsecase
+		body       string
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, body: notUrgent, statusCode: 200},
+		{name: "missing name", usecase: &mocks.MockUsecase{}, body: `{"trigger":"status_changed","actions":[{"type":"remove_tag","value":"urgent"}]}`, statusCode: 400},
+		{name: "rule not found", usecase: &mocks.MockUsecase{Error: core.ErrRecordNotFound}, body: notUrgent, statusCode: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewRuleHandler(tt.usecase).ServeHTTP(rec, httptest.NewRequest("PUT", "/1", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestRuleHandler_Executions(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    *mocks.MockUsecase
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{Entries: []*models.RuleExecution{{ID: 1, RuleID: 1, TaskID: 2, Status: models.ExecutionApplied}}}, statusCode: 200},
+		{name: "rule not found", usecase: &mocks.MockUsecase{Error: core.ErrRecordNotFound}, statusCode: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewRuleHandler(tt.usecase).ServeHTTP(rec, httptest.NewRequest("GET", "/1/executions", nil))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestRuleHandler_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    *mocks.MockUsecase
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{Entries: []*models.RuleExecution{{ID: 1, RuleID: 1, TaskID: 2, Status: models.ExecutionApplied}}}, statusCode: 200},
+		{name: "forbidden", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, statusCode: 403},
+		{name: "usecase error", usecase: &mocks.MockUsecase{Error: errors.New("Usecase.Error()")}, statusCode: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewRuleHandler(tt.usecase).ServeHTTP(rec, httptest.NewRequest("POST", "/run", nil))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockRepository implements inerface rule.Repository
+type MockRepository struct {
+	Error error
+	Rules []*models.Rule
+	// Entries is the execution log, oldest first
+	Entries []*models.RuleExecution
+}
+
+func (m *MockRepository) find(id int) *models.Rule {
+	for _, r := range m.Rules {
+		if r.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+//Add appends a copy of the rule to Rules
+func (m *MockRepository) Add(ctx context.Context, r *models.Rule) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	r.ID = len(m.Rules) + 1
+	r.CreatedAt = time.Now()
+	r.UpdatedAt = r.CreatedAt
+	stored := *r
+	m.Rules = append(m.Rules, &stored)
+	return nil
+}
+
+//Get returns a copy of the rule of Rules with the id
+func (m *MockRepository) Get(ctx context.Context, id int) (*models.Rule, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if r := m.find(id); r != nil {
+		found := *r
+		return &found, nil
+	}
+	return nil, core.ErrRecordNotFound
+}
+
+//List returns Rules
+func (m *MockRepository) List(ctx context.Context) ([]*models.Rule, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	return m.Rules, nil
+}
+
+//Edit replaces the rule
+func (m *MockRepository) Edit(ctx context.Context, r *models.Rule) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	old := m.find(r.ID)
+	if old == nil {
+		return core.ErrRecordNotFound
+	}
+	*old = *r
+	old.UpdatedAt = time.Now()
+	return nil
+}
+
+//Delete removes the rule from Rules
+func (m *MockRepository) Delete(ctx context.Context, id int) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for i, r := range m.Rules {
+		if r.ID == id {
+			m.Rules = append(m.Rules[:i], m.Rules[i+1:]...)
+			return nil
+		}
+	}
+	return core.ErrRecordNotFound
+}
+
+//Enabled returns the enabled rules of Rules on one of the triggers
+func (m *MockRepository) Enabled(ctx context.Context, triggers ...string) ([]*models.Rule, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	rules := []*models.Rule{}
+	for _, r := range m.Rules {
+		for _, trigger := range triggers {
+			if r.Enabled && r.Trigger == trigger {
+				rules = append(rules, r)
+			}
+		}
+	}
+	return rules, nil
+}
+
+//Log appends the executions to Entries
+func (m *MockRepository) Log(ctx context.Context, executions ...*models.RuleExecution) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for _, e := range executions {
+		e.ID = len(m.Entries) + 1
+		m.Entries = append(m.Entries, e)
+	}
+	return nil
+}
+
+//Executions returns up to limit entries of the rule, latest first
+func (m *MockRepository) Executions(ctx context.Context, id int, limit int) ([]*models.RuleExecution, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	executions := []*models.RuleExecution{}
+	for i := len(m.Entries) - 1; i >= 0 && len(executions) < limit; i-- {
+		if m.Entries[i].RuleID == id {
+			executions = append(executions, m.Entries[i])
+		}
+	}
+	return executions, nil
+}
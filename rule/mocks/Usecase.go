@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockUsecase implements inerface rule.Usecase
+type MockUsecase struct {
+	Error   error
+	Rules   []*models.Rule
+	Entries []*models.RuleExecution
+}
+
+//Add rule
+func (m *MockUsecase) Add(ctx context.Context, r *models.Rule) error {
+	return m.Error
+}
+
+//Get returns the first rule of Rules
+func (m *MockUsecase) Get(ctx context.Context, id int) (*models.Rule, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if len(m.Rules) == 0 {
+		return &models.Rule{}, nil
+	}
+	return m.Rules[0], nil
+}
+
+//List returns Rules
+func (m *MockUsecase) List(ctx context.Context) ([]*models.Rule, error) {
+	return m.Rules, m.Error
+}
+
+//Edit rule
+func (m *MockUsecase) Edit(ctx context.Context, r *models.Rule) error {
+	return m.Error
+}
+
+//Delete rule
+func (m *MockUsecase) Delete(ctx context.Context, id int) error {
+	return m.Error
+}
+
+//Executions returns Entries
+func (m *MockUsecase) Executions(ctx context.Context, id int) ([]*models.RuleExecution, error) {
+	return m.Entries, m.Error
+}
+
+//Run returns Entries
+func (m *MockUsecase) Run(ctx context.Context) ([]*models.RuleExecution, error) {
+	return m.Entries, m.Error
+}
@@ -0,0 +1,23 @@
+package rule
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Repository represents rule's interface
+type Repository interface {
+	Add(context.Context, *models.Rule) error
+	Get(context.Context, int) (*models.Rule, error)
+	// List returns the rules of the workspace ordered by name
+	List(context.Context) ([]*models.Rule, error)
+	Edit(context.Context, *models.Rule) error
+	Delete(context.Context, int) error
+	// Enabled returns the enabled rules on one of the triggers in the order they were added
+	Enabled(ctx context.Context, triggers ...string) ([]*models.Rule, error)
+	// Log appends entries to the execution log
+	Log(context.Context, ...*models.RuleExecution) error
+	// Executions returns up to limit entries of the execution log of a rule, latest first
+	Executions(ctx context.Context, id int, limit int) ([]*models.RuleExecution, error)
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/lib/pq"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/rule"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+const ruleColumns = "id_rule, name, trigger, condition, actions, enabled, created_at, updated_at"
+
+type postgresRuleRepository struct {
+	*sql.DB
+}
+
+// NewPostgresRuleRepository will create an object that represent the rule.Repository interface
+func NewPostgresRuleRepository(db *sql.DB) rule.Repository {
+	return &postgresRuleRepository{db}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRule(s scanner) (*models.Rule, error) {
+	r := &models.Rule{}
+	var actions []byte
+	if err := s.Scan(&r.ID, &r.Name, &r.Trigger, &r.Condition, &actions, &r.Enabled, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return r, json.Unmarshal(actions, &r.Actions)
+}
+
+func (p *postgresRuleRepository) list(ctx context.Context, query string, args ...interface{}) ([]*models.Rule, error) {
+	rules := make([]*models.Rule, 0)
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, query, args...)
+	if err != nil {
+		return rules, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		r, err := scanRule(rows)
+		if err != nil {
+			return []*models.Rule{}, err
+		}
+		rules = append(rules, r)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.Rule{}, err
+	}
+	return rules, nil
+}
+
+func (p *postgresRuleRepository) Add(ctx context.Context, r *models.Rule) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	actions, err := json.Marshal(r.Actions)
+	if err != nil {
+		return err
+	}
+	return transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "INSERT INTO task_rule(name, trigger, condition, actions, enabled, id_workspace) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id_rule, created_at, updated_at",
+		r.Name, r.Trigger, r.Condition, actions, r.Enabled, workspace).Scan(&r.ID, &r.CreatedAt, &r.UpdatedAt)
+}
+
+func (p *postgresRuleRepository) Get(ctx context.Context, id int) (*models.Rule, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "SELECT "+ruleColumns+" FROM task_rule WHERE id_rule = $1 AND id_workspace = $2", id, workspace)
+	r, err := scanRule(row)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	return r, err
+}
+
+func (p *postgresRuleRepository) List(ctx context.Context) ([]*models.Rule, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return make([]*models.Rule, 0), err
+	}
+	return p.list(ctx, "SELECT "+ruleColumns+" FROM task_rule WHERE id_workspace = $1 ORDER BY name, id_rule", workspace)
+}
+
+func (p *postgresRuleRepository) Edit(ctx context.Context, r *models.Rule) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	actions, err := json.Marshal(r.Actions)
+	if err != nil {
+		return err
+	}
+	err = transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "UPDATE task_rule SET name = $1, trigger = $2, condition = $3, actions = $4, enabled = $5, updated_at = now() WHERE id_rule = $6 AND id_workspace = $7 RETURNING created_at, updated_at",
+		r.Name, r.Trigger, r.Condition, actions, r.Enabled, r.ID, workspace).Scan(&r.CreatedAt, &r.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresRuleRepository) Delete(ctx context.Context, id int) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "DELETE FROM task_rule WHERE id_rule = $1 AND id_workspace = $2", id, workspace)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresRuleRepository) Enabled(ctx context.Context, triggers ...string) ([]*models.Rule, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return make([]*models.Rule, 0), err
+	}
+	return p.list(ctx, "SELECT "+ruleColumns+" FROM task_rule WHERE id_workspace = $1 AND trigger = ANY($2) AND enabled ORDER BY id_rule",
+		workspace, pq.Array(triggers))
+}
+
+func (p *postgresRuleRepository) Log(ctx context.Context, executions ...*models.RuleExecution) error {
+	if len(executions) == 0 {
+		return nil
+	}
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	rules := make([]int64, len(executions))
+	tasks := make([]int64, len(executions))
+	triggers := make([]string, len(executions))
+	statuses := make([]string, len(executions))
+	errs := make([]string, len(executions))
+	for i, e := range executions {
+		rules[i] = int64(e.RuleID)
+		tasks[i] = int64(e.TaskID)
+		triggers[i] = e.Trigger
+		statuses[i] = e.Status
+		errs[i] = e.Error
+	}
+	// the join drops the entries of rules out of the workspace
+	_, err = transaction.Conn(ctx, p.DB).ExecContext(ctx, "INSERT INTO task_rule_execution(id_rule, id_workspace, id_task, trigger, status, error) SELECT v.id_rule, r.id_workspace, v.id_task, v.trigger, v.status, v.error FROM unnest($1::int[], $2::int[], $3::varchar[], $4::varchar[], $5::text[]) AS v(id_rule, id_task, trigger, status, error) JOIN task_rule r ON r.id_rule = v.id_rule WHERE r.id_workspace = $6",
+		pq.Array(rules), pq.Array(tasks), pq.Array(triggers), pq.Array(statuses), pq.Array(errs), workspace)
+	return err
+}
+
+func (p *postgresRuleRepository) Executions(ctx context.Context, id int, limit int) ([]*models.RuleExecution, error) {
+	executions := make([]*models.RuleExecution, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return executions, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT id_execution, id_rule, id_task, trigger, status, error, created_at FROM task_rule_execution WHERE id_rule = $1 AND id_workspace = $2 ORDER BY created_at DESC, id_execution DESC LIMIT $3",
+		id, workspace, limit)
+	if err != nil {
+		return executions, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		e := &models.RuleExecution{}
+		if err := rows.Scan(&e.ID, &e.RuleID, &e.TaskID, &e.Trigger, &e.Status, &e.Error, &e.CreatedAt); err != nil {
+			return []*models.RuleExecution{}, err
+		}
+		executions = append(executions, e)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.RuleExecution{}, err
+	}
+	return executions, nil
+}
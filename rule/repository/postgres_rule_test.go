@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+var ruleRowColumns = []string{"id_rule", "name", "trigger", "condition", "actions", "enabled", "created_at", "updated_at"}
+
+var (
+	notUrgent     = []*models.RuleAction{{Type: models.ActionRemoveTag, Value: "urgent"}}
+	notUrgentJSON = []byte(`[{"type":"remove_tag","value":"urgent"}]`)
+)
+
+func Test_postgresRuleRepository_Add(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO task_rule(name, trigger, condition, actions, enabled, id_workspace) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id_rule, created_at, updated_at")).
+		WithArgs("Done is not urgent", models.TriggerStatusChanged, "status:done", notUrgentJSON, true, coremocks.Tenant).
+		WillReturnRows(sqlmock.NewRows([]string{"id_rule", "created_at", "updated_at"}).AddRow(3, time.Time{}, time.Time{}))
+	r := &models.Rule{Name: "Done is not urgent", Trigger: models.TriggerStatusChanged, Condition: "status:done", Actions: notUrgent, Enabled: true}
+	if err := NewPostgresRuleRepository(db).Add(coremocks.TenantContext(), r); err != nil || r.ID != 3 {
+		t.Errorf("Add() = rule %d, %v, want rule 3", r.ID, err)
+	}
+}
+
+func Test_postgresRuleRepository_Get(t *testing.T) {
+	query := "SELECT id_rule, name, trigger, condition, actions, enabled, created_at, updated_at FROM task_rule WHERE id_rule = $1 AND id_workspace = $2"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *models.Rule
+		wantErr error
+	}{{
+		name: "Normal Case 1: rule found",
+		rows: sqlmock.NewRows(ruleRowColumns).AddRow(3, "Done is not urgent", models.TriggerStatusChanged, "status:done", notUrgentJSON, true, time.Time{}, time.Time{}),
+		want: &models.Rule{ID: 3, Name: "Done is not urgent", Trigger: models.TriggerStatusChanged, Condition: "status:done", Actions: notUrgent, Enabled: true},
+	}, {
+		name:    "rule not found",
+		rows:    sqlmock.NewRows(ruleRowColumns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(3, coremocks.Tenant).WillReturnRows(tt.rows)
+			got, err := NewPostgresRuleRepository(db).Get(coremocks.TenantContext(), 3)
+			if err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresRuleRepository_List(t *testing.T) {
+	query := "SELECT id_rule, name, trigger, condition, actions, enabled, created_at, updated_at FROM task_rule WHERE id_workspace = $1 ORDER BY name, id_rule"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbError error
+		want    []*models.Rule
+		wantErr bool
+	}{{
+		name: "Normal Case 1: ordered by name",
+		rows: sqlmock.NewRows(ruleRowColumns).
+			AddRow(3, "Done is not urgent", models.TriggerStatusChanged, "status:done", notUrgentJSON, true, time.Time{}, time.Time{}).
+			AddRow(4, "Overdue", models.TriggerScheduled, "due<today", []byte(`[{"type":"set_priority","value":"1"}]`), false, time.Time{}, time.Time{}),
+		want: []*models.Rule{
+			{ID: 3, Name: "Done is not urgent", Trigger: models.TriggerStatusChanged, Condition: "status:done", Actions: notUrgent, Enabled: true},
+			{ID: 4, Name: "Overdue", Trigger: models.TriggerScheduled, Condition: "due<today", Actions: []*models.RuleAction{{Type: models.ActionSetPriority, Value: "1"}}},
+		},
+	}, {
+		name:    "malformed actions",
+		rows:    sqlmock.NewRows(ruleRowColumns).AddRow(3, "Done is not urgent", models.TriggerStatusChanged, "", []byte(`{`), true, time.Time{}, time.Time{}),
+		want:    []*models.Rule{},
+		wantErr: true,
+	}, {
+		name:    "db error",
+		rows:    sqlmock.NewRows(ruleRowColumns),
+		dbError: errors.New("db error"),
+		want:    []*models.Rule{},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant).WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			got, err := NewPostgresRuleRepository(db).List(coremocks.TenantContext())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresRuleRepository_Edit(t *testing.T) {
+	query := "UPDATE task_rule SET name = $1, trigger = $2, condition = $3, actions = $4, enabled = $5, updated_at = now() WHERE id_rule = $6 AND id_workspace = $7 RETURNING created_at, updated_at"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr error
+	}{
+		{name: "Normal Case 1: rule edited", rows: sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Time{}, time.Time{})},
+		{name: "rule not found", rows: sqlmock.NewRows([]string{"created_at", "updated_at"}), wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("Done is not urgent", models.TriggerStatusChanged, "status:done", notUrgentJSON, false, 3, coremocks.Tenant).WillReturnRows(tt.rows)
+			r := &models.Rule{ID: 3, Name: "Done is not urgent", Trigger: models.TriggerStatusChanged, Condition: "status:done", Actions: notUrgent}
+			if err := NewPostgresRuleRepository(db).Edit(coremocks.TenantContext(), r); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresRuleRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "Normal Case 1: rule deleted", affected: 1},
+		{name: "rule not found", affected: 0, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta("DELETE FROM task_rule WHERE id_rule = $1 AND id_workspace = $2")).WithArgs(3, coremocks.Tenant).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if err := NewPostgresRuleRepository(db).Delete(coremocks.TenantContext(), 3); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresRuleRepository_Enabled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id_rule, name, trigger, condition, actions, enabled, created_at, updated_at FROM task_rule WHERE id_workspace = $1 AND trigger = ANY($2) AND enabled ORDER BY id_rule")).
+		WithArgs(coremocks.Tenant, pq.Array([]string{models.TriggerUpdated, models.TriggerStatusChanged})).
+		WillReturnRows(sqlmock.NewRows(ruleRowColumns).AddRow(3, "Done is not urgent", models.TriggerStatusChanged, "status:done", notUrgentJSON, true, time.Time{}, time.Time{}))
+	got, err := NewPostgresRuleRepository(db).Enabled(coremocks.TenantContext(), models.TriggerUpdated, models.TriggerStatusChanged)
+	want := []*models.Rule{{ID: 3, Name: "Done is not urgent", Trigger: models.TriggerStatusChanged, Condition: "status:done", Actions: notUrgent, Enabled: true}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Enabled() = %v, %v, want %v", got, err, want)
+	}
+}
+
+func Test_postgresRuleRepository_Log(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO task_rule_execution(id_rule, id_workspace, id_task, trigger, status, error) SELECT v.id_rule, r.id_workspace, v.id_task, v.trigger, v.status, v.error FROM unnest($1::int[], $2::int[], $3::varchar[], $4::varchar[], $5::text[]) AS v(id_rule, id_task, trigger, status, error) JOIN task_rule r ON r.id_rule = v.id_rule WHERE r.id_workspace = $6")).
+		WithArgs(pq.Array([]int64{3, 4}), pq.Array([]int64{1, 1}), pq.Array([]string{models.TriggerStatusChanged, models.TriggerUpdated}),
+			pq.Array([]string{models.ExecutionApplied, models.ExecutionFailed}), pq.Array([]string{"", "wip limit reached"}), coremocks.Tenant).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	p := NewPostgresRuleRepository(db)
+	err = p.Log(coremocks.TenantContext(),
+		&models.RuleExecution{RuleID: 3, TaskID: 1, Trigger: models.TriggerStatusChanged, Status: models.ExecutionApplied},
+		&models.RuleExecution{RuleID: 4, TaskID: 1, Trigger: models.TriggerUpdated, Status: models.ExecutionFailed, Error: "wip limit reached"})
+	if err != nil {
+		t.Errorf("Log() error = %v", err)
+	}
+	// nothing to log, nothing sent
+	if err := p.Log(coremocks.TenantContext()); err != nil {
+		t.Errorf("Log() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_postgresRuleRepository_Executions(t *testing.T) {
+	query := "SELECT id_execution, id_rule, id_task, trigger, status, error, created_at FROM task_rule_execution WHERE id_rule = $1 AND id_workspace = $2 ORDER BY created_at DESC, id_execution DESC LIMIT $3"
+	columns := []string{"id_execution", "id_rule", "id_task", "trigger", "status", "error", "created_at"}
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbError error
+		want    []*models.RuleExecution
+		wantErr bool
+	}{{
+		name: "Normal Case 1: latest first",
+		rows: sqlmock.NewRows(columns).
+			AddRow(9, 3, 1, models.TriggerStatusChanged, models.ExecutionSkipped, "the rule already changed the task", time.Time{}).
+			AddRow(8, 3, 1, models.TriggerStatusChanged, models.ExecutionApplied, "", time.Time{}),
+		want: []*models.RuleExecution{
+			{ID: 9, RuleID: 3, TaskID: 1, Trigger: models.TriggerStatusChanged, Status: models.ExecutionSkipped, Error: "the rule already changed the task"},
+			{ID: 8, RuleID: 3, TaskID: 1, Trigger: models.TriggerStatusChanged, Status: models.ExecutionApplied},
+		},
+	}, {
+		name:    "db error",
+		rows:    sqlmock.NewRows(columns),
+		dbError: errors.New("db error"),
+		want:    []*models.RuleExecution{},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(3, coremocks.Tenant, 100).WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			got, err := NewPostgresRuleRepository(db).Executions(coremocks.TenantContext(), 3, 100)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresRuleRepository_withoutTenant(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	ctx := context.Background()
+	p := NewPostgresRuleRepository(db)
+	r := &models.Rule{ID: 1, Name: "Done is not urgent", Trigger: models.TriggerStatusChanged, Actions: notUrgent}
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Add", func() error { return p.Add(ctx, r) }},
+		{"Get", func() error { _, err := p.Get(ctx, 1); return err }},
+		{"List", func() error { _, err := p.List(ctx); return err }},
+		{"Edit", func() error { return p.Edit(ctx, r) }},
+		{"Delete", func() error { return p.Delete(ctx, 1) }},
+		{"Enabled", func() error { _, err := p.Enabled(ctx, models.TriggerCreated); return err }},
+		{"Log", func() error { return p.Log(ctx, &models.RuleExecution{RuleID: 1, TaskID: 1}) }},
+		{"Executions", func() error { _, err := p.Executions(ctx, 1, 100); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != core.ErrNoTenant {
+				t.Errorf("expected %v, got %v", core.ErrNoTenant, err)
+			}
+		})
+	}
+}
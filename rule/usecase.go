@@ -0,0 +1,24 @@
+package rule
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Usecase represents rule's usecases
+type Usecase interface {
+	// Add stores the rule, core.ErrInvalidRule is returned when its condition
+	// does not compile or the value of one of its actions is invalid
+	Add(context.Context, *models.Rule) error
+	Get(context.Context, int) (*models.Rule, error)
+	List(context.Context) ([]*models.Rule, error)
+	// Edit replaces the rule, see Add
+	Edit(context.Context, *models.Rule) error
+	Delete(context.Context, int) error
+	// Executions returns the latest entries of the execution log of a rule
+	Executions(ctx context.Context, id int) ([]*models.RuleExecution, error)
+	// Run runs the scheduled rules on the tasks matching their condition and
+	// returns the executions recorded
+	Run(context.Context) ([]*models.RuleExecution, error)
+}
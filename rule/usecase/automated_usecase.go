@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/rule"
+	"github.com/pratheeshm/todo-golang/task"
+)
+
+type automatedUsecase struct {
+	task.Usecase
+	ruleRepo rule.Repository
+	engine   *engine
+}
+
+// NewAutomatedUsecase will create a task.Usecase running the rules of the workspace
+// once tu added or edited a task, the actions of the rules go through the usecase
+// created so that they trigger rules in turn. Bulk requests, imports, sync pushes
+// and checklist changes do not trigger rules
+func NewAutomatedUsecase(tu task.Usecase, rr rule.Repository) task.Usecase {
+	a := &automatedUsecase{
+		Usecase:  tu,
+		ruleRepo: rr,
+	}
+	a.engine = &engine{ruleRepo: rr, tasks: a}
+	return a
+}
+
+func (a *automatedUsecase) Add(ctx context.Context, t *models.Task) error {
+	rules, err := a.ruleRepo.Enabled(ctx, models.TriggerCreated)
+	if err != nil {
+		return err
+	}
+	if err := a.Usecase.Add(ctx, t); err != nil {
+		return err
+	}
+	if len(rules) > 0 {
+		a.engine.fire(ctx, rules, t.ID, models.TriggerCreated)
+	}
+	return nil
+}
+
+func (a *automatedUsecase) Edit(ctx context.Context, t *models.Task) error {
+	return a.edit(ctx, t, a.Usecase.Edit)
+}
+
+func (a *automatedUsecase) EditVersion(ctx context.Context, t *models.Task, version int64) error {
+	return a.edit(ctx, t, func(ctx context.Context, t *models.Task) error {
+		return a.Usecase.EditVersion(ctx, t, version)
+	})
+}
+
+// edit saves t with save and runs the rules triggered by the change
+func (a *automatedUsecase) edit(ctx context.Context, t *models.Task, save func(context.Context, *models.Task) error) error {
+	rules, err := a.ruleRepo.Enabled(ctx, models.TriggerUpdated, models.TriggerStatusChanged)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return save(ctx, t)
+	}
+	old, err := a.Usecase.Get(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	status := old.Status
+	if err := save(ctx, t); err != nil {
+		return err
+	}
+	triggers := []string{models.TriggerUpdated}
+	if status != t.Status {
+		triggers = append(triggers, models.TriggerStatusChanged)
+	}
+	a.engine.fire(ctx, rules, t.ID, triggers...)
+	return nil
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	accessmocks "github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/rule/mocks"
+	"github.com/pratheeshm/todo-golang/task"
+	taskmocks "github.com/pratheeshm/todo-golang/task/mocks"
+	taskusecase "github.com/pratheeshm/todo-golang/task/usecase"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+// fixture runs the rules over a task usecase limiting the tasks in progress to one
+type fixture struct {
+	rules    *mocks.MockRepository
+	tasks    *taskmocks.MockRepository
+	access   *accessmocks.MockUsecase
+	automate task.Usecase
+}
+
+func newFixture(role string, rules []*models.Rule, tasks ...*models.Task) *fixture {
+	f := &fixture{
+		rules:  &mocks.MockRepository{Rules: rules},
+		tasks:  &taskmocks.MockRepository{Tasks: tasks},
+		access: accessmocks.RoleUsecase(role),
+	}
+	tu := taskusecase.NewTaskUsecase(f.tasks, &taskmocks.MockEventRepository{}, &taskmocks.MockSearchRepository{}, &taskmocks.MockChecklistRepository{},
+		transaction.NewMemoryTransactor(), taskusecase.WithWIPLimits(map[string]int{"inprogress": 1}, models.WIPReject))
+	f.automate = NewAutomatedUsecase(taskusecase.NewAuthorizedUsecase(tu, f.tasks, f.access), f.rules)
+	return f
+}
+
+// statuses returns the rule and the status of the entries of the execution log
+func statuses(entries []*models.RuleExecution) [][2]interface{} {
+	got := [][2]interface{}{}
+	for _, e := range entries {
+		got = append(got, [2]interface{}{e.RuleID, e.Status})
+	}
+	return got
+}
+
+var notUrgent = &models.Rule{ID: 1, Name: "Done is not urgent", Trigger: models.TriggerStatusChanged, Condition: "status:done",
+	Actions: []*models.RuleAction{{Type: models.ActionRemoveTag, Value: "urgent"}}, Enabled: true}
+
+func Test_automatedUsecase_Edit(t *testing.T) {
+	f := newFixture(models.RoleMember, []*models.Rule{notUrgent},
+		&models.Task{ID: 1, Title: "Take math notes", Status: "todo", Tags: []string{"urgent", "math"}})
+	ctx := accessmocks.GrantedContext()
+	if err := f.automate.Edit(ctx, &models.Task{ID: 1, Title: "Take math notes", Status: "done", Tags: []string{"urgent", "math"}}); err != nil {
+		t.Fatalf("automatedUsecase.Edit() error = %v", err)
+	}
+	if got, want := f.tasks.Tasks[0].Tags, []string{"math"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tags = %v, want %v", got, want)
+	}
+	want := []*models.RuleExecution{{ID: 1, RuleID: 1, TaskID: 1, Trigger: models.TriggerStatusChanged, Status: models.ExecutionApplied}}
+	if !reflect.DeepEqual(f.rules.Entries, want) {
+		t.Errorf("execution log = %v, want %v", f.rules.Entries, want)
+	}
+	// the status stays the same, the rule does not run again
+	if err := f.automate.Edit(ctx, &models.Task{ID: 1, Title: "Take notes", Status: "done", Tags: []string{"urgent"}}); err != nil {
+		t.Fatalf("automatedUsecase.Edit() error = %v", err)
+	}
+	if got, want := f.tasks.Tasks[0].Tags, []string{"urgent"}; !reflect.DeepEqual(got, want) || len(f.rules.Entries) != 1 {
+		t.Errorf("tags = %v with %d executions, want %v with 1", got, len(f.rules.Entries), want)
+	}
+}
+
+func Test_engine_run_conflict(t *testing.T) {
+	f := newFixture(models.RoleMember, []*models.Rule{notUrgent},
+		&models.Task{ID: 1, Title: "Take notes", Status: "done", Tags: []string{"urgent", "math"}, Version: 5})
+	// the task was read before someone renamed it
+	stale := &models.Task{ID: 1, Title: "Take math notes", Status: "done", Tags: []string{"urgent", "math"}, Version: 4}
+	got := f.automate.(*automatedUsecase).engine.run(accessmocks.GrantedContext(), []*models.Rule{notUrgent}, models.TriggerStatusChanged, stale)
+	want := []*models.RuleExecution{{RuleID: 1, TaskID: 1, Trigger: models.TriggerStatusChanged,
+		Status: models.ExecutionFailed, Error: core.ErrConflict.Error()}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("executions = %v, want %v", got, want)
+	}
+	if task := f.tasks.Tasks[0]; task.Title != "Take notes" || len(task.Tags) != 2 {
+		t.Errorf("task = %+v, want it left as it is", task)
+	}
+}
+
+func Test_automatedUsecase_Edit_disabled(t *testing.T) {
+	disabled := *notUrgent
+	disabled.Enabled = false
+	f := newFixture(models.RoleMember, []*models.Rule{&disabled},
+		&models.Task{ID: 1, Title: "Take math notes", Status: "todo", Tags: []string{"urgent"}})
+	if err := f.automate.Edit(accessmocks.GrantedContext(), &models.Task{ID: 1, Title: "Take math notes", Status: "done", Tags: []string{"urgent"}}); err != nil {
+		t.Fatalf("automatedUsecase.Edit() error = %v", err)
+	}
+	if got := f.tasks.Tasks[0].Tags; len(got) != 1 || len(f.rules.Entries) != 0 {
+		t.Errorf("tags = %v with %d executions, want the rule not to run", got, len(f.rules.Entries))
+	}
+}
+
+func Test_automatedUsecase_Edit_loop(t *testing.T) {
+	// rule 1 takes the tag off, rule 2 puts it back
+	rules := []*models.Rule{
+		{ID: 1, Trigger: models.TriggerUpdated, Condition: "tag:review", Enabled: true,
+			Actions: []*models.RuleAction{{Type: models.ActionRemoveTag, Value: "review"}}},
+		{ID: 2, Trigger: models.TriggerUpdated, Condition: "NOT tag:review", Enabled: true,
+			Actions: []*models.RuleAction{{Type: models.ActionAddTag, Value: "review"}}},
+	}
+	f := newFixture(models.RoleMember, rules, &models.Task{ID: 1, Title: "Take math notes", Status: "todo"})
+	if err := f.automate.Edit(accessmocks.GrantedContext(), &models.Task{ID: 1, Title: "Take notes", Status: "todo", Tags: []string{"review"}}); err != nil {
+		t.Fatalf("automatedUsecase.Edit() error = %v", err)
+	}
+	want := [][2]interface{}{{1, models.ExecutionSkipped}, {2, models.ExecutionApplied}, {1, models.ExecutionApplied}}
+	if got := statuses(f.rules.Entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("execution log = %v, want %v", got, want)
+	}
+	if got, want := f.tasks.Tasks[0].Tags, []string{"review"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tags = %v, want %v", got, want)
+	}
+}
+
+func Test_automatedUsecase_Edit_depth(t *testing.T) {
+	// every rule raises the priority by one, each triggered by the one before. The
+	// rules stopped deeper than MaxDepth run once the chain comes back up
+	rules := []*models.Rule{}
+	for i := 1; i <= 8; i++ {
+		rules = append(rules, &models.Rule{ID: i, Trigger: models.TriggerUpdated, Condition: "priority:" + strconv.Itoa(i), Enabled: true,
+			Actions: []*models.RuleAction{{Type: models.ActionSetPriority, Value: strconv.Itoa(i + 1)}}})
+	}
+	f := newFixture(models.RoleMember, rules, &models.Task{ID: 1, Title: "Take math notes", Status: "todo"})
+	if err := f.automate.Edit(accessmocks.GrantedContext(), &models.Task{ID: 1, Title: "Take math notes", Status: "todo", Priority: 1}); err != nil {
+		t.Fatalf("automatedUsecase.Edit() error = %v", err)
+	}
+	if got := f.tasks.Tasks[0].Priority; got != 9 {
+		t.Errorf("priority = %d, want 9", got)
+	}
+	applied, skipped := 0, 0
+	for _, e := range f.rules.Entries {
+		switch {
+		case e.Status == models.ExecutionApplied:
+			applied++
+		case e.Status == models.ExecutionSkipped && e.Error == "too many rules triggered in a row":
+			skipped++
+		}
+	}
+	if applied != 8 || skipped == 0 || applied+skipped != len(f.rules.Entries) {
+		t.Errorf("execution log = %v, want every rule applied once and the deepest skipped", statuses(f.rules.Entries))
+	}
+}
+
+func Test_automatedUsecase_Add(t *testing.T) {
+	start := &models.Rule{ID: 1, Trigger: models.TriggerCreated, Condition: "tag:start", Enabled: true,
+		Actions: []*models.RuleAction{{Type: models.ActionSetStatus, Value: "inprogress"}}}
+	tests := []struct {
+		name       string
+		role       string
+		tasks      []*models.Task
+		task       *models.Task
+		wantErr    error
+		wantStatus string
+		wantLog    [][2]interface{}
+	}{
+		{name: "Normal Case 1: rule applied", role: models.RoleMember,
+			task:       &models.Task{Title: "Take math notes", Status: "todo", Tags: []string{"start"}},
+			wantStatus: "inprogress", wantLog: [][2]interface{}{{1, models.ExecutionApplied}}},
+		{name: "condition not met", role: models.RoleMember,
+			task:       &models.Task{Title: "Take math notes", Status: "todo"},
+			wantStatus: "todo", wantLog: [][2]interface{}{}},
+		{name: "action failed, the task stays", role: models.RoleMember,
+			tasks:      []*models.Task{{ID: 1, Title: "Read chapter 3", Status: "inprogress"}},
+			task:       &models.Task{Title: "Take math notes", Status: "todo", Tags: []string{"start"}},
+			wantStatus: "todo", wantLog: [][2]interface{}{{1, models.ExecutionFailed}}},
+		{name: "viewer can not add", role: models.RoleViewer,
+			task: &models.Task{Title: "Take math notes", Status: "todo", Tags: []string{"start"}}, wantErr: core.ErrForbidden, wantLog: [][2]interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.role, []*models.Rule{start}, tt.tasks...)
+			if err := f.automate.Add(accessmocks.GrantedContext(), tt.task); err != tt.wantErr {
+				t.Fatalf("automatedUsecase.Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := statuses(f.rules.Entries); !reflect.DeepEqual(got, tt.wantLog) {
+				t.Errorf("execution log = %v, want %v", got, tt.wantLog)
+			}
+			if tt.wantErr == nil && f.tasks.Tasks[len(f.tasks.Tasks)-1].Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", f.tasks.Tasks[len(f.tasks.Tasks)-1].Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_automatedUsecase_rulesError(t *testing.T) {
+	f := newFixture(models.RoleMember, nil, &models.Task{ID: 1, Title: "Take math notes", Status: "todo"})
+	f.rules.Error = core.ErrNoTenant
+	if err := f.automate.Edit(accessmocks.GrantedContext(), &models.Task{ID: 1, Title: "Take notes", Status: "todo"}); err != core.ErrNoTenant {
+		t.Errorf("automatedUsecase.Edit() error = %v, want %v", err, core.ErrNoTenant)
+	}
+	if f.tasks.Tasks[0].Title != "Take math notes" {
+		t.Errorf("the task was edited without its rules")
+	}
+}
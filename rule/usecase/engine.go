@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/filter"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/rule"
+	"github.com/pratheeshm/todo-golang/task"
+)
+
+// MaxDepth is how deep the changes made by rules trigger other rules, a rule
+// triggered by the change of another one being one step deeper
+const MaxDepth = 5
+
+type chainKey struct{}
+
+// chain follows the rules run because of one change made by a user. A rule
+// changes a task once at most in a chain, which stops two rules undoing each other
+type chain struct {
+	depth int
+	fired map[[2]int]bool
+}
+
+// chainFromContext returns the chain of ctx, a new one when the change comes from a user
+func chainFromContext(ctx context.Context) *chain {
+	if c, ok := ctx.Value(chainKey{}).(*chain); ok {
+		return c
+	}
+	return &chain{fired: map[[2]int]bool{}}
+}
+
+// engine runs the rules of the workspace, the actions change the tasks through
+// tasks so that their changes trigger rules in turn
+type engine struct {
+	ruleRepo rule.Repository
+	tasks    task.Usecase
+}
+
+// checkRule returns core.ErrInvalidRule when the condition of r does not compile
+// or the value of one of its actions is invalid
+func checkRule(r *models.Rule) error {
+	if _, err := filter.Parse(r.Condition); err != nil {
+		return core.ErrInvalidRule
+	}
+	t := &models.Task{}
+	for _, a := range r.Actions {
+		if err := act(t, a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// act makes the change of the action a to t
+func act(t *models.Task, a *models.RuleAction) error {
+	switch a.Type {
+	case models.ActionSetStatus:
+		for _, s := range models.BoardStatuses {
+			if a.Value == s {
+				t.Status = s
+				return nil
+			}
+		}
+	case models.ActionSetPriority:
+		p, err := strconv.Atoi(a.Value)
+		if err == nil && p >= 0 && p <= 9 {
+			t.Priority = p
+			return nil
+		}
+	case models.ActionSetProject:
+		t.Project = a.Value
+		return nil
+	case models.ActionAddTag:
+		if a.Value == "" || len(a.Value) > 30 {
+			break
+		}
+		for _, tag := range t.Tags {
+			if tag == a.Value {
+				return nil
+			}
+		}
+		t.Tags = append(t.Tags, a.Value)
+		return nil
+	case models.ActionRemoveTag:
+		tags := []string{}
+		for _, tag := range t.Tags {
+			if tag != a.Value {
+				tags = append(tags, tag)
+			}
+		}
+		t.Tags = tags
+		return nil
+	}
+	return core.ErrInvalidRule
+}
+
+// change returns a copy of t changed by the actions of r, and whether they changed anything
+func change(t *models.Task, r *models.Rule) (*models.Task, bool, error) {
+	changed := *t
+	changed.Tags = append([]string{}, t.Tags...)
+	for _, a := range r.Actions {
+		if err := act(&changed, a); err != nil {
+			return nil, false, err
+		}
+	}
+	same := changed.Status == t.Status && changed.Priority == t.Priority && changed.Project == t.Project && len(changed.Tags) == len(t.Tags)
+	for i := 0; same && i < len(t.Tags); i++ {
+		same = changed.Tags[i] == t.Tags[i]
+	}
+	return &changed, !same, nil
+}
+
+// run runs the rules on trigger whose condition t matches and returns the
+// executions to log. A rule leaving the task as it is does not run, and the
+// change of a rule fails when the task changed since t was read
+func (e *engine) run(ctx context.Context, rules []*models.Rule, trigger string, t *models.Task) []*models.RuleExecution {
+	c := chainFromContext(ctx)
+	next := context.WithValue(ctx, chainKey{}, &chain{depth: c.depth + 1, fired: c.fired})
+	executions := []*models.RuleExecution{}
+	for _, r := range rules {
+		if r.Trigger != trigger {
+			continue
+		}
+		expr, err := filter.Parse(r.Condition)
+		if err != nil || !filter.Match(expr, t) {
+			continue
+		}
+		ex := &models.RuleExecution{RuleID: r.ID, TaskID: t.ID, Trigger: trigger}
+		changed, ok, err := change(t, r)
+		key := [2]int{r.ID, t.ID}
+		switch {
+		case err != nil:
+			ex.Status, ex.Error = models.ExecutionFailed, err.Error()
+		case !ok:
+			continue
+		case c.fired[key]:
+			ex.Status, ex.Error = models.ExecutionSkipped, "the rule already changed the task"
+		case c.depth >= MaxDepth:
+			ex.Status, ex.Error = models.ExecutionSkipped, "too many rules triggered in a row"
+		default:
+			c.fired[key] = true
+			if err := e.tasks.EditVersion(next, changed, t.Version); err != nil {
+				ex.Status, ex.Error = models.ExecutionFailed, err.Error()
+				break
+			}
+			ex.Status = models.ExecutionApplied
+		}
+		executions = append(executions, ex)
+		if ex.Status == models.ExecutionApplied {
+			// the rules the change triggered may have changed the task further
+			if t, err = e.tasks.Get(ctx, t.ID); err != nil {
+				logrus.Error(err)
+				return executions
+			}
+		}
+	}
+	return executions
+}
+
+// fire runs the rules on the triggers after a change of the task id and logs
+// their executions. The change is made, errors are only reported
+func (e *engine) fire(ctx context.Context, rules []*models.Rule, id int, triggers ...string) {
+	executions := []*models.RuleExecution{}
+	for _, trigger := range triggers {
+		t, err := e.tasks.Get(ctx, id)
+		if err != nil {
+			logrus.Error(err)
+			return
+		}
+		executions = append(executions, e.run(ctx, rules, trigger, t)...)
+	}
+	if err := e.ruleRepo.Log(ctx, executions...); err != nil {
+		logrus.Error(err)
+	}
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func Test_checkRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *models.Rule
+		wantErr error
+	}{
+		{name: "Normal Case 1: remove a tag when done", rule: &models.Rule{Condition: "status:done",
+			Actions: []*models.RuleAction{{Type: models.ActionRemoveTag, Value: "urgent"}}}},
+		{name: "overdue", rule: &models.Rule{Condition: "due<today AND status!=done",
+			Actions: []*models.RuleAction{{Type: models.ActionSetPriority, Value: "1"}}}},
+		{name: "no condition", rule: &models.Rule{Actions: []*models.RuleAction{{Type: models.ActionSetProject}}}},
+		{name: "condition does not compile", rule: &models.Rule{Condition: "status:later",
+			Actions: []*models.RuleAction{{Type: models.ActionSetProject}}}, wantErr: core.ErrInvalidRule},
+		{name: "invalid status", rule: &models.Rule{Actions: []*models.RuleAction{{Type: models.ActionSetStatus, Value: "later"}}}, wantErr: core.ErrInvalidRule},
+		{name: "invalid priority", rule: &models.Rule{Actions: []*models.RuleAction{{Type: models.ActionSetPriority, Value: "high"}}}, wantErr: core.ErrInvalidRule},
+		{name: "priority out of range", rule: &models.Rule{Actions: []*models.RuleAction{{Type: models.ActionSetPriority, Value: "10"}}}, wantErr: core.ErrInvalidRule},
+		{name: "empty tag", rule: &models.Rule{Actions: []*models.RuleAction{{Type: models.ActionAddTag}}}, wantErr: core.ErrInvalidRule},
+		{name: "unknown action", rule: &models.Rule{Actions: []*models.RuleAction{{Type: "notify", Value: "alice"}}}, wantErr: core.ErrInvalidRule},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkRule(tt.rule); err != tt.wantErr {
+				t.Errorf("checkRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_change(t *testing.T) {
+	task := &models.Task{ID: 1, Title: "Take math notes", Status: "todo", Priority: 5, Tags: []string{"urgent", "math"}}
+	tests := []struct {
+		name        string
+		actions     []*models.RuleAction
+		want        *models.Task
+		wantChanged bool
+	}{
+		{name: "Normal Case 1: status and priority",
+			actions: []*models.RuleAction{{Type: models.ActionSetStatus, Value: "done"}, {Type: models.ActionSetPriority, Value: "1"}},
+			want:    &models.Task{ID: 1, Title: "Take math notes", Status: "done", Priority: 1, Tags: []string{"urgent", "math"}}, wantChanged: true},
+		{name: "remove a tag", actions: []*models.RuleAction{{Type: models.ActionRemoveTag, Value: "urgent"}},
+			want: &models.Task{ID: 1, Title: "Take math notes", Status: "todo", Priority: 5, Tags: []string{"math"}}, wantChanged: true},
+		{name: "add a tag", actions: []*models.RuleAction{{Type: models.ActionAddTag, Value: "school"}, {Type: models.ActionSetProject, Value: "school"}},
+			want: &models.Task{ID: 1, Title: "Take math notes", Status: "todo", Priority: 5, Project: "school", Tags: []string{"urgent", "math", "school"}}, wantChanged: true},
+		{name: "tag already there", actions: []*models.RuleAction{{Type: models.ActionAddTag, Value: "math"}},
+			want: &models.Task{ID: 1, Title: "Take math notes", Status: "todo", Priority: 5, Tags: []string{"urgent", "math"}}},
+		{name: "tags in another order", actions: []*models.RuleAction{{Type: models.ActionRemoveTag, Value: "urgent"}, {Type: models.ActionAddTag, Value: "urgent"}},
+			want: &models.Task{ID: 1, Title: "Take math notes", Status: "todo", Priority: 5, Tags: []string{"math", "urgent"}}, wantChanged: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed, err := change(task, &models.Rule{Actions: tt.actions})
+			if err != nil {
+				t.Fatalf("change() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) || changed != tt.wantChanged {
+				t.Errorf("change() = %+v, %v, want %+v, %v", got, changed, tt.want, tt.wantChanged)
+			}
+		})
+	}
+	if want := []string{"urgent", "math"}; !reflect.DeepEqual(task.Tags, want) {
+		t.Errorf("tags of the task = %v, want %v", task.Tags, want)
+	}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/rule"
+	"github.com/pratheeshm/todo-golang/task"
+)
+
+// ExecutionsLimit is the number of entries of the execution log returned for a rule
+const ExecutionsLimit = 100
+
+type ruleUsecase struct {
+	ruleRepo      rule.Repository
+	accessUsecase access.Usecase
+	engine        *engine
+}
+
+// NewRuleUsecase will create new a ruleUsecase object representation of rule.Usecase interface.
+// The rules are managed by the admins of the workspace, tu changes the tasks of the
+// scheduled rules and should be the usecase created by NewAutomatedUsecase
+func NewRuleUsecase(rr rule.Repository, tu task.Usecase, au access.Usecase) rule.Usecase {
+	return &ruleUsecase{
+		ruleRepo:      rr,
+		accessUsecase: au,
+		engine:        &engine{ruleRepo: rr, tasks: tu},
+	}
+}
+
+func (ru *ruleUsecase) Add(ctx context.Context, r *models.Rule) error {
+	if err := access.Allow(ctx, ru.accessUsecase, access.ActionManage); err != nil {
+		return err
+	}
+	if err := checkRule(r); err != nil {
+		return err
+	}
+	return ru.ruleRepo.Add(ctx, r)
+}
+
+func (ru *ruleUsecase) Get(ctx context.Context, id int) (*models.Rule, error) {
+	if err := access.Allow(ctx, ru.accessUsecase, access.ActionRead); err != nil {
+		return nil, err
+	}
+	return ru.ruleRepo.Get(ctx, id)
+}
+
+func (ru *ruleUsecase) List(ctx context.Context) ([]*models.Rule, error) {
+	if err := access.Allow(ctx, ru.accessUsecase, access.ActionRead); err != nil {
+		return nil, err
+	}
+	return ru.ruleRepo.List(ctx)
+}
+
+func (ru *ruleUsecase) Edit(ctx context.Context, r *models.Rule) error {
+	if err := access.Allow(ctx, ru.accessUsecase, access.ActionManage); err != nil {
+		return err
+	}
+	if err := checkRule(r); err != nil {
+		return err
+	}
+	return ru.ruleRepo.Edit(ctx, r)
+}
+
+func (ru *ruleUsecase) Delete(ctx context.Context, id int) error {
+	if err := access.Allow(ctx, ru.accessUsecase, access.ActionManage); err != nil {
+		return err
+	}
+	return ru.ruleRepo.Delete(ctx, id)
+}
+
+func (ru *ruleUsecase) Executions(ctx context.Context, id int) ([]*models.RuleExecution, error) {
+	if err := access.Allow(ctx, ru.accessUsecase, access.ActionRead); err != nil {
+		return nil, err
+	}
+	if _, err := ru.ruleRepo.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	return ru.ruleRepo.Executions(ctx, id, ExecutionsLimit)
+}
+
+// Run gives every task its own chain, a scheduled rule changes a task once a run
+func (ru *ruleUsecase) Run(ctx context.Context) ([]*models.RuleExecution, error) {
+	if err := access.Allow(ctx, ru.accessUsecase, access.ActionManage); err != nil {
+		return nil, err
+	}
+	rules, err := ru.ruleRepo.Enabled(ctx, models.TriggerScheduled)
+	if err != nil {
+		return nil, err
+	}
+	executions := []*models.RuleExecution{}
+	for _, r := range rules {
+		tasks, err := ru.engine.tasks.List(ctx, &models.TaskFilter{Query: r.Condition})
+		if err != nil {
+			return executions, err
+		}
+		for _, t := range tasks {
+			executions = append(executions, ru.engine.run(ctx, []*models.Rule{r}, models.TriggerScheduled, t)...)
+		}
+	}
+	return executions, ru.ruleRepo.Log(ctx, executions...)
+}
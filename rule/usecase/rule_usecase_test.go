@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	accessmocks "github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/rule/mocks"
+	taskmocks "github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+func TestNewRuleUsecase(t *testing.T) {
+	rr := &mocks.MockRepository{}
+	tu := &taskmocks.MockUsecase{}
+	au := &accessmocks.MockUsecase{}
+	want := &ruleUsecase{ruleRepo: rr, accessUsecase: au, engine: &engine{ruleRepo: rr, tasks: tu}}
+	if got := NewRuleUsecase(rr, tu, au); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewRuleUsecase() = %v, want %v", got, want)
+	}
+}
+
+// newRuleUsecase returns the usecase of the fixture, its rules change the tasks of the fixture
+func newRuleUsecase(f *fixture) *ruleUsecase {
+	return NewRuleUsecase(f.rules, f.automate, f.access).(*ruleUsecase)
+}
+
+func Test_ruleUsecase_Add(t *testing.T) {
+	overdue := func() *models.Rule {
+		return &models.Rule{Name: "Overdue", Trigger: models.TriggerScheduled, Condition: "due<today AND status!=done", Enabled: true,
+			Actions: []*models.RuleAction{{Type: models.ActionSetPriority, Value: "1"}}}
+	}
+	invalid := overdue()
+	invalid.Condition = "due<tomorrow"
+	tests := []struct {
+		name    string
+		role    string
+		rule    *models.Rule
+		wantErr error
+	}{
+		{name: "Normal Case 1: rule added", role: models.RoleAdmin, rule: overdue()},
+		{name: "condition does not compile", role: models.RoleAdmin, rule: invalid, wantErr: core.ErrInvalidRule},
+		{name: "member can not add", role: models.RoleMember, rule: overdue(), wantErr: core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.role, nil)
+			if err := newRuleUsecase(f).Add(accessmocks.GrantedContext(), tt.rule); err != tt.wantErr {
+				t.Fatalf("ruleUsecase.Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if want := map[bool]int{true: 1, false: 0}[tt.wantErr == nil]; len(f.rules.Rules) != want {
+				t.Errorf("rules = %d, want %d", len(f.rules.Rules), want)
+			}
+		})
+	}
+}
+
+func Test_ruleUsecase_Edit(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		rule    *models.Rule
+		wantErr error
+	}{
+		{name: "Normal Case 1: rule edited", role: models.RoleAdmin, rule: &models.Rule{ID: 1, Name: "Done", Trigger: models.TriggerStatusChanged,
+			Actions: []*models.RuleAction{{Type: models.ActionRemoveTag, Value: "urgent"}}}},
+		{name: "invalid action", role: models.RoleAdmin, rule: &models.Rule{ID: 1, Name: "Done", Trigger: models.TriggerStatusChanged,
+			Actions: []*models.RuleAction{{Type: models.ActionSetStatus, Value: "later"}}}, wantErr: core.ErrInvalidRule},
+		{name: "rule not found", role: models.RoleAdmin, rule: &models.Rule{ID: 9, Name: "Done", Trigger: models.TriggerStatusChanged,
+			Actions: []*models.RuleAction{{Type: models.ActionRemoveTag, Value: "urgent"}}}, wantErr: core.ErrRecordNotFound},
+		{name: "member can not edit", role: models.RoleMember, rule: &models.Rule{ID: 1, Name: "Done", Trigger: models.TriggerStatusChanged,
+			Actions: []*models.RuleAction{{Type: models.ActionRemoveTag, Value: "urgent"}}}, wantErr: core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := *notUrgent
+			f := newFixture(tt.role, []*models.Rule{&stored})
+			if err := newRuleUsecase(f).Edit(accessmocks.GrantedContext(), tt.rule); err != tt.wantErr {
+				t.Errorf("ruleUsecase.Edit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ruleUsecase_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		wantErr error
+	}{
+		{name: "Normal Case 1: rule deleted", role: models.RoleAdmin},
+		{name: "member can not delete", role: models.RoleMember, wantErr: core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := *notUrgent
+			f := newFixture(tt.role, []*models.Rule{&stored})
+			if err := newRuleUsecase(f).Delete(accessmocks.GrantedContext(), 1); err != tt.wantErr {
+				t.Errorf("ruleUsecase.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ruleUsecase_Get(t *testing.T) {
+	f := newFixture(models.RoleViewer, []*models.Rule{notUrgent})
+	u := newRuleUsecase(f)
+	if got, err := u.Get(accessmocks.GrantedContext(), 1); err != nil || got.Name != notUrgent.Name {
+		t.Errorf("ruleUsecase.Get() = %v, %v, want %s", got, err, notUrgent.Name)
+	}
+	if got, err := u.List(accessmocks.GrantedContext()); err != nil || len(got) != 1 {
+		t.Errorf("ruleUsecase.List() = %v, %v, want 1 rule", got, err)
+	}
+}
+
+func Test_ruleUsecase_Executions(t *testing.T) {
+	f := newFixture(models.RoleViewer, []*models.Rule{notUrgent})
+	f.rules.Entries = []*models.RuleExecution{
+		{ID: 1, RuleID: 1, TaskID: 1, Status: models.ExecutionApplied},
+		{ID: 2, RuleID: 2, TaskID: 1, Status: models.ExecutionApplied},
+		{ID: 3, RuleID: 1, TaskID: 2, Status: models.ExecutionFailed},
+	}
+	u := newRuleUsecase(f)
+	got, err := u.Executions(accessmocks.GrantedContext(), 1)
+	if want := []*models.RuleExecution{f.rules.Entries[2], f.rules.Entries[0]}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("ruleUsecase.Executions() = %v, %v, want %v", got, err, want)
+	}
+	if _, err := u.Executions(accessmocks.GrantedContext(), 9); err != core.ErrRecordNotFound {
+		t.Errorf("ruleUsecase.Executions() error = %v, want %v", err, core.ErrRecordNotFound)
+	}
+}
+
+func Test_ruleUsecase_Run(t *testing.T) {
+	yesterday := time.Now().UTC().AddDate(0, 0, -1)
+	nextWeek := time.Now().UTC().AddDate(0, 0, 7)
+	overdue := &models.Rule{ID: 1, Trigger: models.TriggerScheduled, Condition: "due<today AND status!=done", Enabled: true,
+		Actions: []*models.RuleAction{{Type: models.ActionSetPriority, Value: "1"}}}
+	tests := []struct {
+		name    string
+		role    string
+		wantErr error
+		want    [][2]interface{}
+	}{
+		{name: "Normal Case 1: overdue tasks", role: models.RoleAdmin, want: [][2]interface{}{{1, models.ExecutionApplied}}},
+		{name: "member can not run the rules", role: models.RoleMember, wantErr: core.ErrForbidden, want: [][2]interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.role, []*models.Rule{overdue, notUrgent},
+				&models.Task{ID: 1, Title: "Take math notes", Status: "todo", Priority: 5, DueDate: &yesterday},
+				&models.Task{ID: 2, Title: "Read chapter 3", Status: "todo", Priority: 5, DueDate: &nextWeek},
+				&models.Task{ID: 3, Title: "Solve exercises", Status: "done", Priority: 5, DueDate: &yesterday},
+				&models.Task{ID: 4, Title: "Buy a notebook", Status: "todo", Priority: 1, DueDate: &yesterday})
+			u := newRuleUsecase(f)
+			got, err := u.Run(accessmocks.GrantedContext())
+			if err != tt.wantErr {
+				t.Fatalf("ruleUsecase.Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(statuses(f.rules.Entries), tt.want) || len(got) != len(tt.want) {
+				t.Errorf("ruleUsecase.Run() = %v, logged %v, want %v", statuses(got), statuses(f.rules.Entries), tt.want)
+			}
+			if tt.wantErr == nil && f.tasks.Tasks[0].Priority != 1 {
+				t.Errorf("priority of the overdue task = %d, want 1", f.tasks.Tasks[0].Priority)
+			}
+			// the tasks are left as they are, a second run changes nothing
+			if _, err := u.Run(accessmocks.GrantedContext()); err != tt.wantErr || len(f.rules.Entries) != len(tt.want) {
+				t.Errorf("second run = %v, logged %v", err, statuses(f.rules.Entries))
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	nethttp "net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/sprint"
+	"github.com/sirupsen/logrus"
+)
+
+//SprintHandler represents http handler for sprints
+type SprintHandler struct {
+	SprintUsecase sprint.Usecase
+}
+
+// NewSprintHandler will initialize the sprints/ resources endpoint
+func NewSprintHandler(su sprint.Usecase) nethttp.Handler {
+	r := chi.NewMux()
+	sprintHandler := &SprintHandler{
+		SprintUsecase: su,
+	}
+	r.Get("/", sprintHandler.List)
+	r.Post("/", sprintHandler.Add)
+	r.Get("/{id:[0-9]+}", sprintHandler.Get)
+	r.Put("/{id:[0-9]+}", sprintHandler.Edit)
+	r.Delete("/{id:[0-9]+}", sprintHandler.Delete)
+	r.Post("/{id:[0-9]+}/tasks", sprintHandler.AddTasks)
+	r.Delete("/{id:[0-9]+}/tasks/{task:[0-9]+}", sprintHandler.RemoveTask)
+	r.Post("/{id:[0-9]+}/close", sprintHandler.Close)
+	r.Get("/{id:[0-9]+}/burndown", sprintHandler.Burndown)
+	return r
+}
+
+func writeJSON(w nethttp.ResponseWriter, status int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	res, _ := json.Marshal(body)
+	w.Write(res)
+}
+
+// writeError answers the errors shared by the sprint endpoints
+func writeError(w nethttp.ResponseWriter, err error) {
+	switch err {
+	case core.ErrForbidden:
+		w.WriteHeader(nethttp.StatusForbidden)
+		w.Write([]byte("forbidden"))
+	case core.ErrRecordNotFound:
+		w.WriteHeader(nethttp.StatusNotFound)
+		w.Write([]byte("not found"))
+	case core.ErrSprintClosed:
+		w.WriteHeader(nethttp.StatusConflict)
+		w.Write([]byte("sprint is closed"))
+	default:
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+	}
+}
+
+// writeSprint answers the sprint, or the error of the call that returned it
+func writeSprint(w nethttp.ResponseWriter, status int, sp *models.Sprint, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, map[string]interface{}{
+		"message": "success",
+		"sprint":  sp,
+	})
+}
+
+// decode reads and validates v from the request body, an empty body is
+// accepted when empty is set
+func decode(w nethttp.ResponseWriter, r *nethttp.Request, v interface{}, empty bool) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !(empty && err == io.EOF) {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return false
+	}
+	validate := validator.New()
+	if err := validate.Struct(v); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return false
+	}
+	return true
+}
+
+func id(r *nethttp.Request) int {
+	id, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	return id
+}
+
+//List handler returns the sprints of the workspace, latest first
+func (h *SprintHandler) List(w nethttp.ResponseWriter, r *nethttp.Request) {
+	sprints, err := h.SprintUsecase.List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"sprints": sprints,
+	})
+}
+
+//Add sprint handler, the sprint lasts two weeks unless it has an end date
+func (h *SprintHandler) Add(w nethttp.ResponseWriter, r *nethttp.Request) {
+	sp := &models.Sprint{}
+	if !decode(w, r, sp, false) {
+		return
+	}
+	err := h.SprintUsecase.Add(r.Context(), sp)
+	writeSprint(w, nethttp.StatusCreated, sp, err)
+}
+
+//Get handler returns a sprint along with the ids of its tasks
+func (h *SprintHandler) Get(w nethttp.ResponseWriter, r *nethttp.Request) {
+	sp, err := h.SprintUsecase.Get(r.Context(), id(r))
+	writeSprint(w, nethttp.StatusOK, sp, err)
+}
+
+//Edit sprint handler
+func (h *SprintHandler) Edit(w nethttp.ResponseWriter, r *nethttp.Request) {
+	sp := &models.Sprint{}
+	if !decode(w, r, sp, false) {
+		return
+	}
+	sp.ID = id(r)
+	err := h.SprintUsecase.Edit(r.Context(), sp)
+	writeSprint(w, nethttp.StatusOK, sp, err)
+}
+
+//Delete sprint handler, the tasks of the sprint go back to the backlog
+func (h *SprintHandler) Delete(w nethttp.ResponseWriter, r *nethttp.Request) {
+	if err := h.SprintUsecase.Delete(r.Context(), id(r)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	w.Write([]byte("success"))
+}
+
+//AddTasks handler plans tasks in the sprint, taking them out of the other open sprints
+func (h *SprintHandler) AddTasks(w nethttp.ResponseWriter, r *nethttp.Request) {
+	st := &models.SprintTasks{}
+	if !decode(w, r, st, false) {
+		return
+	}
+	sp, err := h.SprintUsecase.AddTasks(r.Context(), id(r), st.Tasks)
+	writeSprint(w, nethttp.StatusOK, sp, err)
+}
+
+//RemoveTask handler takes a task out of the sprint
+func (h *SprintHandler) RemoveTask(w nethttp.ResponseWriter, r *nethttp.Request) {
+	taskID, _ := strconv.Atoi(chi.URLParam(r, "task"))
+	if err := h.SprintUsecase.RemoveTask(r.Context(), id(r), taskID); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	w.Write([]byte("success"))
+}
+
+//Close handler closes the sprint and carries its unfinished tasks over to the
+//sprint "carry_to" of the body, or back to the backlog without one
+func (h *SprintHandler) Close(w nethttp.ResponseWriter, r *nethttp.Request) {
+	c := &models.SprintClose{}
+	if !decode(w, r, c, true) {
+		return
+	}
+	closure, err := h.SprintUsecase.Close(r.Context(), id(r), c)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"sprint":  closure.Sprint,
+		"carried": closure.Carried,
+	})
+}
+
+//Burndown handler returns the tasks of the sprint left at the end of every day
+//along with the ideal pace
+func (h *SprintHandler) Burndown(w nethttp.ResponseWriter, r *nethttp.Request) {
+	points, err := h.SprintUsecase.Burndown(r.Context(), id(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message":  "success",
+		"burndown": points,
+	})
+}
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/sprint/mocks"
+)
+
+func TestNewSprintHandler(t *testing.T) {
+	h := NewSprintHandler(&mocks.MockUsecase{})
+	sprint := `{"name":"Sprint 1","start_date":"2026-10-19T00:00:00Z"}`
+	tests := []struct {
+		method     string
+		url        string
+		body       string
+		statusCode int
+	}{
+		{"GET", "/", "", 200},
+		{"POST", "/", sprint, 201},
+		{"GET", "/1", "", 200},
+		{"PUT", "/1", sprint, 200},
+		{"DELETE", "/1", "", 200},
+		{"POST", "/1/tasks", `{"tasks":[1,2]}`, 200},
+		{"DELETE", "/1/tasks/2", "", 200},
+		{"POST", "/1/close", `{"carry_to":2}`, 200},
+		{"POST", "/1/close", "", 200},
+		{"GET", "/1/burndown", "", 200},
+		{"GET", "/abc", "", 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Errorf("got statuscode %d but expected %d", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestSprintHandler_Add(t *testing.T) {
+	body := `{"name":"Sprint 1","start_date":"2026-10-19T00:00:00Z"}`
+	tests := []struct {
+		name       string
+		usecase    *mocks.MockUsecase
+		body       string
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, body: body, statusCode: 201},
+		{name: "missing name", usecase: &mocks.MockUsecase{}, body: `{"start_date":"2026-10-19T00:00:00Z"}`, statusCode: 400},
+		{name: "ends before it starts", usecase: &mocks.MockUsecase{},
+			body: `{"name":"Sprint 1","start_date":"2026-10-19T00:00:00Z","end_date":"2026-10-18T00:00:00Z"}`, statusCode: 400},
+		{name: "body parse error", usecase: &mocks.MockUsecase{}, body: `[]`, statusCode: 400},
+		{name: "forbidden", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, body: body, statusCode: 403},
+		{name: "usecase error", usecase: &mocks.MockUsecase{Error: errors.New("Usecase.Error()")}, body: body, statusCode: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewSprintHandler(tt.usecase).ServeHTTP(rec, httptest.NewRequest("POST", "/", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestSprintHandler_AddTasks(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    *mocks.MockUsecase
+		body       string
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, body: `{"tasks":[1,2]}`, statusCode: 200},
+		{name: "no tasks", usecase: &mocks.MockUsecase{}, body: `{"tasks":[]}`, statusCode: 400},
+		{name: "invalid task", usecase: &mocks.MockUsecase{}, body: `{"tasks":[0]}`, statusCode: 400},
+		{name: "closed sprint", usecase: &mocks.MockUsecase{Error: core.ErrSprintClosed}, body: `{"tasks":[1]}`, statusCode: 409},
+		{name: "task not found", usecase: &mocks.MockUsecase{Error: core.ErrRecordNotFound}, body: `{"tasks":[1]}`, statusCode: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewSprintHandler(tt.usecase).ServeHTTP(rec, httptest.NewRequest("POST", "/1/tasks", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestSprintHandler_Close(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    *mocks.MockUsecase
+		body       string
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{Carried: []int{2, 5}}, body: `{"carry_to":2}`, statusCode: 200},
+		{name: "negative sprint", usecase: &mocks.MockUsecase{}, body: `{"carry_to":-1}`, statusCode: 400},
+		{name: "body parse error", usecase: &mocks.MockUsecase{}, body: `{`, statusCode: 400},
+		{name: "closed sprint", usecase: &mocks.MockUsecase{Error: core.ErrSprintClosed}, body: `{}`, statusCode: 409},
+		{name: "forbidden", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, body: `{}`, statusCode: 403},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewSprintHandler(tt.usecase).ServeHTTP(rec, httptest.NewRequest("POST", "/1/close", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if rec.Code != 200 {
+				return
+			}
+			res := struct {
+				Carried []int `json:"carried"`
+			}{}
+			if err := json.NewDecoder(rec.Body).Decode(&res); err != nil || !reflect.DeepEqual(res.Carried, tt.usecase.Carried) {
+				t.Errorf("Test - %s , got carried %v, %v", tt.name, res.Carried, err)
+			}
+		})
+	}
+}
+
+func TestSprintHandler_Burndown(t *testing.T) {
+	uc := &mocks.MockUsecase{Points: []*models.BurndownPoint{{Remaining: 5, Ideal: 5}, {Remaining: 4, Ideal: 2.5}}}
+	rec := httptest.NewRecorder()
+	NewSprintHandler(uc).ServeHTTP(rec, httptest.NewRequest("GET", "/1/burndown", nil))
+	if rec.Code != 200 {
+		t.Fatalf("got statuscode %d but expected 200", rec.Code)
+	}
+	res := struct {
+		Burndown []*models.BurndownPoint `json:"burndown"`
+	}{}
+	if err := json.NewDecoder(rec.Body).Decode(&res); err != nil || !reflect.DeepEqual(res.Burndown, uc.Points) {
+		t.Errorf("got burndown %v, %v", res.Burndown, err)
+	}
+	rec = httptest.NewRecorder()
+	NewSprintHandler(&mocks.MockUsecase{Error: core.ErrRecordNotFound}).ServeHTTP(rec, httptest.NewRequest("GET", "/1/burndown", nil))
+	if rec.Code != 404 {
+		t.Errorf("got statuscode %d but expected 404", rec.Code)
+	}
+}
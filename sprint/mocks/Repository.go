@@ -0,0 +1,217 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+//MockRepository implements inerface sprint.Repository
+type MockRepository struct {
+	Error   error
+	Sprints []*models.Sprint
+	// Members are the ids of the tasks of every sprint
+	Members map[int][]int
+	// Statuses are the statuses of the tasks of the workspace by id
+	Statuses map[int]string
+	// Carried are the tasks Carry marked as carried over
+	Carried []int
+	Points  []*models.BurndownPoint
+}
+
+func (m *MockRepository) find(id int) *models.Sprint {
+	for _, sp := range m.Sprints {
+		if sp.ID == id {
+			return sp
+		}
+	}
+	return nil
+}
+
+func contains(ids []int, id int) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// remember restores the members when the transaction of ctx rolls back
+func (m *MockRepository) remember(ctx context.Context) {
+	members := map[int][]int{}
+	for id, tasks := range m.Members {
+		members[id] = append([]int{}, tasks...)
+	}
+	transaction.OnRollback(ctx, func() {
+		m.Members = members
+	})
+}
+
+//Add appends a copy of the sprint to Sprints
+func (m *MockRepository) Add(ctx context.Context, sp *models.Sprint) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	sp.ID = len(m.Sprints) + 1
+	sp.CreatedAt = time.Now()
+	stored := *sp
+	m.Sprints = append(m.Sprints, &stored)
+	return nil
+}
+
+//Get returns a copy of the sprint of Sprints with the id
+func (m *MockRepository) Get(ctx context.Context, id int) (*models.Sprint, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if sp := m.find(id); sp != nil {
+		found := *sp
+		return &found, nil
+	}
+	return nil, core.ErrRecordNotFound
+}
+
+//List returns Sprints
+func (m *MockRepository) List(ctx context.Context) ([]*models.Sprint, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	return m.Sprints, nil
+}
+
+//Edit replaces the name, goal and dates of an open sprint
+func (m *MockRepository) Edit(ctx context.Context, sp *models.Sprint) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	old := m.find(sp.ID)
+	if old == nil || old.ClosedAt != nil {
+		return core.ErrRecordNotFound
+	}
+	old.Name, old.Goal, old.StartDate, old.EndDate = sp.Name, sp.Goal, sp.StartDate, sp.EndDate
+	return nil
+}
+
+//Delete removes the sprint from Sprints
+func (m *MockRepository) Delete(ctx context.Context, id int) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for i, sp := range m.Sprints {
+		if sp.ID == id {
+			m.Sprints = append(m.Sprints[:i], m.Sprints[i+1:]...)
+			delete(m.Members, id)
+			return nil
+		}
+	}
+	return core.ErrRecordNotFound
+}
+
+//Tasks returns the members of the sprint
+func (m *MockRepository) Tasks(ctx context.Context, id int) ([]int, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	tasks := append([]int{}, m.Members[id]...)
+	sort.Ints(tasks)
+	return tasks, nil
+}
+
+//AddTasks adds the tasks of Statuses to the members of the sprint and takes them out of the other open sprints
+func (m *MockRepository) AddTasks(ctx context.Context, id int, tasks []int) ([]int, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	m.remember(ctx)
+	if m.Members == nil {
+		m.Members = map[int][]int{}
+	}
+	found := []int{}
+	for _, taskID := range tasks {
+		if _, ok := m.Statuses[taskID]; !ok || contains(found, taskID) {
+			continue
+		}
+		found = append(found, taskID)
+		for _, sp := range m.Sprints {
+			if sp.ID == id || sp.ClosedAt != nil {
+				continue
+			}
+			kept := []int{}
+			for _, member := range m.Members[sp.ID] {
+				if member != taskID {
+					kept = append(kept, member)
+				}
+			}
+			m.Members[sp.ID] = kept
+		}
+		if !contains(m.Members[id], taskID) {
+			m.Members[id] = append(m.Members[id], taskID)
+		}
+	}
+	sort.Ints(found)
+	return found, nil
+}
+
+//RemoveTask removes the task from the members of the sprint
+func (m *MockRepository) RemoveTask(ctx context.Context, id, taskID int) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for i, member := range m.Members[id] {
+		if member == taskID {
+			m.Members[id] = append(m.Members[id][:i], m.Members[id][i+1:]...)
+			return nil
+		}
+	}
+	return core.ErrRecordNotFound
+}
+
+//Close sets the closing time of an open sprint, unset again when the transaction of ctx rolls back
+func (m *MockRepository) Close(ctx context.Context, id int, at time.Time) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	sp := m.find(id)
+	if sp == nil || sp.ClosedAt != nil {
+		return core.ErrRecordNotFound
+	}
+	sp.ClosedAt = &at
+	transaction.OnRollback(ctx, func() {
+		sp.ClosedAt = nil
+	})
+	return nil
+}
+
+//Carry appends the members of the sprint that are not done to Carried and returns them
+func (m *MockRepository) Carry(ctx context.Context, id int) ([]int, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	carried := []int{}
+	for _, taskID := range m.Members[id] {
+		if status, ok := m.Statuses[taskID]; ok && status != "done" {
+			carried = append(carried, taskID)
+		}
+	}
+	sort.Ints(carried)
+	m.Carried = append(m.Carried, carried...)
+	return carried, nil
+}
+
+//Burndown returns copies of Points
+func (m *MockRepository) Burndown(ctx context.Context, id int) ([]*models.BurndownPoint, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	points := []*models.BurndownPoint{}
+	for _, b := range m.Points {
+		found := *b
+		points = append(points, &found)
+	}
+	return points, nil
+}
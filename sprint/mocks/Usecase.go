@@ -0,0 +1,74 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockUsecase implements inerface sprint.Usecase
+type MockUsecase struct {
+	Error   error
+	Sprints []*models.Sprint
+	Carried []int
+	Points  []*models.BurndownPoint
+}
+
+func (m *MockUsecase) first() (*models.Sprint, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if len(m.Sprints) == 0 {
+		return &models.Sprint{}, nil
+	}
+	return m.Sprints[0], nil
+}
+
+//Add sprint
+func (m *MockUsecase) Add(ctx context.Context, sp *models.Sprint) error {
+	return m.Error
+}
+
+//Get returns the first sprint of Sprints
+func (m *MockUsecase) Get(ctx context.Context, id int) (*models.Sprint, error) {
+	return m.first()
+}
+
+//List returns Sprints
+func (m *MockUsecase) List(ctx context.Context) ([]*models.Sprint, error) {
+	return m.Sprints, m.Error
+}
+
+//Edit sprint
+func (m *MockUsecase) Edit(ctx context.Context, sp *models.Sprint) error {
+	return m.Error
+}
+
+//Delete sprint
+func (m *MockUsecase) Delete(ctx context.Context, id int) error {
+	return m.Error
+}
+
+//AddTasks returns the first sprint of Sprints
+func (m *MockUsecase) AddTasks(ctx context.Context, id int, tasks []int) (*models.Sprint, error) {
+	return m.first()
+}
+
+//RemoveTask from sprint
+func (m *MockUsecase) RemoveTask(ctx context.Context, id, taskID int) error {
+	return m.Error
+}
+
+//Close returns the first sprint of Sprints along with Carried
+func (m *MockUsecase) Close(ctx context.Context, id int, c *models.SprintClose) (*models.SprintClosure, error) {
+	sp, err := m.first()
+	if err != nil {
+		return nil, err
+	}
+	return &models.SprintClosure{Sprint: sp, Carried: m.Carried}, nil
+}
+
+//Burndown returns Points
+func (m *MockUsecase) Burndown(ctx context.Context, id int) ([]*models.BurndownPoint, error) {
+	return m.Points, m.Error
+}
@@ -0,0 +1,32 @@
+package sprint
+
+import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Repository represents sprint's interface
+type Repository interface {
+	Add(context.Context, *models.Sprint) error
+	Get(context.Context, int) (*models.Sprint, error)
+	// List returns the sprints of the workspace, latest first
+	List(context.Context) ([]*models.Sprint, error)
+	// Edit changes the name, goal and dates of an open sprint
+	Edit(context.Context, *models.Sprint) error
+	Delete(context.Context, int) error
+	// Tasks returns the ids of the tasks of a sprint
+	Tasks(ctx context.Context, id int) ([]int, error)
+	// AddTasks adds the tasks of the workspace to a sprint, taking them out of the
+	// other open sprints, and returns the ones it found
+	AddTasks(ctx context.Context, id int, tasks []int) ([]int, error)
+	RemoveTask(ctx context.Context, id, taskID int) error
+	// Close closes an open sprint at the given time
+	Close(ctx context.Context, id int, at time.Time) error
+	// Carry marks the tasks of a sprint that are not done as carried over and returns them
+	Carry(ctx context.Context, id int) ([]int, error)
+	// Burndown returns the number of tasks of a sprint not done at the end of every
+	// day of the sprint, up to today or to the day it closed
+	Burndown(ctx context.Context, id int) ([]*models.BurndownPoint, error)
+}
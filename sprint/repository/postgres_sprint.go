@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/sprint"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+const sprintColumns = "id_sprint, name, goal, start_date, end_date, closed_at, created_at"
+
+// burndownQuery counts the tasks of the sprint $1 that are neither done nor deleted at the end
+// of every day, UTC, an event writing a task sets its status until the next one
+const burndownQuery = "WITH state AS (SELECT e.status, e.created_at AS since, " +
+	"lead(e.created_at) OVER (PARTITION BY e.id_task ORDER BY e.created_at, e.id_event) AS until " +
+	"FROM task_event e JOIN sprint_task st ON st.id_task = e.id_task WHERE st.id_sprint = $1 " +
+	"AND e.type IN ('" + models.EventCreated + "', '" + models.EventUpdated + "', '" + models.EventDeleted + "')) " +
+	"SELECT d.day::date, count(s.status) FROM sprint sp " +
+	"CROSS JOIN generate_series(sp.start_date::timestamp, sp.end_date::timestamp, interval '1 day') AS d(day) " +
+	"LEFT JOIN state s ON s.status NOT IN ('done', '') AND s.since < (d.day + interval '1 day') AT TIME ZONE 'UTC' " +
+	"AND (s.until IS NULL OR s.until >= (d.day + interval '1 day') AT TIME ZONE 'UTC') " +
+	"WHERE sp.id_sprint = $1 AND sp.id_workspace = $2 AND d.day AT TIME ZONE 'UTC' <= COALESCE(sp.closed_at, now()) " +
+	"GROUP BY d.day ORDER BY d.day"
+
+type postgresSprintRepository struct {
+	*sql.DB
+}
+
+// NewPostgresSprintRepository will create an object that represent the sprint.Repository interface
+func NewPostgresSprintRepository(db *sql.DB) sprint.Repository {
+	return &postgresSprintRepository{db}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSprint(s scanner) (*models.Sprint, error) {
+	sp := &models.Sprint{}
+	err := s.Scan(&sp.ID, &sp.Name, &sp.Goal, &sp.StartDate, &sp.EndDate, &sp.ClosedAt, &sp.CreatedAt)
+	return sp, err
+}
+
+func toInt64s(ids []int) []int64 {
+	ints := make([]int64, len(ids))
+	for i, id := range ids {
+		ints[i] = int64(id)
+	}
+	return ints
+}
+
+// scanIDs reads the ids of the rows, in order
+func scanIDs(rows *sql.Rows, err error) ([]int, error) {
+	ids := make([]int, 0)
+	if err != nil {
+		return ids, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return []int{}, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return []int{}, err
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+func (p *postgresSprintRepository) Add(ctx context.Context, sp *models.Sprint) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "INSERT INTO sprint(name, goal, start_date, end_date, id_workspace) VALUES ($1, $2, $3, $4, $5) RETURNING id_sprint, created_at",
+		sp.Name, sp.Goal, sp.StartDate, sp.EndDate, workspace).Scan(&sp.ID, &sp.CreatedAt)
+}
+
+func (p *postgresSprintRepository) Get(ctx context.Context, id int) (*models.Sprint, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "SELECT "+sprintColumns+" FROM sprint WHERE id_sprint = $1 AND id_workspace = $2", id, workspace)
+	sp, err := scanSprint(row)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	return sp, err
+}
+
+func (p *postgresSprintRepository) List(ctx context.Context) ([]*models.Sprint, error) {
+	sprints := make([]*models.Sprint, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return sprints, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT "+sprintColumns+" FROM sprint WHERE id_workspace = $1 ORDER BY start_date DESC, id_sprint DESC", workspace)
+	if err != nil {
+		return sprints, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		sp, err := scanSprint(rows)
+		if err != nil {
+			return []*models.Sprint{}, err
+		}
+		sprints = append(sprints, sp)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.Sprint{}, err
+	}
+	return sprints, nil
+}
+
+func (p *postgresSprintRepository) Edit(ctx context.Context, sp *models.Sprint) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	err = transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "UPDATE sprint SET name = $1, goal = $2, start_date = $3, end_date = $4 WHERE id_sprint = $5 AND id_workspace = $6 AND closed_at IS NULL RETURNING created_at",
+		sp.Name, sp.Goal, sp.StartDate, sp.EndDate, sp.ID, workspace).Scan(&sp.CreatedAt)
+	if err == sql.ErrNoRows {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresSprintRepository) Delete(ctx context.Context, id int) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "DELETE FROM sprint WHERE id_sprint = $1 AND id_workspace = $2", id, workspace)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresSprintRepository) Tasks(ctx context.Context, id int) ([]int, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return []int{}, err
+	}
+	return scanIDs(transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT st.id_task FROM sprint_task st JOIN sprint s ON s.id_sprint = st.id_sprint WHERE st.id_sprint = $1 AND s.id_workspace = $2",
+		id, workspace))
+}
+
+// AddTasks clears the carried over mark of a task planned again in the sprint
+func (p *postgresSprintRepository) AddTasks(ctx context.Context, id int, tasks []int) ([]int, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return []int{}, err
+	}
+	ids := pq.Array(toInt64s(tasks))
+	_, err = transaction.Conn(ctx, p.DB).ExecContext(ctx, "DELETE FROM sprint_task st USING sprint s WHERE s.id_sprint = st.id_sprint AND s.id_workspace = $1 AND s.closed_at IS NULL AND s.id_sprint <> $2 AND st.id_task = ANY($3)",
+		workspace, id, ids)
+	if err != nil {
+		return []int{}, err
+	}
+	return scanIDs(transaction.Conn(ctx, p.DB).QueryContext(ctx, "INSERT INTO sprint_task(id_sprint, id_task) SELECT $1, t.id_task FROM task t "+
+		"WHERE t.id_task = ANY($2) AND t.id_workspace = $3 AND NOT t.deleted AND EXISTS (SELECT 1 FROM sprint WHERE id_sprint = $1 AND id_workspace = $3) "+
+		"ON CONFLICT (id_sprint, id_task) DO UPDATE SET carried = false RETURNING id_task", id, ids, workspace))
+}
+
+func (p *postgresSprintRepository) RemoveTask(ctx context.Context, id, taskID int) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "DELETE FROM sprint_task st USING sprint s WHERE s.id_sprint = st.id_sprint AND st.id_sprint = $1 AND st.id_task = $2 AND s.id_workspace = $3",
+		id, taskID, workspace)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresSprintRepository) Close(ctx context.Context, id int, at time.Time) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "UPDATE sprint SET closed_at = $1 WHERE id_sprint = $2 AND id_workspace = $3 AND closed_at IS NULL", at, id, workspace)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresSprintRepository) Carry(ctx context.Context, id int) ([]int, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return []int{}, err
+	}
+	return scanIDs(transaction.Conn(ctx, p.DB).QueryContext(ctx, "UPDATE sprint_task st SET carried = true FROM task t, sprint s "+
+		"WHERE st.id_sprint = $1 AND s.id_sprint = st.id_sprint AND s.id_workspace = $2 AND t.id_task = st.id_task AND NOT t.deleted AND t.status <> 'done' RETURNING st.id_task",
+		id, workspace))
+}
+
+func (p *postgresSprintRepository) Burndown(ctx context.Context, id int) ([]*models.BurndownPoint, error) {
+	points := make([]*models.BurndownPoint, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return points, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, burndownQuery, id, workspace)
+	if err != nil {
+		return points, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		b := &models.BurndownPoint{}
+		if err := rows.Scan(&b.Day, &b.Remaining); err != nil {
+			return []*models.BurndownPoint{}, err
+		}
+		points = append(points, b)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.BurndownPoint{}, err
+	}
+	return points, nil
+}
@@ -0,0 +1,354 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+var sprintRowColumns = []string{"id_sprint", "name", "goal", "start_date", "end_date", "closed_at", "created_at"}
+
+var (
+	start = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	end   = time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func Test_postgresSprintRepository_Add(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO sprint(name, goal, start_date, end_date, id_workspace) VALUES ($1, $2, $3, $4, $5) RETURNING id_sprint, created_at")).
+		WithArgs("Sprint 1", "Ship sprints", start, end, coremocks.Tenant).
+		WillReturnRows(sqlmock.NewRows([]string{"id_sprint", "created_at"}).AddRow(3, time.Time{}))
+	sp := &models.Sprint{Name: "Sprint 1", Goal: "Ship sprints", StartDate: start, EndDate: end}
+	if err := NewPostgresSprintRepository(db).Add(coremocks.TenantContext(), sp); err != nil || sp.ID != 3 {
+		t.Errorf("Add() = sprint %d, %v, want sprint 3", sp.ID, err)
+	}
+}
+
+func Test_postgresSprintRepository_Get(t *testing.T) {
+	query := "SELECT id_sprint, name, goal, start_date, end_date, closed_at, created_at FROM sprint WHERE id_sprint = $1 AND id_workspace = $2"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *models.Sprint
+		wantErr error
+	}{{
+		name: "Normal Case 1: open sprint",
+		rows: sqlmock.NewRows(sprintRowColumns).AddRow(3, "Sprint 1", "", start, end, nil, time.Time{}),
+		want: &models.Sprint{ID: 3, Name: "Sprint 1", StartDate: start, EndDate: end},
+	}, {
+		name:    "sprint not found",
+		rows:    sqlmock.NewRows(sprintRowColumns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(3, coremocks.Tenant).WillReturnRows(tt.rows)
+			got, err := NewPostgresSprintRepository(db).Get(coremocks.TenantContext(), 3)
+			if err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresSprintRepository_List(t *testing.T) {
+	query := "SELECT id_sprint, name, goal, start_date, end_date, closed_at, created_at FROM sprint WHERE id_workspace = $1 ORDER BY start_date DESC, id_sprint DESC"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbError error
+		want    []*models.Sprint
+		wantErr bool
+	}{{
+		name: "Normal Case 1: latest first",
+		rows: sqlmock.NewRows(sprintRowColumns).
+			AddRow(4, "Sprint 2", "", end, end.AddDate(0, 0, 13), nil, time.Time{}).
+			AddRow(3, "Sprint 1", "", start, end, end, time.Time{}),
+		want: []*models.Sprint{
+			{ID: 4, Name: "Sprint 2", StartDate: end, EndDate: end.AddDate(0, 0, 13)},
+			{ID: 3, Name: "Sprint 1", StartDate: start, EndDate: end, ClosedAt: &end},
+		},
+	}, {
+		name:    "db error",
+		rows:    sqlmock.NewRows(sprintRowColumns),
+		dbError: errors.New("db error"),
+		want:    []*models.Sprint{},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant).WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			got, err := NewPostgresSprintRepository(db).List(coremocks.TenantContext())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresSprintRepository_Edit(t *testing.T) {
+	query := "UPDATE sprint SET name = $1, goal = $2, start_date = $3, end_date = $4 WHERE id_sprint = $5 AND id_workspace = $6 AND closed_at IS NULL RETURNING created_at"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr error
+	}{
+		{name: "Normal Case 1: sprint edited", rows: sqlmock.NewRows([]string{"created_at"}).AddRow(time.Time{})},
+		{name: "closed or missing sprint", rows: sqlmock.NewRows([]string{"created_at"}), wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("Sprint 1", "", start, end, 3, coremocks.Tenant).WillReturnRows(tt.rows)
+			sp := &models.Sprint{ID: 3, Name: "Sprint 1", StartDate: start, EndDate: end}
+			if err := NewPostgresSprintRepository(db).Edit(coremocks.TenantContext(), sp); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresSprintRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "Normal Case 1: sprint deleted", affected: 1},
+		{name: "sprint not found", affected: 0, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sprint WHERE id_sprint = $1 AND id_workspace = $2")).WithArgs(3, coremocks.Tenant).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if err := NewPostgresSprintRepository(db).Delete(coremocks.TenantContext(), 3); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresSprintRepository_Tasks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT st.id_task FROM sprint_task st JOIN sprint s ON s.id_sprint = st.id_sprint WHERE st.id_sprint = $1 AND s.id_workspace = $2")).
+		WithArgs(3, coremocks.Tenant).WillReturnRows(sqlmock.NewRows([]string{"id_task"}).AddRow(5).AddRow(2))
+	got, err := NewPostgresSprintRepository(db).Tasks(coremocks.TenantContext(), 3)
+	if err != nil || !reflect.DeepEqual(got, []int{2, 5}) {
+		t.Errorf("Tasks() = %v, %v, want [2 5]", got, err)
+	}
+}
+
+func Test_postgresSprintRepository_AddTasks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	ids, _ := pq.Int64Array{2, 5, 9}.Value()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sprint_task st USING sprint s WHERE s.id_sprint = st.id_sprint AND s.id_workspace = $1 AND s.closed_at IS NULL AND s.id_sprint <> $2 AND st.id_task = ANY($3)")).
+		WithArgs(coremocks.Tenant, 3, ids).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO sprint_task(id_sprint, id_task) SELECT $1, t.id_task FROM task t "+
+		"WHERE t.id_task = ANY($2) AND t.id_workspace = $3 AND NOT t.deleted AND EXISTS (SELECT 1 FROM sprint WHERE id_sprint = $1 AND id_workspace = $3) "+
+		"ON CONFLICT (id_sprint, id_task) DO UPDATE SET carried = false RETURNING id_task")).
+		WithArgs(3, ids, coremocks.Tenant).WillReturnRows(sqlmock.NewRows([]string{"id_task"}).AddRow(5).AddRow(2))
+	got, err := NewPostgresSprintRepository(db).AddTasks(coremocks.TenantContext(), 3, []int{2, 5, 9})
+	if err != nil || !reflect.DeepEqual(got, []int{2, 5}) {
+		t.Errorf("AddTasks() = %v, %v, want [2 5]", got, err)
+	}
+}
+
+func Test_postgresSprintRepository_RemoveTask(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "Normal Case 1: task removed", affected: 1},
+		{name: "task not in the sprint", affected: 0, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sprint_task st USING sprint s WHERE s.id_sprint = st.id_sprint AND st.id_sprint = $1 AND st.id_task = $2 AND s.id_workspace = $3")).
+				WithArgs(3, 5, coremocks.Tenant).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if err := NewPostgresSprintRepository(db).RemoveTask(coremocks.TenantContext(), 3, 5); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresSprintRepository_Close(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "Normal Case 1: sprint closed", affected: 1},
+		{name: "closed or missing sprint", affected: 0, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta("UPDATE sprint SET closed_at = $1 WHERE id_sprint = $2 AND id_workspace = $3 AND closed_at IS NULL")).
+				WithArgs(end, 3, coremocks.Tenant).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if err := NewPostgresSprintRepository(db).Close(coremocks.TenantContext(), 3, end); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresSprintRepository_Carry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE sprint_task st SET carried = true FROM task t, sprint s "+
+		"WHERE st.id_sprint = $1 AND s.id_sprint = st.id_sprint AND s.id_workspace = $2 AND t.id_task = st.id_task AND NOT t.deleted AND t.status <> 'done' RETURNING st.id_task")).
+		WithArgs(3, coremocks.Tenant).WillReturnRows(sqlmock.NewRows([]string{"id_task"}).AddRow(9).AddRow(5))
+	got, err := NewPostgresSprintRepository(db).Carry(coremocks.TenantContext(), 3)
+	if err != nil || !reflect.DeepEqual(got, []int{5, 9}) {
+		t.Errorf("Carry() = %v, %v, want [5 9]", got, err)
+	}
+}
+
+func Test_postgresSprintRepository_Burndown(t *testing.T) {
+	// assigning a task records an event too, only the writes of the task tell its status
+	query := "WITH state AS (SELECT e.status, e.created_at AS since, " +
+		"lead(e.created_at) OVER (PARTITION BY e.id_task ORDER BY e.created_at, e.id_event) AS until " +
+		"FROM task_event e JOIN sprint_task st ON st.id_task = e.id_task WHERE st.id_sprint = $1 " +
+		"AND e.type IN ('created', 'updated', 'deleted')) " +
+		"SELECT d.day::date, count(s.status) FROM sprint sp " +
+		"CROSS JOIN generate_series(sp.start_date::timestamp, sp.end_date::timestamp, interval '1 day') AS d(day) " +
+		"LEFT JOIN state s ON s.status NOT IN ('done', '') AND s.since < (d.day + interval '1 day') AT TIME ZONE 'UTC' " +
+		"AND (s.until IS NULL OR s.until >= (d.day + interval '1 day') AT TIME ZONE 'UTC') " +
+		"WHERE sp.id_sprint = $1 AND sp.id_workspace = $2 AND d.day AT TIME ZONE 'UTC' <= COALESCE(sp.closed_at, now()) " +
+		"GROUP BY d.day ORDER BY d.day"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbError error
+		want    []*models.BurndownPoint
+		wantErr bool
+	}{{
+		name: "Normal Case 1: days so far",
+		rows: sqlmock.NewRows([]string{"day", "remaining"}).AddRow(start, 5).AddRow(start.AddDate(0, 0, 1), 3),
+		want: []*models.BurndownPoint{{Day: start, Remaining: 5}, {Day: start.AddDate(0, 0, 1), Remaining: 3}},
+	}, {
+		name: "task assigned mid-sprint stays remaining",
+		rows: sqlmock.NewRows([]string{"day", "remaining"}).AddRow(start, 2).AddRow(start.AddDate(0, 0, 1), 2),
+		want: []*models.BurndownPoint{{Day: start, Remaining: 2}, {Day: start.AddDate(0, 0, 1), Remaining: 2}},
+	}, {
+		name:    "db error",
+		rows:    sqlmock.NewRows([]string{"day", "remaining"}),
+		dbError: errors.New("db error"),
+		want:    []*models.BurndownPoint{},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(3, coremocks.Tenant).WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			got, err := NewPostgresSprintRepository(db).Burndown(coremocks.TenantContext(), 3)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresSprintRepository_withoutTenant(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	ctx := context.Background()
+	p := NewPostgresSprintRepository(db)
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Add", func() error { return p.Add(ctx, &models.Sprint{Name: "Sprint 1", StartDate: start, EndDate: end}) }},
+		{"Get", func() error { _, err := p.Get(ctx, 1); return err }},
+		{"List", func() error { _, err := p.List(ctx); return err }},
+		{"Edit", func() error {
+			return p.Edit(ctx, &models.Sprint{ID: 1, Name: "Sprint 1", StartDate: start, EndDate: end})
+		}},
+		{"Delete", func() error { return p.Delete(ctx, 1) }},
+		{"Tasks", func() error { _, err := p.Tasks(ctx, 1); return err }},
+		{"AddTasks", func() error { _, err := p.AddTasks(ctx, 1, []int{2}); return err }},
+		{"RemoveTask", func() error { return p.RemoveTask(ctx, 1, 2) }},
+		{"Close", func() error { return p.Close(ctx, 1, end) }},
+		{"Carry", func() error { _, err := p.Carry(ctx, 1); return err }},
+		{"Burndown", func() error { _, err := p.Burndown(ctx, 1); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != core.ErrNoTenant {
+				t.Errorf("expected %v, got %v", core.ErrNoTenant, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package sprint
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Usecase represents sprint's usecases
+type Usecase interface {
+	// Add stores the sprint, two weeks long unless it has an end date
+	Add(context.Context, *models.Sprint) error
+	// Get returns a sprint along with its tasks
+	Get(context.Context, int) (*models.Sprint, error)
+	List(context.Context) ([]*models.Sprint, error)
+	// Edit changes a sprint, core.ErrSprintClosed is returned once it is closed
+	Edit(context.Context, *models.Sprint) error
+	Delete(context.Context, int) error
+	// AddTasks plans tasks in an open sprint and returns the sprint
+	AddTasks(ctx context.Context, id int, tasks []int) (*models.Sprint, error)
+	// RemoveTask takes a task out of an open sprint
+	RemoveTask(ctx context.Context, id, taskID int) error
+	// Close closes a sprint and carries its unfinished tasks over in one transaction
+	Close(ctx context.Context, id int, c *models.SprintClose) (*models.SprintClosure, error)
+	// Burndown returns the tasks of a sprint left day by day along with the ideal pace
+	Burndown(ctx context.Context, id int) ([]*models.BurndownPoint, error)
+}
@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/sprint"
+)
+
+type sprintUsecase struct {
+	sprintRepo    sprint.Repository
+	accessUsecase access.Usecase
+	transactor    core.Transactor
+}
+
+// NewSprintUsecase will create new a sprintUsecase object representation of sprint.Usecase interface.
+// Sprints belong to the whole workspace: its viewers read them, its members plan them and its admins delete them
+func NewSprintUsecase(sr sprint.Repository, au access.Usecase, t core.Transactor) sprint.Usecase {
+	return &sprintUsecase{
+		sprintRepo:    sr,
+		accessUsecase: au,
+		transactor:    t,
+	}
+}
+
+// open returns the sprint, core.ErrSprintClosed is returned once it is closed
+func (su *sprintUsecase) open(ctx context.Context, id int) (*models.Sprint, error) {
+	sp, err := su.sprintRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sp.ClosedAt != nil {
+		return nil, core.ErrSprintClosed
+	}
+	return sp, nil
+}
+
+// day returns the UTC day of t, the dates of the sprints are stored without a time
+func day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// dates moves the dates of the sprint to their days, a sprint without an end date lasts models.SprintDays
+func dates(sp *models.Sprint) {
+	sp.StartDate = day(sp.StartDate)
+	if sp.EndDate.IsZero() {
+		sp.EndDate = sp.StartDate.AddDate(0, 0, models.SprintDays-1)
+	}
+	sp.EndDate = day(sp.EndDate)
+}
+
+func (su *sprintUsecase) Add(ctx context.Context, sp *models.Sprint) error {
+	if err := access.Allow(ctx, su.accessUsecase, access.ActionWrite); err != nil {
+		return err
+	}
+	dates(sp)
+	return su.sprintRepo.Add(ctx, sp)
+}
+
+func (su *sprintUsecase) Get(ctx context.Context, id int) (*models.Sprint, error) {
+	if err := access.Allow(ctx, su.accessUsecase, access.ActionRead); err != nil {
+		return nil, err
+	}
+	sp, err := su.sprintRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sp.Tasks, err = su.sprintRepo.Tasks(ctx, id); err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+func (su *sprintUsecase) List(ctx context.Context) ([]*models.Sprint, error) {
+	if err := access.Allow(ctx, su.accessUsecase, access.ActionRead); err != nil {
+		return nil, err
+	}
+	return su.sprintRepo.List(ctx)
+}
+
+func (su *sprintUsecase) Edit(ctx context.Context, sp *models.Sprint) error {
+	if err := access.Allow(ctx, su.accessUsecase, access.ActionWrite); err != nil {
+		return err
+	}
+	if _, err := su.open(ctx, sp.ID); err != nil {
+		return err
+	}
+	dates(sp)
+	return su.sprintRepo.Edit(ctx, sp)
+}
+
+func (su *sprintUsecase) Delete(ctx context.Context, id int) error {
+	if err := access.Allow(ctx, su.accessUsecase, access.ActionDelete); err != nil {
+		return err
+	}
+	return su.sprintRepo.Delete(ctx, id)
+}
+
+// AddTasks plans all the tasks or none, core.ErrRecordNotFound is returned
+// when one of them is not a task of the workspace
+func (su *sprintUsecase) AddTasks(ctx context.Context, id int, tasks []int) (*models.Sprint, error) {
+	if err := access.Allow(ctx, su.accessUsecase, access.ActionWrite); err != nil {
+		return nil, err
+	}
+	wanted := map[int]bool{}
+	for _, taskID := range tasks {
+		wanted[taskID] = true
+	}
+	var sp *models.Sprint
+	err := su.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if sp, err = su.open(ctx, id); err != nil {
+			return err
+		}
+		found, err := su.sprintRepo.AddTasks(ctx, id, tasks)
+		if err != nil {
+			return err
+		}
+		if len(found) != len(wanted) {
+			return core.ErrRecordNotFound
+		}
+		sp.Tasks, err = su.sprintRepo.Tasks(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+func (su *sprintUsecase) RemoveTask(ctx context.Context, id, taskID int) error {
+	if err := access.Allow(ctx, su.accessUsecase, access.ActionWrite); err != nil {
+		return err
+	}
+	if _, err := su.open(ctx, id); err != nil {
+		return err
+	}
+	return su.sprintRepo.RemoveTask(ctx, id, taskID)
+}
+
+// Close carries the unfinished tasks over to an open sprint, or leaves them in
+// the backlog. They stay in the closed sprint marked as carried over
+func (su *sprintUsecase) Close(ctx context.Context, id int, c *models.SprintClose) (*models.SprintClosure, error) {
+	if err := access.Allow(ctx, su.accessUsecase, access.ActionWrite); err != nil {
+		return nil, err
+	}
+	closure := &models.SprintClosure{}
+	err := su.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		sp, err := su.open(ctx, id)
+		if err != nil {
+			return err
+		}
+		if c.CarryTo == id {
+			return core.ErrSprintClosed
+		}
+		if c.CarryTo != 0 {
+			if _, err := su.open(ctx, c.CarryTo); err != nil {
+				return err
+			}
+		}
+		now := time.Now()
+		if err := su.sprintRepo.Close(ctx, id, now); err != nil {
+			return err
+		}
+		sp.ClosedAt = &now
+		if closure.Carried, err = su.sprintRepo.Carry(ctx, id); err != nil {
+			return err
+		}
+		if c.CarryTo != 0 && len(closure.Carried) > 0 {
+			if _, err := su.sprintRepo.AddTasks(ctx, c.CarryTo, closure.Carried); err != nil {
+				return err
+			}
+		}
+		if sp.Tasks, err = su.sprintRepo.Tasks(ctx, id); err != nil {
+			return err
+		}
+		closure.Sprint = sp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return closure, nil
+}
+
+// Burndown sets the ideal of every day on a straight line from the tasks left
+// at the end of the first day down to none at the end of the last one
+func (su *sprintUsecase) Burndown(ctx context.Context, id int) ([]*models.BurndownPoint, error) {
+	if err := access.Allow(ctx, su.accessUsecase, access.ActionRead); err != nil {
+		return nil, err
+	}
+	sp, err := su.sprintRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	points, err := su.sprintRepo.Burndown(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	last := float64(sp.EndDate.Sub(sp.StartDate) / (24 * time.Hour))
+	for i, b := range points {
+		if last > 0 {
+			b.Ideal = float64(points[0].Remaining) * (last - float64(i)) / last
+		}
+	}
+	return points, nil
+}
@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	accessmocks "github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/sprint/mocks"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+var (
+	start  = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	end    = start.AddDate(0, 0, 13)
+	closed = start.AddDate(0, 0, -1)
+)
+
+// sprint 1 is open with tasks 1 and 2, sprint 2 is open and empty, sprint 3 is closed
+func newFixture(role string) (*mocks.MockRepository, *sprintUsecase) {
+	repo := &mocks.MockRepository{
+		Sprints: []*models.Sprint{
+			{ID: 1, Name: "Sprint 1", StartDate: start, EndDate: end},
+			{ID: 2, Name: "Sprint 2", StartDate: end.AddDate(0, 0, 1), EndDate: end.AddDate(0, 0, 14)},
+			{ID: 3, Name: "Sprint 0", StartDate: start.AddDate(0, 0, -14), EndDate: closed, ClosedAt: &closed},
+		},
+		Members:  map[int][]int{1: {1, 2}},
+		Statuses: map[int]string{1: "done", 2: "inprogress", 3: "todo"},
+	}
+	access := accessmocks.RoleUsecase(role)
+	return repo, NewSprintUsecase(repo, access, transaction.NewMemoryTransactor()).(*sprintUsecase)
+}
+
+func TestNewSprintUsecase(t *testing.T) {
+	sr := &mocks.MockRepository{}
+	au := &accessmocks.MockUsecase{}
+	tx := transaction.NewMemoryTransactor()
+	want := &sprintUsecase{sprintRepo: sr, accessUsecase: au, transactor: tx}
+	if got := NewSprintUsecase(sr, au, tx); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewSprintUsecase() = %v, want %v", got, want)
+	}
+}
+
+func Test_sprintUsecase_Add(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		sprint  *models.Sprint
+		wantEnd time.Time
+		wantErr error
+	}{
+		{name: "Normal Case 1: two weeks by default", role: models.RoleMember,
+			sprint: &models.Sprint{Name: "Sprint 4", StartDate: start.Add(15 * time.Hour)}, wantEnd: end},
+		{name: "end date", role: models.RoleMember,
+			sprint: &models.Sprint{Name: "Sprint 4", StartDate: start, EndDate: start.AddDate(0, 0, 6)}, wantEnd: start.AddDate(0, 0, 6)},
+		{name: "viewer can not plan", role: models.RoleViewer, sprint: &models.Sprint{Name: "Sprint 4", StartDate: start}, wantErr: core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, u := newFixture(tt.role)
+			if err := u.Add(accessmocks.GrantedContext(), tt.sprint); err != tt.wantErr {
+				t.Fatalf("sprintUsecase.Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (!tt.sprint.StartDate.Equal(start) || !tt.sprint.EndDate.Equal(tt.wantEnd)) {
+				t.Errorf("sprintUsecase.Add() dates %v - %v, want %v - %v", tt.sprint.StartDate, tt.sprint.EndDate, start, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func Test_sprintUsecase_Get(t *testing.T) {
+	_, u := newFixture(models.RoleViewer)
+	got, err := u.Get(accessmocks.GrantedContext(), 1)
+	if err != nil {
+		t.Fatalf("sprintUsecase.Get() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Tasks, []int{1, 2}) {
+		t.Errorf("sprintUsecase.Get() tasks = %v, want [1 2]", got.Tasks)
+	}
+	if _, err := u.Get(accessmocks.GrantedContext(), 42); err != core.ErrRecordNotFound {
+		t.Errorf("sprintUsecase.Get() of a missing sprint error = %v", err)
+	}
+}
+
+func Test_sprintUsecase_Edit(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		wantErr error
+	}{
+		{name: "Normal Case 1: open sprint", id: 1},
+		{name: "closed sprint", id: 3, wantErr: core.ErrSprintClosed},
+		{name: "sprint not found", id: 42, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, u := newFixture(models.RoleMember)
+			if err := u.Edit(accessmocks.GrantedContext(), &models.Sprint{ID: tt.id, Name: "Renamed", StartDate: start}); err != tt.wantErr {
+				t.Errorf("sprintUsecase.Edit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_sprintUsecase_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		wantErr error
+	}{
+		{name: "Normal Case 1: admin deletes", role: models.RoleAdmin},
+		{name: "member can not delete", role: models.RoleMember, wantErr: core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, u := newFixture(tt.role)
+			if err := u.Delete(accessmocks.GrantedContext(), 1); err != tt.wantErr {
+				t.Errorf("sprintUsecase.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_sprintUsecase_AddTasks(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          int
+		tasks       []int
+		wantMembers map[int][]int
+		wantErr     error
+	}{
+		{name: "Normal Case 1: tasks move over from the other open sprint", id: 2, tasks: []int{2, 3, 3},
+			wantMembers: map[int][]int{1: {1}, 2: {2, 3}}},
+		{name: "missing task plans nothing", id: 2, tasks: []int{2, 42},
+			wantMembers: map[int][]int{1: {1, 2}}, wantErr: core.ErrRecordNotFound},
+		{name: "closed sprint", id: 3, tasks: []int{3},
+			wantMembers: map[int][]int{1: {1, 2}}, wantErr: core.ErrSprintClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, u := newFixture(models.RoleMember)
+			sp, err := u.AddTasks(accessmocks.GrantedContext(), tt.id, tt.tasks)
+			if err != tt.wantErr {
+				t.Fatalf("sprintUsecase.AddTasks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(sp.Tasks, tt.wantMembers[tt.id]) {
+				t.Errorf("sprintUsecase.AddTasks() tasks = %v, want %v", sp.Tasks, tt.wantMembers[tt.id])
+			}
+			if !reflect.DeepEqual(repo.Members, tt.wantMembers) {
+				t.Errorf("members = %v, want %v", repo.Members, tt.wantMembers)
+			}
+		})
+	}
+}
+
+func Test_sprintUsecase_RemoveTask(t *testing.T) {
+	repo, u := newFixture(models.RoleMember)
+	if err := u.RemoveTask(accessmocks.GrantedContext(), 1, 2); err != nil {
+		t.Fatalf("sprintUsecase.RemoveTask() error = %v", err)
+	}
+	if !reflect.DeepEqual(repo.Members[1], []int{1}) {
+		t.Errorf("members = %v, want [1]", repo.Members[1])
+	}
+	if err := u.RemoveTask(accessmocks.GrantedContext(), 3, 2); err != core.ErrSprintClosed {
+		t.Errorf("sprintUsecase.RemoveTask() of a closed sprint error = %v", err)
+	}
+}
+
+func Test_sprintUsecase_Close(t *testing.T) {
+	tests := []struct {
+		name        string
+		carryTo     int
+		wantCarried []int
+		wantMembers map[int][]int
+		wantErr     error
+	}{
+		{name: "Normal Case 1: carried over to the next sprint", carryTo: 2, wantCarried: []int{2},
+			wantMembers: map[int][]int{1: {1, 2}, 2: {2}}},
+		{name: "back to the backlog", wantCarried: []int{2},
+			wantMembers: map[int][]int{1: {1, 2}}},
+		{name: "carried over to a closed sprint", carryTo: 3, wantMembers: map[int][]int{1: {1, 2}}, wantErr: core.ErrSprintClosed},
+		{name: "carried over to itself", carryTo: 1, wantMembers: map[int][]int{1: {1, 2}}, wantErr: core.ErrSprintClosed},
+		{name: "carried over to a missing sprint", carryTo: 42, wantMembers: map[int][]int{1: {1, 2}}, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, u := newFixture(models.RoleMember)
+			got, err := u.Close(accessmocks.GrantedContext(), 1, &models.SprintClose{CarryTo: tt.carryTo})
+			if err != tt.wantErr {
+				t.Fatalf("sprintUsecase.Close() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(repo.Members, tt.wantMembers) {
+				t.Errorf("members = %v, want %v", repo.Members, tt.wantMembers)
+			}
+			if err != nil {
+				if repo.Sprints[0].ClosedAt != nil {
+					t.Errorf("sprint closed")
+				}
+				return
+			}
+			if !reflect.DeepEqual(got.Carried, tt.wantCarried) || got.Sprint.ClosedAt == nil || !reflect.DeepEqual(got.Sprint.Tasks, []int{1, 2}) {
+				t.Errorf("sprintUsecase.Close() = %+v, %+v", got, got.Sprint)
+			}
+		})
+	}
+	_, u := newFixture(models.RoleMember)
+	if _, err := u.Close(accessmocks.GrantedContext(), 3, &models.SprintClose{}); err != core.ErrSprintClosed {
+		t.Errorf("sprintUsecase.Close() of a closed sprint error = %v", err)
+	}
+}
+
+func Test_sprintUsecase_Burndown(t *testing.T) {
+	repo, u := newFixture(models.RoleViewer)
+	repo.Sprints[0].EndDate = start.AddDate(0, 0, 4)
+	repo.Points = []*models.BurndownPoint{
+		{Day: start, Remaining: 8},
+		{Day: start.AddDate(0, 0, 1), Remaining: 8},
+		{Day: start.AddDate(0, 0, 2), Remaining: 5},
+	}
+	got, err := u.Burndown(accessmocks.GrantedContext(), 1)
+	if err != nil {
+		t.Fatalf("sprintUsecase.Burndown() error = %v", err)
+	}
+	want := []*models.BurndownPoint{
+		{Day: start, Remaining: 8, Ideal: 8},
+		{Day: start.AddDate(0, 0, 1), Remaining: 8, Ideal: 6},
+		{Day: start.AddDate(0, 0, 2), Remaining: 5, Ideal: 4},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sprintUsecase.Burndown() = %v, want %v", got, want)
+	}
+	if _, err := u.Burndown(accessmocks.GrantedContext(), 42); err != core.ErrRecordNotFound {
+		t.Errorf("sprintUsecase.Burndown() of a missing sprint error = %v", err)
+	}
+}
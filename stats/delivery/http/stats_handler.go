@@ -0,0 +1,125 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/stats"
+	"github.com/sirupsen/logrus"
+)
+
+// maxBuckets bounds the number of points of the series of a query
+const maxBuckets = 366
+
+// defaultDays is the length of the range when the query has no "from"
+const defaultDays = 30
+
+var errTooLong = errors.New("stats range too long")
+
+//StatsHandler represents http handler for the statistics
+type StatsHandler struct {
+	StatsUsecase stats.Usecase
+}
+
+// NewStatsHandler will initialize the statistics endpoint
+func NewStatsHandler(su stats.Usecase) nethttp.Handler {
+	r := chi.NewMux()
+	statsHandler := &StatsHandler{
+		StatsUsecase: su,
+	}
+	r.Get("/", statsHandler.Stats)
+	return r
+}
+
+// parseTime reads a time of the query string, either a day or a RFC 3339 time
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// bucketStart returns the start of the bucket of the interval holding t
+func bucketStart(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if interval == models.StatsByWeek {
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+	return day
+}
+
+// parseStats reads the stats query from the query string. The range runs up
+// to the end of today and over the last 30 days unless set, its start is moved
+// back to the start of its bucket
+func parseStats(r *nethttp.Request, now time.Time) (*models.StatsQuery, error) {
+	q := r.URL.Query()
+	sq := &models.StatsQuery{Interval: q.Get("interval"), Project: q.Get("project")}
+	if sq.Interval == "" {
+		sq.Interval = models.StatsByDay
+	}
+	var err error
+	sq.To = bucketStart(now, models.StatsByDay).AddDate(0, 0, 1)
+	if s := q.Get("to"); s != "" {
+		if sq.To, err = parseTime(s); err != nil {
+			return nil, err
+		}
+	}
+	sq.From = sq.To.AddDate(0, 0, -defaultDays)
+	if s := q.Get("from"); s != "" {
+		if sq.From, err = parseTime(s); err != nil {
+			return nil, err
+		}
+	}
+	sq.From = bucketStart(sq.From, sq.Interval)
+	validate := validator.New()
+	if err := validate.Struct(sq); err != nil {
+		return nil, err
+	}
+	step := 24 * time.Hour
+	if sq.Interval == models.StatsByWeek {
+		step *= 7
+	}
+	if sq.To.Sub(sq.From) > maxBuckets*step {
+		return nil, errTooLong
+	}
+	return sq, nil
+}
+
+//Stats handler returns the statistics of the tasks of the workspace, or of a
+//project: the tasks by status, and over the range from "from" to "to" by
+//"interval", day or week, the tasks created and completed, the average cycle
+//and lead times and the cumulative flow along with the burndown
+func (h *StatsHandler) Stats(w nethttp.ResponseWriter, r *nethttp.Request) {
+	q, err := parseStats(r, time.Now())
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("invalid stats query"))
+		return
+	}
+	s, err := h.StatsUsecase.Stats(r.Context(), q)
+	if err != nil {
+		if err == core.ErrForbidden {
+			w.WriteHeader(nethttp.StatusForbidden)
+			w.Write([]byte("forbidden"))
+			return
+		}
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(nethttp.StatusOK)
+	res, _ := json.Marshal(map[string]interface{}{
+		"message": "success",
+		"stats":   s,
+	})
+	w.Write(res)
+}
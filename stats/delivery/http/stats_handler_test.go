@@ -0,0 +1,112 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/stats/mocks"
+)
+
+func Test_bucketStart(t *testing.T) {
+	// 2026-10-07 is a Wednesday
+	at := time.Date(2026, 10, 7, 15, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	if got, want := bucketStart(at, models.StatsByDay), time.Date(2026, 10, 7, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("bucketStart() of the day = %v, want %v", got, want)
+	}
+	if got, want := bucketStart(at, models.StatsByWeek), time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("bucketStart() of the week = %v, want %v", got, want)
+	}
+	sunday := time.Date(2026, 10, 11, 23, 0, 0, 0, time.UTC)
+	if got, want := bucketStart(sunday, models.StatsByWeek), time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("bucketStart() of a sunday = %v, want %v", got, want)
+	}
+}
+
+func Test_parseStats(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		url     string
+		want    *models.StatsQuery
+		wantErr bool
+	}{{
+		name: "Normal Case 1: last 30 days",
+		url:  "/stats",
+		want: &models.StatsQuery{From: time.Date(2026, 9, 20, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), Interval: models.StatsByDay},
+	}, {
+		name: "weeks of a project",
+		url:  "/stats?from=2026-10-01&to=2026-11-01T00:00:00Z&interval=week&project=school",
+		want: &models.StatsQuery{From: time.Date(2026, 9, 28, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+			Interval: models.StatsByWeek, Project: "school"},
+	}, {
+		name:    "reversed range",
+		url:     "/stats?from=2026-11-01&to=2026-10-01",
+		wantErr: true,
+	}, {
+		name:    "unknown interval",
+		url:     "/stats?interval=month",
+		wantErr: true,
+	}, {
+		name:    "invalid time",
+		url:     "/stats?from=yesterday",
+		wantErr: true,
+	}, {
+		name:    "too many days",
+		url:     "/stats?from=2020-01-01&to=2026-01-01",
+		wantErr: true,
+	}, {
+		name: "years by week",
+		url:  "/stats?from=2020-01-06&to=2026-01-05&interval=week",
+		want: &models.StatsQuery{From: time.Date(2020, 1, 6, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Interval: models.StatsByWeek},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStats(httptest.NewRequest("GET", tt.url, nil), now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseStats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseStats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatsHandler_Stats(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		usecase    *mocks.MockUsecase
+		statusCode int
+	}{
+		{name: "Success case", url: "/?from=2026-10-01&to=2026-11-01", usecase: &mocks.MockUsecase{}, statusCode: 200},
+		{name: "invalid query", url: "/?interval=year", usecase: &mocks.MockUsecase{}, statusCode: 400},
+		{name: "forbidden", url: "/?project=school", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, statusCode: 403},
+		{name: "usecase error", url: "/", usecase: &mocks.MockUsecase{Error: errors.New("Usecase.Error()")}, statusCode: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewStatsHandler(tt.usecase)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if rec.Code != 200 {
+				return
+			}
+			res := struct {
+				Stats *models.Stats `json:"stats"`
+			}{}
+			if err := json.NewDecoder(rec.Body).Decode(&res); err != nil || res.Stats == nil {
+				t.Errorf("Test - %s , got stats %+v, %v", tt.name, res.Stats, err)
+			}
+		})
+	}
+}
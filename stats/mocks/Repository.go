@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockRepository implements inerface stats.Repository
+type MockRepository struct {
+	Error      error
+	Counts     []*models.StatusCount
+	Points     []*models.ThroughputPoint
+	Lead       *models.LeadTimes
+	FlowPoints []*models.FlowPoint
+	// Query is the query of the last call computing a series
+	Query *models.StatsQuery
+}
+
+//Statuses returns Counts
+func (m *MockRepository) Statuses(ctx context.Context, project string) ([]*models.StatusCount, error) {
+	return m.Counts, m.Error
+}
+
+//Throughput records the query and returns Points
+func (m *MockRepository) Throughput(ctx context.Context, q *models.StatsQuery) ([]*models.ThroughputPoint, error) {
+	m.Query = q
+	return m.Points, m.Error
+}
+
+//LeadTimes records the query and returns Lead
+func (m *MockRepository) LeadTimes(ctx context.Context, q *models.StatsQuery) (*models.LeadTimes, error) {
+	m.Query = q
+	return m.Lead, m.Error
+}
+
+//Flow records the query and returns FlowPoints
+func (m *MockRepository) Flow(ctx context.Context, q *models.StatsQuery) ([]*models.FlowPoint, error) {
+	m.Query = q
+	return m.FlowPoints, m.Error
+}
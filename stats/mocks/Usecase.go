@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockUsecase implements inerface stats.Usecase
+type MockUsecase struct {
+	Error  error
+	Result *models.Stats
+	// Query is the query of the last call to Stats
+	Query *models.StatsQuery
+}
+
+//Stats records the query and returns Result
+func (m *MockUsecase) Stats(ctx context.Context, q *models.StatsQuery) (*models.Stats, error) {
+	m.Query = q
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if m.Result == nil {
+		return &models.Stats{}, nil
+	}
+	return m.Result, nil
+}
@@ -0,0 +1,20 @@
+package stats
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Repository represents the statistics' interface, the tasks are the ones of the
+//project of the query, or of the whole workspace without one
+type Repository interface {
+	// Statuses counts the tasks by their current status
+	Statuses(ctx context.Context, project string) ([]*models.StatusCount, error)
+	// Throughput counts the tasks created and completed in every bucket of the range
+	Throughput(context.Context, *models.StatsQuery) ([]*models.ThroughputPoint, error)
+	// LeadTimes averages the cycle and lead times of the tasks completed within the range
+	LeadTimes(context.Context, *models.StatsQuery) (*models.LeadTimes, error)
+	// Flow counts the tasks by status at the end of every bucket of the range
+	Flow(context.Context, *models.StatsQuery) ([]*models.FlowPoint, error)
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/stats"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+// the history of the tasks tells their statuses over time, an event sets the
+// status of its task until the next one. A deleted task has the empty status.
+// Only the events writing the task count, (un)assigning it changes no status
+const (
+	statusEvents    = "e.type IN ('" + models.EventCreated + "', '" + models.EventUpdated + "', '" + models.EventDeleted + "')"
+	transitionQuery = "SELECT e.id_task, e.status, e.created_at, lag(e.status) OVER (PARTITION BY e.id_task ORDER BY e.created_at, e.id_event) AS previous " +
+		"FROM task_event e JOIN task t ON t.id_task = e.id_task WHERE " + statusEvents + " AND "
+	stateQuery = "SELECT e.status, e.created_at AS since, lead(e.created_at) OVER (PARTITION BY e.id_task ORDER BY e.created_at, e.id_event) AS until " +
+		"FROM task_event e JOIN task t ON t.id_task = e.id_task WHERE " + statusEvents + " AND "
+	// completion keeps the transitions moving a task to done
+	completion = "c.status = 'done' AND c.previous IS DISTINCT FROM 'done' AND c.created_at >= $2 AND c.created_at < $3"
+	// buckets are the starts of the buckets of [$2, $3), $4 long
+	buckets = "generate_series($2::timestamptz, $3::timestamptz - interval '1 microsecond', $4::interval) AS b(start)"
+)
+
+// steps are the lengths of the buckets of the intervals
+var steps = map[string]string{
+	models.StatsByDay:  "1 day",
+	models.StatsByWeek: "1 week",
+}
+
+type postgresStatsRepository struct {
+	*sql.DB
+}
+
+// NewPostgresStatsRepository will create an object that represent the stats.Repository interface
+func NewPostgresStatsRepository(db *sql.DB) stats.Repository {
+	return &postgresStatsRepository{db}
+}
+
+// scope returns the condition keeping the tasks t of the project, appending its argument to args
+func scope(project string, args ...interface{}) (string, []interface{}) {
+	where := "t.id_workspace = $1"
+	if project != "" {
+		args = append(args, project)
+		where += fmt.Sprintf(" AND t.project = $%d", len(args))
+	}
+	return where, args
+}
+
+// series returns the arguments of the queries computing a series over the buckets of q
+func series(workspace int, q *models.StatsQuery) (string, []interface{}, error) {
+	step, ok := steps[q.Interval]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown stats interval %q", q.Interval)
+	}
+	where, args := scope(q.Project, workspace, q.From, q.To, step)
+	return where, args, nil
+}
+
+func (p *postgresStatsRepository) Statuses(ctx context.Context, project string) ([]*models.StatusCount, error) {
+	counts := make([]*models.StatusCount, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return counts, err
+	}
+	where, args := scope(project, workspace)
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT t.status, count(*) FROM task t WHERE "+where+
+		" AND NOT t.deleted GROUP BY t.status ORDER BY t.status", args...)
+	if err != nil {
+		return counts, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		c := &models.StatusCount{}
+		if err := rows.Scan(&c.Status, &c.Count); err != nil {
+			return []*models.StatusCount{}, err
+		}
+		counts = append(counts, c)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.StatusCount{}, err
+	}
+	return counts, nil
+}
+
+func (p *postgresStatsRepository) Throughput(ctx context.Context, q *models.StatsQuery) ([]*models.ThroughputPoint, error) {
+	points := make([]*models.ThroughputPoint, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return points, err
+	}
+	where, args, err := series(workspace, q)
+	if err != nil {
+		return points, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "WITH happened AS ("+
+		"SELECT t.created_at AS at, 1 AS created, 0 AS completed FROM task t WHERE "+where+" AND t.created_at >= $2 AND t.created_at < $3 "+
+		"UNION ALL SELECT c.created_at, 0, 1 FROM ("+transitionQuery+where+") c WHERE "+completion+") "+
+		"SELECT b.start, COALESCE(sum(h.created), 0), COALESCE(sum(h.completed), 0) FROM "+buckets+
+		" LEFT JOIN happened h ON h.at >= b.start AND h.at < b.start + $4::interval GROUP BY b.start ORDER BY b.start", args...)
+	if err != nil {
+		return points, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		t := &models.ThroughputPoint{}
+		if err := rows.Scan(&t.Start, &t.Created, &t.Completed); err != nil {
+			return []*models.ThroughputPoint{}, err
+		}
+		points = append(points, t)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.ThroughputPoint{}, err
+	}
+	return points, nil
+}
+
+// LeadTimes starts the cycle of a task at its first event out of todo, the
+// completion itself when the task never went through another status
+func (p *postgresStatsRepository) LeadTimes(ctx context.Context, q *models.StatsQuery) (*models.LeadTimes, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where, args := scope(q.Project, workspace, q.From, q.To)
+	l := &models.LeadTimes{}
+	err = transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "WITH completed AS ("+
+		"SELECT c.id_task, max(c.created_at) AS done_at FROM ("+transitionQuery+where+") c WHERE "+completion+" GROUP BY c.id_task) "+
+		"SELECT count(*), COALESCE(avg(extract(epoch FROM d.done_at - s.started_at)), 0)::bigint, COALESCE(avg(extract(epoch FROM d.done_at - t.created_at)), 0)::bigint "+
+		"FROM completed d JOIN task t ON t.id_task = d.id_task "+
+		"CROSS JOIN LATERAL (SELECT min(e.created_at) AS started_at FROM task_event e WHERE e.id_task = d.id_task AND "+statusEvents+" AND e.status NOT IN ('todo', '')) s",
+		args...).Scan(&l.Completed, &l.CycleSeconds, &l.LeadSeconds)
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (p *postgresStatsRepository) Flow(ctx context.Context, q *models.StatsQuery) ([]*models.FlowPoint, error) {
+	points := make([]*models.FlowPoint, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return points, err
+	}
+	where, args, err := series(workspace, q)
+	if err != nil {
+		return points, err
+	}
+	// every bucket comes back, with the empty status when no task was around yet
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "WITH state AS ("+stateQuery+where+") "+
+		"SELECT b.start, COALESCE(s.status, ''), count(s.status) FROM "+buckets+
+		" LEFT JOIN state s ON s.status <> '' AND s.since < b.start + $4::interval AND (s.until IS NULL OR s.until >= b.start + $4::interval)"+
+		" GROUP BY b.start, s.status ORDER BY b.start, s.status", args...)
+	if err != nil {
+		return points, err
+	}
+	defer rows.Close()
+	var last *models.FlowPoint
+	for rows.Next() {
+		f := &models.FlowPoint{}
+		var status string
+		var n int
+		if err := rows.Scan(&f.Start, &status, &n); err != nil {
+			return []*models.FlowPoint{}, err
+		}
+		if last == nil || !last.Start.Equal(f.Start) {
+			f.Statuses = map[string]int{}
+			points = append(points, f)
+			last = f
+		}
+		if status == "" {
+			continue
+		}
+		last.Statuses[status] = n
+		if status != "done" {
+			last.Open += n
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.FlowPoint{}, err
+	}
+	return points, nil
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+var (
+	first  = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	second = first.AddDate(0, 0, 1)
+	third  = first.AddDate(0, 0, 2)
+)
+
+func Test_postgresStatsRepository_Statuses(t *testing.T) {
+	tests := []struct {
+		name    string
+		project string
+		query   string
+		args    []driver.Value
+		rows    *sqlmock.Rows
+		dbError error
+		want    []*models.StatusCount
+		wantErr bool
+	}{{
+		name:  "Normal Case 1: whole workspace",
+		query: "SELECT t.status, count(*) FROM task t WHERE t.id_workspace = $1 AND NOT t.deleted GROUP BY t.status ORDER BY t.status",
+		args:  []driver.Value{coremocks.Tenant},
+		rows:  sqlmock.NewRows([]string{"status", "count"}).AddRow("done", 4).AddRow("todo", 2),
+		want:  []*models.StatusCount{{Status: "done", Count: 4}, {Status: "todo", Count: 2}},
+	}, {
+		name:    "project",
+		project: "school",
+		query:   "SELECT t.status, count(*) FROM task t WHERE t.id_workspace = $1 AND t.project = $2 AND NOT t.deleted GROUP BY t.status ORDER BY t.status",
+		args:    []driver.Value{coremocks.Tenant, "school"},
+		rows:    sqlmock.NewRows([]string{"status", "count"}),
+		want:    []*models.StatusCount{},
+	}, {
+		name:    "db error",
+		query:   "SELECT t.status, count(*) FROM task t WHERE t.id_workspace = $1 AND NOT t.deleted GROUP BY t.status ORDER BY t.status",
+		args:    []driver.Value{coremocks.Tenant},
+		rows:    sqlmock.NewRows([]string{"status", "count"}),
+		dbError: errors.New("db error"),
+		want:    []*models.StatusCount{},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(tt.query)).WithArgs(tt.args...).WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			got, err := NewPostgresStatsRepository(db).Statuses(coremocks.TenantContext(), tt.project)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresStatsRepository_Throughput(t *testing.T) {
+	query := "WITH happened AS (SELECT t.created_at AS at, 1 AS created, 0 AS completed FROM task t WHERE t.id_workspace = $1 AND t.project = $5 " +
+		"AND t.created_at >= $2 AND t.created_at < $3 UNION ALL SELECT c.created_at, 0, 1 FROM (" +
+		"SELECT e.id_task, e.status, e.created_at, lag(e.status) OVER (PARTITION BY e.id_task ORDER BY e.created_at, e.id_event) AS previous " +
+		"FROM task_event e JOIN task t ON t.id_task = e.id_task WHERE e.type IN ('created', 'updated', 'deleted') AND t.id_workspace = $1 AND t.project = $5) c " +
+		"WHERE c.status = 'done' AND c.previous IS DISTINCT FROM 'done' AND c.created_at >= $2 AND c.created_at < $3) " +
+		"SELECT b.start, COALESCE(sum(h.created), 0), COALESCE(sum(h.completed), 0) " +
+		"FROM generate_series($2::timestamptz, $3::timestamptz - interval '1 microsecond', $4::interval) AS b(start) " +
+		"LEFT JOIN happened h ON h.at >= b.start AND h.at < b.start + $4::interval GROUP BY b.start ORDER BY b.start"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant, first, third, "1 day", "school").
+		WillReturnRows(sqlmock.NewRows([]string{"start", "created", "completed"}).AddRow(first, 3, 1).AddRow(second, 0, 2))
+	q := &models.StatsQuery{From: first, To: third, Interval: models.StatsByDay, Project: "school"}
+	got, err := NewPostgresStatsRepository(db).Throughput(coremocks.TenantContext(), q)
+	if err != nil {
+		t.Fatalf("Throughput() error = %v", err)
+	}
+	want := []*models.ThroughputPoint{{Start: first, Created: 3, Completed: 1}, {Start: second, Completed: 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Throughput() = %v, want %v", got, want)
+	}
+	if _, err := NewPostgresStatsRepository(db).Throughput(coremocks.TenantContext(), &models.StatsQuery{From: first, To: third, Interval: "month"}); err == nil {
+		t.Errorf("Throughput() of an unknown interval error = nil")
+	}
+}
+
+func Test_postgresStatsRepository_LeadTimes(t *testing.T) {
+	query := "WITH completed AS (SELECT c.id_task, max(c.created_at) AS done_at FROM (" +
+		"SELECT e.id_task, e.status, e.created_at, lag(e.status) OVER (PARTITION BY e.id_task ORDER BY e.created_at, e.id_event) AS previous " +
+		"FROM task_event e JOIN task t ON t.id_task = e.id_task WHERE e.type IN ('created', 'updated', 'deleted') AND t.id_workspace = $1) c " +
+		"WHERE c.status = 'done' AND c.previous IS DISTINCT FROM 'done' AND c.created_at >= $2 AND c.created_at < $3 GROUP BY c.id_task) " +
+		"SELECT count(*), COALESCE(avg(extract(epoch FROM d.done_at - s.started_at)), 0)::bigint, COALESCE(avg(extract(epoch FROM d.done_at - t.created_at)), 0)::bigint " +
+		"FROM completed d JOIN task t ON t.id_task = d.id_task " +
+		"CROSS JOIN LATERAL (SELECT min(e.created_at) AS started_at FROM task_event e WHERE e.id_task = d.id_task AND e.type IN ('created', 'updated', 'deleted') AND e.status NOT IN ('todo', '')) s"
+	tests := []struct {
+		name    string
+		dbError error
+		want    *models.LeadTimes
+		wantErr bool
+	}{
+		{name: "Normal Case 1: averages", want: &models.LeadTimes{Completed: 3, CycleSeconds: 7200, LeadSeconds: 86400}},
+		{name: "db error", dbError: errors.New("db error"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant, first, third).
+				WillReturnRows(sqlmock.NewRows([]string{"count", "cycle", "lead"}).AddRow(3, 7200, 86400)).WillReturnError(tt.dbError)
+			got, err := NewPostgresStatsRepository(db).LeadTimes(coremocks.TenantContext(), &models.StatsQuery{From: first, To: third, Interval: models.StatsByDay})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresStatsRepository_Flow(t *testing.T) {
+	query := "WITH state AS (SELECT e.status, e.created_at AS since, lead(e.created_at) OVER (PARTITION BY e.id_task ORDER BY e.created_at, e.id_event) AS until " +
+		"FROM task_event e JOIN task t ON t.id_task = e.id_task WHERE e.type IN ('created', 'updated', 'deleted') AND t.id_workspace = $1) " +
+		"SELECT b.start, COALESCE(s.status, ''), count(s.status) " +
+		"FROM generate_series($2::timestamptz, $3::timestamptz - interval '1 microsecond', $4::interval) AS b(start) " +
+		"LEFT JOIN state s ON s.status <> '' AND s.since < b.start + $4::interval AND (s.until IS NULL OR s.until >= b.start + $4::interval) " +
+		"GROUP BY b.start, s.status ORDER BY b.start, s.status"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	end := first.AddDate(0, 0, 21)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant, first, end, "1 week").
+		WillReturnRows(sqlmock.NewRows([]string{"start", "status", "count"}).
+			AddRow(first, "", 0).
+			AddRow(first.AddDate(0, 0, 7), "inprogress", 1).
+			AddRow(first.AddDate(0, 0, 7), "todo", 2).
+			AddRow(first.AddDate(0, 0, 14), "done", 2).
+			AddRow(first.AddDate(0, 0, 14), "todo", 1))
+	got, err := NewPostgresStatsRepository(db).Flow(coremocks.TenantContext(), &models.StatsQuery{From: first, To: end, Interval: models.StatsByWeek})
+	if err != nil {
+		t.Fatalf("Flow() error = %v", err)
+	}
+	want := []*models.FlowPoint{
+		{Start: first, Statuses: map[string]int{}},
+		{Start: first.AddDate(0, 0, 7), Statuses: map[string]int{"inprogress": 1, "todo": 2}, Open: 3},
+		{Start: first.AddDate(0, 0, 14), Statuses: map[string]int{"done": 2, "todo": 1}, Open: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Flow() = %v, want %v", got, want)
+	}
+}
+
+func Test_postgresStatsRepository_withoutTenant(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	ctx := context.Background()
+	p := NewPostgresStatsRepository(db)
+	q := &models.StatsQuery{From: first, To: third, Interval: models.StatsByDay}
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Statuses", func() error { _, err := p.Statuses(ctx, ""); return err }},
+		{"Throughput", func() error { _, err := p.Throughput(ctx, q); return err }},
+		{"LeadTimes", func() error { _, err := p.LeadTimes(ctx, q); return err }},
+		{"Flow", func() error { _, err := p.Flow(ctx, q); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != core.ErrNoTenant {
+				t.Errorf("expected %v, got %v", core.ErrNoTenant, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package stats
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Usecase represents the statistics' usecases
+type Usecase interface {
+	// Stats computes the statistics of the tasks over the range of the query
+	Stats(context.Context, *models.StatsQuery) (*models.Stats, error)
+}
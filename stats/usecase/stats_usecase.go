@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/stats"
+)
+
+type statsUsecase struct {
+	statsRepo     stats.Repository
+	accessUsecase access.Usecase
+}
+
+// NewStatsUsecase will create new a statsUsecase object representation of stats.Usecase interface
+func NewStatsUsecase(sr stats.Repository, au access.Usecase) stats.Usecase {
+	return &statsUsecase{
+		statsRepo:     sr,
+		accessUsecase: au,
+	}
+}
+
+// Stats needs to read the project of the query, or the whole workspace without one
+func (su *statsUsecase) Stats(ctx context.Context, q *models.StatsQuery) (*models.Stats, error) {
+	p, err := su.accessUsecase.Policy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Can(access.ActionRead, q.Project, 0) {
+		return nil, core.ErrForbidden
+	}
+	s := &models.Stats{}
+	if s.Statuses, err = su.statsRepo.Statuses(ctx, q.Project); err != nil {
+		return nil, err
+	}
+	if s.Throughput, err = su.statsRepo.Throughput(ctx, q); err != nil {
+		return nil, err
+	}
+	if s.LeadTimes, err = su.statsRepo.LeadTimes(ctx, q); err != nil {
+		return nil, err
+	}
+	if s.Flow, err = su.statsRepo.Flow(ctx, q); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	accessmocks "github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/stats/mocks"
+)
+
+func TestNewStatsUsecase(t *testing.T) {
+	sr := &mocks.MockRepository{}
+	au := &accessmocks.MockUsecase{}
+	want := &statsUsecase{statsRepo: sr, accessUsecase: au}
+	if got := NewStatsUsecase(sr, au); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewStatsUsecase() = %v, want %v", got, want)
+	}
+}
+
+func Test_statsUsecase_Stats(t *testing.T) {
+	first := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	repo := func() *mocks.MockRepository {
+		return &mocks.MockRepository{
+			Counts:     []*models.StatusCount{{Status: "todo", Count: 2}},
+			Points:     []*models.ThroughputPoint{{Start: first, Created: 2}},
+			Lead:       &models.LeadTimes{},
+			FlowPoints: []*models.FlowPoint{{Start: first, Statuses: map[string]int{"todo": 2}, Open: 2}},
+		}
+	}
+	want := &models.Stats{
+		Statuses:   []*models.StatusCount{{Status: "todo", Count: 2}},
+		Throughput: []*models.ThroughputPoint{{Start: first, Created: 2}},
+		LeadTimes:  &models.LeadTimes{},
+		Flow:       []*models.FlowPoint{{Start: first, Statuses: map[string]int{"todo": 2}, Open: 2}},
+	}
+	tests := []struct {
+		name    string
+		grant   *models.Grant
+		project string
+		dbError error
+		want    *models.Stats
+		wantErr error
+	}{
+		{name: "Normal Case 1: workspace viewer", grant: &models.Grant{UserID: 3, Role: models.RoleViewer}, want: want},
+		{name: "project viewer reads the project", grant: &models.Grant{UserID: 3, Role: models.RoleViewer, Project: "school"}, project: "school", want: want},
+		{name: "project viewer can not read the workspace", grant: &models.Grant{UserID: 3, Role: models.RoleViewer, Project: "school"}, wantErr: core.ErrForbidden},
+		{name: "db error", grant: &models.Grant{UserID: 3, Role: models.RoleViewer}, dbError: errors.New("db error"), wantErr: errors.New("db error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := repo()
+			sr.Error = tt.dbError
+			u := NewStatsUsecase(sr, &accessmocks.MockUsecase{Grants: []*models.Grant{tt.grant}})
+			got, err := u.Stats(accessmocks.GrantedContext(), &models.StatsQuery{From: first, To: first.AddDate(0, 0, 1), Interval: models.StatsByDay, Project: tt.project})
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("statsUsecase.Stats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("statsUsecase.Stats() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+//Assign handler adds users to the assignees of a task
+func (h *TaskHandler) Assign(w nethttp.ResponseWriter, r *nethttp.Request) {
+	h.changeAssignees(w, r, h.TaskUsecase.Assign)
+}
+
+//Unassign handler removes users from the assignees of a task
+func (h *TaskHandler) Unassign(w nethttp.ResponseWriter, r *nethttp.Request) {
+	h.changeAssignees(w, r, h.TaskUsecase.Unassign)
+}
+
+func (h *TaskHandler) changeAssignees(w nethttp.ResponseWriter, r *nethttp.Request,
+	change func(context.Context, int, []int) (*models.Task, error)) {
+	id, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	req := &models.AssigneeChange{}
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(req)
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return
+	}
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return
+	}
+	task, err := change(r.Context(), id, req.UserIDs)
+	if err != nil {
+		switch err {
+		case core.ErrForbidden:
+			writeForbidden(w)
+		case core.ErrRecordNotFound:
+			w.WriteHeader(nethttp.StatusNotFound)
+			w.Write([]byte("task not found"))
+		case core.ErrNotMember:
+			w.WriteHeader(nethttp.StatusUnprocessableEntity)
+			w.Write([]byte("user is not a member of the workspace"))
+		default:
+			logrus.Error(err)
+			w.WriteHeader(nethttp.StatusInternalServerError)
+			w.Write([]byte("internal server error"))
+		}
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	res, _ := json.Marshal(map[string]interface{}{
+		"message": "success",
+		"task":    task,
+	})
+	w.Write(res)
+}
+
+//MyTasks handler lists the tasks assigned to the authenticated user,
+//it takes the filters of the list handler
+func (h *TaskHandler) MyTasks(w nethttp.ResponseWriter, r *nethttp.Request) {
+	u, ok := core.UserFromContext(r.Context())
+	if !ok {
+		writeForbidden(w)
+		return
+	}
+	filter, err := parseFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	filter.Assignee = u.ID
+	tasks, err := h.TaskUsecase.List(r.Context(), filter)
+	if err != nil {
+		if err == core.ErrForbidden {
+			writeForbidden(w)
+			return
+		}
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	res, _ := json.Marshal(map[string]interface{}{
+		"message": "success",
+		"tasks":   tasks,
+	})
+	w.Write(res)
+}
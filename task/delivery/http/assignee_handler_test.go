@@ -0,0 +1,155 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+func TestTaskHandler_Assign(t *testing.T) {
+	assigned := []*models.Task{{ID: 1, Title: "Take math notes", Status: "todo", Assignees: []int{3}}}
+	tests := []struct {
+		name       string
+		usecase    task.Usecase
+		body       string
+		statusCode int
+	}{{
+		name:       "Normal Case1: assign a user",
+		usecase:    &mocks.MockUsecase{Tasks: assigned},
+		body:       `{"id_users":[3]}`,
+		statusCode: 200,
+	}, {
+		name:       "invalid body",
+		usecase:    &mocks.MockUsecase{Tasks: assigned},
+		body:       `{"id_users":`,
+		statusCode: 400,
+	}, {
+		name:       "no user",
+		usecase:    &mocks.MockUsecase{Tasks: assigned},
+		body:       `{"id_users":[]}`,
+		statusCode: 400,
+	}, {
+		name:       "invalid user",
+		usecase:    &mocks.MockUsecase{Tasks: assigned},
+		body:       `{"id_users":[0]}`,
+		statusCode: 400,
+	}, {
+		name:       "task not found",
+		usecase:    &mocks.MockUsecase{Error: core.ErrRecordNotFound},
+		body:       `{"id_users":[3]}`,
+		statusCode: 404,
+	}, {
+		name:       "user out of the workspace",
+		usecase:    &mocks.MockUsecase{Error: core.ErrNotMember},
+		body:       `{"id_users":[3]}`,
+		statusCode: 422,
+	}, {
+		name:       "forbidden",
+		usecase:    &mocks.MockUsecase{Error: core.ErrForbidden},
+		body:       `{"id_users":[3]}`,
+		statusCode: 403,
+	}, {
+		name:       "db error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("db error")},
+		body:       `{"id_users":[3]}`,
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		for _, method := range []string{"POST", "DELETE"} {
+			t.Run(tt.name+" "+method, func(t *testing.T) {
+				h := &TaskHandler{TaskUsecase: tt.usecase}
+				req := httptest.NewRequest(method, "/task/1/assignees", bytes.NewBufferString(tt.body))
+				ctx := chi.NewRouteContext()
+				ctx.URLParams.Add("id", "1")
+				req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+				rec := httptest.NewRecorder()
+				if method == "POST" {
+					h.Assign(rec, req)
+				} else {
+					h.Unassign(rec, req)
+				}
+				if rec.Code != tt.statusCode {
+					t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+				}
+				if rec.Code != 200 {
+					return
+				}
+				var got struct {
+					Task *models.Task `json:"task"`
+				}
+				if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+					t.Fatalf("can not decode response: %v", err)
+				}
+				if got.Task == nil || !reflect.DeepEqual(got.Task.Assignees, []int{3}) {
+					t.Errorf("expected the task with its assignees, got %+v", got.Task)
+				}
+			})
+		}
+	}
+}
+
+func TestTaskHandler_MyTasks(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    *mocks.MockUsecase
+		user       *models.User
+		target     string
+		statusCode int
+	}{{
+		name:       "Normal Case1: tasks of the user",
+		usecase:    &mocks.MockUsecase{Tasks: []*models.Task{{ID: 1, Assignees: []int{3}}}},
+		user:       &models.User{ID: 3},
+		target:     "/me/tasks?status=todo",
+		statusCode: 200,
+	}, {
+		name:       "invalid filter",
+		usecase:    &mocks.MockUsecase{},
+		user:       &models.User{ID: 3},
+		target:     "/me/tasks?status=later",
+		statusCode: 400,
+	}, {
+		name:       "no user",
+		usecase:    &mocks.MockUsecase{},
+		target:     "/me/tasks",
+		statusCode: 403,
+	}, {
+		name:       "forbidden",
+		usecase:    &mocks.MockUsecase{Error: core.ErrForbidden},
+		user:       &models.User{ID: 3},
+		target:     "/me/tasks",
+		statusCode: 403,
+	}, {
+		name:       "db error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("db error")},
+		user:       &models.User{ID: 3},
+		target:     "/me/tasks",
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TaskHandler{TaskUsecase: tt.usecase}
+			req := httptest.NewRequest("GET", tt.target, nil)
+			if tt.user != nil {
+				req = req.WithContext(core.WithUser(req.Context(), tt.user))
+			}
+			rec := httptest.NewRecorder()
+			h.MyTasks(rec, req)
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if rec.Code == 200 && (tt.usecase.Filter.Assignee != tt.user.ID || tt.usecase.Filter.Status != "todo") {
+				t.Errorf("Test - %s , listed with %+v", tt.name, tt.usecase.Filter)
+			}
+		})
+	}
+}
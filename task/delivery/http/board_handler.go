@@ -0,0 +1,37 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/sirupsen/logrus"
+)
+
+//Board handler returns the tasks of the workspace grouped by status in the manual
+//order, with the count and the WIP limit of every column
+func (h *TaskHandler) Board(w nethttp.ResponseWriter, r *nethttp.Request) {
+	board, err := h.TaskUsecase.Board(r.Context())
+	if err != nil {
+		if err == core.ErrForbidden {
+			writeForbidden(w)
+			return
+		}
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	res, _ := json.Marshal(map[string]interface{}{
+		"message": "success",
+		"board":   board,
+	})
+	w.Write(res)
+}
+
+// writeWIPLimit answers 422, the column of the status of the task is full
+func writeWIPLimit(w nethttp.ResponseWriter) {
+	w.WriteHeader(nethttp.StatusUnprocessableEntity)
+	w.Write([]byte("wip limit reached"))
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+func TestTaskHandler_Board(t *testing.T) {
+	board := &models.Board{Columns: []*models.BoardColumn{
+		{Status: "todo", Count: 1, Tasks: []*models.Task{{ID: 2, Title: "Read chapter 3", Status: "todo"}}},
+		{Status: "inprogress", Count: 2, Limit: 1, Exceeded: true, Tasks: []*models.Task{
+			{ID: 1, Title: "Take math notes", Status: "inprogress"}, {ID: 3, Title: "Solve exercises", Status: "inprogress"}}},
+		{Status: "done", Tasks: []*models.Task{}},
+	}}
+	tests := []struct {
+		name       string
+		usecase    task.Usecase
+		statusCode int
+	}{
+		{name: "Normal Case1: board", usecase: &mocks.MockUsecase{TaskBoard: board}, statusCode: 200},
+		{name: "forbidden", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, statusCode: 403},
+		{name: "db error", usecase: &mocks.MockUsecase{Error: errors.New("db error")}, statusCode: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TaskHandler{TaskUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Board(rec, httptest.NewRequest("GET", "/board", nil))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if rec.Code != 200 {
+				return
+			}
+			var got struct {
+				Board *models.Board `json:"board"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("Test - %s , can not decode body: %v", tt.name, err)
+			}
+			if !reflect.DeepEqual(got.Board, board) {
+				t.Errorf("Test - %s , got board %v but expected %v", tt.name, got.Board, board)
+			}
+		})
+	}
+}
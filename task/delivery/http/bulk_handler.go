@@ -0,0 +1,62 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+//Bulk handler applies many operations in one request. A rolled back
+//atomic request answers 422 with the result of every operation
+func (h *TaskHandler) Bulk(w nethttp.ResponseWriter, r *nethttp.Request) {
+	req := &models.BulkRequest{}
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(req)
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return
+	}
+	if req.Mode == "" {
+		req.Mode = models.BulkAtomic
+	}
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return
+	}
+	resp, err := h.TaskUsecase.Bulk(r.Context(), req)
+	if err != nil {
+		if err == core.ErrForbidden {
+			writeForbidden(w)
+			return
+		}
+		if err == core.ErrWIPLimit {
+			writeWIPLimit(w)
+			return
+		}
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+		return
+	}
+	message := "success"
+	status := nethttp.StatusOK
+	if !resp.Committed {
+		message = "failure"
+		status = nethttp.StatusUnprocessableEntity
+	}
+	w.WriteHeader(status)
+	res, _ := json.Marshal(map[string]interface{}{
+		"message":   message,
+		"committed": resp.Committed,
+		"results":   resp.Results,
+	})
+	w.Write(res)
+}
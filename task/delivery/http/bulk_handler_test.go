@@ -0,0 +1,64 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+func TestTaskHandler_Bulk(t *testing.T) {
+	body := `{"mode":"atomic","operations":[{"op":"delete","id_task":1}]}`
+	tests := []struct {
+		name       string
+		usecase    task.Usecase
+		body       string
+		statusCode int
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{BulkResponse: &models.BulkResponse{Committed: true}},
+		body:       body,
+		statusCode: 200,
+	}, {
+		name:       "rolled back",
+		usecase:    &mocks.MockUsecase{BulkResponse: &models.BulkResponse{}},
+		body:       body,
+		statusCode: 422,
+	}, {
+		name:       "unknown mode",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{"mode":"sometimes","operations":[{"op":"delete","id_task":1}]}`,
+		statusCode: 400,
+	}, {
+		name:       "no operations",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{"operations":[]}`,
+		statusCode: 400,
+	}, {
+		name:       "body parse error",
+		usecase:    &mocks.MockUsecase{},
+		body:       `[]`,
+		statusCode: 400,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("Usecase.Error()")},
+		body:       body,
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TaskHandler{TaskUsecase: tt.usecase}
+			req := httptest.NewRequest("POST", "/tasks/bulk", bytes.NewBufferString(tt.body))
+			rec := httptest.NewRecorder()
+			h.Bulk(rec, req)
+			if res := rec.Result(); res.StatusCode != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d",
+					tt.name, res.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
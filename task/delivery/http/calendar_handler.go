@@ -0,0 +1,155 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	nethttp "net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+// feedToken signs the workspace, the user and the filter of a calendar feed along with the
+// feed key of the user, a token only opens the feed it was issued for. A new feed key revokes
+// the tokens of the user, changing the secret revokes every token
+func feedToken(secret []byte, key string, workspace, user int, filter *models.TaskFilter) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(key + "\x00" + strconv.Itoa(workspace) + "\x00" + strconv.Itoa(user) + "\x00" + filter.Status + "\x00" + filter.Project + "\x00" + filter.Tag + "\x00" + filter.Query))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// feedQuery keeps the tasks of query that have a due date, the others can not be placed on a calendar
+func feedQuery(query string) string {
+	if query == "" {
+		return "due!=none"
+	}
+	return "(" + query + ") AND due!=none"
+}
+
+// feedName names the calendar after its filter
+func feedName(filter *models.TaskFilter) string {
+	name := "Tasks"
+	if filter.Project != "" {
+		name += " - " + filter.Project
+	}
+	if filter.Tag != "" {
+		name += " #" + filter.Tag
+	}
+	return name
+}
+
+func writeInternalError(w nethttp.ResponseWriter) {
+	w.WriteHeader(nethttp.StatusInternalServerError)
+	w.Write([]byte("internal server error"))
+}
+
+//CalendarURL handler returns the subscription url of the calendar feed
+//of the tasks matching the list filters
+func (h *TaskHandler) CalendarURL(w nethttp.ResponseWriter, r *nethttp.Request) {
+	h.calendarURL(w, r, false)
+}
+
+//RotateCalendar handler gives the user a new feed key, which revokes the calendar
+//feed urls issued so far, and returns the subscription url of the list filters
+func (h *TaskHandler) RotateCalendar(w nethttp.ResponseWriter, r *nethttp.Request) {
+	h.calendarURL(w, r, true)
+}
+
+// calendarURL answers the feed url of the filters of r, signed with a new feed key
+// when rotate is set or the user has none yet
+func (h *TaskHandler) calendarURL(w nethttp.ResponseWriter, r *nethttp.Request, rotate bool) {
+	filter, err := parseFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	workspace, err := core.TenantFromContext(r.Context())
+	u, ok := core.UserFromContext(r.Context())
+	if err != nil || !ok {
+		logrus.Error("calendar url requested without workspace or user")
+		writeInternalError(w)
+		return
+	}
+	key := ""
+	if !rotate {
+		if key, err = h.UserUsecase.FeedKey(r.Context(), u.ID); err != nil {
+			logrus.Error(err)
+			writeInternalError(w)
+			return
+		}
+	}
+	if key == "" {
+		if key, err = h.UserUsecase.RotateFeedKey(r.Context()); err != nil {
+			logrus.Error(err)
+			writeInternalError(w)
+			return
+		}
+	}
+	q := url.Values{}
+	for key, value := range map[string]string{"status": filter.Status, "project": filter.Project, "tag": filter.Tag,
+		"q": filter.Query} {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	q.Set("workspace", strconv.Itoa(workspace))
+	q.Set("user", strconv.Itoa(u.ID))
+	q.Set("token", feedToken(h.FeedSecret, key, workspace, u.ID, filter))
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	feed := url.URL{Scheme: scheme, Host: r.Host, Path: "/calendar.ics", RawQuery: q.Encode()}
+	w.WriteHeader(nethttp.StatusOK)
+	res, _ := json.Marshal(map[string]interface{}{
+		"message": "success",
+		"url":     feed.String(),
+	})
+	w.Write(res)
+}
+
+//Calendar handler streams the tasks with a due date matching the list filters as an
+//iCalendar feed of VTODO entries. The token is checked against the workspace, the user,
+//the filters and the feed key of the user, it takes the place of an access token.
+//The feed follows the role of the user
+func (h *TaskHandler) Calendar(w nethttp.ResponseWriter, r *nethttp.Request) {
+	filter, err := parseFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	workspace, _ := strconv.Atoi(r.URL.Query().Get("workspace"))
+	user, _ := strconv.Atoi(r.URL.Query().Get("user"))
+	token := r.URL.Query().Get("token")
+	if workspace == 0 || user == 0 {
+		writeInvalidToken(w)
+		return
+	}
+	key, err := h.UserUsecase.FeedKey(r.Context(), user)
+	if err != nil && err != core.ErrRecordNotFound {
+		logrus.Error(err)
+		writeInternalError(w)
+		return
+	}
+	if key == "" || !hmac.Equal([]byte(token), []byte(feedToken(h.FeedSecret, key, workspace, user, filter))) {
+		writeInvalidToken(w)
+		return
+	}
+	filter.Query = feedQuery(filter.Query)
+	r = r.WithContext(core.WithTenant(core.WithUser(r.Context(), &models.User{ID: user}), workspace))
+	h.streamTasks(w, r, filter, &icsTaskWriter{w: w, name: feedName(filter)}, func() {
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Content-Disposition", `inline; filename="tasks.ics"`)
+		w.WriteHeader(nethttp.StatusOK)
+	})
+}
+
+func writeInvalidToken(w nethttp.ResponseWriter) {
+	w.WriteHeader(nethttp.StatusForbidden)
+	w.Write([]byte("invalid token"))
+}
@@ -0,0 +1,207 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+	usermocks "github.com/pratheeshm/todo-golang/user/mocks"
+)
+
+func TestTaskHandler_Calendar(t *testing.T) {
+	secret := []byte("secret")
+	token := feedToken(secret, "key", 1, 3, &models.TaskFilter{Project: "school"})
+	tasks := []*models.Task{{ID: 1, Title: "Take math notes", Status: "todo", Project: "school"}}
+	tests := []struct {
+		name       string
+		usecase    task.Usecase
+		users      *usermocks.MockUsecase
+		url        string
+		statusCode int
+		contains   string
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		url:        "/calendar.ics?workspace=1&user=3&project=school&token=" + token,
+		statusCode: 200,
+		contains:   "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+	}, {
+		name:       "empty calendar",
+		usecase:    &mocks.MockUsecase{},
+		url:        "/calendar.ics?workspace=1&user=3&project=school&token=" + token,
+		statusCode: 200,
+		contains:   "X-WR-CALNAME:Tasks - school\r\nEND:VCALENDAR\r\n",
+	}, {
+		name:       "token of another filter",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		url:        "/calendar.ics?workspace=1&user=3&project=work&token=" + token,
+		statusCode: 403,
+	}, {
+		name:       "token of another workspace",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		url:        "/calendar.ics?workspace=2&user=3&project=school&token=" + token,
+		statusCode: 403,
+	}, {
+		name:       "missing workspace",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		url:        "/calendar.ics?user=3&project=school&token=" + token,
+		statusCode: 403,
+	}, {
+		name:       "token of another user",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		url:        "/calendar.ics?workspace=1&user=4&project=school&token=" + token,
+		statusCode: 403,
+	}, {
+		name:       "missing user",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		url:        "/calendar.ics?workspace=1&project=school&token=" + token,
+		statusCode: 403,
+	}, {
+		name:       "feed key rotated",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		users:      &usermocks.MockUsecase{Feed: "new key"},
+		url:        "/calendar.ics?workspace=1&user=3&project=school&token=" + token,
+		statusCode: 403,
+	}, {
+		name:       "user without feed key",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		users:      &usermocks.MockUsecase{},
+		url:        "/calendar.ics?workspace=1&user=3&project=school&token=" + token,
+		statusCode: 403,
+	}, {
+		name:       "unknown user",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		users:      &usermocks.MockUsecase{Error: core.ErrRecordNotFound},
+		url:        "/calendar.ics?workspace=1&user=3&project=school&token=" + token,
+		statusCode: 403,
+	}, {
+		name:       "feed key error",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		users:      &usermocks.MockUsecase{Error: errors.New("Usecase.Error()")},
+		url:        "/calendar.ics?workspace=1&user=3&project=school&token=" + token,
+		statusCode: 500,
+	}, {
+		name:       "role does not allow reading",
+		usecase:    &mocks.MockUsecase{Error: core.ErrForbidden},
+		url:        "/calendar.ics?workspace=1&user=3&project=school&token=" + token,
+		statusCode: 403,
+	}, {
+		name:       "missing token",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		url:        "/calendar.ics?project=school",
+		statusCode: 403,
+	}, {
+		name:       "invalid filter",
+		usecase:    &mocks.MockUsecase{},
+		url:        "/calendar.ics?workspace=1&user=3&status=later&token=" + token,
+		statusCode: 400,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("Usecase.Error()")},
+		url:        "/calendar.ics?workspace=1&user=3&project=school&token=" + token,
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.users == nil {
+				tt.users = &usermocks.MockUsecase{Feed: "key"}
+			}
+			h := &TaskHandler{TaskUsecase: tt.usecase, FeedSecret: secret, UserUsecase: tt.users}
+			req := httptest.NewRequest("GET", tt.url, nil)
+			rec := httptest.NewRecorder()
+			h.Calendar(rec, req)
+			res := rec.Result()
+			if res.StatusCode != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d",
+					tt.name, res.StatusCode, tt.statusCode)
+			}
+			if !strings.Contains(rec.Body.String(), tt.contains) {
+				t.Errorf("Test - %s , got body %q, expected it to contain %q", tt.name, rec.Body.String(), tt.contains)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_Calendar_dueOnly(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "Normal Case 1: no query", want: "due!=none"},
+		{name: "query", query: "tag:bug OR tag:math", want: "(tag:bug OR tag:math) AND due!=none"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := &models.TaskFilter{Query: tt.query}
+			u := &mocks.MockUsecase{}
+			h := &TaskHandler{TaskUsecase: u, FeedSecret: []byte("secret"), UserUsecase: &usermocks.MockUsecase{Feed: "key"}}
+			q := url.Values{"workspace": {"1"}, "user": {"3"}, "q": {tt.query}, "token": {feedToken(h.FeedSecret, "key", 1, 3, filter)}}
+			rec := httptest.NewRecorder()
+			h.Calendar(rec, httptest.NewRequest("GET", "/calendar.ics?"+q.Encode(), nil))
+			if rec.Code != 200 {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, 200)
+			}
+			if u.Filter == nil || u.Filter.Query != tt.want {
+				t.Errorf("Test - %s , got filter %+v, expected the query %q", tt.name, u.Filter, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_RotateCalendar(t *testing.T) {
+	users := &usermocks.MockUsecase{Feed: "key"}
+	h := &TaskHandler{TaskUsecase: &mocks.MockUsecase{}, FeedSecret: []byte("secret"), UserUsecase: users}
+	urls := []string{}
+	for _, handle := range []nethttp.HandlerFunc{h.CalendarURL, h.RotateCalendar} {
+		req := httptest.NewRequest("GET", "/calendar/url?project=school", nil)
+		req = req.WithContext(core.WithTenant(core.WithUser(req.Context(), &models.User{ID: 3}), 1))
+		rec := httptest.NewRecorder()
+		handle(rec, req)
+		res := map[string]string{}
+		if err := json.NewDecoder(rec.Body).Decode(&res); err != nil || rec.Code != 200 {
+			t.Fatalf("got statuscode %d but expected 200", rec.Code)
+		}
+		urls = append(urls, res["url"])
+	}
+	for i, want := range []int{403, 200} {
+		rec := httptest.NewRecorder()
+		h.Calendar(rec, httptest.NewRequest("GET", urls[i], nil))
+		if rec.Code != want {
+			t.Errorf("feed url %d answered %d but expected %d", i, rec.Code, want)
+		}
+	}
+}
+
+func TestTaskHandler_CalendarURL(t *testing.T) {
+	h := &TaskHandler{TaskUsecase: &mocks.MockUsecase{}, FeedSecret: []byte("secret"), UserUsecase: &usermocks.MockUsecase{}}
+	req := httptest.NewRequest("GET", "http://todo.example/calendar/url?project=school&tag=math", nil)
+	req = req.WithContext(core.WithTenant(core.WithUser(req.Context(), &models.User{ID: 3}), 1))
+	rec := httptest.NewRecorder()
+	h.CalendarURL(rec, req)
+	if rec.Code != 200 {
+		t.Fatalf("got statuscode %d but expected 200", rec.Code)
+	}
+	res := map[string]string{}
+	if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+		t.Fatalf("Can not decode body: %v", err)
+	}
+	feed := httptest.NewRequest("GET", res["url"], nil)
+	if feed.Host != "todo.example" || feed.URL.Path != "/calendar.ics" || feed.URL.Query().Get("workspace") != "1" ||
+		feed.URL.Query().Get("user") != "3" {
+		t.Errorf("unexpected feed url %s", res["url"])
+	}
+	rec = httptest.NewRecorder()
+	h.Calendar(rec, feed)
+	if rec.Code != 200 {
+		t.Errorf("feed url answered %d but expected 200", rec.Code)
+	}
+}
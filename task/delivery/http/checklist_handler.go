@@ -0,0 +1,109 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+// itemIDs returns the ids of the task and of the checklist item of the url
+func itemIDs(r *nethttp.Request) (int, int) {
+	taskID, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	id, _ := strconv.Atoi(chi.URLParam(r, "item"))
+	return taskID, id
+}
+
+// writeTask answers the task, or the error of the call that returned it
+func writeTask(w nethttp.ResponseWriter, status int, task *models.Task, err error) {
+	if err != nil {
+		switch err {
+		case core.ErrForbidden:
+			writeForbidden(w)
+		case core.ErrRecordNotFound:
+			w.WriteHeader(nethttp.StatusNotFound)
+			w.Write([]byte("not found"))
+		default:
+			logrus.Error(err)
+			w.WriteHeader(nethttp.StatusInternalServerError)
+			w.Write([]byte("internal server error"))
+		}
+		return
+	}
+	w.WriteHeader(status)
+	res, _ := json.Marshal(map[string]interface{}{
+		"message": "success",
+		"task":    task,
+	})
+	w.Write(res)
+}
+
+//Get handler returns a task with its checklist
+func (h *TaskHandler) Get(w nethttp.ResponseWriter, r *nethttp.Request) {
+	id, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	task, err := h.TaskUsecase.Get(r.Context(), id)
+	writeTask(w, nethttp.StatusOK, task, err)
+}
+
+//AddChecklistItem handler appends an item to the checklist of a task
+func (h *TaskHandler) AddChecklistItem(w nethttp.ResponseWriter, r *nethttp.Request) {
+	item := &models.ChecklistItem{}
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(item)
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return
+	}
+	validate := validator.New()
+	err = validate.Struct(item)
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return
+	}
+	item.TaskID, _ = itemIDs(r)
+	task, err := h.TaskUsecase.AddChecklistItem(r.Context(), item)
+	writeTask(w, nethttp.StatusCreated, task, err)
+}
+
+//ToggleChecklistItem handler checks or unchecks an item of the checklist of a task
+func (h *TaskHandler) ToggleChecklistItem(w nethttp.ResponseWriter, r *nethttp.Request) {
+	taskID, id := itemIDs(r)
+	task, err := h.TaskUsecase.ToggleChecklistItem(r.Context(), taskID, id)
+	writeTask(w, nethttp.StatusOK, task, err)
+}
+
+//MoveChecklistItem handler puts an item of the checklist of a task at another position
+func (h *TaskHandler) MoveChecklistItem(w nethttp.ResponseWriter, r *nethttp.Request) {
+	move := &models.ChecklistMove{}
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(move)
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return
+	}
+	validate := validator.New()
+	err = validate.Struct(move)
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return
+	}
+	taskID, id := itemIDs(r)
+	task, err := h.TaskUsecase.MoveChecklistItem(r.Context(), taskID, id, move.Position)
+	writeTask(w, nethttp.StatusOK, task, err)
+}
+
+//DeleteChecklistItem handler deletes an item of the checklist of a task
+func (h *TaskHandler) DeleteChecklistItem(w nethttp.ResponseWriter, r *nethttp.Request) {
+	taskID, id := itemIDs(r)
+	task, err := h.TaskUsecase.DeleteChecklistItem(r.Context(), taskID, id)
+	writeTask(w, nethttp.StatusOK, task, err)
+}
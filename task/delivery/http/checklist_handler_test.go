@@ -0,0 +1,125 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+func TestTaskHandler_Checklist(t *testing.T) {
+	tasks := []*models.Task{{ID: 1, Title: "Take math notes", Status: "todo",
+		Checklist: []*models.ChecklistItem{{ID: 2, TaskID: 1, Text: "Read chapter 3"}}}}
+	tests := []struct {
+		name       string
+		usecase    task.Usecase
+		handler    func(*TaskHandler) nethttp.HandlerFunc
+		body       string
+		statusCode int
+	}{{
+		name:       "Normal Case1: get a task",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.Get },
+		statusCode: 200,
+	}, {
+		name:       "get a missing task",
+		usecase:    &mocks.MockUsecase{Error: core.ErrRecordNotFound},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.Get },
+		statusCode: 404,
+	}, {
+		name:       "get a forbidden task",
+		usecase:    &mocks.MockUsecase{Error: core.ErrForbidden},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.Get },
+		statusCode: 403,
+	}, {
+		name:       "get db error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("db error")},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.Get },
+		statusCode: 500,
+	}, {
+		name:       "Normal Case2: add an item",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.AddChecklistItem },
+		body:       `{"text":"Take notes"}`,
+		statusCode: 201,
+	}, {
+		name:       "add invalid body",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.AddChecklistItem },
+		body:       `{"text":`,
+		statusCode: 400,
+	}, {
+		name:       "add without text",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.AddChecklistItem },
+		body:       `{"text":""}`,
+		statusCode: 400,
+	}, {
+		name:       "add to a missing task",
+		usecase:    &mocks.MockUsecase{Error: core.ErrRecordNotFound},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.AddChecklistItem },
+		body:       `{"text":"Take notes"}`,
+		statusCode: 404,
+	}, {
+		name:       "Normal Case3: toggle an item",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.ToggleChecklistItem },
+		statusCode: 200,
+	}, {
+		name:       "toggle a missing item",
+		usecase:    &mocks.MockUsecase{Error: core.ErrRecordNotFound},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.ToggleChecklistItem },
+		statusCode: 404,
+	}, {
+		name:       "Normal Case4: move an item",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.MoveChecklistItem },
+		body:       `{"position":0}`,
+		statusCode: 200,
+	}, {
+		name:       "move invalid body",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.MoveChecklistItem },
+		body:       `{"position":`,
+		statusCode: 400,
+	}, {
+		name:       "move to a negative position",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.MoveChecklistItem },
+		body:       `{"position":-1}`,
+		statusCode: 400,
+	}, {
+		name:       "Normal Case5: delete an item",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.DeleteChecklistItem },
+		statusCode: 200,
+	}, {
+		name:       "delete forbidden",
+		usecase:    &mocks.MockUsecase{Error: core.ErrForbidden},
+		handler:    func(h *TaskHandler) nethttp.HandlerFunc { return h.DeleteChecklistItem },
+		statusCode: 403,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TaskHandler{TaskUsecase: tt.usecase}
+			req := httptest.NewRequest("POST", "/task/1/checklist/2", bytes.NewBufferString(tt.body))
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("id", "1")
+			ctx.URLParams.Add("item", "2")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+			rec := httptest.NewRecorder()
+			tt.handler(h)(rec, req)
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+// flushEvery is the number of rows written between two flushes of the response
+const flushEvery = 100
+
+// taskWriter writes tasks one at a time in an export format
+type taskWriter interface {
+	Begin() error
+	Write(*models.Task) error
+	End() error
+}
+
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"json":   "application/json",
+	"ndjson": "application/x-ndjson",
+}
+
+var csvHeader = []string{"id_task", "title", "status", "description", "priority", "project", "tags", "due_date", "version", "created_at", "updated_at"}
+
+// csvTagSeparator joins the tags of a task in a single csv cell
+const csvTagSeparator = ";"
+
+type csvTaskWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvTaskWriter) Begin() error {
+	return c.w.Write(csvHeader)
+}
+
+func (c *csvTaskWriter) Write(t *models.Task) error {
+	return c.w.Write([]string{
+		strconv.Itoa(t.ID),
+		t.Title,
+		t.Status,
+		t.Description,
+		strconv.Itoa(t.Priority),
+		t.Project,
+		strings.Join(t.Tags, csvTagSeparator),
+		formatDueDate(t.DueDate),
+		strconv.FormatInt(t.Version, 10),
+		t.CreatedAt.Format(time.RFC3339),
+		t.UpdatedAt.Format(time.RFC3339),
+	})
+}
+
+// formatDueDate leaves the cell empty for tasks without a due date
+func formatDueDate(due *time.Time) string {
+	if due == nil {
+		return ""
+	}
+	return due.Format(time.RFC3339)
+}
+
+func (c *csvTaskWriter) End() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonTaskWriter writes a JSON array, or one object per line when lines is set
+type jsonTaskWriter struct {
+	w     io.Writer
+	lines bool
+	count int
+}
+
+func (j *jsonTaskWriter) Begin() error {
+	if j.lines {
+		return nil
+	}
+	_, err := io.WriteString(j.w, "[")
+	return err
+}
+
+func (j *jsonTaskWriter) Write(t *models.Task) error {
+	b, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	sep := ""
+	switch {
+	case j.lines:
+		b = append(b, '\n')
+	case j.count > 0:
+		sep = ","
+	}
+	j.count++
+	if _, err := io.WriteString(j.w, sep); err != nil {
+		return err
+	}
+	_, err = j.w.Write(b)
+	return err
+}
+
+func (j *jsonTaskWriter) End() error {
+	if j.lines {
+		return nil
+	}
+	_, err := io.WriteString(j.w, "]\n")
+	return err
+}
+
+func newTaskWriter(format string, w io.Writer) taskWriter {
+	switch format {
+	case "csv":
+		return &csvTaskWriter{w: csv.NewWriter(w)}
+	case "ndjson":
+		return &jsonTaskWriter{w: w, lines: true}
+	default:
+		return &jsonTaskWriter{w: w}
+	}
+}
+
+//Export handler streams the tasks matching the list filters as csv, json or ndjson
+func (h *TaskHandler) Export(w nethttp.ResponseWriter, r *nethttp.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("invalid format"))
+		return
+	}
+	filter, err := parseFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	h.streamTasks(w, r, filter, newTaskWriter(format, w), func() {
+		writeExportHeaders(w, format, contentType)
+	})
+}
+
+// streamTasks writes the tasks matching filter through tw, writeHeaders
+// is called once right before the first byte of the body
+func (h *TaskHandler) streamTasks(w nethttp.ResponseWriter, r *nethttp.Request, filter *models.TaskFilter,
+	tw taskWriter, writeHeaders func()) {
+	flusher, _ := w.(nethttp.Flusher)
+	started := false
+	rows := 0
+	err := h.TaskUsecase.Export(r.Context(), filter, func(t *models.Task) error {
+		if !started {
+			// headers go out with the first row so that a failing query can still answer 500
+			started = true
+			writeHeaders()
+			if err := tw.Begin(); err != nil {
+				return err
+			}
+		}
+		rows++
+		if err := tw.Write(t); err != nil {
+			return err
+		}
+		if rows%flushEvery == 0 && flusher != nil {
+			if c, ok := tw.(*csvTaskWriter); ok {
+				c.w.Flush()
+			}
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err == core.ErrForbidden && !started {
+		writeForbidden(w)
+		return
+	}
+	if err != nil {
+		logrus.Error(err)
+		if !started {
+			w.WriteHeader(nethttp.StatusInternalServerError)
+			w.Write([]byte("internal server error"))
+		}
+		// the status is already sent, a truncated body is all we can do
+		return
+	}
+	if !started {
+		writeHeaders()
+		if err := tw.Begin(); err != nil {
+			logrus.Error(err)
+			return
+		}
+	}
+	if err := tw.End(); err != nil {
+		logrus.Error(err)
+	}
+}
+
+func writeExportHeaders(w nethttp.ResponseWriter, format, contentType string) {
+	filename := fmt.Sprintf("tasks-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(nethttp.StatusOK)
+}
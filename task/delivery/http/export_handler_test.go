@@ -0,0 +1,98 @@
+package http
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+func TestTaskHandler_Export(t *testing.T) {
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	tasks := []*models.Task{
+		{ID: 1, Title: "Take math notes", Status: "todo", Priority: 2, Project: "school", Tags: []string{"math", "notes"}, Version: 3, CreatedAt: at, UpdatedAt: at},
+		{ID: 2, Title: "do physics, homework", Status: "done", DueDate: &at, Version: 4, CreatedAt: at, UpdatedAt: at},
+	}
+	tests := []struct {
+		name        string
+		usecase     task.Usecase
+		url         string
+		statusCode  int
+		contentType string
+		body        string
+	}{{
+		name:        "csv",
+		usecase:     &mocks.MockUsecase{Tasks: tasks},
+		url:         "/export?format=csv",
+		statusCode:  200,
+		contentType: "text/csv; charset=utf-8",
+		body: "id_task,title,status,description,priority,project,tags,due_date,version,created_at,updated_at\n" +
+			"1,Take math notes,todo,,2,school,math;notes,,3,2026-10-01T09:00:00Z,2026-10-01T09:00:00Z\n" +
+			"2,\"do physics, homework\",done,,0,,,2026-10-01T09:00:00Z,4,2026-10-01T09:00:00Z,2026-10-01T09:00:00Z\n",
+	}, {
+		name:        "ndjson",
+		usecase:     &mocks.MockUsecase{Tasks: tasks[:1]},
+		url:         "/export?format=ndjson",
+		statusCode:  200,
+		contentType: "application/x-ndjson",
+		body: `{"id_task":1,"title":"Take math notes","status":"todo","description":"","priority":2,"project":"school",` +
+			`"tags":["math","notes"],"version":3,` +
+			`"created_at":"2026-10-01T09:00:00Z","updated_at":"2026-10-01T09:00:00Z"}` + "\n",
+	}, {
+		name:        "json without tasks",
+		usecase:     &mocks.MockUsecase{},
+		url:         "/export?format=json&status=done",
+		statusCode:  200,
+		contentType: "application/json",
+		body:        "[]\n",
+	}, {
+		name:       "invalid format",
+		usecase:    &mocks.MockUsecase{},
+		url:        "/export?format=xml",
+		statusCode: 400,
+		body:       "invalid format",
+	}, {
+		name:       "invalid filter",
+		usecase:    &mocks.MockUsecase{},
+		url:        "/export?status=completed",
+		statusCode: 400,
+		body:       "invalid filter",
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("Usecase.Error()")},
+		url:        "/export",
+		statusCode: 500,
+		body:       "internal server error",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TaskHandler{TaskUsecase: tt.usecase}
+			req := httptest.NewRequest("GET", tt.url, nil)
+			rec := httptest.NewRecorder()
+			h.Export(rec, req)
+			res := rec.Result()
+			if res.StatusCode != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, res.StatusCode, tt.statusCode)
+			}
+			body, _ := ioutil.ReadAll(res.Body)
+			if string(body) != tt.body {
+				t.Errorf("Test - %s , got body %q but expected %q", tt.name, body, tt.body)
+			}
+			if tt.contentType == "" {
+				return
+			}
+			if ct := res.Header.Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("Test - %s , got content type %s but expected %s", tt.name, ct, tt.contentType)
+			}
+			if cd := res.Header.Get("Content-Disposition"); !strings.HasPrefix(cd, "attachment; filename=\"tasks-") {
+				t.Errorf("Test - %s , unexpected content disposition %s", tt.name, cd)
+			}
+		})
+	}
+}
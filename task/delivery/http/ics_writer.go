@@ -0,0 +1,111 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+// icsUIDDomain makes the UID of a task unique across calendars while
+// keeping it stable across requests
+const icsUIDDomain = "todo-golang"
+
+// icsLineLength is the longest content line allowed by RFC 5545, in octets
+const icsLineLength = 75
+
+const icsTimeLayout = "20060102T150405Z"
+
+var icsStatuses = map[string]string{
+	"todo":       "NEEDS-ACTION",
+	"inprogress": "IN-PROCESS",
+	"done":       "COMPLETED",
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// icsTaskWriter writes tasks as the VTODO components of an RFC 5545 calendar
+type icsTaskWriter struct {
+	w    io.Writer
+	name string
+	err  error
+}
+
+// line writes a content line, folded at icsLineLength octets
+func (c *icsTaskWriter) line(name, value string) {
+	if c.err != nil {
+		return
+	}
+	l := name + ":" + value
+	var b strings.Builder
+	for limit := icsLineLength; len(l) > limit; limit = icsLineLength - 1 {
+		cut := limit
+		// never split a multi-byte character
+		for cut > 0 && !utf8.RuneStart(l[cut]) {
+			cut--
+		}
+		b.WriteString(l[:cut])
+		b.WriteString("\r\n ")
+		l = l[cut:]
+	}
+	b.WriteString(l)
+	b.WriteString("\r\n")
+	_, c.err = io.WriteString(c.w, b.String())
+}
+
+func icsTime(t time.Time) string {
+	return t.UTC().Format(icsTimeLayout)
+}
+
+func (c *icsTaskWriter) Begin() error {
+	c.line("BEGIN", "VCALENDAR")
+	c.line("VERSION", "2.0")
+	c.line("PRODID", "-//todo-golang//tasks//EN")
+	c.line("CALSCALE", "GREGORIAN")
+	c.line("X-WR-CALNAME", icsEscaper.Replace(c.name))
+	return c.err
+}
+
+func (c *icsTaskWriter) Write(t *models.Task) error {
+	c.line("BEGIN", "VTODO")
+	c.line("UID", fmt.Sprintf("task-%d@%s", t.ID, icsUIDDomain))
+	c.line("DTSTAMP", icsTime(t.UpdatedAt))
+	c.line("CREATED", icsTime(t.CreatedAt))
+	c.line("LAST-MODIFIED", icsTime(t.UpdatedAt))
+	c.line("SUMMARY", icsEscaper.Replace(t.Title))
+	if status, ok := icsStatuses[t.Status]; ok {
+		c.line("STATUS", status)
+	}
+	if t.Status == "done" {
+		// the time a task was completed is not kept, its last change is the closest
+		c.line("COMPLETED", icsTime(t.UpdatedAt))
+		c.line("PERCENT-COMPLETE", "100")
+	}
+	// both go from 1 (highest) to 9 (lowest) with 0 for undefined
+	if t.Priority > 0 {
+		c.line("PRIORITY", fmt.Sprint(t.Priority))
+	}
+	if t.DueDate != nil {
+		c.line("DUE", icsTime(*t.DueDate))
+	}
+	if len(t.Tags) > 0 {
+		categories := make([]string, len(t.Tags))
+		for i, tag := range t.Tags {
+			categories[i] = icsEscaper.Replace(tag)
+		}
+		c.line("CATEGORIES", strings.Join(categories, ","))
+	}
+	if t.Project != "" {
+		c.line("X-TODO-PROJECT", icsEscaper.Replace(t.Project))
+	}
+	c.line("END", "VTODO")
+	return c.err
+}
+
+func (c *icsTaskWriter) End() error {
+	c.line("END", "VCALENDAR")
+	return c.err
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func Test_icsTaskWriter(t *testing.T) {
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 11, 1, 17, 30, 0, 0, time.FixedZone("CET", 3600))
+	tests := []struct {
+		name string
+		task *models.Task
+		want string
+	}{{
+		name: "Normal Case1: todo with due date",
+		task: &models.Task{ID: 1, Title: "Take math notes; chapter 2", Status: "todo", Priority: 2,
+			Project: "school", Tags: []string{"math", "a,b"}, DueDate: &due, CreatedAt: at, UpdatedAt: at},
+		want: "BEGIN:VTODO\r\n" +
+			"UID:task-1@todo-golang\r\n" +
+			"DTSTAMP:20261001T090000Z\r\n" +
+			"CREATED:20261001T090000Z\r\n" +
+			"LAST-MODIFIED:20261001T090000Z\r\n" +
+			"SUMMARY:Take math notes\\; chapter 2\r\n" +
+			"STATUS:NEEDS-ACTION\r\n" +
+			"PRIORITY:2\r\n" +
+			"DUE:20261101T163000Z\r\n" +
+			"CATEGORIES:math,a\\,b\r\n" +
+			"X-TODO-PROJECT:school\r\n" +
+			"END:VTODO\r\n",
+	}, {
+		name: "done task",
+		task: &models.Task{ID: 2, Title: "do physics homework", Status: "done", CreatedAt: at, UpdatedAt: at},
+		want: "BEGIN:VTODO\r\n" +
+			"UID:task-2@todo-golang\r\n" +
+			"DTSTAMP:20261001T090000Z\r\n" +
+			"CREATED:20261001T090000Z\r\n" +
+			"LAST-MODIFIED:20261001T090000Z\r\n" +
+			"SUMMARY:do physics homework\r\n" +
+			"STATUS:COMPLETED\r\n" +
+			"COMPLETED:20261001T090000Z\r\n" +
+			"PERCENT-COMPLETE:100\r\n" +
+			"END:VTODO\r\n",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &bytes.Buffer{}
+			if err := (&icsTaskWriter{w: b}).Write(tt.task); err != nil {
+				t.Fatalf("icsTaskWriter.Write() error = %v", err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("icsTaskWriter.Write() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_icsTaskWriter_line(t *testing.T) {
+	b := &bytes.Buffer{}
+	c := &icsTaskWriter{w: b}
+	c.line("SUMMARY", strings.Repeat("é", 60))
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], " ") {
+		t.Fatalf("expected a folded line, got %q", b.String())
+	}
+	var unfolded string
+	for _, l := range lines {
+		if len(l) > icsLineLength {
+			t.Errorf("line of %d octets: %q", len(l), l)
+		}
+		unfolded += strings.TrimPrefix(l, " ")
+	}
+	if unfolded != "SUMMARY:"+strings.Repeat("é", 60) {
+		t.Errorf("unfolded line = %q", unfolded)
+	}
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"strconv"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+// maxImportSize is the largest import file accepted
+const maxImportSize = 10 << 20
+
+//Import handler adds the tasks of a csv, json or todo.txt file. With dry_run
+//the rows are only validated. A file with invalid rows answers 422 and adds nothing
+func (h *TaskHandler) Import(w nethttp.ResponseWriter, r *nethttp.Request) {
+	q := r.URL.Query()
+	dryRun := false
+	if d := q.Get("dry_run"); d != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(d); err != nil {
+			w.WriteHeader(nethttp.StatusBadRequest)
+			w.Write([]byte("invalid dry_run"))
+			return
+		}
+	}
+	body := nethttp.MaxBytesReader(w, r.Body, maxImportSize)
+	var rows []*models.ImportRow
+	var err error
+	switch q.Get("format") {
+	case "csv":
+		var mapping map[string]string
+		mapping, err = parseColumnMapping(q.Get("map"))
+		if err == nil {
+			rows, err = parseCSVImport(body, mapping)
+		}
+	case "json", "":
+		rows, err = parseJSONImport(body)
+	case "todotxt":
+		rows, err = parseTodoTxtImport(body)
+	default:
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("invalid format"))
+		return
+	}
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body: " + err.Error()))
+		return
+	}
+	result, err := h.TaskUsecase.Import(r.Context(), rows, dryRun)
+	if err != nil {
+		if err == core.ErrForbidden {
+			writeForbidden(w)
+			return
+		}
+		if err == core.ErrWIPLimit {
+			writeWIPLimit(w)
+			return
+		}
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+		return
+	}
+	message := "success"
+	status := nethttp.StatusOK
+	if !result.DryRun && !result.Committed && len(rows) > 0 {
+		message = "failure"
+		status = nethttp.StatusUnprocessableEntity
+	}
+	w.WriteHeader(status)
+	res, _ := json.Marshal(map[string]interface{}{
+		"message":   message,
+		"dry_run":   result.DryRun,
+		"committed": result.Committed,
+		"imported":  result.Imported,
+		"rows":      result.Rows,
+	})
+	w.Write(res)
+}
@@ -0,0 +1,193 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+func TestTaskHandler_Import(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    task.Usecase
+		query      string
+		body       string
+		statusCode int
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{ImportResult: &models.ImportResult{Committed: true, Imported: 1}},
+		query:      "?format=json",
+		body:       `[{"title":"buy milk","status":"todo"}]`,
+		statusCode: 200,
+	}, {
+		name:       "dry run",
+		usecase:    &mocks.MockUsecase{ImportResult: &models.ImportResult{DryRun: true}},
+		query:      "?format=todotxt&dry_run=true",
+		body:       "(A) Call the bank +finance @phone\n",
+		statusCode: 200,
+	}, {
+		name:       "invalid rows",
+		usecase:    &mocks.MockUsecase{ImportResult: &models.ImportResult{}},
+		query:      "?format=csv",
+		body:       "title,status\n,later\n",
+		statusCode: 422,
+	}, {
+		name:       "unknown format",
+		usecase:    &mocks.MockUsecase{},
+		query:      "?format=xml",
+		statusCode: 400,
+	}, {
+		name:       "bad dry_run",
+		usecase:    &mocks.MockUsecase{},
+		query:      "?format=json&dry_run=maybe",
+		body:       `[]`,
+		statusCode: 400,
+	}, {
+		name:       "unreadable file",
+		usecase:    &mocks.MockUsecase{},
+		query:      "?format=json",
+		body:       `{"title":"buy milk"}`,
+		statusCode: 400,
+	}, {
+		name:       "mapped column missing",
+		usecase:    &mocks.MockUsecase{},
+		query:      "?format=csv&map=title:Name",
+		body:       "title\nbuy milk\n",
+		statusCode: 400,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("Usecase.Error()")},
+		query:      "?format=json",
+		body:       `[{"title":"buy milk","status":"todo"}]`,
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TaskHandler{TaskUsecase: tt.usecase}
+			req := httptest.NewRequest("POST", "/import"+tt.query, bytes.NewBufferString(tt.body))
+			rec := httptest.NewRecorder()
+			h.Import(rec, req)
+			if res := rec.Result(); res.StatusCode != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d",
+					tt.name, res.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
+
+func Test_parseCSVImport(t *testing.T) {
+	due := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		body    string
+		mapping map[string]string
+		want    []*models.ImportRow
+		wantErr bool
+	}{{
+		name: "columns by name",
+		body: "Title,Status,Priority,Project,Tags,Due_Date\nbuy milk,Done,2,home,errand;shop,2026-10-05\nCall the bank,,B,,,\n",
+		want: []*models.ImportRow{
+			{Line: 2, Task: &models.Task{Title: "buy milk", Status: "done", Priority: 2, Project: "home",
+				Tags: []string{"errand", "shop"}, DueDate: &due}},
+			{Line: 3, Task: &models.Task{Title: "Call the bank", Status: "todo", Priority: 2, Tags: []string{}}},
+		},
+	}, {
+		name:    "mapped columns",
+		body:    "Name,State\nbuy milk,inprogress\n",
+		mapping: map[string]string{"title": "Name", "status": "state"},
+		want: []*models.ImportRow{
+			{Line: 2, Task: &models.Task{Title: "buy milk", Status: "inprogress", Tags: []string{}}},
+		},
+	}, {
+		name: "bad priority is a row error",
+		body: "title,priority\nbuy milk,high\n",
+		want: []*models.ImportRow{
+			{Line: 2, Task: &models.Task{Title: "buy milk", Status: "todo", Tags: []string{}},
+				Error: `invalid priority "high"`},
+		},
+	}, {
+		name:    "no title column",
+		body:    "name\nbuy milk\n",
+		wantErr: true,
+	}, {
+		name:    "empty file",
+		body:    "",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCSVImport(strings.NewReader(tt.body), tt.mapping)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCSVImport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCSVImport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseJSONImport(t *testing.T) {
+	got, err := parseJSONImport(strings.NewReader(
+		`[{"id_task":7,"title":"buy milk","tags":["shop"]},{"title":5}]`))
+	if err != nil {
+		t.Fatalf("parseJSONImport() error = %v", err)
+	}
+	want := &models.Task{Title: "buy milk", Status: "todo", Tags: []string{"shop"}}
+	if len(got) != 2 || !reflect.DeepEqual(got[0].Task, want) {
+		t.Fatalf("parseJSONImport() = %v, want first task %v", got, want)
+	}
+	if got[1].Line != 2 || got[1].Error == "" {
+		t.Errorf("parseJSONImport() second row = %+v, want a row error", got[1])
+	}
+}
+
+func Test_parseTodoTxtLine(t *testing.T) {
+	due := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		line    string
+		want    *models.Task
+		wantErr bool
+	}{{
+		line: "(A) 2026-10-01 Call the bank +finance +home @phone due:2026-10-05",
+		want: &models.Task{Title: "Call the bank", Status: "todo", Priority: 1, Project: "finance",
+			Tags: []string{"phone"}, DueDate: &due},
+	}, {
+		line: "x 2026-10-02 2026-09-30 buy milk @errand",
+		want: &models.Task{Title: "buy milk", Status: "done", Tags: []string{"errand"}},
+	}, {
+		line: "read https://example.com pri:C",
+		want: &models.Task{Title: "read https://example.com", Status: "todo", Priority: 3, Tags: []string{}},
+	}, {
+		line:    "Buy milk pri:x due:2026-11-01",
+		wantErr: true,
+	}, {
+		line:    "Buy milk due:bad pri:B",
+		wantErr: true,
+	}, {
+		line:    "Buy milk pri:B due:bad",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := parseTodoTxtLine(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTodoTxtLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTodoTxtLine() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
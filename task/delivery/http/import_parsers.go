@@ -0,0 +1,273 @@
+package http
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+// importFields are the task fields a csv column can be mapped to
+var importFields = []string{"title", "status", "description", "priority", "project", "tags", "due_date"}
+
+// parseColumnMapping reads "field:Column,field:Column" into a field to column map
+func parseColumnMapping(s string) (map[string]string, error) {
+	mapping := map[string]string{}
+	if s == "" {
+		return mapping, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[1]) == "" {
+			return nil, fmt.Errorf("invalid column mapping %q", pair)
+		}
+		field := strings.ToLower(strings.TrimSpace(kv[0]))
+		if !isImportField(field) {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+		mapping[field] = strings.TrimSpace(kv[1])
+	}
+	return mapping, nil
+}
+
+func isImportField(field string) bool {
+	for _, f := range importFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePriority accepts a number from 0 to 9 or a todo.txt letter, A being the highest
+func parsePriority(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if len(s) == 1 && s[0] >= 'A' && s[0] <= 'Z' {
+		if p := int(s[0]-'A') + 1; p < 9 {
+			return p, nil
+		}
+		return 9, nil
+	}
+	p, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid priority %q", s)
+	}
+	return p, nil
+}
+
+// parseDueDate accepts an RFC 3339 time or a plain date, read as midnight UTC
+func parseDueDate(s string) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if due, err := time.Parse(layout, s); err == nil {
+			return &due, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid due date %q", s)
+}
+
+func splitTags(s string) []string {
+	tags := []string{}
+	for _, t := range strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == ',' }) {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// parseCSVImport reads a csv file with a header row. Columns are matched to task
+// fields through mapping, or by name when a field is not mapped
+func parseCSVImport(r io.Reader, mapping map[string]string) ([]*models.ImportRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errors.New("missing header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+	index := map[string]int{}
+	for i, h := range header {
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	columns := map[string]int{}
+	for _, field := range importFields {
+		name, mapped := mapping[field]
+		if !mapped {
+			name = field
+		}
+		i, ok := index[strings.ToLower(name)]
+		if !ok && mapped {
+			return nil, fmt.Errorf("column %q not found", name)
+		}
+		if ok {
+			columns[field] = i
+		}
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("no column for title")
+	}
+	rows := []*models.ImportRow{}
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			if _, ok := err.(*csv.ParseError); ok {
+				rows = append(rows, &models.ImportRow{Line: line, Error: err.Error()})
+				continue
+			}
+			return nil, err
+		}
+		cell := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := &models.ImportRow{Line: line}
+		task := &models.Task{
+			Title:       cell("title"),
+			Status:      strings.ToLower(cell("status")),
+			Description: cell("description"),
+			Project:     cell("project"),
+			Tags:        splitTags(cell("tags")),
+		}
+		if task.Status == "" {
+			task.Status = "todo"
+		}
+		if task.Priority, err = parsePriority(cell("priority")); err != nil {
+			row.Error = err.Error()
+		}
+		if task.DueDate, err = parseDueDate(cell("due_date")); err != nil {
+			row.Error = err.Error()
+		}
+		row.Task = task
+		rows = append(rows, row)
+	}
+}
+
+// parseJSONImport reads a json array of tasks
+func parseJSONImport(r io.Reader) ([]*models.ImportRow, error) {
+	var items []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, err
+	}
+	rows := make([]*models.ImportRow, len(items))
+	for i, item := range items {
+		row := &models.ImportRow{Line: i + 1}
+		task := &models.Task{}
+		if err := json.Unmarshal(item, task); err != nil {
+			row.Error = err.Error()
+		} else {
+			// ids and versions belong to the server
+			task.ID, task.Version = 0, 0
+			if task.Status == "" {
+				task.Status = "todo"
+			}
+			row.Task = task
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// parseTodoTxtImport reads the todo.txt format, one task per line:
+//
+//	x 2026-10-02 2026-09-30 (A) Call the bank +finance @phone
+//
+// Completed tasks become done, projects fill the project and contexts the tags.
+// Dates and key:value extensions other than pri: and due: are dropped
+func parseTodoTxtImport(r io.Reader) ([]*models.ImportRow, error) {
+	rows := []*models.ImportRow{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		row := &models.ImportRow{Line: line}
+		task, err := parseTodoTxtLine(text)
+		if err != nil {
+			row.Error = err.Error()
+		}
+		row.Task = task
+		rows = append(rows, row)
+	}
+	return rows, scanner.Err()
+}
+
+func parseTodoTxtLine(text string) (*models.Task, error) {
+	words := strings.Fields(text)
+	task := &models.Task{Status: "todo", Tags: []string{}}
+	if len(words) > 0 && words[0] == "x" {
+		task.Status = "done"
+		words = words[1:]
+		// completion date, then creation date
+		for i := 0; i < 2 && len(words) > 0 && isTodoTxtDate(words[0]); i++ {
+			words = words[1:]
+		}
+	}
+	if len(words) > 0 && len(words[0]) == 3 && words[0][0] == '(' && words[0][2] == ')' &&
+		words[0][1] >= 'A' && words[0][1] <= 'Z' {
+		priority, err := parsePriority(words[0][1:2])
+		if err != nil {
+			return task, err
+		}
+		task.Priority = priority
+		words = words[1:]
+	}
+	if len(words) > 0 && isTodoTxtDate(words[0]) {
+		words = words[1:]
+	}
+	title := make([]string, 0, len(words))
+	for _, w := range words {
+		switch {
+		case len(w) > 1 && w[0] == '+':
+			if task.Project == "" {
+				task.Project = w[1:]
+			}
+		case len(w) > 1 && w[0] == '@':
+			task.Tags = append(task.Tags, w[1:])
+		case strings.HasPrefix(w, "pri:") && len(w) == 5:
+			priority, err := parsePriority(w[4:])
+			if err != nil {
+				return task, err
+			}
+			task.Priority = priority
+		case strings.HasPrefix(w, "due:") && len(w) > 4:
+			due, err := parseDueDate(w[4:])
+			if err != nil {
+				return task, err
+			}
+			task.DueDate = due
+		case strings.Index(w, ":") > 0 && !strings.Contains(w, "://") && !strings.HasSuffix(w, ":"):
+			// key:value extension
+		default:
+			title = append(title, w)
+		}
+	}
+	task.Title = strings.Join(title, " ")
+	return task, nil
+}
+
+func isTodoTxtDate(s string) bool {
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil
+}
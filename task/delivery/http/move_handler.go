@@ -0,0 +1,58 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+//Move handler places a task right after and/or right before other tasks in the
+//manual order, the order List returns the tasks in when no sort is given
+func (h *TaskHandler) Move(w nethttp.ResponseWriter, r *nethttp.Request) {
+	move := &models.TaskMove{}
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(move)
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return
+	}
+	validate := validator.New()
+	err = validate.Struct(move)
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return
+	}
+	id, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	task, err := h.TaskUsecase.Move(r.Context(), id, move)
+	if err != nil {
+		switch err {
+		case core.ErrForbidden:
+			writeForbidden(w)
+		case core.ErrRecordNotFound:
+			w.WriteHeader(nethttp.StatusNotFound)
+			w.Write([]byte("task not found"))
+		case core.ErrInvalidMove:
+			w.WriteHeader(nethttp.StatusUnprocessableEntity)
+			w.Write([]byte("the task moved before does not come after the task moved after"))
+		default:
+			logrus.Error(err)
+			w.WriteHeader(nethttp.StatusInternalServerError)
+			w.Write([]byte("internal server error"))
+		}
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	res, _ := json.Marshal(map[string]interface{}{
+		"message": "success",
+		"task":    task,
+	})
+	w.Write(res)
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+func TestTaskHandler_Move(t *testing.T) {
+	tasks := []*models.Task{{ID: 1, Title: "Take math notes", Status: "todo"}}
+	tests := []struct {
+		name       string
+		usecase    task.Usecase
+		body       string
+		statusCode int
+	}{{
+		name:       "Normal Case1: after a task",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		body:       `{"after":2}`,
+		statusCode: 200,
+	}, {
+		name:       "Normal Case2: between two tasks",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		body:       `{"after":2,"before":3}`,
+		statusCode: 200,
+	}, {
+		name:       "invalid body",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		body:       `{"after":`,
+		statusCode: 400,
+	}, {
+		name:       "no neighbour",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		body:       `{}`,
+		statusCode: 400,
+	}, {
+		name:       "negative neighbour",
+		usecase:    &mocks.MockUsecase{Tasks: tasks},
+		body:       `{"before":-2}`,
+		statusCode: 400,
+	}, {
+		name:       "task not found",
+		usecase:    &mocks.MockUsecase{Error: core.ErrRecordNotFound},
+		body:       `{"after":2}`,
+		statusCode: 404,
+	}, {
+		name:       "neighbours out of order",
+		usecase:    &mocks.MockUsecase{Error: core.ErrInvalidMove},
+		body:       `{"after":3,"before":2}`,
+		statusCode: 422,
+	}, {
+		name:       "forbidden",
+		usecase:    &mocks.MockUsecase{Error: core.ErrForbidden},
+		body:       `{"after":2}`,
+		statusCode: 403,
+	}, {
+		name:       "db error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("db error")},
+		body:       `{"after":2}`,
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TaskHandler{TaskUsecase: tt.usecase}
+			req := httptest.NewRequest("POST", "/task/1/move", bytes.NewBufferString(tt.body))
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("id", "1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+			rec := httptest.NewRecorder()
+			h.Move(rec, req)
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
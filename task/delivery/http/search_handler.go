@@ -0,0 +1,53 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultSearchLimit is the number of results returned when no limit is given
+const defaultSearchLimit = 20
+
+//Search handler returns the tasks whose title or description match q, best match first
+func (h *TaskHandler) Search(w nethttp.ResponseWriter, r *nethttp.Request) {
+	q := r.URL.Query()
+	query := &models.SearchQuery{Query: q.Get("q"), Limit: defaultSearchLimit}
+	if l := q.Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil {
+			w.WriteHeader(nethttp.StatusBadRequest)
+			w.Write([]byte("invalid limit"))
+			return
+		}
+		query.Limit = limit
+	}
+	validate := validator.New()
+	if err := validate.Struct(query); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return
+	}
+	results, err := h.TaskUsecase.Search(r.Context(), query)
+	if err != nil {
+		if err == core.ErrForbidden {
+			writeForbidden(w)
+			return
+		}
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	res, _ := json.Marshal(map[string]interface{}{
+		"message": "success",
+		"results": results,
+	})
+	w.Write(res)
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+func TestTaskHandler_Search(t *testing.T) {
+	results := []*models.SearchResult{{Task: &models.Task{ID: 1, Title: "Take math notes", Status: "todo"},
+		Rank: 0.6, Title: "Take <mark>math</mark> notes"}}
+	tests := []struct {
+		name       string
+		usecase    task.Usecase
+		url        string
+		statusCode int
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{SearchResults: results},
+		url:        "/search?q=mat",
+		statusCode: 200,
+	}, {
+		name:       "with limit",
+		usecase:    &mocks.MockUsecase{SearchResults: results},
+		url:        "/search?q=mat&limit=5",
+		statusCode: 200,
+	}, {
+		name:       "missing query",
+		usecase:    &mocks.MockUsecase{},
+		url:        "/search",
+		statusCode: 400,
+	}, {
+		name:       "limit out of range",
+		usecase:    &mocks.MockUsecase{},
+		url:        "/search?q=mat&limit=1000",
+		statusCode: 400,
+	}, {
+		name:       "invalid limit",
+		usecase:    &mocks.MockUsecase{},
+		url:        "/search?q=mat&limit=all",
+		statusCode: 400,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("Usecase.Error()")},
+		url:        "/search?q=mat",
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TaskHandler{TaskUsecase: tt.usecase}
+			req := httptest.NewRequest("GET", tt.url, nil)
+			rec := httptest.NewRecorder()
+			h.Search(rec, req)
+			if res := rec.Result(); res.StatusCode != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d",
+					tt.name, res.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
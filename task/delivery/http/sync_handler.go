@@ -0,0 +1,84 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"strconv"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+type pushRequest struct {
+	Changes []*models.SyncChange `json:"changes"`
+}
+
+//Sync handler returns the changes made after the token query param
+func (h *TaskHandler) Sync(w nethttp.ResponseWriter, r *nethttp.Request) {
+	limit := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		var err error
+		limit, err = strconv.Atoi(l)
+		if err != nil {
+			w.WriteHeader(nethttp.StatusBadRequest)
+			w.Write([]byte("invalid limit"))
+			return
+		}
+	}
+	delta, err := h.TaskUsecase.Sync(r.Context(), r.URL.Query().Get("token"), limit)
+	if err != nil {
+		if err == core.ErrForbidden {
+			writeForbidden(w)
+			return
+		}
+		if err == core.ErrInvalidSyncToken {
+			w.WriteHeader(nethttp.StatusBadRequest)
+			w.Write([]byte("invalid sync token"))
+			return
+		}
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	res, _ := json.Marshal(map[string]interface{}{
+		"message":  "success",
+		"created":  delta.Created,
+		"updated":  delta.Updated,
+		"deleted":  delta.Deleted,
+		"token":    delta.Token,
+		"has_more": delta.HasMore,
+	})
+	w.Write(res)
+}
+
+//Push handler applies the changes of an offline client
+func (h *TaskHandler) Push(w nethttp.ResponseWriter, r *nethttp.Request) {
+	req := &pushRequest{}
+	d := json.NewDecoder(r.Body)
+	err := d.Decode(req)
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return
+	}
+	results, err := h.TaskUsecase.Push(r.Context(), req.Changes)
+	if err != nil {
+		if err == core.ErrForbidden {
+			writeForbidden(w)
+			return
+		}
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	res, _ := json.Marshal(map[string]interface{}{
+		"message": "success",
+		"results": results,
+	})
+	w.Write(res)
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+func TestTaskHandler_Sync(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    task.Usecase
+		url        string
+		statusCode int
+	}{{
+		name: "Success case",
+		usecase: &mocks.MockUsecase{Delta: &models.SyncDelta{
+			Created: []*models.Task{{ID: 1, Title: "Take math notes", Status: "todo"}},
+			Token:   "abc",
+		}},
+		url:        "/sync?token=xyz&limit=10",
+		statusCode: 200,
+	}, {
+		name:       "invalid limit",
+		usecase:    &mocks.MockUsecase{},
+		url:        "/sync?limit=ten",
+		statusCode: 400,
+	}, {
+		name:       "invalid token",
+		usecase:    &mocks.MockUsecase{Error: core.ErrInvalidSyncToken},
+		url:        "/sync?token=xyz",
+		statusCode: 400,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("Usecase.Error()")},
+		url:        "/sync",
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TaskHandler{TaskUsecase: tt.usecase}
+			req := httptest.NewRequest("GET", tt.url, nil)
+			rec := httptest.NewRecorder()
+			h.Sync(rec, req)
+			res := rec.Result()
+			if res.StatusCode != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d",
+					tt.name, res.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_Push(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    task.Usecase
+		body       string
+		statusCode int
+		results    int
+	}{{
+		name: "Success case",
+		usecase: &mocks.MockUsecase{SyncResults: []*models.SyncResult{
+			{ClientID: "a", ID: 1, Status: models.SyncApplied, Version: 4},
+		}},
+		body:       `{"changes":[{"client_id":"a","title":"Take math notes","status":"todo"}]}`,
+		statusCode: 200,
+		results:    1,
+	}, {
+		name:       "body parse error",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{"changes":"a"}`,
+		statusCode: 400,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("Usecase.Error()")},
+		body:       `{"changes":[]}`,
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TaskHandler{TaskUsecase: tt.usecase}
+			req := httptest.NewRequest("POST", "/sync", bytes.NewBufferString(tt.body))
+			rec := httptest.NewRecorder()
+			h.Push(rec, req)
+			res := rec.Result()
+			if res.StatusCode != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d",
+					tt.name, res.StatusCode, tt.statusCode)
+			}
+			if tt.statusCode != 200 {
+				return
+			}
+			body, _ := ioutil.ReadAll(res.Body)
+			resp := struct {
+				Results []*models.SyncResult `json:"results"`
+			}{}
+			if err := json.Unmarshal(body, &resp); err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			if len(resp.Results) != tt.results {
+				t.Errorf("expected %d results but got %d", tt.results, len(resp.Results))
+			}
+		})
+	}
+}
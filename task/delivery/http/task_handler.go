@@ -8,26 +8,65 @@ import (
 	"github.com/go-chi/chi"
 	"github.com/go-playground/validator/v10"
 	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/filter"
 	"github.com/pratheeshm/todo-golang/models"
 	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/user"
 	"github.com/sirupsen/logrus"
 )
 
 //TaskHandler represents http handler for task
 type TaskHandler struct {
 	TaskUsecase task.Usecase
+	// FeedSecret signs the calendar feed urls
+	FeedSecret []byte
+	// UserUsecase keeps the calendar feed keys of the users
+	UserUsecase user.Usecase
 }
 
-// NewTaskHandler will initialize the task/ resources endpoint
-func NewTaskHandler(tu task.Usecase) nethttp.Handler {
+// NewTaskHandler will initialize the task/ resources endpoint,
+// idempotent guards the endpoints creating tasks against retries and
+// authenticate guards every endpoint but the calendar feed, which carries its own token.
+// The calendar feed is only served when feedSecret is set, the feed urls are signed with the
+// feed keys uu keeps
+func NewTaskHandler(tu task.Usecase, uu user.Usecase, idempotent, authenticate func(nethttp.Handler) nethttp.Handler, feedSecret []byte) nethttp.Handler {
 	r := chi.NewMux()
 	taskHandler := &TaskHandler{
 		TaskUsecase: tu,
+		FeedSecret:  feedSecret,
+		UserUsecase: uu,
+	}
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.With(idempotent).Post("/add", taskHandler.Add)
+		r.Get("/list", taskHandler.List)
+		r.Get("/export", taskHandler.Export)
+		r.Get("/search", taskHandler.Search)
+		r.Get("/board", taskHandler.Board)
+		r.Post("/import", taskHandler.Import)
+		r.Get("/task/{id:[0-9]+}", taskHandler.Get)
+		r.Put("/task/{id:[0-9]+}", taskHandler.Edit)
+		r.Delete("/task/{id:[0-9]+}", taskHandler.Delete)
+		r.Get("/task/{id:[0-9]+}/history", taskHandler.History)
+		r.Post("/task/{id:[0-9]+}/move", taskHandler.Move)
+		r.Post("/task/{id:[0-9]+}/assignees", taskHandler.Assign)
+		r.Delete("/task/{id:[0-9]+}/assignees", taskHandler.Unassign)
+		r.Post("/task/{id:[0-9]+}/checklist", taskHandler.AddChecklistItem)
+		r.Post("/task/{id:[0-9]+}/checklist/{item:[0-9]+}/toggle", taskHandler.ToggleChecklistItem)
+		r.Post("/task/{id:[0-9]+}/checklist/{item:[0-9]+}/move", taskHandler.MoveChecklistItem)
+		r.Delete("/task/{id:[0-9]+}/checklist/{item:[0-9]+}", taskHandler.DeleteChecklistItem)
+		r.Get("/me/tasks", taskHandler.MyTasks)
+		r.Get("/sync", taskHandler.Sync)
+		r.Post("/sync", taskHandler.Push)
+		r.Post("/tasks/bulk", taskHandler.Bulk)
+		if len(feedSecret) > 0 {
+			r.Get("/calendar/url", taskHandler.CalendarURL)
+			r.Post("/calendar/rotate", taskHandler.RotateCalendar)
+		}
+	})
+	if len(feedSecret) > 0 {
+		r.Get("/calendar.ics", taskHandler.Calendar)
 	}
-	r.Post("/add", taskHandler.Add)
-	r.Get("/list", taskHandler.List)
-	r.Put("/task/{id:[0-9]+}", taskHandler.Edit)
-	r.Delete("/task/{id:[0-9]+}", taskHandler.Delete)
 	return r
 }
 
@@ -48,8 +87,16 @@ func (h *TaskHandler) Add(w nethttp.ResponseWriter, r *nethttp.Request) {
 		w.Write([]byte("validation error"))
 		return
 	}
-	err = h.TaskUsecase.Add(task)
+	err = h.TaskUsecase.Add(r.Context(), task)
 	if err != nil {
+		if err == core.ErrForbidden {
+			writeForbidden(w)
+			return
+		}
+		if err == core.ErrWIPLimit {
+			writeWIPLimit(w)
+			return
+		}
 		w.WriteHeader(nethttp.StatusInternalServerError)
 		w.Write([]byte("internal server error"))
 		return
@@ -58,10 +105,57 @@ func (h *TaskHandler) Add(w nethttp.ResponseWriter, r *nethttp.Request) {
 	w.Write([]byte("success"))
 }
 
+// writeForbidden answers 403, the role of the user does not allow the request
+func writeForbidden(w nethttp.ResponseWriter) {
+	w.WriteHeader(nethttp.StatusForbidden)
+	w.Write([]byte("forbidden"))
+}
+
+// parseFilter reads the task filters from the query string,
+// q and sort are checked against the language of the filter package
+func parseFilter(r *nethttp.Request) (*models.TaskFilter, error) {
+	q := r.URL.Query()
+	tf := &models.TaskFilter{
+		Status:  q.Get("status"),
+		Project: q.Get("project"),
+		Tag:     q.Get("tag"),
+		Query:   q.Get("q"),
+		Sort:    q.Get("sort"),
+	}
+	validate := validator.New()
+	if err := validate.Struct(tf); err != nil {
+		return tf, err
+	}
+	if _, err := filter.Parse(tf.Query); err != nil {
+		return tf, err
+	}
+	_, err := filter.ParseSort(tf.Sort)
+	return tf, err
+}
+
+// writeFilterError answers 400, saying where a query is wrong
+func writeFilterError(w nethttp.ResponseWriter, err error) {
+	w.WriteHeader(nethttp.StatusBadRequest)
+	if ferr, ok := err.(*filter.Error); ok {
+		w.Write([]byte("invalid filter: " + ferr.Error()))
+		return
+	}
+	w.Write([]byte("invalid filter"))
+}
+
 //List handler
 func (h *TaskHandler) List(w nethttp.ResponseWriter, r *nethttp.Request) {
-	tasks, err := h.TaskUsecase.List()
+	filter, err := parseFilter(r)
 	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	tasks, err := h.TaskUsecase.List(r.Context(), filter)
+	if err != nil {
+		if err == core.ErrForbidden {
+			writeForbidden(w)
+			return
+		}
 		w.WriteHeader(nethttp.StatusInternalServerError)
 		w.Write([]byte("internal server error"))
 		return
@@ -99,8 +193,16 @@ func (h *TaskHandler) Edit(w nethttp.ResponseWriter, r *nethttp.Request) {
 		w.Write([]byte("validation error"))
 		return
 	}
-	err = h.TaskUsecase.Edit(task)
+	err = h.TaskUsecase.Edit(r.Context(), task)
 	if err != nil {
+		if err == core.ErrForbidden {
+			writeForbidden(w)
+			return
+		}
+		if err == core.ErrWIPLimit {
+			writeWIPLimit(w)
+			return
+		}
 		if err == core.ErrRecordNotFound {
 			w.WriteHeader(nethttp.StatusBadRequest)
 			w.Write([]byte("failure"))
@@ -123,8 +225,12 @@ func (h *TaskHandler) Delete(w nethttp.ResponseWriter, r *nethttp.Request) {
 		return
 	}
 	id, _ := strconv.Atoi(chi.URLParam(r, "id"))
-	err := h.TaskUsecase.Delete(id)
+	err := h.TaskUsecase.Delete(r.Context(), id)
 	if err != nil {
+		if err == core.ErrForbidden {
+			writeForbidden(w)
+			return
+		}
 		if err == core.ErrRecordNotFound {
 			w.WriteHeader(nethttp.StatusBadRequest)
 			w.Write([]byte("failure"))
@@ -138,3 +244,35 @@ func (h *TaskHandler) Delete(w nethttp.ResponseWriter, r *nethttp.Request) {
 	w.WriteHeader(nethttp.StatusOK)
 	w.Write([]byte("success"))
 }
+
+//History handler returns the recorded changes of a task
+func (h *TaskHandler) History(w nethttp.ResponseWriter, r *nethttp.Request) {
+	if chi.URLParam(r, "id") == "" {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("id is empty"))
+		return
+	}
+	id, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	events, err := h.TaskUsecase.History(r.Context(), id)
+	if err != nil {
+		if err == core.ErrForbidden {
+			writeForbidden(w)
+			return
+		}
+		if err == core.ErrRecordNotFound {
+			w.WriteHeader(nethttp.StatusNotFound)
+			w.Write([]byte("task not found"))
+			return
+		}
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	res, _ := json.Marshal(map[string]interface{}{
+		"message": "success",
+		"events":  events,
+	})
+	w.Write(res)
+}
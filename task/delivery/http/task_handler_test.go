@@ -9,12 +9,19 @@ import (
 	"io/ioutil"
 	nethttp "net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/pratheeshm/todo-golang/core"
+	idemhttp "github.com/pratheeshm/todo-golang/idempotency/delivery/http"
+	idemrepo "github.com/pratheeshm/todo-golang/idempotency/repository"
+	"github.com/pratheeshm/todo-golang/models"
 	"github.com/pratheeshm/todo-golang/task"
 	"github.com/pratheeshm/todo-golang/task/mocks"
+	userhttp "github.com/pratheeshm/todo-golang/user/delivery/http"
+	usermocks "github.com/pratheeshm/todo-golang/user/mocks"
 )
 
 func TestTaskHandler_Add(t *testing.T) {
@@ -75,6 +82,29 @@ func TestTaskHandler_Add(t *testing.T) {
 			"title":  "Test title",
 		},
 		message: "validation error",
+	}, {
+		name: "tag with a unit separator",
+		fields: fields{
+			TaskUsecase: &mocks.MockUsecase{},
+		},
+		statusCode: 400,
+		body: map[string]interface{}{
+			"status": "todo",
+			"title":  "Test title",
+			"tags":   []string{"math\x1fschool"},
+		},
+		message: "validation error",
+	}, {
+		name: "wip limit reached",
+		fields: fields{
+			TaskUsecase: &mocks.MockUsecase{Error: core.ErrWIPLimit},
+		},
+		statusCode: 422,
+		body: map[string]interface{}{
+			"status": "inprogress",
+			"title":  "Test title",
+		},
+		message: "wip limit reached",
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -107,38 +137,61 @@ func TestTaskHandler_Add(t *testing.T) {
 func TestNewTaskHandler(t *testing.T) {
 	u := &mocks.MockUsecase{}
 	urlStatus := map[bool]string{true: "Found", false: "Not found"}
-	server := httptest.NewServer(NewTaskHandler(u))
+	idempotent := idemhttp.NewIdempotencyMiddleware(idemrepo.NewMemoryIdempotencyRepository(), time.Hour)
+	users := &usermocks.MockUsecase{User: &models.User{ID: 1}}
+	server := httptest.NewServer(NewTaskHandler(u, users, idempotent, userhttp.NewAuthMiddleware(users), []byte("secret")))
 	defer server.Close()
 	baseURL := fmt.Sprintf("%s", server.URL)
 	tests := []struct {
 		name    string
 		method  string
 		url     string
+		token   string
 		isFound bool
 	}{{
 		name:    "Normal Test1:=",
 		method:  "GET",
 		url:     "/list",
+		token:   "access",
+		isFound: true,
+	}, {
+		name:    "calendar feed url",
+		method:  "GET",
+		url:     "/calendar/url?project=school",
+		token:   "access",
+		isFound: true,
+	}, {
+		name:    "calendar feed key rotation",
+		method:  "POST",
+		url:     "/calendar/rotate?project=school",
+		token:   "access",
+		isFound: true,
+	}, {
+		name:    "calendar feed without access token",
+		method:  "GET",
+		url:     "/calendar.ics?token=abc",
 		isFound: true,
 	}, {
 		name:    "invalid endpoint",
 		method:  "GET",
 		url:     "/abc",
+		token:   "access",
 		isFound: false,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var res *nethttp.Response
-			var err error
-			switch method := tt.method; method {
-			case "GET":
-				res, err = nethttp.Get(baseURL + tt.url)
-			default:
-				t.Fatalf("Method not found")
+			req, err := nethttp.NewRequest(tt.method, baseURL+tt.url, nil)
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
 			}
+			res, err := nethttp.DefaultClient.Do(req)
 			if err != nil {
 				t.Fatalf("got error: %v", err)
 			}
+			res.Body.Close()
 			if notFound := (res.StatusCode == nethttp.StatusNotFound); notFound == tt.isFound {
 				t.Fatalf("URL - %v  expected it as %v but got %v",
 					tt.url, urlStatus[tt.isFound], urlStatus[!notFound])
@@ -147,6 +200,70 @@ func TestNewTaskHandler(t *testing.T) {
 	}
 }
 
+func TestNewTaskHandler_authentication(t *testing.T) {
+	idempotent := idemhttp.NewIdempotencyMiddleware(idemrepo.NewMemoryIdempotencyRepository(), time.Hour)
+	users := &usermocks.MockUsecase{Error: core.ErrInvalidToken}
+	h := NewTaskHandler(&mocks.MockUsecase{}, users, idempotent, userhttp.NewAuthMiddleware(users), []byte("secret"))
+	for _, target := range []string{"/add", "/list", "/export", "/search?q=a", "/task/1", "/task/1/history", "/task/1/assignees", "/me/tasks", "/sync", "/tasks/bulk", "/calendar/url", "/calendar/rotate"} {
+		method := "GET"
+		switch target {
+		case "/add", "/tasks/bulk", "/task/1/assignees", "/calendar/rotate":
+			method = "POST"
+		case "/task/1":
+			method = "DELETE"
+		}
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("Authorization", "Bearer expired")
+		h.ServeHTTP(rec, req)
+		if rec.Code != nethttp.StatusUnauthorized {
+			t.Errorf("Test - %s %s , got statuscode %d but expected %d", method, target, rec.Code, nethttp.StatusUnauthorized)
+		}
+	}
+}
+
+func TestNewTaskHandler_forbidden(t *testing.T) {
+	idempotent := idemhttp.NewIdempotencyMiddleware(idemrepo.NewMemoryIdempotencyRepository(), time.Hour)
+	users := &usermocks.MockUsecase{User: &models.User{ID: 3, WorkspaceID: 1}}
+	h := NewTaskHandler(&mocks.MockUsecase{Error: core.ErrForbidden}, users, idempotent, userhttp.NewAuthMiddleware(users), []byte("secret"))
+	tests := []struct {
+		method string
+		target string
+		body   string
+	}{
+		{"POST", "/add", `{"title":"Take math notes","status":"todo"}`},
+		{"GET", "/list", ""},
+		{"GET", "/export", ""},
+		{"GET", "/search?q=math", ""},
+		{"POST", "/import", `[{"title":"Take math notes","status":"todo"}]`},
+		{"PUT", "/task/1", `{"title":"Take math notes","status":"done"}`},
+		{"DELETE", "/task/1", ""},
+		{"GET", "/task/1/history", ""},
+		{"POST", "/task/1/assignees", `{"id_users":[3]}`},
+		{"DELETE", "/task/1/assignees", `{"id_users":[3]}`},
+		{"GET", "/task/1", ""},
+		{"POST", "/task/1/checklist", `{"text":"Read chapter 3"}`},
+		{"POST", "/task/1/checklist/2/toggle", ""},
+		{"POST", "/task/1/checklist/2/move", `{"position":0}`},
+		{"DELETE", "/task/1/checklist/2", ""},
+		{"POST", "/task/1/move", `{"after":2}`},
+		{"GET", "/board", ""},
+		{"GET", "/me/tasks", ""},
+		{"GET", "/sync", ""},
+		{"POST", "/sync", `{"changes":[]}`},
+		{"POST", "/tasks/bulk", `{"operations":[{"op":"delete","id_task":1}]}`},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.body))
+		req.Header.Set("Authorization", "Bearer access")
+		h.ServeHTTP(rec, req)
+		if rec.Code != nethttp.StatusForbidden || rec.Body.String() != "forbidden" {
+			t.Errorf("Test - %s %s , got statuscode %d %q but expected %d", tt.method, tt.target, rec.Code, rec.Body.String(), nethttp.StatusForbidden)
+		}
+	}
+}
+
 func TestTaskHandler_List(t *testing.T) {
 	type fields struct {
 		TaskUsecase task.Usecase
@@ -154,13 +271,30 @@ func TestTaskHandler_List(t *testing.T) {
 	tests := []struct {
 		name       string
 		fields     fields
+		query      string
 		statusCode int
+		body       string
 	}{{
 		name: "Success case",
 		fields: fields{
 			TaskUsecase: &mocks.MockUsecase{},
 		},
 		statusCode: 200,
+	}, {
+		name: "query",
+		fields: fields{
+			TaskUsecase: &mocks.MockUsecase{},
+		},
+		query:      "?q=" + url.QueryEscape(`status:inprogress AND (tag:bug OR title:~"login") AND due<2026-11-01`),
+		statusCode: 200,
+	}, {
+		name: "invalid query",
+		fields: fields{
+			TaskUsecase: &mocks.MockUsecase{},
+		},
+		query:      "?q=" + url.QueryEscape("status:inprogress AND due<soon"),
+		statusCode: 400,
+		body:       `invalid filter: position 27: invalid date "soon", expected YYYY-MM-DD or RFC 3339`,
 	}, {
 		name: "failure case",
 		fields: fields{
@@ -175,13 +309,16 @@ func TestTaskHandler_List(t *testing.T) {
 			h := &TaskHandler{
 				TaskUsecase: tt.fields.TaskUsecase,
 			}
-			req := httptest.NewRequest("GET", "localhost:3000/list", nil)
+			req := httptest.NewRequest("GET", "localhost:3000/list"+tt.query, nil)
 			rec := httptest.NewRecorder()
 			h.List(rec, req)
 			res := rec.Result()
 			if res.StatusCode != tt.statusCode {
 				t.Fatalf("expected statusCode %d but got %d", tt.statusCode, res.StatusCode)
 			}
+			if tt.body != "" && rec.Body.String() != tt.body {
+				t.Errorf("expected body %q but got %q", tt.body, rec.Body.String())
+			}
 		})
 	}
 }
@@ -228,6 +365,19 @@ func TestTaskHandler_Edit(t *testing.T) {
 			"status": "todo",
 		},
 		statusCode: 500,
+	}, {
+		name: "wip limit reached",
+		fields: fields{TaskUsecase: &mocks.MockUsecase{
+			Error: core.ErrWIPLimit,
+		}},
+		urlParam: map[string]string{
+			"id": "3",
+		},
+		body: map[string]interface{}{
+			"title":  "Take math notes",
+			"status": "inprogress",
+		},
+		statusCode: 422,
 	}, {
 		name: "record not found error",
 		fields: fields{TaskUsecase: &mocks.MockUsecase{
@@ -360,3 +510,48 @@ func TestTaskHandler_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskHandler_History(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    task.Usecase
+		urlParam   map[string]string
+		statusCode int
+	}{{
+		name:       "Normal Case1:",
+		usecase:    &mocks.MockUsecase{},
+		urlParam:   map[string]string{"id": "1"},
+		statusCode: 200,
+	}, {
+		name:       "empty id",
+		usecase:    &mocks.MockUsecase{},
+		statusCode: 400,
+	}, {
+		name:       "record not found",
+		usecase:    &mocks.MockUsecase{Error: core.ErrRecordNotFound},
+		urlParam:   map[string]string{"id": "1"},
+		statusCode: 404,
+	}, {
+		name:       "db error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("db error")},
+		urlParam:   map[string]string{"id": "1"},
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TaskHandler{TaskUsecase: tt.usecase}
+			req := httptest.NewRequest("GET", "/task/1/history", nil)
+			ctx := chi.NewRouteContext()
+			for k, v := range tt.urlParam {
+				ctx.URLParams.Add(k, v)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, ctx))
+			rec := httptest.NewRecorder()
+			h.History(rec, req)
+			if res := rec.Result(); res.StatusCode != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d",
+					tt.name, res.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+//MockChecklistRepository implements inerface task.ChecklistRepository
+type MockChecklistRepository struct {
+	Error error
+	Items []*models.ChecklistItem
+}
+
+//List returns copies of the items of the task ordered by position
+func (m *MockChecklistRepository) List(ctx context.Context, taskID int) ([]*models.ChecklistItem, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	items := []*models.ChecklistItem{}
+	for _, item := range m.Items {
+		if item.TaskID == taskID {
+			found := *item
+			items = append(items, &found)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Position < items[j].Position
+	})
+	return items, nil
+}
+
+func (m *MockChecklistRepository) count(taskID int) int {
+	n := 0
+	for _, item := range m.Items {
+		if item.TaskID == taskID {
+			n++
+		}
+	}
+	return n
+}
+
+//Add appends a copy of the item to Items, last of its task
+func (m *MockChecklistRepository) Add(ctx context.Context, item *models.ChecklistItem) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	item.ID = len(m.Items) + 1
+	item.Position = m.count(item.TaskID)
+	item.CreatedAt = time.Now()
+	stored := *item
+	m.Items = append(m.Items, &stored)
+	transaction.OnRollback(ctx, func() {
+		m.Items = m.Items[:len(m.Items)-1]
+	})
+	return nil
+}
+
+func (m *MockChecklistRepository) find(taskID, id int) (*models.ChecklistItem, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	for _, item := range m.Items {
+		if item.ID == id && item.TaskID == taskID {
+			return item, nil
+		}
+	}
+	return nil, core.ErrRecordNotFound
+}
+
+//Toggle flips the item of Items
+func (m *MockChecklistRepository) Toggle(ctx context.Context, taskID, id int) (*models.ChecklistItem, error) {
+	item, err := m.find(taskID, id)
+	if err != nil {
+		return nil, err
+	}
+	item.Checked = !item.Checked
+	transaction.OnRollback(ctx, func() {
+		item.Checked = !item.Checked
+	})
+	found := *item
+	return &found, nil
+}
+
+//Move renumbers the items of the task with the item at position
+func (m *MockChecklistRepository) Move(ctx context.Context, taskID, id, position int) error {
+	item, err := m.find(taskID, id)
+	if err != nil {
+		return err
+	}
+	items, _ := m.List(ctx, taskID)
+	others := []*models.ChecklistItem{}
+	for _, other := range items {
+		if other.ID != id {
+			others = append(others, other)
+		}
+	}
+	if position > len(others) {
+		position = len(others)
+	}
+	for rank, other := range others {
+		if rank >= position {
+			rank++
+		}
+		stored, _ := m.find(taskID, other.ID)
+		stored.Position = rank
+	}
+	item.Position = position
+	return nil
+}
+
+//Delete removes the item from Items and closes the gap it leaves
+func (m *MockChecklistRepository) Delete(ctx context.Context, taskID, id int) error {
+	item, err := m.find(taskID, id)
+	if err != nil {
+		return err
+	}
+	kept := []*models.ChecklistItem{}
+	for _, stored := range m.Items {
+		if stored == item {
+			continue
+		}
+		if stored.TaskID == taskID && stored.Position > item.Position {
+			stored.Position--
+		}
+		kept = append(kept, stored)
+	}
+	m.Items = kept
+	return nil
+}
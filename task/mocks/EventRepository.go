@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+//MockEventRepository implements inerface task.EventRepository
+type MockEventRepository struct {
+	Error  error
+	Events []*models.TaskEvent
+}
+
+//Add appends the events to Events, the append is undone when the transaction of ctx rolls back
+func (m *MockEventRepository) Add(ctx context.Context, events ...*models.TaskEvent) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	n := len(m.Events)
+	m.Events = append(m.Events, events...)
+	transaction.OnRollback(ctx, func() {
+		m.Events = m.Events[:n]
+	})
+	return nil
+}
+
+//List returns the events of the task
+func (m *MockEventRepository) List(ctx context.Context, taskID int) ([]*models.TaskEvent, error) {
+	events := []*models.TaskEvent{}
+	for _, e := range m.Events {
+		if e.TaskID == taskID {
+			events = append(events, e)
+		}
+	}
+	return events, m.Error
+}
@@ -1,31 +1,329 @@
 package mocks
 
 import (
+	"context"
+	"sort"
+
+	"github.com/pratheeshm/todo-golang/core"
 	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/rank"
+	"github.com/pratheeshm/todo-golang/transaction"
 )
 
 //MockRepository implements inerface task.Repository
 type MockRepository struct {
 	Error error
 	Tasks []*models.Task
+	// Ranks holds the ranks of the tasks by id, the tasks missing have none
+	Ranks map[int]string
+	// Locked holds the statuses locked by LockStatus, in order
+	Locked []string
 }
 
 //Delete task
-func (m *MockRepository) Delete(id int) error {
+func (m *MockRepository) Delete(ctx context.Context, id int) error {
 	return m.Error
 }
 
-//Add task
-func (m *MockRepository) Add(task *models.Task) error {
-	return m.Error
+//Add appends the task to Tasks, the append is undone when the transaction of ctx rolls back
+func (m *MockRepository) Add(ctx context.Context, task *models.Task) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	task.ID = len(m.Tasks) + 1
+	m.Tasks = append(m.Tasks, task)
+	transaction.OnRollback(ctx, func() {
+		m.Tasks = m.Tasks[:len(m.Tasks)-1]
+	})
+	return nil
 }
 
-//Edit task
-func (m *MockRepository) Edit(task *models.Task) error {
-	return m.Error
+//Edit replaces the fields of the task of Tasks with the same id, when there is one
+func (m *MockRepository) Edit(ctx context.Context, task *models.Task) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	if t := m.find(task.ID); t != nil && t != task {
+		old := *t
+		t.Status, t.Title, t.Description, t.Priority, t.Project, t.Tags, t.DueDate = task.Status, task.Title, task.Description, task.Priority, task.Project, task.Tags, task.DueDate
+		transaction.OnRollback(ctx, func() {
+			*t = old
+		})
+	}
+	return nil
+}
+
+//Get returns the task of Tasks with the given id
+func (m *MockRepository) Get(ctx context.Context, id int) (*models.Task, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if t := m.find(id); t != nil {
+		return t, nil
+	}
+	return nil, core.ErrRecordNotFound
 }
 
 //List tasks
-func (m *MockRepository) List() ([]*models.Task, error) {
+func (m *MockRepository) List(ctx context.Context, filter *models.TaskFilter) ([]*models.Task, error) {
 	return m.Tasks, m.Error
 }
+
+//Each calls fn for every task of Tasks
+func (m *MockRepository) Each(ctx context.Context, filter *models.TaskFilter, fn func(*models.Task) error) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for _, t := range m.Tasks {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Changes returns the tasks of Tasks after the cursor
+func (m *MockRepository) Changes(ctx context.Context, since models.SyncCursor, limit int) ([]*models.Task, error) {
+	tasks := make([]*models.Task, 0)
+	for _, t := range m.Tasks {
+		if since.Before(models.SyncCursor{TxID: t.ChangeTxID, Seq: t.Version}) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks, m.Error
+}
+
+//EditVersion compares version against the task of Tasks with the same id and edits it when they match
+func (m *MockRepository) EditVersion(ctx context.Context, task *models.Task, version int64) error {
+	if err := m.checkVersion(task.ID, version); err != nil {
+		return err
+	}
+	return m.Edit(ctx, task)
+}
+
+//DeleteVersion compares version against the task of Tasks with the same id
+func (m *MockRepository) DeleteVersion(ctx context.Context, id int, version int64) error {
+	return m.checkVersion(id, version)
+}
+
+func (m *MockRepository) find(id int) *models.Task {
+	for _, t := range m.Tasks {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (m *MockRepository) checkVersion(id int, version int64) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	t := m.find(id)
+	if t == nil {
+		return core.ErrRecordNotFound
+	}
+	if t.Version != version {
+		return core.ErrConflict
+	}
+	return nil
+}
+
+//AddMany gives the tasks consecutive ids after the ones in Tasks
+func (m *MockRepository) AddMany(ctx context.Context, tasks []*models.Task) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for i, t := range tasks {
+		t.ID = len(m.Tasks) + i + 1
+	}
+	return nil
+}
+
+//EditMany returns the ids found in Tasks
+func (m *MockRepository) EditMany(ctx context.Context, tasks []*models.Task) ([]int, error) {
+	ids := make([]int, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	return m.found(ids)
+}
+
+//DeleteMany returns the ids found in Tasks
+func (m *MockRepository) DeleteMany(ctx context.Context, ids []int) ([]int, error) {
+	return m.found(ids)
+}
+
+//SetStatusMany returns the ids found in Tasks
+func (m *MockRepository) SetStatusMany(ctx context.Context, ids []int, status string) ([]int, error) {
+	return m.found(ids)
+}
+
+func (m *MockRepository) found(ids []int) ([]int, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	found := []int{}
+	for _, id := range ids {
+		if m.find(id) != nil {
+			found = append(found, id)
+		}
+	}
+	return found, nil
+}
+
+//Assign adds the users to the assignees of the task of Tasks and returns the ones it added
+func (m *MockRepository) Assign(ctx context.Context, id int, users []int) ([]int, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	t := m.find(id)
+	if t == nil {
+		return nil, core.ErrRecordNotFound
+	}
+	added := []int{}
+	for _, u := range users {
+		if !hasInt(t.Assignees, u) {
+			t.Assignees = append(t.Assignees, u)
+			added = append(added, u)
+		}
+	}
+	return added, nil
+}
+
+//Unassign removes the users from the assignees of the task of Tasks and returns the ones it removed
+func (m *MockRepository) Unassign(ctx context.Context, id int, users []int) ([]int, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	t := m.find(id)
+	if t == nil {
+		return nil, core.ErrRecordNotFound
+	}
+	kept, removed := []int{}, []int{}
+	for _, u := range t.Assignees {
+		if hasInt(users, u) {
+			removed = append(removed, u)
+		} else {
+			kept = append(kept, u)
+		}
+	}
+	t.Assignees = kept
+	return removed, nil
+}
+
+func hasInt(ints []int, n int) bool {
+	for _, i := range ints {
+		if i == n {
+			return true
+		}
+	}
+	return false
+}
+
+// ordered returns the ids of Tasks in the manual order, the unranked ones last
+func (m *MockRepository) ordered() []int {
+	ids := make([]int, len(m.Tasks))
+	for i, t := range m.Tasks {
+		ids[i] = t.ID
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		ri, iok := m.Ranks[ids[i]]
+		rj, jok := m.Ranks[ids[j]]
+		if iok != jok {
+			return iok
+		}
+		if ri != rj {
+			return ri < rj
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+//Bounds returns the ranks around the neighbours of Tasks the way the repository does
+func (m *MockRepository) Bounds(ctx context.Context, id, after, before int) (string, string, error) {
+	if m.Error != nil {
+		return "", "", m.Error
+	}
+	for _, n := range []int{after, before} {
+		if n == 0 {
+			continue
+		}
+		if m.find(n) == nil {
+			return "", "", core.ErrRecordNotFound
+		}
+		if _, ok := m.Ranks[n]; !ok {
+			return "", "", core.ErrUnranked
+		}
+	}
+	others := []int{}
+	for _, o := range m.ordered() {
+		if o != id {
+			others = append(others, o)
+		}
+	}
+	for i, o := range others {
+		switch {
+		case after != 0 && o == after:
+			if before != 0 {
+				return m.Ranks[after], m.Ranks[before], nil
+			}
+			if i+1 < len(others) {
+				return m.Ranks[after], m.Ranks[others[i+1]], nil
+			}
+			return m.Ranks[after], "", nil
+		case after == 0 && o == before:
+			if i > 0 {
+				return m.Ranks[others[i-1]], m.Ranks[before], nil
+			}
+			return "", m.Ranks[before], nil
+		}
+	}
+	// the neighbour is the moved task itself
+	return m.Ranks[after], m.Ranks[before], nil
+}
+
+//SetRank changes the rank of the task in Ranks
+func (m *MockRepository) SetRank(ctx context.Context, id int, r string) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	if m.find(id) == nil {
+		return core.ErrRecordNotFound
+	}
+	if m.Ranks == nil {
+		m.Ranks = map[int]string{}
+	}
+	m.Ranks[id] = r
+	return nil
+}
+
+//Rebalance spreads the ranks of Tasks, keeping their order
+func (m *MockRepository) Rebalance(ctx context.Context) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	ids := m.ordered()
+	ranks := map[int]string{}
+	for i, r := range rank.Spread(len(ids)) {
+		ranks[ids[i]] = r
+	}
+	m.Ranks = ranks
+	return nil
+}
+
+//LockStatus appends status to Locked and counts the tasks of Tasks in status
+func (m *MockRepository) LockStatus(ctx context.Context, status string) (int, error) {
+	if m.Error != nil {
+		return 0, m.Error
+	}
+	m.Locked = append(m.Locked, status)
+	n := 0
+	for _, t := range m.Tasks {
+		if t.Status == status {
+			n++
+		}
+	}
+	return n, nil
+}
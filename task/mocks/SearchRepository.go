@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockSearchRepository implements inerface task.SearchRepository
+type MockSearchRepository struct {
+	Error   error
+	Results []*models.SearchResult
+}
+
+//Search returns Results
+func (m *MockSearchRepository) Search(ctx context.Context, query *models.SearchQuery) ([]*models.SearchResult, error) {
+	return m.Results, m.Error
+}
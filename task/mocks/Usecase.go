@@ -1,29 +1,148 @@
 package mocks
 
-import "github.com/pratheeshm/todo-golang/models"
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
 
 //MockUsecase implements inerface task.Usecase
 type MockUsecase struct {
-	Error error
-	Tasks []*models.Task
+	Error         error
+	Tasks         []*models.Task
+	Delta         *models.SyncDelta
+	SyncResults   []*models.SyncResult
+	BulkResponse  *models.BulkResponse
+	Events        []*models.TaskEvent
+	ImportResult  *models.ImportResult
+	SearchResults []*models.SearchResult
+	TaskBoard     *models.Board
+	// Filter is the filter of the last call to List or Export
+	Filter *models.TaskFilter
 }
 
 //Add task
-func (m *MockUsecase) Add(*models.Task) error {
+func (m *MockUsecase) Add(context.Context, *models.Task) error {
 	return m.Error
 }
 
 //Delete task
-func (m *MockUsecase) Delete(int) error {
+func (m *MockUsecase) Delete(context.Context, int) error {
 	return m.Error
 }
 
 //Edit task
-func (m *MockUsecase) Edit(*models.Task) error {
+func (m *MockUsecase) Edit(context.Context, *models.Task) error {
 	return m.Error
 }
 
-//List tasks
-func (m *MockUsecase) List() ([]*models.Task, error) {
+//EditVersion task
+func (m *MockUsecase) EditVersion(context.Context, *models.Task, int64) error {
+	return m.Error
+}
+
+//List returns Tasks and keeps the filter in Filter
+func (m *MockUsecase) List(ctx context.Context, filter *models.TaskFilter) ([]*models.Task, error) {
+	m.Filter = filter
 	return m.Tasks, m.Error
 }
+
+//Export calls fn for every task of Tasks and keeps the filter in Filter
+func (m *MockUsecase) Export(ctx context.Context, filter *models.TaskFilter, fn func(*models.Task) error) error {
+	m.Filter = filter
+	if m.Error != nil {
+		return m.Error
+	}
+	for _, t := range m.Tasks {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//History returns Events
+func (m *MockUsecase) History(ctx context.Context, id int) ([]*models.TaskEvent, error) {
+	return m.Events, m.Error
+}
+
+//Sync returns Delta
+func (m *MockUsecase) Sync(ctx context.Context, token string, limit int) (*models.SyncDelta, error) {
+	return m.Delta, m.Error
+}
+
+//Push returns SyncResults
+func (m *MockUsecase) Push(context.Context, []*models.SyncChange) ([]*models.SyncResult, error) {
+	return m.SyncResults, m.Error
+}
+
+//Bulk returns BulkResponse
+func (m *MockUsecase) Bulk(context.Context, *models.BulkRequest) (*models.BulkResponse, error) {
+	return m.BulkResponse, m.Error
+}
+
+//Import returns ImportResult
+func (m *MockUsecase) Import(ctx context.Context, rows []*models.ImportRow, dryRun bool) (*models.ImportResult, error) {
+	return m.ImportResult, m.Error
+}
+
+//Search returns SearchResults
+func (m *MockUsecase) Search(ctx context.Context, query *models.SearchQuery) ([]*models.SearchResult, error) {
+	return m.SearchResults, m.Error
+}
+
+//Assign returns the first task of Tasks
+func (m *MockUsecase) Assign(ctx context.Context, id int, users []int) (*models.Task, error) {
+	return m.first()
+}
+
+//Unassign returns the first task of Tasks
+func (m *MockUsecase) Unassign(ctx context.Context, id int, users []int) (*models.Task, error) {
+	return m.first()
+}
+
+//Get returns the first task of Tasks
+func (m *MockUsecase) Get(ctx context.Context, id int) (*models.Task, error) {
+	return m.first()
+}
+
+//AddChecklistItem returns the first task of Tasks
+func (m *MockUsecase) AddChecklistItem(ctx context.Context, item *models.ChecklistItem) (*models.Task, error) {
+	return m.first()
+}
+
+//ToggleChecklistItem returns the first task of Tasks
+func (m *MockUsecase) ToggleChecklistItem(ctx context.Context, taskID, id int) (*models.Task, error) {
+	return m.first()
+}
+
+//MoveChecklistItem returns the first task of Tasks
+func (m *MockUsecase) MoveChecklistItem(ctx context.Context, taskID, id, position int) (*models.Task, error) {
+	return m.first()
+}
+
+//DeleteChecklistItem returns the first task of Tasks
+func (m *MockUsecase) DeleteChecklistItem(ctx context.Context, taskID, id int) (*models.Task, error) {
+	return m.first()
+}
+
+//Move returns the first task of Tasks
+func (m *MockUsecase) Move(ctx context.Context, id int, move *models.TaskMove) (*models.Task, error) {
+	return m.first()
+}
+
+//Board returns TaskBoard
+func (m *MockUsecase) Board(ctx context.Context) (*models.Board, error) {
+	return m.TaskBoard, m.Error
+}
+
+func (m *MockUsecase) first() (*models.Task, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if len(m.Tasks) == 0 {
+		return nil, core.ErrRecordNotFound
+	}
+	return m.Tasks[0], nil
+}
@@ -1,13 +1,79 @@
 package task
 
 import (
+	"context"
+
 	"github.com/pratheeshm/todo-golang/models"
 )
 
 //Repository represents task's interface
 type Repository interface {
-	Add(*models.Task) error
-	Delete(int) error
-	Edit(*models.Task) error
-	List() ([]*models.Task, error)
+	Add(context.Context, *models.Task) error
+	Delete(context.Context, int) error
+	Edit(context.Context, *models.Task) error
+	Get(context.Context, int) (*models.Task, error)
+	List(context.Context, *models.TaskFilter) ([]*models.Task, error)
+	// Each calls fn for every task matching the filter, one row at a time
+	Each(ctx context.Context, filter *models.TaskFilter, fn func(*models.Task) error) error
+	// Changes returns up to limit tasks, tombstones included, changed after
+	// the cursor by committed transactions ordered by cursor
+	Changes(ctx context.Context, since models.SyncCursor, limit int) ([]*models.Task, error)
+	// EditVersion edits the task only if it is still at the given version
+	EditVersion(ctx context.Context, task *models.Task, version int64) error
+	// DeleteVersion deletes the task only if it is still at the given version
+	DeleteVersion(ctx context.Context, id int, version int64) error
+	// AddMany adds the tasks with a single statement
+	AddMany(context.Context, []*models.Task) error
+	// EditMany edits the tasks with a single statement and returns the ids it found
+	EditMany(context.Context, []*models.Task) ([]int, error)
+	// DeleteMany deletes the tasks with a single statement and returns the ids it found
+	DeleteMany(context.Context, []int) ([]int, error)
+	// SetStatusMany moves the tasks to status and returns the ids it found
+	SetStatusMany(ctx context.Context, ids []int, status string) ([]int, error)
+	// Assign adds the users to the assignees of the task and returns the ones it added,
+	// core.ErrNotMember is returned when a user has no role in the workspace
+	Assign(ctx context.Context, id int, users []int) ([]int, error)
+	// Unassign removes the users from the assignees of the task and returns the ones it removed
+	Unassign(ctx context.Context, id int, users []int) ([]int, error)
+	// Bounds returns the ranks a task moved right after the task after and right
+	// before the task before goes between, looking up the missing neighbour.
+	// An empty rank is a bound of the order, core.ErrUnranked is returned when
+	// the lower bound has no rank
+	Bounds(ctx context.Context, id, after, before int) (lower, upper string, err error)
+	// SetRank changes the rank of a task
+	SetRank(ctx context.Context, id int, rank string) error
+	// Rebalance spreads the ranks of all the tasks of the workspace evenly, keeping their order
+	Rebalance(context.Context) error
+	// LockStatus locks the column of status until the end of the transaction, so
+	// that writes checking its WIP limit run one at a time, and returns the number
+	// of tasks of the workspace in status
+	LockStatus(ctx context.Context, status string) (int, error)
+}
+
+//SearchRepository represents task full-text search's interface
+type SearchRepository interface {
+	// Search returns the tasks matching the query, best match first
+	Search(context.Context, *models.SearchQuery) ([]*models.SearchResult, error)
+}
+
+//EventRepository represents task history's interface
+type EventRepository interface {
+	// Add records the events with a single statement
+	Add(context.Context, ...*models.TaskEvent) error
+	// List returns the history of a task, oldest first
+	List(ctx context.Context, taskID int) ([]*models.TaskEvent, error)
+}
+
+//ChecklistRepository represents the checklists of the tasks' interface,
+//the items are read and changed through the task they belong to
+type ChecklistRepository interface {
+	// List returns the items of the checklist of a task ordered by position
+	List(ctx context.Context, taskID int) ([]*models.ChecklistItem, error)
+	// Add appends the item to the checklist of its task
+	Add(context.Context, *models.ChecklistItem) error
+	// Toggle flips the checked state of an item and returns it
+	Toggle(ctx context.Context, taskID, id int) (*models.ChecklistItem, error)
+	// Move puts an item at position and renumbers the items of the checklist
+	Move(ctx context.Context, taskID, id, position int) error
+	Delete(ctx context.Context, taskID, id int) error
 }
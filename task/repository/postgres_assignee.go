@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+// the old assignees are read in a CTE, the RETURNING clause compares them with the users
+const (
+	assignQuery = "WITH old AS (SELECT assignees FROM task WHERE id_task = $2 AND id_workspace = $3 AND NOT deleted FOR UPDATE) " +
+		"UPDATE task t SET assignees = ARRAY(SELECT DISTINCT u FROM unnest(old.assignees || $1::int[]) AS u ORDER BY u) " +
+		"FROM old WHERE t.id_task = $2 AND t.id_workspace = $3 " +
+		"RETURNING ARRAY(SELECT u FROM unnest($1::int[]) AS u EXCEPT SELECT unnest(old.assignees) ORDER BY 1)"
+	unassignQuery = "WITH old AS (SELECT assignees FROM task WHERE id_task = $2 AND id_workspace = $3 AND NOT deleted FOR UPDATE) " +
+		"UPDATE task t SET assignees = ARRAY(SELECT u FROM unnest(old.assignees) AS u WHERE u <> ALL($1::int[]) ORDER BY u) " +
+		"FROM old WHERE t.id_task = $2 AND t.id_workspace = $3 " +
+		"RETURNING ARRAY(SELECT u FROM unnest(old.assignees) AS u WHERE u = ANY($1::int[]) ORDER BY u)"
+)
+
+// distinct returns the ids without duplicates, in their first order
+func distinct(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	res := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+func (p *postgresTaskRepository) Assign(ctx context.Context, id int, users []int) ([]int, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	users = distinct(users)
+	// any grant in the workspace makes a user assignable, a shared task included
+	var members int
+	err = p.conn(ctx).QueryRowContext(ctx, "SELECT count(DISTINCT id_user) FROM role_grant WHERE id_workspace = $1 AND id_user = ANY($2::int[])",
+		workspace, pq.Array(toInt64s(users))).Scan(&members)
+	if err != nil {
+		return nil, err
+	}
+	if members != len(users) {
+		return nil, core.ErrNotMember
+	}
+	return p.changeAssignees(ctx, assignQuery, id, users, workspace)
+}
+
+func (p *postgresTaskRepository) Unassign(ctx context.Context, id int, users []int) ([]int, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.changeAssignees(ctx, unassignQuery, id, distinct(users), workspace)
+}
+
+func (p *postgresTaskRepository) changeAssignees(ctx context.Context, query string, id int, users []int, workspace int) ([]int, error) {
+	var changed []int
+	err := p.conn(ctx).QueryRowContext(ctx, query, pq.Array(toInt64s(users)), id, workspace).Scan(intArray{&changed})
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
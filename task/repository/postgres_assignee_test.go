@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_postgresTaskRepository_Assign(t *testing.T) {
+	members := "SELECT count(DISTINCT id_user) FROM role_grant WHERE id_workspace = $1 AND id_user = ANY($2::int[])"
+	tests := []struct {
+		name      string
+		members   int
+		rows      *sqlmock.Rows
+		dbError   error
+		want      []int
+		wantErr   error
+		wantQuery bool
+	}{{
+		name:      "Normal Case 1: assign two users, one already assigned",
+		members:   2,
+		rows:      sqlmock.NewRows([]string{"array"}).AddRow("{5}"),
+		want:      []int{5},
+		wantQuery: true,
+	}, {
+		name:    "user out of the workspace",
+		members: 1,
+		wantErr: core.ErrNotMember,
+	}, {
+		name:      "task not found",
+		members:   2,
+		rows:      sqlmock.NewRows([]string{"array"}),
+		wantErr:   core.ErrRecordNotFound,
+		wantQuery: true,
+	}, {
+		name:      "db error",
+		members:   2,
+		rows:      sqlmock.NewRows([]string{"array"}),
+		dbError:   errors.New("db error"),
+		wantErr:   errors.New("db error"),
+		wantQuery: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(members)).WithArgs(coremocks.Tenant, "{3,5}").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.members))
+			if tt.wantQuery {
+				mock.ExpectQuery(regexp.QuoteMeta(assignQuery)).WithArgs("{3,5}", 1, coremocks.Tenant).
+					WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			}
+			got, err := NewPostgresTaskRepository(db).Assign(coremocks.TenantContext(), 1, []int{3, 5, 3})
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func Test_postgresTaskRepository_Unassign(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(unassignQuery)).WithArgs("{3}", 1, coremocks.Tenant).
+		WillReturnRows(sqlmock.NewRows([]string{"array"}).AddRow("{3}"))
+	got, err := NewPostgresTaskRepository(db).Unassign(coremocks.TenantContext(), 1, []int{3})
+	if err != nil || !reflect.DeepEqual(got, []int{3}) {
+		t.Errorf("postgresTaskRepository.Unassign() = %v, %v, want [3]", got, err)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(unassignQuery)).WithArgs("{3}", 2, coremocks.Tenant).
+		WillReturnRows(sqlmock.NewRows([]string{"array"}))
+	if _, err := NewPostgresTaskRepository(db).Unassign(coremocks.TenantContext(), 2, []int{3}); err != core.ErrRecordNotFound {
+		t.Errorf("postgresTaskRepository.Unassign() error = %v, wantErr %v", err, core.ErrRecordNotFound)
+	}
+}
+
+func Test_distinct(t *testing.T) {
+	if got := distinct([]int{5, 3, 5, 1, 3}); !reflect.DeepEqual(got, []int{5, 3, 1}) {
+		t.Errorf("distinct() = %v, want [5 3 1]", got)
+	}
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+// LockStatus takes a transaction level advisory lock on the column, the count
+// is a separate statement so that it sees the writes committed while waiting
+func (p *postgresTaskRepository) LockStatus(ctx context.Context, status string) (int, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	_, err = p.conn(ctx).ExecContext(ctx, "SELECT pg_advisory_xact_lock(id_workspace, hashtext($2)) FROM workspace WHERE id_workspace = $1", workspace, status)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	err = p.conn(ctx).QueryRowContext(ctx, "SELECT count(*) FROM task WHERE id_workspace = $1 AND NOT deleted AND status = $2", workspace, status).Scan(&n)
+	return n, err
+}
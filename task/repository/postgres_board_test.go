@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_postgresTaskRepository_LockStatus(t *testing.T) {
+	lock := "SELECT pg_advisory_xact_lock(id_workspace, hashtext($2)) FROM workspace WHERE id_workspace = $1"
+	query := "SELECT count(*) FROM task WHERE id_workspace = $1 AND NOT deleted AND status = $2"
+	tests := []struct {
+		name    string
+		lockErr error
+		rows    *sqlmock.Rows
+		dbError error
+		want    int
+		wantErr bool
+	}{
+		{name: "Normal Case 1: tasks in progress", rows: sqlmock.NewRows([]string{"count"}).AddRow(3), want: 3},
+		{name: "lock error", lockErr: errors.New("deadlock detected"), wantErr: true},
+		{name: "db error", rows: sqlmock.NewRows([]string{"count"}), dbError: errors.New("db error"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta(lock)).WithArgs(coremocks.Tenant, "inprogress").
+				WillReturnResult(sqlmock.NewResult(0, 1)).WillReturnError(tt.lockErr)
+			if tt.lockErr == nil {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant, "inprogress").WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			}
+			got, err := NewPostgresTaskRepository(db).LockStatus(coremocks.TenantContext(), "inprogress")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Test %s - got = %d, want %d", tt.name, got, tt.want)
+			}
+		})
+	}
+}
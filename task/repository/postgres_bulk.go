@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+// tagSeparator joins the tags of a task in AddMany, models.Task does not accept it in a tag
+const tagSeparator = "\x1f"
+
+func (p *postgresTaskRepository) AddMany(ctx context.Context, tasks []*models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	createdBy := creator(ctx)
+	titles := make([]string, len(tasks))
+	statuses := make([]string, len(tasks))
+	descriptions := make([]string, len(tasks))
+	priorities := make([]int64, len(tasks))
+	projects := make([]string, len(tasks))
+	// arrays of arrays must be rectangular, so tags travel joined by a unit separator
+	tagLists := make([]string, len(tasks))
+	// and due dates as text, empty when unset
+	dueDates := make([]string, len(tasks))
+	for i, t := range tasks {
+		titles[i] = t.Title
+		statuses[i] = t.Status
+		descriptions[i] = t.Description
+		priorities[i] = int64(t.Priority)
+		projects[i] = t.Project
+		tagLists[i] = strings.Join(t.Tags, tagSeparator)
+		t.CreatedBy = createdBy
+		if t.DueDate != nil {
+			dueDates[i] = t.DueDate.Format(time.RFC3339Nano)
+		}
+	}
+	// the ids are taken before the insert so that each row can be matched with its
+	// ordinal, the order of the rows RETURNING answers is not defined
+	rows, err := p.conn(ctx).QueryContext(ctx, "WITH v AS (SELECT nextval('task_id_task_seq') AS id_task, * FROM unnest($1::varchar[], $2::varchar[], $3::text[], $4::smallint[], $5::varchar[], $6::text[], $7::text[]) WITH ORDINALITY AS v(title, status, description, priority, project, tags, due_date, ordinal)), i AS (INSERT INTO task(id_task, title, status, description, priority, project, tags, due_date, id_workspace, created_by) SELECT v.id_task, v.title, v.status, v.description, v.priority, v.project, string_to_array(v.tags, E'\\x1f'), NULLIF(v.due_date, '')::timestamptz, $8, NULLIF($9, 0) FROM v RETURNING id_task, change_seq) SELECT v.ordinal, i.id_task, i.change_seq FROM i JOIN v ON v.id_task = i.id_task",
+		pq.Array(titles), pq.Array(statuses), pq.Array(descriptions), pq.Array(priorities), pq.Array(projects), pq.Array(tagLists), pq.Array(dueDates),
+		workspace, createdBy)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ordinal int
+		var id int
+		var version int64
+		if err := rows.Scan(&ordinal, &id, &version); err != nil {
+			return err
+		}
+		if ordinal < 1 || ordinal > len(tasks) {
+			continue
+		}
+		tasks[ordinal-1].ID, tasks[ordinal-1].Version = id, version
+	}
+	return rows.Err()
+}
+
+// EditMany only changes title and status, which is all a bulk update carries
+func (p *postgresTaskRepository) EditMany(ctx context.Context, tasks []*models.Task) ([]int, error) {
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(tasks))
+	titles := make([]string, len(tasks))
+	statuses := make([]string, len(tasks))
+	byID := make(map[int]*models.Task, len(tasks))
+	for i, t := range tasks {
+		ids[i] = int64(t.ID)
+		titles[i] = t.Title
+		statuses[i] = t.Status
+		byID[t.ID] = t
+	}
+	rows, err := p.conn(ctx).QueryContext(ctx, "UPDATE task t SET title = v.title, status = v.status FROM unnest($1::int[], $2::varchar[], $3::varchar[]) AS v(id_task, title, status) WHERE t.id_task = v.id_task AND t.id_workspace = $4 AND NOT t.deleted RETURNING t.id_task, t.change_seq",
+		pq.Array(ids), pq.Array(titles), pq.Array(statuses), workspace)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	found := make([]int, 0, len(tasks))
+	for rows.Next() {
+		var id int
+		var version int64
+		if err := rows.Scan(&id, &version); err != nil {
+			return nil, err
+		}
+		if t, ok := byID[id]; ok {
+			t.Version = version
+		}
+		found = append(found, id)
+	}
+	return found, rows.Err()
+}
+
+func (p *postgresTaskRepository) DeleteMany(ctx context.Context, ids []int) ([]int, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return scanIDs(p.conn(ctx).QueryContext(ctx, "UPDATE task SET deleted = true WHERE id_task = ANY($1) AND id_workspace = $2 AND NOT deleted RETURNING id_task",
+		pq.Array(toInt64s(ids)), workspace))
+}
+
+func (p *postgresTaskRepository) SetStatusMany(ctx context.Context, ids []int, status string) ([]int, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return scanIDs(p.conn(ctx).QueryContext(ctx, "UPDATE task SET status = $1 WHERE id_task = ANY($2) AND id_workspace = $3 AND NOT deleted RETURNING id_task",
+		status, pq.Array(toInt64s(ids)), workspace))
+}
+
+func scanIDs(rows *sql.Rows, err error) ([]int, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func toInt64s(ids []int) []int64 {
+	res := make([]int64, len(ids))
+	for i, id := range ids {
+		res[i] = int64(id)
+	}
+	return res
+}
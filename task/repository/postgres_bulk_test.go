@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	addManyQuery       = "WITH v AS (SELECT nextval('task_id_task_seq') AS id_task, * FROM unnest($1::varchar[], $2::varchar[], $3::text[], $4::smallint[], $5::varchar[], $6::text[], $7::text[]) WITH ORDINALITY AS v(title, status, description, priority, project, tags, due_date, ordinal)), i AS (INSERT INTO task(id_task, title, status, description, priority, project, tags, due_date, id_workspace, created_by) SELECT v.id_task, v.title, v.status, v.description, v.priority, v.project, string_to_array(v.tags, E'\\x1f'), NULLIF(v.due_date, '')::timestamptz, $8, NULLIF($9, 0) FROM v RETURNING id_task, change_seq) SELECT v.ordinal, i.id_task, i.change_seq FROM i JOIN v ON v.id_task = i.id_task"
+	editManyQuery      = "UPDATE task t SET title = v.title, status = v.status FROM unnest($1::int[], $2::varchar[], $3::varchar[]) AS v(id_task, title, status) WHERE t.id_task = v.id_task AND t.id_workspace = $4 AND NOT t.deleted RETURNING t.id_task, t.change_seq"
+	deleteManyQuery    = "UPDATE task SET deleted = true WHERE id_task = ANY($1) AND id_workspace = $2 AND NOT deleted RETURNING id_task"
+	setStatusManyQuery = "UPDATE task SET status = $1 WHERE id_task = ANY($2) AND id_workspace = $3 AND NOT deleted RETURNING id_task"
+)
+
+func Test_postgresTaskRepository_AddMany(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	tasks := []*models.Task{
+		{Title: "Take math notes", Status: "todo"},
+		{Title: "do physics homework", Status: "done"},
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(addManyQuery)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), coremocks.Tenant, 0).
+		WillReturnRows(mock.NewRows([]string{"ordinal", "id_task", "change_seq"}).AddRow(2, 8, 21).AddRow(1, 7, 20))
+	p := NewPostgresTaskRepository(db)
+	if err := p.AddMany(coremocks.TenantContext(), tasks); err != nil {
+		t.Fatalf("postgresTaskRepository.AddMany() error = %v", err)
+	}
+	if tasks[0].ID != 7 || tasks[0].Version != 20 || tasks[1].ID != 8 || tasks[1].Version != 21 {
+		t.Errorf("expected ids and versions to be set by ordinal, got %v %v", tasks[0], tasks[1])
+	}
+	if err := p.AddMany(coremocks.TenantContext(), nil); err != nil {
+		t.Errorf("empty batch should not hit the db, got %v", err)
+	}
+}
+
+func Test_postgresTaskRepository_EditMany(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	tasks := []*models.Task{
+		{ID: 1, Title: "Take math notes", Status: "todo"},
+		{ID: 2, Title: "do physics homework", Status: "done"},
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(editManyQuery)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), coremocks.Tenant).
+		WillReturnRows(mock.NewRows([]string{"id_task", "change_seq"}).AddRow(2, 30))
+	p := NewPostgresTaskRepository(db)
+	found, err := p.EditMany(coremocks.TenantContext(), tasks)
+	if err != nil {
+		t.Fatalf("postgresTaskRepository.EditMany() error = %v", err)
+	}
+	if !reflect.DeepEqual(found, []int{2}) {
+		t.Errorf("expected found [2], got %v", found)
+	}
+	if tasks[1].Version != 30 {
+		t.Errorf("expected version 30, got %d", tasks[1].Version)
+	}
+}
+
+func Test_postgresTaskRepository_DeleteMany(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbError error
+		want    []int
+		wantErr bool
+	}{{
+		name: "Normal Case 1: some tasks found",
+		rows: mock.NewRows([]string{"id_task"}).AddRow(1).AddRow(3),
+		want: []int{1, 3},
+	}, {
+		name:    "db error",
+		rows:    mock.NewRows([]string{"id_task"}),
+		dbError: errors.New("db error"),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectQuery(regexp.QuoteMeta(deleteManyQuery)).
+				WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			p := NewPostgresTaskRepository(db)
+			got, err := p.DeleteMany(coremocks.TenantContext(), []int{1, 2, 3})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresTaskRepository_SetStatusMany(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(setStatusManyQuery)).WithArgs("done", sqlmock.AnyArg(), coremocks.Tenant).
+		WillReturnRows(mock.NewRows([]string{"id_task"}).AddRow(4))
+	p := NewPostgresTaskRepository(db)
+	got, err := p.SetStatusMany(coremocks.TenantContext(), []int{4}, "done")
+	if err != nil {
+		t.Fatalf("postgresTaskRepository.SetStatusMany() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{4}) {
+		t.Errorf("expected [4], got %v", got)
+	}
+}
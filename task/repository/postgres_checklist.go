@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+const checklistColumns = "id_item, id_task, text, checked, position, created_at"
+
+// moveItemQuery ranks the other items of the checklist from 0 and leaves room for the
+// item at position $3, a position past the end puts the item last
+const moveItemQuery = "WITH item AS (SELECT id_item, id_task FROM checklist_item WHERE id_item = $1 AND id_task = $2 AND id_workspace = $4 FOR UPDATE), " +
+	"others AS (SELECT c.id_item, row_number() OVER (ORDER BY c.position, c.id_item) - 1 AS rank FROM checklist_item c JOIN item ON c.id_task = item.id_task WHERE c.id_item <> item.id_item), " +
+	"ranked AS (SELECT id_item, CASE WHEN rank < $3 THEN rank ELSE rank + 1 END AS position FROM others " +
+	"UNION ALL SELECT id_item, LEAST($3, (SELECT count(*) FROM others)) FROM item) " +
+	"UPDATE checklist_item c SET position = ranked.position FROM ranked WHERE c.id_item = ranked.id_item AND c.id_workspace = $4"
+
+type postgresChecklistRepository struct {
+	*sql.DB
+}
+
+// NewPostgresChecklistRepository will create an object that represent the task.ChecklistRepository interface
+func NewPostgresChecklistRepository(db *sql.DB) task.ChecklistRepository {
+	return &postgresChecklistRepository{db}
+}
+
+func scanChecklistItem(s scanner) (*models.ChecklistItem, error) {
+	item := &models.ChecklistItem{}
+	err := s.Scan(&item.ID, &item.TaskID, &item.Text, &item.Checked, &item.Position, &item.CreatedAt)
+	return item, err
+}
+
+func (p *postgresChecklistRepository) List(ctx context.Context, taskID int) ([]*models.ChecklistItem, error) {
+	items := make([]*models.ChecklistItem, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return items, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT "+checklistColumns+" FROM checklist_item WHERE id_task = $1 AND id_workspace = $2 ORDER BY position, id_item",
+		taskID, workspace)
+	if err != nil {
+		return items, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		item, err := scanChecklistItem(rows)
+		if err != nil {
+			return []*models.ChecklistItem{}, err
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.ChecklistItem{}, err
+	}
+	return items, nil
+}
+
+// Add puts the item last, it only adds to the tasks of the workspace that are not deleted
+func (p *postgresChecklistRepository) Add(ctx context.Context, item *models.ChecklistItem) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	err = transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "INSERT INTO checklist_item(id_task, id_workspace, text, position) "+
+		"SELECT $1, $2, $3, COALESCE((SELECT max(position) + 1 FROM checklist_item WHERE id_task = $1), 0) "+
+		"WHERE EXISTS (SELECT 1 FROM task WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted) RETURNING "+checklistColumns,
+		item.TaskID, workspace, item.Text).Scan(&item.ID, &item.TaskID, &item.Text, &item.Checked, &item.Position, &item.CreatedAt)
+	if err == sql.ErrNoRows {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresChecklistRepository) Toggle(ctx context.Context, taskID, id int) (*models.ChecklistItem, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "UPDATE checklist_item SET checked = NOT checked WHERE id_item = $1 AND id_task = $2 AND id_workspace = $3 RETURNING "+checklistColumns,
+		id, taskID, workspace)
+	item, err := scanChecklistItem(row)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	return item, err
+}
+
+// Move renumbers the whole checklist, the gaps left by Delete are closed
+func (p *postgresChecklistRepository) Move(ctx context.Context, taskID, id, position int) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, moveItemQuery,
+		id, taskID, position, workspace)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+// Delete leaves a gap in the positions, the order of the items is kept
+func (p *postgresChecklistRepository) Delete(ctx context.Context, taskID, id int) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "DELETE FROM checklist_item WHERE id_item = $1 AND id_task = $2 AND id_workspace = $3", id, taskID, workspace)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+var checklistRowColumns = []string{"id_item", "id_task", "text", "checked", "position", "created_at"}
+
+func Test_postgresChecklistRepository_List(t *testing.T) {
+	query := "SELECT id_item, id_task, text, checked, position, created_at FROM checklist_item WHERE id_task = $1 AND id_workspace = $2 ORDER BY position, id_item"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbError error
+		want    []*models.ChecklistItem
+		wantErr bool
+	}{{
+		name: "Normal Case 1: items of the task",
+		rows: sqlmock.NewRows(checklistRowColumns).
+			AddRow(2, 1, "Read chapter 3", true, 0, time.Time{}).
+			AddRow(1, 1, "Take notes", false, 1, time.Time{}),
+		want: []*models.ChecklistItem{
+			{ID: 2, TaskID: 1, Text: "Read chapter 3", Checked: true, Position: 0},
+			{ID: 1, TaskID: 1, Text: "Take notes", Position: 1},
+		},
+	}, {
+		name:    "db error",
+		rows:    sqlmock.NewRows(checklistRowColumns),
+		dbError: errors.New("db error"),
+		want:    []*models.ChecklistItem{},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant).WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			got, err := NewPostgresChecklistRepository(db).List(coremocks.TenantContext(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresChecklistRepository_Add(t *testing.T) {
+	query := "INSERT INTO checklist_item(id_task, id_workspace, text, position) " +
+		"SELECT $1, $2, $3, COALESCE((SELECT max(position) + 1 FROM checklist_item WHERE id_task = $1), 0) " +
+		"WHERE EXISTS (SELECT 1 FROM task WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted) RETURNING id_item, id_task, text, checked, position, created_at"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *models.ChecklistItem
+		wantErr error
+	}{{
+		name: "Normal Case 1: item added last",
+		rows: sqlmock.NewRows(checklistRowColumns).AddRow(3, 1, "Read chapter 3", false, 2, time.Time{}),
+		want: &models.ChecklistItem{ID: 3, TaskID: 1, Text: "Read chapter 3", Position: 2},
+	}, {
+		name:    "task not found",
+		rows:    sqlmock.NewRows(checklistRowColumns),
+		want:    &models.ChecklistItem{TaskID: 1, Text: "Read chapter 3"},
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant, "Read chapter 3").WillReturnRows(tt.rows)
+			item := &models.ChecklistItem{TaskID: 1, Text: "Read chapter 3"}
+			if err := NewPostgresChecklistRepository(db).Add(coremocks.TenantContext(), item); err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(item, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, item, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresChecklistRepository_Toggle(t *testing.T) {
+	query := "UPDATE checklist_item SET checked = NOT checked WHERE id_item = $1 AND id_task = $2 AND id_workspace = $3 RETURNING id_item, id_task, text, checked, position, created_at"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *models.ChecklistItem
+		wantErr error
+	}{{
+		name: "Normal Case 1: item checked",
+		rows: sqlmock.NewRows(checklistRowColumns).AddRow(2, 1, "Read chapter 3", true, 0, time.Time{}),
+		want: &models.ChecklistItem{ID: 2, TaskID: 1, Text: "Read chapter 3", Checked: true},
+	}, {
+		name:    "item not found",
+		rows:    sqlmock.NewRows(checklistRowColumns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(2, 1, coremocks.Tenant).WillReturnRows(tt.rows)
+			got, err := NewPostgresChecklistRepository(db).Toggle(coremocks.TenantContext(), 1, 2)
+			if err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresChecklistRepository_Move(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "Normal Case 1: checklist renumbered", affected: 3},
+		{name: "item not found", affected: 0, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta(moveItemQuery)).WithArgs(2, 1, 0, coremocks.Tenant).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if err := NewPostgresChecklistRepository(db).Move(coremocks.TenantContext(), 1, 2, 0); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresChecklistRepository_Delete(t *testing.T) {
+	query := "DELETE FROM checklist_item WHERE id_item = $1 AND id_task = $2 AND id_workspace = $3"
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "Normal Case 1: item deleted", affected: 1},
+		{name: "item not found", affected: 0, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(2, 1, coremocks.Tenant).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if err := NewPostgresChecklistRepository(db).Delete(coremocks.TenantContext(), 1, 2); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func (p *postgresTaskRepository) Each(ctx context.Context, filter *models.TaskFilter, fn func(*models.Task) error) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	where, args, err := filterClause(workspace, filter)
+	if err != nil {
+		return err
+	}
+	order, err := orderClause(filter)
+	if err != nil {
+		return err
+	}
+	rows, err := p.conn(ctx).QueryContext(ctx, "SELECT "+taskColumns+" FROM task WHERE "+where+" "+order, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_postgresTaskRepository_Each(t *testing.T) {
+	query := "SELECT id_task, status, title, description, priority, project, tags, due_date, COALESCE(created_by, 0), assignees, change_seq, created_seq, deleted, created_at, updated_at FROM task WHERE id_workspace = $1 AND NOT deleted AND status = $2 ORDER BY rank NULLS LAST, id_task"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	stop := errors.New("stop")
+	tests := []struct {
+		name     string
+		fnErr    error
+		wantSeen int
+		wantErr  error
+	}{{
+		name:     "Normal Case 1: every row",
+		wantSeen: 2,
+	}, {
+		name:     "callback error stops the iteration",
+		fnErr:    stop,
+		wantSeen: 1,
+		wantErr:  stop,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant, "todo").
+				WillReturnRows(mock.NewRows(taskRowColumns).
+					AddRow(1, "todo", "Take math notes", "", 0, "", "{}", nil, 0, "{}", 3, 1, false, time.Time{}, time.Time{}).
+					AddRow(2, "todo", "do physics homework", "", 0, "", "{}", nil, 0, "{}", 4, 2, false, time.Time{}, time.Time{}))
+			p := NewPostgresTaskRepository(db)
+			seen := 0
+			err := p.Each(coremocks.TenantContext(), &models.TaskFilter{Status: "todo"}, func(*models.Task) error {
+				seen++
+				return tt.fnErr
+			})
+			if err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if seen != tt.wantSeen {
+				t.Errorf("Test %s - saw %d rows, want %d", tt.name, seen, tt.wantSeen)
+			}
+		})
+	}
+}
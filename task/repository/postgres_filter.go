@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pratheeshm/todo-golang/filter"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+// filterColumns maps the fields of the query language to their column,
+// only these names ever reach the SQL
+var filterColumns = map[string]string{
+	"id":          "id_task",
+	"status":      "status",
+	"title":       "title",
+	"description": "description",
+	"project":     "project",
+	"tag":         "tags",
+	"priority":    "priority",
+	"due":         "due_date",
+	"created":     "created_at",
+	"updated":     "updated_at",
+}
+
+// orderClause returns the ORDER BY clause of the sort of the filter, ids break ties.
+// Without a sort the tasks come in the manual order
+func orderClause(tf *models.TaskFilter) (string, error) {
+	if tf == nil || tf.Sort == "" {
+		return "ORDER BY rank NULLS LAST, id_task", nil
+	}
+	keys, err := filter.ParseSort(tf.Sort)
+	if err != nil {
+		return "", err
+	}
+	terms := make([]string, 0, len(keys)+1)
+	for _, k := range keys {
+		dir := "ASC"
+		if k.Desc {
+			dir = "DESC"
+		}
+		terms = append(terms, filterColumns[k.Field]+" "+dir+" NULLS LAST")
+	}
+	return "ORDER BY " + strings.Join(append(terms, "id_task"), ", "), nil
+}
+
+var sqlOperators = map[string]string{
+	filter.Has: "=",
+	filter.Eq:  "=",
+	filter.Ne:  "<>",
+	filter.Lt:  "<",
+	filter.Le:  "<=",
+	filter.Gt:  ">",
+	filter.Ge:  ">=",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// filterCompiler turns a parsed query into a condition, every value
+// becomes an argument placed after the ones already in args
+type filterCompiler struct {
+	args []interface{}
+}
+
+func (c *filterCompiler) arg(v interface{}) string {
+	c.args = append(c.args, v)
+	return fmt.Sprintf("$%d", len(c.args))
+}
+
+// compileFilter returns the SQL condition of e along with args extended with its arguments
+func compileFilter(e filter.Expr, args []interface{}) (string, []interface{}, error) {
+	c := &filterCompiler{args: args}
+	sql, err := c.compile(e)
+	return sql, c.args, err
+}
+
+func (c *filterCompiler) compile(e filter.Expr) (string, error) {
+	switch e := e.(type) {
+	case *filter.And:
+		return c.binary("AND", e.Left, e.Right)
+	case *filter.Or:
+		return c.binary("OR", e.Left, e.Right)
+	case *filter.Not:
+		sql, err := c.compile(e.Expr)
+		return "NOT " + sql, err
+	case *filter.Cond:
+		return c.cond(e)
+	}
+	return "", fmt.Errorf("unexpected filter node %T", e)
+}
+
+func (c *filterCompiler) binary(op string, left, right filter.Expr) (string, error) {
+	l, err := c.compile(left)
+	if err != nil {
+		return "", err
+	}
+	r, err := c.compile(right)
+	if err != nil {
+		return "", err
+	}
+	return "(" + l + " " + op + " " + r + ")", nil
+}
+
+func (c *filterCompiler) cond(e *filter.Cond) (string, error) {
+	column, ok := filterColumns[e.Field]
+	if !ok {
+		return "", fmt.Errorf("unknown filter field %q", e.Field)
+	}
+	switch filter.Fields[e.Field] {
+	case filter.KindTags:
+		sql := c.arg(e.Value) + " = ANY(" + column + ")"
+		if e.Op == filter.Ne {
+			return "NOT (" + sql + ")", nil
+		}
+		return sql, nil
+	case filter.KindEnum, filter.KindText:
+		if e.Op == filter.Contains {
+			return column + " ILIKE " + c.arg("%"+likeEscaper.Replace(e.Value)+"%"), nil
+		}
+		return column + " " + sqlOperators[e.Op] + " " + c.arg(e.Value), nil
+	case filter.KindInt:
+		return column + " " + sqlOperators[e.Op] + " " + c.arg(e.Int), nil
+	case filter.KindTime:
+		return c.timeCond(column, e), nil
+	}
+	return "", fmt.Errorf("unknown filter field %q", e.Field)
+}
+
+// timeCond compares a column with the day of a date or with an instant
+func (c *filterCompiler) timeCond(column string, e *filter.Cond) string {
+	if e.None {
+		if e.Op == filter.Ne {
+			return column + " IS NOT NULL"
+		}
+		return column + " IS NULL"
+	}
+	if e.Until.Equal(e.Time) {
+		return column + " " + sqlOperators[e.Op] + " " + c.arg(e.Time)
+	}
+	switch e.Op {
+	case filter.Lt:
+		return column + " < " + c.arg(e.Time)
+	case filter.Le:
+		return column + " < " + c.arg(e.Until)
+	case filter.Gt:
+		return column + " >= " + c.arg(e.Until)
+	case filter.Ge:
+		return column + " >= " + c.arg(e.Time)
+	}
+	day := "(" + column + " >= " + c.arg(e.Time) + " AND " + column + " < " + c.arg(e.Until) + ")"
+	if e.Op == filter.Ne {
+		return "NOT " + day
+	}
+	return day
+}
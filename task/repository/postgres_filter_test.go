@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/filter"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func Test_compileFilter(t *testing.T) {
+	day := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		query    string
+		args     []interface{}
+		want     string
+		wantArgs []interface{}
+	}{{
+		name:     "Normal Case1: example of the documentation",
+		query:    `status:inprogress AND (tag:bug OR title:~"login") AND due<2026-11-01`,
+		want:     "((status = $1 AND ($2 = ANY(tags) OR title ILIKE $3)) AND due_date < $4)",
+		wantArgs: []interface{}{"inprogress", "bug", "%login%", day},
+	}, {
+		name:     "placeholders follow the arguments already there",
+		query:    "NOT priority>2 OR tag!=ui",
+		args:     []interface{}{"todo"},
+		want:     "(NOT priority > $2 OR NOT ($3 = ANY(tags)))",
+		wantArgs: []interface{}{"todo", 2, "ui"},
+	}, {
+		name:     "a date covers the whole day",
+		query:    "due:2026-11-01 AND created<=2026-11-01 AND updated!=2026-11-01",
+		want:     "(((due_date >= $1 AND due_date < $2) AND created_at < $3) AND NOT (updated_at >= $4 AND updated_at < $5))",
+		wantArgs: []interface{}{day, day.AddDate(0, 0, 1), day.AddDate(0, 0, 1), day, day.AddDate(0, 0, 1)},
+	}, {
+		name:     "instants and none",
+		query:    "updated>=2026-11-01T00:00:00Z AND due:none",
+		want:     "(updated_at >= $1 AND due_date IS NULL)",
+		wantArgs: []interface{}{day},
+	}, {
+		name:     "values never reach the sql",
+		query:    `description:~"100%_done'; DROP TABLE task; --"`,
+		want:     "description ILIKE $1",
+		wantArgs: []interface{}{`%100\%\_done'; DROP TABLE task; --%`},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := filter.Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got, args, err := compileFilter(e, tt.args)
+			if err != nil {
+				t.Fatalf("compileFilter() error = %v", err)
+			}
+			if got != tt.want || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("compileFilter() = %q %v, want %q %v", got, args, tt.want, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func Test_orderClause(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  *models.TaskFilter
+		want    string
+		wantErr bool
+	}{{
+		name: "no filter, manual order",
+		want: "ORDER BY rank NULLS LAST, id_task",
+	}, {
+		name:   "sort",
+		filter: &models.TaskFilter{Sort: "-priority,due"},
+		want:   "ORDER BY priority DESC NULLS LAST, due_date ASC NULLS LAST, id_task",
+	}, {
+		name:    "invalid sort",
+		filter:  &models.TaskFilter{Sort: "tag"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := orderClause(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("orderClause() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("orderClause() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
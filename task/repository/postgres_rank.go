@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/rank"
+)
+
+// the neighbours are looked up in the manual order, (rank, id_task), leaving the moved task out
+const (
+	nextRankQuery = "SELECT COALESCE(rank, '') FROM task WHERE id_workspace = $1 AND NOT deleted AND id_task <> $2 " +
+		"AND (rank > $3 OR rank = $3 AND id_task > $4 OR rank IS NULL) ORDER BY rank NULLS LAST, id_task LIMIT 1"
+	previousRankQuery = "SELECT rank FROM task WHERE id_workspace = $1 AND NOT deleted AND id_task <> $2 " +
+		"AND (rank < $3 OR rank = $3 AND id_task < $4) ORDER BY rank DESC, id_task DESC LIMIT 1"
+)
+
+// rankOf returns the rank of a task, whether it has one or not
+func (p *postgresTaskRepository) rankOf(ctx context.Context, workspace, id int) (sql.NullString, error) {
+	var r sql.NullString
+	err := p.conn(ctx).QueryRowContext(ctx, "SELECT rank FROM task WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted", id, workspace).Scan(&r)
+	if err == sql.ErrNoRows {
+		return r, core.ErrRecordNotFound
+	}
+	return r, err
+}
+
+// neighbour returns the rank the query finds next to the task of rank r, empty when there is none
+func (p *postgresTaskRepository) neighbour(ctx context.Context, query string, workspace, id int, r string, of int) (string, error) {
+	var found string
+	err := p.conn(ctx).QueryRowContext(ctx, query, workspace, id, r, of).Scan(&found)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return found, err
+}
+
+func (p *postgresTaskRepository) Bounds(ctx context.Context, id, after, before int) (string, string, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	var lower, upper string
+	if after != 0 {
+		r, err := p.rankOf(ctx, workspace, after)
+		if err != nil {
+			return "", "", err
+		}
+		if !r.Valid {
+			return "", "", core.ErrUnranked
+		}
+		lower = r.String
+	}
+	if before != 0 {
+		r, err := p.rankOf(ctx, workspace, before)
+		if err != nil {
+			return "", "", err
+		}
+		if !r.Valid {
+			// the unranked tasks come last, a rank before one of them is a rank after the others
+			return "", "", core.ErrUnranked
+		}
+		upper = r.String
+	}
+	switch {
+	case after != 0 && before == 0:
+		upper, err = p.neighbour(ctx, nextRankQuery, workspace, id, lower, after)
+	case after == 0 && before != 0:
+		lower, err = p.neighbour(ctx, previousRankQuery, workspace, id, upper, before)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return lower, upper, nil
+}
+
+func (p *postgresTaskRepository) SetRank(ctx context.Context, id int, r string) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := p.conn(ctx).ExecContext(ctx, "UPDATE task SET rank = $1 WHERE id_task = $2 AND id_workspace = $3 AND NOT deleted", r, id, workspace)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+// Rebalance locks the tasks of the workspace so that the order can not change in between
+func (p *postgresTaskRepository) Rebalance(ctx context.Context) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := p.conn(ctx).QueryContext(ctx, "SELECT id_task FROM task WHERE id_workspace = $1 AND NOT deleted ORDER BY rank NULLS LAST, id_task FOR UPDATE", workspace)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	_, err = p.conn(ctx).ExecContext(ctx, "UPDATE task t SET rank = r.rank FROM unnest($1::int[], $2::text[]) AS r(id_task, rank) WHERE t.id_task = r.id_task AND t.id_workspace = $3",
+		pq.Array(ids), pq.Array(rank.Spread(len(ids))), workspace)
+	return err
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_postgresTaskRepository_Bounds(t *testing.T) {
+	rankQuery := "SELECT rank FROM task WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted"
+	nextQuery := "SELECT COALESCE(rank, '') FROM task WHERE id_workspace = $1 AND NOT deleted AND id_task <> $2 " +
+		"AND (rank > $3 OR rank = $3 AND id_task > $4 OR rank IS NULL) ORDER BY rank NULLS LAST, id_task LIMIT 1"
+	previousQuery := "SELECT rank FROM task WHERE id_workspace = $1 AND NOT deleted AND id_task <> $2 " +
+		"AND (rank < $3 OR rank = $3 AND id_task < $4) ORDER BY rank DESC, id_task DESC LIMIT 1"
+	tests := []struct {
+		name      string
+		after     int
+		before    int
+		expect    func(sqlmock.Sqlmock)
+		wantLower string
+		wantUpper string
+		wantErr   error
+	}{{
+		name:  "Normal Case 1: after a task, before the next one",
+		after: 2,
+		expect: func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery(regexp.QuoteMeta(rankQuery)).WithArgs(2, coremocks.Tenant).WillReturnRows(sqlmock.NewRows([]string{"rank"}).AddRow("F"))
+			mock.ExpectQuery(regexp.QuoteMeta(nextQuery)).WithArgs(coremocks.Tenant, 1, "F", 2).WillReturnRows(sqlmock.NewRows([]string{"rank"}).AddRow("V"))
+		},
+		wantLower: "F",
+		wantUpper: "V",
+	}, {
+		name:  "after the last task",
+		after: 2,
+		expect: func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery(regexp.QuoteMeta(rankQuery)).WithArgs(2, coremocks.Tenant).WillReturnRows(sqlmock.NewRows([]string{"rank"}).AddRow("F"))
+			mock.ExpectQuery(regexp.QuoteMeta(nextQuery)).WithArgs(coremocks.Tenant, 1, "F", 2).WillReturnRows(sqlmock.NewRows([]string{"rank"}))
+		},
+		wantLower: "F",
+	}, {
+		name:   "before the first task",
+		before: 3,
+		expect: func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery(regexp.QuoteMeta(rankQuery)).WithArgs(3, coremocks.Tenant).WillReturnRows(sqlmock.NewRows([]string{"rank"}).AddRow("V"))
+			mock.ExpectQuery(regexp.QuoteMeta(previousQuery)).WithArgs(coremocks.Tenant, 1, "V", 3).WillReturnRows(sqlmock.NewRows([]string{"rank"}))
+		},
+		wantUpper: "V",
+	}, {
+		name:   "between two tasks",
+		after:  2,
+		before: 3,
+		expect: func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery(regexp.QuoteMeta(rankQuery)).WithArgs(2, coremocks.Tenant).WillReturnRows(sqlmock.NewRows([]string{"rank"}).AddRow("F"))
+			mock.ExpectQuery(regexp.QuoteMeta(rankQuery)).WithArgs(3, coremocks.Tenant).WillReturnRows(sqlmock.NewRows([]string{"rank"}).AddRow("V"))
+		},
+		wantLower: "F",
+		wantUpper: "V",
+	}, {
+		name:  "after an unranked task",
+		after: 2,
+		expect: func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery(regexp.QuoteMeta(rankQuery)).WithArgs(2, coremocks.Tenant).WillReturnRows(sqlmock.NewRows([]string{"rank"}).AddRow(nil))
+		},
+		wantErr: core.ErrUnranked,
+	}, {
+		name:  "neighbour not found",
+		after: 2,
+		expect: func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery(regexp.QuoteMeta(rankQuery)).WithArgs(2, coremocks.Tenant).WillReturnRows(sqlmock.NewRows([]string{"rank"}))
+		},
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			tt.expect(mock)
+			lower, upper, err := NewPostgresTaskRepository(db).Bounds(coremocks.TenantContext(), 1, tt.after, tt.before)
+			if err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if lower != tt.wantLower || upper != tt.wantUpper {
+				t.Errorf("Test %s - got = %q, %q, want %q, %q", tt.name, lower, upper, tt.wantLower, tt.wantUpper)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Test %s - there were unfulfilled expectations: %s", tt.name, err)
+			}
+		})
+	}
+}
+
+func Test_postgresTaskRepository_SetRank(t *testing.T) {
+	query := "UPDATE task SET rank = $1 WHERE id_task = $2 AND id_workspace = $3 AND NOT deleted"
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "Normal Case 1: rank changed", affected: 1},
+		{name: "task not found", affected: 0, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs("V", 1, coremocks.Tenant).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if err := NewPostgresTaskRepository(db).SetRank(coremocks.TenantContext(), 1, "V"); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresTaskRepository_Rebalance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id_task FROM task WHERE id_workspace = $1 AND NOT deleted ORDER BY rank NULLS LAST, id_task FOR UPDATE")).
+		WithArgs(coremocks.Tenant).WillReturnRows(sqlmock.NewRows([]string{"id_task"}).AddRow(3).AddRow(1).AddRow(2))
+	// the tasks keep their order, the ranks are spread over it
+	ids, _ := pq.Int64Array{3, 1, 2}.Value()
+	ranks, _ := pq.StringArray{"F", "V", "k"}.Value()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE task t SET rank = r.rank FROM unnest($1::int[], $2::text[]) AS r(id_task, rank) WHERE t.id_task = r.id_task AND t.id_workspace = $3")).
+		WithArgs(ids, ranks, coremocks.Tenant).WillReturnResult(sqlmock.NewResult(0, 3))
+	if err := NewPostgresTaskRepository(db).Rebalance(coremocks.TenantContext()); err != nil {
+		t.Fatalf("Rebalance() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+// searchVector is the document searched, the title weighs more than the description.
+// It has to match the expression of the index built by EnsureSearchIndex for the index to be used
+const searchVector = "setweight(to_tsvector(%[1]s, title), 'A') || setweight(to_tsvector(%[1]s, description), 'B')"
+
+// ts_headline marks the matches between these control characters, the snippet is
+// escaped before they are replaced with the html markers
+const (
+	headlineStart = "\x02"
+	headlineStop  = "\x03"
+)
+
+const (
+	titleHeadline       = `StartSel="` + headlineStart + `", StopSel="` + headlineStop + `", HighlightAll=true`
+	descriptionHeadline = `StartSel="` + headlineStart + `", StopSel="` + headlineStop + `", MaxFragments=2, MaxWords=20, MinWords=5`
+)
+
+// snippetMarks replaces the headline markers of an escaped snippet
+var snippetMarks = strings.NewReplacer(headlineStart, models.SearchMarkStart, headlineStop, models.SearchMarkStop)
+
+// searchLanguage is the name of a text search configuration that can be part of an index name
+var searchLanguage = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type postgresSearchRepository struct {
+	*sql.DB
+	query string
+}
+
+// NewPostgresSearchRepository will create an object that represent the task.SearchRepository interface.
+// language names the text search configuration, such as english or simple, the index of EnsureSearchIndex is built with
+func NewPostgresSearchRepository(db *sql.DB, language string) task.SearchRepository {
+	lang := pq.QuoteLiteral(language)
+	vector := fmt.Sprintf(searchVector, lang)
+	query := "SELECT " + taskColumns + ", ts_rank(" + vector + ", q) AS rank" +
+		", ts_headline(" + lang + ", title, q, " + pq.QuoteLiteral(titleHeadline) + ")" +
+		", ts_headline(" + lang + ", description, q, " + pq.QuoteLiteral(descriptionHeadline) + ")" +
+		" FROM task, to_tsquery(" + lang + ", $1) q WHERE id_workspace = $3 AND NOT deleted AND " + vector + " @@ q" +
+		" ORDER BY rank DESC, id_task LIMIT $2"
+	return &postgresSearchRepository{DB: db, query: query}
+}
+
+// EnsureSearchIndex creates the full-text index of the text search configuration language
+// unless it exists, so that the index always matches the configured language. Each
+// language has its own index, task_search_<language>_idx
+func EnsureSearchIndex(ctx context.Context, db *sql.DB, language string) error {
+	if !searchLanguage.MatchString(language) {
+		return fmt.Errorf("invalid search language %q", language)
+	}
+	_, err := db.ExecContext(ctx, "CREATE INDEX IF NOT EXISTS task_search_"+language+"_idx ON task USING GIN (("+
+		fmt.Sprintf(searchVector, pq.QuoteLiteral(language))+"))")
+	return err
+}
+
+// snippet escapes a headline of ts_headline and marks its matches
+func snippet(headline string) string {
+	return snippetMarks.Replace(html.EscapeString(headline))
+}
+
+// prefixQuery turns the words of a search into a tsquery matching words starting with each of them
+func prefixQuery(q string) string {
+	terms := searchTerms(q)
+	for i, t := range terms {
+		terms[i] = t + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+func (p *postgresSearchRepository) Search(ctx context.Context, query *models.SearchQuery) ([]*models.SearchResult, error) {
+	results := make([]*models.SearchResult, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return results, err
+	}
+	tsquery := prefixQuery(query.Query)
+	if tsquery == "" {
+		return results, nil
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, p.query, tsquery, query.Limit, workspace)
+	if err != nil {
+		return results, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		result := &models.SearchResult{Task: &models.Task{}}
+		fields := append(taskFields(result.Task), &result.Rank, &result.Title, &result.Description)
+		if err := rows.Scan(fields...); err != nil {
+			return []*models.SearchResult{}, err
+		}
+		result.Title, result.Description = snippet(result.Title), snippet(result.Description)
+		results = append(results, result)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.SearchResult{}, err
+	}
+	return results, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func Test_postgresSearchRepository_Search(t *testing.T) {
+	query := "SELECT id_task, status, title, description, priority, project, tags, due_date, COALESCE(created_by, 0), assignees, change_seq, created_seq, deleted, created_at, updated_at, " +
+		"ts_rank(setweight(to_tsvector('simple', title), 'A') || setweight(to_tsvector('simple', description), 'B'), q) AS rank, " +
+		"ts_headline('simple', title, q, 'StartSel=\"\x02\", StopSel=\"\x03\", HighlightAll=true'), " +
+		"ts_headline('simple', description, q, 'StartSel=\"\x02\", StopSel=\"\x03\", MaxFragments=2, MaxWords=20, MinWords=5') " +
+		"FROM task, to_tsquery('simple', $1) q WHERE id_workspace = $3 AND NOT deleted AND " +
+		"setweight(to_tsvector('simple', title), 'A') || setweight(to_tsvector('simple', description), 'B') @@ q " +
+		"ORDER BY rank DESC, id_task LIMIT $2"
+	columns := append(append([]string{}, taskRowColumns...), "rank", "title_snippet", "description_snippet")
+	tests := []struct {
+		name      string
+		q         string
+		tsquery   string
+		rows      *sqlmock.Rows
+		dbErr     error
+		wantTitle []string
+		wantErr   bool
+	}{{
+		name:    "Normal Case 1: prefix search",
+		q:       "Math no",
+		tsquery: "math:* & no:*",
+		rows: sqlmock.NewRows(columns).
+			AddRow(1, "todo", "Take math notes", "", 0, "", "{}", nil, 0, "{}", 3, 1, false, time.Time{}, time.Time{},
+				0.6, "Take \x02math\x03 \x02notes\x03", ""),
+		wantTitle: []string{"Take <mark>math</mark> <mark>notes</mark>"},
+	}, {
+		name:    "snippets are escaped",
+		q:       "script",
+		tsquery: "script:*",
+		rows: sqlmock.NewRows(columns).
+			AddRow(1, "todo", "<script>", "", 0, "", "{}", nil, 0, "{}", 3, 1, false, time.Time{}, time.Time{},
+				0.6, "<\x02script\x03>", ""),
+		wantTitle: []string{"&lt;<mark>script</mark>&gt;"},
+	}, {
+		name:      "operators are dropped",
+		q:         "!|&",
+		wantTitle: []string{},
+	}, {
+		name:    "db error",
+		q:       "math",
+		tsquery: "math:*",
+		dbErr:   errors.New("db error"),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			if tt.tsquery != "" {
+				exp := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(tt.tsquery, 10, coremocks.Tenant)
+				if tt.dbErr != nil {
+					exp.WillReturnError(tt.dbErr)
+				} else {
+					exp.WillReturnRows(tt.rows)
+				}
+			}
+			p := NewPostgresSearchRepository(db, "simple")
+			got, err := p.Search(coremocks.TenantContext(), &models.SearchQuery{Query: tt.q, Limit: 10})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("postgresSearchRepository.Search() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got) != len(tt.wantTitle) {
+				t.Fatalf("postgresSearchRepository.Search() = %d results, want %d", len(got), len(tt.wantTitle))
+			}
+			for i, r := range got {
+				if r.Title != tt.wantTitle[i] || r.Task.ID == 0 {
+					t.Errorf("result %d = %+v", i, r)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestEnsureSearchIndex(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		query    string
+		wantErr  bool
+	}{{
+		name:     "Normal Case 1",
+		language: "english",
+		query: "CREATE INDEX IF NOT EXISTS task_search_english_idx ON task USING GIN ((" +
+			"setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', description), 'B')))",
+	}, {
+		name:     "invalid language",
+		language: "english; DROP TABLE task",
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			if tt.query != "" {
+				mock.ExpectExec(regexp.QuoteMeta(tt.query)).WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+			if err := EnsureSearchIndex(context.Background(), db, tt.language); (err != nil) != tt.wantErr {
+				t.Errorf("EnsureSearchIndex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
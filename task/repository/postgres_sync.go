@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+// changesQuery pages through the changes by (change_txid, change_seq). A
+// transaction below the oldest one in flight has committed or rolled back, so
+// nothing can show up behind the cursor later
+const changesQuery = "SELECT " + taskColumns + ", change_txid, created_txid FROM task WHERE id_workspace = $1 AND (change_txid, change_seq) > ($2, $3) AND change_txid < txid_snapshot_xmin(txid_current_snapshot()) ORDER BY change_txid, change_seq LIMIT $4"
+
+func (p *postgresTaskRepository) Changes(ctx context.Context, since models.SyncCursor, limit int) ([]*models.Task, error) {
+	tasks := make([]*models.Task, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return tasks, err
+	}
+	rows, err := p.conn(ctx).QueryContext(ctx, changesQuery, workspace, since.TxID, since.Seq, limit)
+	if err != nil {
+		return tasks, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		task := &models.Task{}
+		err := rows.Scan(append(taskFields(task), &task.ChangeTxID, &task.CreatedTxID)...)
+		if err != nil {
+			return []*models.Task{}, err
+		}
+		tasks = append(tasks, task)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.Task{}, err
+	}
+	return tasks, nil
+}
+
+func (p *postgresTaskRepository) EditVersion(ctx context.Context, task *models.Task, version int64) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	err = p.conn(ctx).QueryRowContext(ctx, "UPDATE task SET status = $1 , title = $2 , description = $3 , priority = $4 , project = $5 , tags = $6 , due_date = $7 where id_task = $8 AND id_workspace = $9 AND change_seq = $10 AND NOT deleted RETURNING change_seq",
+		task.Status, task.Title, task.Description, task.Priority, task.Project, pq.Array(tags(task)), task.DueDate, task.ID, workspace, version).Scan(&task.Version)
+	if err == sql.ErrNoRows {
+		return p.versionError(ctx, task.ID)
+	}
+	return err
+}
+
+func (p *postgresTaskRepository) DeleteVersion(ctx context.Context, id int, version int64) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := p.conn(ctx).ExecContext(ctx, "UPDATE task SET deleted = true where id_task = $1 AND id_workspace = $2 AND change_seq = $3 AND NOT deleted",
+		id, workspace, version)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return p.versionError(ctx, id)
+	}
+	return nil
+}
+
+// versionError tells apart a missing task from one changed by someone else
+func (p *postgresTaskRepository) versionError(ctx context.Context, id int) error {
+	var version int64
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	err = p.conn(ctx).QueryRowContext(ctx, "SELECT change_seq FROM task WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted", id, workspace).Scan(&version)
+	if err == sql.ErrNoRows {
+		return core.ErrRecordNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return core.ErrConflict
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+var taskRowColumns = []string{"id_task", "status", "title", "description", "priority", "project", "tags", "due_date", "created_by", "assignees", "change_seq",
+	"created_seq", "deleted", "created_at", "updated_at"}
+
+func Test_postgresTaskRepository_Changes(t *testing.T) {
+	query := "SELECT id_task, status, title, description, priority, project, tags, due_date, COALESCE(created_by, 0), assignees, change_seq, created_seq, deleted, created_at, updated_at, change_txid, created_txid FROM task WHERE id_workspace = $1 AND (change_txid, change_seq) > ($2, $3) AND change_txid < txid_snapshot_xmin(txid_current_snapshot()) ORDER BY change_txid, change_seq LIMIT $4"
+	columns := append(append([]string{}, taskRowColumns...), "change_txid", "created_txid")
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbError error
+		want    []*models.Task
+		wantErr bool
+	}{{
+		name: "Normal Case 1: changes with a tombstone",
+		rows: mock.NewRows(columns).
+			AddRow(1, "todo", "Take math notes", "", 0, "", "{}", nil, 0, "{}", 3, 1, false, time.Time{}, time.Time{}, 31, 30).
+			AddRow(2, "done", "do physics homework", "", 0, "", "{}", nil, 0, "{}", 4, 2, true, time.Time{}, time.Time{}, 32, 30),
+		want: []*models.Task{
+			{ID: 1, Status: "todo", Title: "Take math notes", Tags: []string{}, Assignees: []int{}, Version: 3, CreatedVersion: 1, ChangeTxID: 31, CreatedTxID: 30},
+			{ID: 2, Status: "done", Title: "do physics homework", Tags: []string{}, Assignees: []int{}, Version: 4, CreatedVersion: 2, ChangeTxID: 32, CreatedTxID: 30, Deleted: true},
+		},
+	}, {
+		name:    "db error",
+		rows:    mock.NewRows(columns),
+		dbError: errors.New("db error"),
+		want:    []*models.Task{},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPostgresTaskRepository(db)
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant, 30, 2, 10).
+				WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			got, err := p.Changes(coremocks.TenantContext(), models.SyncCursor{TxID: 30, Seq: 2}, 10)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresTaskRepository_EditVersion(t *testing.T) {
+	query := "UPDATE task SET status = $1 , title = $2 , description = $3 , priority = $4 , project = $5 , tags = $6 , due_date = $7 where id_task = $8 AND id_workspace = $9 AND change_seq = $10 AND NOT deleted RETURNING change_seq"
+	versionQuery := "SELECT change_seq FROM task WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	tests := []struct {
+		name        string
+		updated     bool
+		exists      bool
+		wantErr     error
+		wantVersion int64
+	}{{
+		name:        "Normal Case 1: version matches",
+		updated:     true,
+		wantVersion: 8,
+	}, {
+		name:    "task changed since version",
+		exists:  true,
+		wantErr: core.ErrConflict,
+	}, {
+		name:    "task deleted",
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &models.Task{ID: 1, Title: "Take math notes", Status: "done"}
+			rows := mock.NewRows([]string{"change_seq"})
+			if tt.updated {
+				rows.AddRow(8)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).
+				WithArgs("done", "Take math notes", "", 0, "", sqlmock.AnyArg(), nil, 1, coremocks.Tenant, 5).WillReturnRows(rows)
+			if !tt.updated {
+				versionRows := mock.NewRows([]string{"change_seq"})
+				if tt.exists {
+					versionRows.AddRow(7)
+				}
+				mock.ExpectQuery(regexp.QuoteMeta(versionQuery)).WithArgs(1, coremocks.Tenant).WillReturnRows(versionRows)
+			}
+			p := NewPostgresTaskRepository(db)
+			if err := p.EditVersion(coremocks.TenantContext(), task, 5); err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if task.Version != tt.wantVersion {
+				t.Errorf("Test %s - got version %d, want %d", tt.name, task.Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func Test_postgresTaskRepository_DeleteVersion(t *testing.T) {
+	query := "UPDATE task SET deleted = true where id_task = $1 AND id_workspace = $2 AND change_seq = $3 AND NOT deleted"
+	versionQuery := "SELECT change_seq FROM task WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		exists       bool
+		wantErr      error
+	}{{
+		name:         "Normal Case 1: version matches",
+		rowsAffected: 1,
+	}, {
+		name:    "task changed since version",
+		exists:  true,
+		wantErr: core.ErrConflict,
+	}, {
+		name:    "task not found",
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant, 5).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			if tt.rowsAffected == 0 {
+				versionRows := mock.NewRows([]string{"change_seq"})
+				if tt.exists {
+					versionRows.AddRow(7)
+				}
+				mock.ExpectQuery(regexp.QuoteMeta(versionQuery)).WithArgs(1, coremocks.Tenant).WillReturnRows(versionRows)
+			}
+			p := NewPostgresTaskRepository(db)
+			if err := p.DeleteVersion(coremocks.TenantContext(), 1, 5); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
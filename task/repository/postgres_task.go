@@ -1,14 +1,23 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
 
 	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/filter"
 	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
 
 	"github.com/pratheeshm/todo-golang/task"
 )
 
+const taskColumns = "id_task, status, title, description, priority, project, tags, due_date, COALESCE(created_by, 0), assignees, change_seq, created_seq, deleted, created_at, updated_at"
+
 type postgresTaskRepository struct {
 	*sql.DB
 }
@@ -17,21 +26,147 @@ type postgresTaskRepository struct {
 func NewPostgresTaskRepository(db *sql.DB) task.Repository {
 	return &postgresTaskRepository{db}
 }
-func (p *postgresTaskRepository) Add(task *models.Task) error {
-	_, err := p.DB.Query("INSERT INTO task(title, status) values($1, $2)",
-		task.Title, task.Status)
-	return err
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// taskFields returns the scan destinations of taskColumns
+func taskFields(task *models.Task) []interface{} {
+	return []interface{}{&task.ID, &task.Status, &task.Title, &task.Description, &task.Priority, &task.Project,
+		pq.Array(&task.Tags), &task.DueDate, &task.CreatedBy, intArray{&task.Assignees}, &task.Version, &task.CreatedVersion, &task.Deleted,
+		&task.CreatedAt, &task.UpdatedAt}
+}
+
+// intArray scans an integer array column into a []int
+type intArray struct {
+	ints *[]int
+}
+
+func (a intArray) Scan(src interface{}) error {
+	var ints pq.Int64Array
+	if err := ints.Scan(src); err != nil {
+		return err
+	}
+	*a.ints = make([]int, len(ints))
+	for i, n := range ints {
+		(*a.ints)[i] = int(n)
+	}
+	return nil
+}
+
+func scanTask(s scanner) (*models.Task, error) {
+	task := &models.Task{}
+	err := s.Scan(taskFields(task)...)
+	return task, err
+}
+
+// tags never returns nil so that the column stays an empty array
+func tags(task *models.Task) []string {
+	if task.Tags == nil {
+		return []string{}
+	}
+	return task.Tags
+}
+
+// filterClause returns the WHERE clause of the filter within the workspace along with its arguments
+func filterClause(workspace int, tf *models.TaskFilter) (string, []interface{}, error) {
+	conds := []string{"id_workspace = $1", "NOT deleted"}
+	args := []interface{}{workspace}
+	if tf == nil {
+		return strings.Join(conds, " AND "), args, nil
+	}
+	if tf.Status != "" {
+		args = append(args, tf.Status)
+		conds = append(conds, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if tf.Project != "" {
+		args = append(args, tf.Project)
+		conds = append(conds, fmt.Sprintf("project = $%d", len(args)))
+	}
+	if tf.Tag != "" {
+		args = append(args, tf.Tag)
+		conds = append(conds, fmt.Sprintf("$%d = ANY(tags)", len(args)))
+	}
+	if tf.Assignee != 0 {
+		args = append(args, tf.Assignee)
+		conds = append(conds, fmt.Sprintf("$%d = ANY(assignees)", len(args)))
+	}
+	if tf.Query != "" {
+		expr, err := filter.Parse(tf.Query)
+		if err != nil {
+			return "", nil, err
+		}
+		if expr != nil {
+			var cond string
+			if cond, args, err = compileFilter(expr, args); err != nil {
+				return "", nil, err
+			}
+			conds = append(conds, cond)
+		}
+	}
+	return strings.Join(conds, " AND "), args, nil
+}
+
+// conn returns the transaction carried by ctx, if any
+func (p *postgresTaskRepository) conn(ctx context.Context) transaction.Executor {
+	return transaction.Conn(ctx, p.DB)
 }
-func (p *postgresTaskRepository) List() ([]*models.Task, error) {
+
+// creator returns the id of the user of ctx, zero when there is none
+func creator(ctx context.Context) int {
+	if u, ok := core.UserFromContext(ctx); ok {
+		return u.ID
+	}
+	return 0
+}
+
+func (p *postgresTaskRepository) Add(ctx context.Context, task *models.Task) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	task.CreatedBy = creator(ctx)
+	return p.conn(ctx).QueryRowContext(ctx, "INSERT INTO task(title, status, description, priority, project, tags, due_date, id_workspace, created_by) values($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, 0)) RETURNING id_task, change_seq",
+		task.Title, task.Status, task.Description, task.Priority, task.Project, pq.Array(tags(task)), task.DueDate,
+		workspace, task.CreatedBy).Scan(&task.ID, &task.Version)
+}
+func (p *postgresTaskRepository) Get(ctx context.Context, id int) (*models.Task, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := p.conn(ctx).QueryRowContext(ctx, "SELECT "+taskColumns+" FROM task WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted", id, workspace)
+	task, err := scanTask(row)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	return task, err
+}
+func (p *postgresTaskRepository) List(ctx context.Context, filter *models.TaskFilter) ([]*models.Task, error) {
 	tasks := make([]*models.Task, 0)
-	rows, err := p.DB.Query("SELECT id_task, status, title FROM task")
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return tasks, err
+	}
+	where, args, err := filterClause(workspace, filter)
+	if err != nil {
+		return tasks, err
+	}
+	order, err := orderClause(filter)
+	if err != nil {
+		return tasks, err
+	}
+	rows, err := p.conn(ctx).QueryContext(ctx, "SELECT "+taskColumns+" FROM task WHERE "+where+" "+order, args...)
 	if err != nil {
 		return tasks, err
 	}
 	defer rows.Close()
 	for rows.Next() {
-		task := &models.Task{}
-		rows.Scan(&task.ID, &task.Status, &task.Title)
+		task, err := scanTask(rows)
+		if err != nil {
+			return []*models.Task{}, err
+		}
 		tasks = append(tasks, task)
 	}
 	if err = rows.Err(); err != nil {
@@ -39,8 +174,12 @@ func (p *postgresTaskRepository) List() ([]*models.Task, error) {
 	}
 	return tasks, err
 }
-func (p *postgresTaskRepository) Delete(id int) error {
-	result, err := p.DB.Exec("DELETE FROM task where id_task = $1", id)
+func (p *postgresTaskRepository) Delete(ctx context.Context, id int) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := p.conn(ctx).ExecContext(ctx, "UPDATE task SET deleted = true where id_task = $1 AND id_workspace = $2 AND NOT deleted", id, workspace)
 	if err != nil {
 		return err
 	}
@@ -50,9 +189,13 @@ func (p *postgresTaskRepository) Delete(id int) error {
 	}
 	return err
 }
-func (p *postgresTaskRepository) Edit(task *models.Task) error {
-	result, err := p.DB.Exec("UPDATE task SET status = $1 , title = $2 where id_task = $3",
-		task.Status, task.Title, task.ID)
+func (p *postgresTaskRepository) Edit(ctx context.Context, task *models.Task) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := p.conn(ctx).ExecContext(ctx, "UPDATE task SET status = $1 , title = $2 , description = $3 , priority = $4 , project = $5 , tags = $6 , due_date = $7 where id_task = $8 AND id_workspace = $9 AND NOT deleted",
+		task.Status, task.Title, task.Description, task.Priority, task.Project, pq.Array(tags(task)), task.DueDate, task.ID, workspace)
 	if err != nil {
 		return err
 	}
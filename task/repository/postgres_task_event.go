@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+type postgresEventRepository struct {
+	*sql.DB
+}
+
+// NewPostgresEventRepository will create an object that represent the task.EventRepository interface
+func NewPostgresEventRepository(db *sql.DB) task.EventRepository {
+	return &postgresEventRepository{db}
+}
+
+func (p *postgresEventRepository) Add(ctx context.Context, events ...*models.TaskEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	ids := make([]int64, len(events))
+	types := make([]string, len(events))
+	statuses := make([]string, len(events))
+	users := make([]int64, len(events))
+	for i, e := range events {
+		ids[i] = int64(e.TaskID)
+		types[i] = e.Type
+		statuses[i] = e.Status
+		users[i] = int64(e.UserID)
+	}
+	// the join drops the events of tasks out of the workspace
+	_, err = transaction.Conn(ctx, p.DB).ExecContext(ctx, "INSERT INTO task_event(id_task, type, status, id_user) SELECT v.id_task, v.type, v.status, NULLIF(v.id_user, 0) FROM unnest($1::int[], $2::varchar[], $3::varchar[], $4::int[]) AS v(id_task, type, status, id_user) JOIN task t ON t.id_task = v.id_task WHERE t.id_workspace = $5",
+		pq.Array(ids), pq.Array(types), pq.Array(statuses), pq.Array(users), workspace)
+	return err
+}
+
+func (p *postgresEventRepository) List(ctx context.Context, taskID int) ([]*models.TaskEvent, error) {
+	events := make([]*models.TaskEvent, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return events, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT e.id_event, e.id_task, e.type, e.status, COALESCE(e.id_user, 0), e.created_at FROM task_event e JOIN task t ON t.id_task = e.id_task WHERE e.id_task = $1 AND t.id_workspace = $2 ORDER BY e.created_at, e.id_event",
+		taskID, workspace)
+	if err != nil {
+		return events, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		e := &models.TaskEvent{}
+		if err := rows.Scan(&e.ID, &e.TaskID, &e.Type, &e.Status, &e.UserID, &e.CreatedAt); err != nil {
+			return []*models.TaskEvent{}, err
+		}
+		events = append(events, e)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.TaskEvent{}, err
+	}
+	return events, nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_postgresEventRepository_Add(t *testing.T) {
+	query := "INSERT INTO task_event(id_task, type, status, id_user) SELECT v.id_task, v.type, v.status, NULLIF(v.id_user, 0) FROM unnest($1::int[], $2::varchar[], $3::varchar[], $4::int[]) AS v(id_task, type, status, id_user) JOIN task t ON t.id_task = v.id_task WHERE t.id_workspace = $5"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	tests := []struct {
+		name    string
+		events  []*models.TaskEvent
+		dbError error
+		wantErr bool
+	}{{
+		name: "Normal Case 1: record events",
+		events: []*models.TaskEvent{
+			{TaskID: 1, Type: models.EventCreated, Status: "todo"},
+			{TaskID: 2, Type: models.EventDeleted},
+			{TaskID: 1, Type: models.EventAssigned, UserID: 3},
+		},
+	}, {
+		name:    "db error",
+		events:  []*models.TaskEvent{{TaskID: 1, Type: models.EventUpdated, Status: "done"}},
+		dbError: errors.New("db error"),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), coremocks.Tenant).
+				WillReturnResult(sqlmock.NewResult(0, int64(len(tt.events)))).WillReturnError(tt.dbError)
+			p := NewPostgresEventRepository(db)
+			if err := p.Add(coremocks.TenantContext(), tt.events...); (err != nil) != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresEventRepository_List(t *testing.T) {
+	query := "SELECT e.id_event, e.id_task, e.type, e.status, COALESCE(e.id_user, 0), e.created_at FROM task_event e JOIN task t ON t.id_task = e.id_task WHERE e.id_task = $1 AND t.id_workspace = $2 ORDER BY e.created_at, e.id_event"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant).
+		WillReturnRows(mock.NewRows([]string{"id_event", "id_task", "type", "status", "id_user", "created_at"}).
+			AddRow(1, 1, "created", "todo", 0, at).
+			AddRow(2, 1, "updated", "done", 0, at).
+			AddRow(3, 1, "assigned", "", 3, at))
+	p := NewPostgresEventRepository(db)
+	got, err := p.List(coremocks.TenantContext(), 1)
+	if err != nil {
+		t.Fatalf("postgresEventRepository.List() error = %v", err)
+	}
+	want := []*models.TaskEvent{
+		{ID: 1, TaskID: 1, Type: "created", Status: "todo", CreatedAt: at},
+		{ID: 2, TaskID: 1, Type: "updated", Status: "done", CreatedAt: at},
+		{ID: 3, TaskID: 1, Type: "assigned", UserID: 3, CreatedAt: at},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("postgresEventRepository.List() = %v, want %v", got, want)
+	}
+}
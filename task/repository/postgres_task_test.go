@@ -6,8 +6,11 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
 	"github.com/pratheeshm/todo-golang/models"
 	"github.com/pratheeshm/todo-golang/task"
 	"github.com/sirupsen/logrus"
@@ -42,7 +45,7 @@ func TestNewPostgresTaskRepository(t *testing.T) {
 }
 
 func Test_postgresTaskRepository_Add(t *testing.T) {
-	query := "INSERT INTO task(title, status) values($1, $2)"
+	query := "INSERT INTO task(title, status, description, priority, project, tags, due_date, id_workspace, created_by) values($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, 0))"
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		logrus.Error("expected no error, but got:", err)
@@ -76,17 +79,22 @@ func Test_postgresTaskRepository_Add(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mock.ExpectQuery(regexp.QuoteMeta(query)).
-				WithArgs("Take maths notes", "todo").WillReturnRows()
+				WithArgs("Take maths notes", "todo", "", 0, "", sqlmock.AnyArg(), nil, coremocks.Tenant, 3).
+				WillReturnRows(mock.NewRows([]string{"id_task", "change_seq"}).AddRow(1, 1))
 			p := NewPostgresTaskRepository(tt.fields.DB)
-			if err := p.Add(tt.args.task); (err != nil) != tt.wantErr {
+			ctx := core.WithUser(coremocks.TenantContext(), &models.User{ID: 3})
+			if err := p.Add(ctx, tt.args.task); (err != nil) != tt.wantErr {
 				t.Errorf("postgresTaskRepository.Add() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.args.task.CreatedBy != 3 {
+				t.Errorf("postgresTaskRepository.Add() created_by = %d, want 3", tt.args.task.CreatedBy)
+			}
 		})
 	}
 }
 
 func Test_postgresTaskRepository_List(t *testing.T) {
-	query := "SELECT id_task, status, title FROM task"
+	query := "SELECT id_task, status, title, description, priority, project, tags, due_date, COALESCE(created_by, 0), assignees, change_seq, created_seq, deleted, created_at, updated_at FROM task WHERE id_workspace = $1 AND NOT deleted"
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		logrus.Error("expected no error, but got:", err)
@@ -110,22 +118,28 @@ func Test_postgresTaskRepository_List(t *testing.T) {
 				DB: db,
 			},
 			want: []*models.Task{&models.Task{
-				ID:     0,
-				Status: "todo",
-				Title:  "Make maths note",
+				ID:        0,
+				Status:    "todo",
+				Title:     "Make maths note",
+				Tags:      []string{},
+				Assignees: []int{},
 			}, &models.Task{
-				ID:     1,
-				Status: "todo",
-				Title:  "do physics homework",
+				ID:        1,
+				Status:    "todo",
+				Title:     "do physics homework",
+				Tags:      []string{},
+				Assignees: []int{},
 			}},
 			rows: []*models.Task{&models.Task{
 				ID:     0,
 				Status: "todo",
 				Title:  "Make maths note",
+				Tags:   []string{},
 			}, &models.Task{
 				ID:     1,
 				Status: "todo",
 				Title:  "do physics homework",
+				Tags:   []string{},
 			}},
 			wantErr: false,
 		}, {
@@ -146,10 +160,12 @@ func Test_postgresTaskRepository_List(t *testing.T) {
 				ID:     0,
 				Status: "todo",
 				Title:  "Make maths note",
+				Tags:   []string{},
 			}, &models.Task{
 				ID:     1,
 				Status: "todo",
 				Title:  "do physics homework",
+				Tags:   []string{},
 			}},
 			want:     []*models.Task{},
 			wantErr:  true,
@@ -160,22 +176,28 @@ func Test_postgresTaskRepository_List(t *testing.T) {
 				DB: db,
 			},
 			want: []*models.Task{&models.Task{
-				ID:     0,
-				Status: "todo",
-				Title:  "Make maths note",
+				ID:        0,
+				Status:    "todo",
+				Title:     "Make maths note",
+				Tags:      []string{},
+				Assignees: []int{},
 			}, &models.Task{
-				ID:     1,
-				Status: "todo",
-				Title:  "do physics homework",
+				ID:        1,
+				Status:    "todo",
+				Title:     "do physics homework",
+				Tags:      []string{},
+				Assignees: []int{},
 			}},
 			rows: []*models.Task{&models.Task{
 				ID:     0,
 				Status: "todo",
 				Title:  "Make maths note",
+				Tags:   []string{},
 			}, &models.Task{
 				ID:     1,
 				Status: "todo",
 				Title:  "do physics homework",
+				Tags:   []string{},
 			}},
 			wantErr: false,
 		},
@@ -183,13 +205,14 @@ func Test_postgresTaskRepository_List(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := NewPostgresTaskRepository(tt.fields.DB)
-			rows := mock.NewRows([]string{"id_task", "status", "title"})
+			rows := mock.NewRows(taskRowColumns)
 			for i, v := range tt.rows {
-				rows = rows.AddRow(v.ID, v.Status, v.Title).RowError(i, tt.rowError[i])
+				rows = rows.AddRow(v.ID, v.Status, v.Title, v.Description, v.Priority, v.Project, "{}", nil, v.CreatedBy, "{}", v.Version, v.CreatedVersion,
+					v.Deleted, v.CreatedAt, v.UpdatedAt).RowError(i, tt.rowError[i])
 			}
-			mock.ExpectQuery(regexp.QuoteMeta(query)).
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant).
 				WillReturnRows(rows).WillReturnError(tt.dbError)
-			got, err := p.List()
+			got, err := p.List(coremocks.TenantContext(), &models.TaskFilter{})
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("Test %s -, error = %v, wantErr %v", tt.name, err, tt.wantErr)
 			}
@@ -201,7 +224,7 @@ func Test_postgresTaskRepository_List(t *testing.T) {
 }
 
 func Test_postgresTaskRepository_Delete(t *testing.T) {
-	query := "DELETE FROM task where id_task = $1"
+	query := "UPDATE task SET deleted = true where id_task = $1 AND id_workspace = $2 AND NOT deleted"
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		logrus.Error(err)
@@ -256,10 +279,10 @@ func Test_postgresTaskRepository_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			p := NewPostgresTaskRepository(tt.fields.DB)
 			mock.ExpectExec(regexp.QuoteMeta(query)).
-				WithArgs(tt.args.id).
+				WithArgs(tt.args.id, coremocks.Tenant).
 				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected)).
 				WillReturnError(tt.dbError)
-			if err := p.Delete(tt.args.id); (err != nil) != tt.wantErr {
+			if err := p.Delete(coremocks.TenantContext(), tt.args.id); (err != nil) != tt.wantErr {
 				t.Errorf("Test %s - got error = %v, wantErr %v", tt.name, err, tt.wantErr)
 			}
 		})
@@ -267,7 +290,7 @@ func Test_postgresTaskRepository_Delete(t *testing.T) {
 }
 
 func Test_postgresTaskRepository_Edit(t *testing.T) {
-	query := "UPDATE task SET status = $1 , title = $2 where id_task = $3"
+	query := "UPDATE task SET status = $1 , title = $2 , description = $3 , priority = $4 , project = $5 , tags = $6 , due_date = $7 where id_task = $8 AND id_workspace = $9 AND NOT deleted"
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		logrus.Error(err)
@@ -334,13 +357,102 @@ func Test_postgresTaskRepository_Edit(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			p := NewPostgresTaskRepository(tt.fields.DB)
 			mock.ExpectExec(regexp.QuoteMeta(query)).
-				WithArgs(tt.args.task.Status, tt.args.task.Title, tt.args.task.ID).
+				WithArgs(tt.args.task.Status, tt.args.task.Title, "", 0, "", sqlmock.AnyArg(), nil, tt.args.task.ID, coremocks.Tenant).
 				WillReturnResult(sqlmock.NewResult(0, tt.rowsUpdated)).
 				WillReturnError(tt.dbError)
-			err := p.Edit(tt.args.task)
+			err := p.Edit(coremocks.TenantContext(), tt.args.task)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Test- %v,error = %v, wantErr %v", tt.name, err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func Test_postgresTaskRepository_Get(t *testing.T) {
+	query := "SELECT id_task, status, title, description, priority, project, tags, due_date, COALESCE(created_by, 0), assignees, change_seq, created_seq, deleted, created_at, updated_at FROM task WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	tests := []struct {
+		name    string
+		id      int
+		rows    *sqlmock.Rows
+		want    *models.Task
+		wantErr error
+	}{{
+		name: "Normal Case 1: Get a task",
+		id:   1,
+		rows: mock.NewRows(taskRowColumns).
+			AddRow(1, "todo", "Take math notes", "", 0, "", "{}", nil, 0, "{3,5}", 4, 2, false, time.Time{}, time.Time{}),
+		want: &models.Task{ID: 1, Status: "todo", Title: "Take math notes", Tags: []string{}, Assignees: []int{3, 5}, Version: 4, CreatedVersion: 2},
+	}, {
+		name:    "task not found",
+		id:      2,
+		rows:    mock.NewRows(taskRowColumns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPostgresTaskRepository(db)
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(tt.id, coremocks.Tenant).WillReturnRows(tt.rows)
+			got, err := p.Get(coremocks.TenantContext(), tt.id)
+			if err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_filterClause(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *models.TaskFilter
+		want     string
+		wantArgs []interface{}
+		wantErr  bool
+	}{{
+		name:     "no filter",
+		want:     "id_workspace = $1 AND NOT deleted",
+		wantArgs: []interface{}{coremocks.Tenant},
+	}, {
+		name:     "status",
+		filter:   &models.TaskFilter{Status: "done"},
+		want:     "id_workspace = $1 AND NOT deleted AND status = $2",
+		wantArgs: []interface{}{coremocks.Tenant, "done"},
+	}, {
+		name:     "project and tag",
+		filter:   &models.TaskFilter{Status: "todo", Project: "school", Tag: "math"},
+		want:     "id_workspace = $1 AND NOT deleted AND status = $2 AND project = $3 AND $4 = ANY(tags)",
+		wantArgs: []interface{}{coremocks.Tenant, "todo", "school", "math"},
+	}, {
+		name:     "query",
+		filter:   &models.TaskFilter{Status: "todo", Query: `tag:bug OR title:~"50%"`},
+		want:     "id_workspace = $1 AND NOT deleted AND status = $2 AND ($3 = ANY(tags) OR title ILIKE $4)",
+		wantArgs: []interface{}{coremocks.Tenant, "todo", "bug", `%50\%%`},
+	}, {
+		name:     "assignee",
+		filter:   &models.TaskFilter{Status: "todo", Assignee: 3},
+		want:     "id_workspace = $1 AND NOT deleted AND status = $2 AND $3 = ANY(assignees)",
+		wantArgs: []interface{}{coremocks.Tenant, "todo", 3},
+	}, {
+		name:    "invalid query",
+		filter:  &models.TaskFilter{Query: "status:later"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args, err := filterClause(coremocks.Tenant, tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("filterClause() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("filterClause() = %q %v, want %q %v", got, args, tt.want, tt.wantArgs)
+			}
+		})
+	}
+}
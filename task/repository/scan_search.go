@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+)
+
+// Weights of a match in the scan search, as setweight gives the title more weight
+const (
+	titleWeight       = 1.0
+	descriptionWeight = 0.4
+)
+
+// snippetWords is the number of words kept around the first match of a description
+const snippetWords = 20
+
+type scanSearchRepository struct {
+	taskRepo task.Repository
+}
+
+// NewScanSearchRepository will create an object that represent the task.SearchRepository interface
+// for repositories without full-text search. It reads every task and matches words by prefix,
+// without the stemming of a text search configuration
+func NewScanSearchRepository(tr task.Repository) task.SearchRepository {
+	return &scanSearchRepository{taskRepo: tr}
+}
+
+// word is a word of a text along with its byte offsets
+type word struct {
+	text       string
+	start, end int
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// splitWords returns the words of s, lowercased
+func splitWords(s string) []word {
+	words := []word{}
+	start := -1
+	for i, r := range s {
+		switch {
+		case isWordRune(r) && start < 0:
+			start = i
+		case !isWordRune(r) && start >= 0:
+			words = append(words, word{strings.ToLower(s[start:i]), start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words = append(words, word{strings.ToLower(s[start:]), start, len(s)})
+	}
+	return words
+}
+
+// searchTerms returns the words of a search, anything else is dropped so that
+// no tsquery operator can get through
+func searchTerms(q string) []string {
+	words := splitWords(q)
+	terms := make([]string, len(words))
+	for i, w := range words {
+		terms[i] = w.text
+	}
+	return terms
+}
+
+// matches returns the words of text starting with one of the terms
+func matches(words []word, terms []string) []bool {
+	hits := make([]bool, len(words))
+	for i, w := range words {
+		for _, t := range terms {
+			if strings.HasPrefix(w.text, t) {
+				hits[i] = true
+				break
+			}
+		}
+	}
+	return hits
+}
+
+// highlight marks the matching words of text[from:to], the text is html escaped
+func highlight(text string, words []word, hits []bool, from, to int) string {
+	var b strings.Builder
+	last := from
+	for i, w := range words {
+		if !hits[i] || w.start < from || w.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[last:w.start]))
+		b.WriteString(models.SearchMarkStart + html.EscapeString(text[w.start:w.end]) + models.SearchMarkStop)
+		last = w.end
+	}
+	b.WriteString(html.EscapeString(text[last:to]))
+	return b.String()
+}
+
+// descriptionSnippet keeps snippetWords words starting a few words before the first match
+func descriptionSnippet(text string, words []word, hits []bool) string {
+	first := 0
+	for i, hit := range hits {
+		if hit {
+			first = i
+			break
+		}
+	}
+	from := first - snippetWords/4
+	if from < 0 {
+		from = 0
+	}
+	to := from + snippetWords
+	if to > len(words) {
+		to = len(words)
+	}
+	if to == 0 {
+		return ""
+	}
+	return highlight(text, words, hits, words[from].start, words[to-1].end)
+}
+
+func (s *scanSearchRepository) Search(ctx context.Context, query *models.SearchQuery) ([]*models.SearchResult, error) {
+	results := make([]*models.SearchResult, 0)
+	terms := searchTerms(query.Query)
+	if len(terms) == 0 {
+		return results, nil
+	}
+	err := s.taskRepo.Each(ctx, nil, func(t *models.Task) error {
+		titleWords, descWords := splitWords(t.Title), splitWords(t.Description)
+		titleHits, descHits := matches(titleWords, terms), matches(descWords, terms)
+		rank := 0.0
+		// every term has to match, as the terms of the tsquery are joined with &
+		for _, term := range terms {
+			found := false
+			for _, w := range titleWords {
+				if strings.HasPrefix(w.text, term) {
+					rank += titleWeight
+					found = true
+				}
+			}
+			for _, w := range descWords {
+				if strings.HasPrefix(w.text, term) {
+					rank += descriptionWeight
+					found = true
+				}
+			}
+			if !found {
+				return nil
+			}
+		}
+		results = append(results, &models.SearchResult{
+			Task:        t,
+			Rank:        rank,
+			Title:       highlight(t.Title, titleWords, titleHits, 0, len(t.Title)),
+			Description: descriptionSnippet(t.Description, descWords, descHits),
+		})
+		return nil
+	})
+	if err != nil {
+		return []*models.SearchResult{}, err
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].Task.ID < results[j].Task.ID
+	})
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+func Test_scanSearchRepository_Search(t *testing.T) {
+	tasks := []*models.Task{
+		{ID: 1, Title: "Take math notes", Description: "chapter on notation"},
+		{ID: 2, Title: "do physics homework", Description: "needs the math notes from monday"},
+		{ID: 3, Title: "buy milk"},
+		{ID: 4, Title: "<b>bold</b> & co"},
+	}
+	tests := []struct {
+		name     string
+		repo     *mocks.MockRepository
+		query    *models.SearchQuery
+		wantIDs  []int
+		wantHits []string
+		wantErr  bool
+	}{{
+		name:     "Normal Case 1: title ranks above description",
+		repo:     &mocks.MockRepository{Tasks: tasks},
+		query:    &models.SearchQuery{Query: "Math", Limit: 10},
+		wantIDs:  []int{1, 2},
+		wantHits: []string{"Take <mark>math</mark> notes", "do physics homework"},
+	}, {
+		name:     "every word has to match a prefix",
+		repo:     &mocks.MockRepository{Tasks: tasks},
+		query:    &models.SearchQuery{Query: "not phys", Limit: 10},
+		wantIDs:  []int{2},
+		wantHits: []string{"do <mark>physics</mark> homework"},
+	}, {
+		name:     "snippets are escaped",
+		repo:     &mocks.MockRepository{Tasks: tasks},
+		query:    &models.SearchQuery{Query: "bold", Limit: 10},
+		wantIDs:  []int{4},
+		wantHits: []string{"&lt;b&gt;<mark>bold</mark>&lt;/b&gt; &amp; co"},
+	}, {
+		name:    "limit",
+		repo:    &mocks.MockRepository{Tasks: tasks},
+		query:   &models.SearchQuery{Query: "notes", Limit: 1},
+		wantIDs: []int{1},
+	}, {
+		name:    "no words",
+		repo:    &mocks.MockRepository{Tasks: tasks},
+		query:   &models.SearchQuery{Query: "&!", Limit: 10},
+		wantIDs: []int{},
+	}, {
+		name:    "repository error",
+		repo:    &mocks.MockRepository{Error: errors.New("db error")},
+		query:   &models.SearchQuery{Query: "math", Limit: 10},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewScanSearchRepository(tt.repo).Search(context.Background(), tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("scanSearchRepository.Search() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("scanSearchRepository.Search() = %d results, want %d", len(got), len(tt.wantIDs))
+			}
+			for i, r := range got {
+				if r.Task.ID != tt.wantIDs[i] {
+					t.Errorf("result %d: got task %d, want %d", i, r.Task.ID, tt.wantIDs[i])
+				}
+				if i < len(tt.wantHits) && r.Title != tt.wantHits[i] {
+					t.Errorf("result %d: got title snippet %q, want %q", i, r.Title, tt.wantHits[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_descriptionSnippet(t *testing.T) {
+	text := "one two three four five six seven eight nine ten eleven twelve thirteen " +
+		"fourteen fifteen sixteen seventeen eighteen nineteen twenty twentyone twentytwo target end"
+	words := splitWords(text)
+	got := descriptionSnippet(text, words, matches(words, []string{"target"}))
+	want := "eighteen nineteen twenty twentyone twentytwo <mark>target</mark> end"
+	if got != want {
+		t.Errorf("descriptionSnippet() = %q, want %q", got, want)
+	}
+}
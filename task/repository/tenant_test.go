@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_repositories_withoutTenant(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	defer db.Close()
+	ctx := context.Background()
+	tr := NewPostgresTaskRepository(db)
+	er := NewPostgresEventRepository(db)
+	sr := NewPostgresSearchRepository(db, "english")
+	cr := NewPostgresChecklistRepository(db)
+	task := &models.Task{ID: 1, Title: "Take math notes", Status: "todo"}
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Add", func() error { return tr.Add(ctx, task) }},
+		{"Get", func() error { _, err := tr.Get(ctx, 1); return err }},
+		{"List", func() error { _, err := tr.List(ctx, &models.TaskFilter{}); return err }},
+		{"Edit", func() error { return tr.Edit(ctx, task) }},
+		{"Delete", func() error { return tr.Delete(ctx, 1) }},
+		{"Each", func() error { return tr.Each(ctx, &models.TaskFilter{}, func(*models.Task) error { return nil }) }},
+		{"Changes", func() error { _, err := tr.Changes(ctx, models.SyncCursor{}, 10); return err }},
+		{"EditVersion", func() error { return tr.EditVersion(ctx, task, 1) }},
+		{"DeleteVersion", func() error { return tr.DeleteVersion(ctx, 1, 1) }},
+		{"AddMany", func() error { return tr.AddMany(ctx, []*models.Task{task}) }},
+		{"EditMany", func() error { _, err := tr.EditMany(ctx, []*models.Task{task}); return err }},
+		{"DeleteMany", func() error { _, err := tr.DeleteMany(ctx, []int{1}); return err }},
+		{"SetStatusMany", func() error { _, err := tr.SetStatusMany(ctx, []int{1}, "done"); return err }},
+		{"Assign", func() error { _, err := tr.Assign(ctx, 1, []int{3}); return err }},
+		{"Unassign", func() error { _, err := tr.Unassign(ctx, 1, []int{3}); return err }},
+		{"Bounds", func() error { _, _, err := tr.Bounds(ctx, 1, 2, 0); return err }},
+		{"SetRank", func() error { return tr.SetRank(ctx, 1, "V") }},
+		{"Rebalance", func() error { return tr.Rebalance(ctx) }},
+		{"LockStatus", func() error { _, err := tr.LockStatus(ctx, "todo"); return err }},
+		{"Checklist List", func() error { _, err := cr.List(ctx, 1); return err }},
+		{"Checklist Add", func() error { return cr.Add(ctx, &models.ChecklistItem{TaskID: 1, Text: "Read chapter 3"}) }},
+		{"Checklist Toggle", func() error { _, err := cr.Toggle(ctx, 1, 2); return err }},
+		{"Checklist Move", func() error { return cr.Move(ctx, 1, 2, 0) }},
+		{"Checklist Delete", func() error { return cr.Delete(ctx, 1, 2) }},
+		{"Event Add", func() error { return er.Add(ctx, &models.TaskEvent{TaskID: 1, Type: models.EventCreated}) }},
+		{"Event List", func() error { _, err := er.List(ctx, 1); return err }},
+		{"Search", func() error {
+			_, err := sr.Search(ctx, &models.SearchQuery{Query: "math", Limit: 10})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != core.ErrNoTenant {
+				t.Errorf("expected %v, got %v", core.ErrNoTenant, err)
+			}
+		})
+	}
+	// the mock expects nothing, a query would have answered with a sqlmock error instead
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -1,11 +1,50 @@
 package task
 
-import "github.com/pratheeshm/todo-golang/models"
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
 
 //Usecase represents task's interface
 type Usecase interface {
-	Add(*models.Task) error
-	Delete(int) error
-	Edit(*models.Task) error
-	List() ([]*models.Task, error)
+	Add(context.Context, *models.Task) error
+	Delete(context.Context, int) error
+	Edit(context.Context, *models.Task) error
+	// EditVersion edits the task only if it is still at the given version,
+	// core.ErrConflict is returned otherwise
+	EditVersion(ctx context.Context, task *models.Task, version int64) error
+	List(context.Context, *models.TaskFilter) ([]*models.Task, error)
+	// Export calls fn for every task matching the filter without loading them all
+	Export(ctx context.Context, filter *models.TaskFilter, fn func(*models.Task) error) error
+	// History returns the events recorded for a task
+	History(ctx context.Context, id int) ([]*models.TaskEvent, error)
+	// Search returns the tasks matching a full-text search, best match first
+	Search(ctx context.Context, query *models.SearchQuery) ([]*models.SearchResult, error)
+	// Sync returns the changes made after the opaque sync token
+	Sync(ctx context.Context, token string, limit int) (*models.SyncDelta, error)
+	// Push applies changes made by an offline client
+	Push(context.Context, []*models.SyncChange) ([]*models.SyncResult, error)
+	// Import validates the rows and, unless dryRun is set, adds them all in one transaction
+	Import(ctx context.Context, rows []*models.ImportRow, dryRun bool) (*models.ImportResult, error)
+	// Bulk applies many operations in one request
+	Bulk(context.Context, *models.BulkRequest) (*models.BulkResponse, error)
+	// Assign adds users to the assignees of a task and returns the task
+	Assign(ctx context.Context, id int, users []int) (*models.Task, error)
+	// Unassign removes users from the assignees of a task and returns the task
+	Unassign(ctx context.Context, id int, users []int) (*models.Task, error)
+	// Get returns a task along with its checklist
+	Get(ctx context.Context, id int) (*models.Task, error)
+	// AddChecklistItem appends an item to the checklist of its task and returns the task
+	AddChecklistItem(context.Context, *models.ChecklistItem) (*models.Task, error)
+	// ToggleChecklistItem checks or unchecks an item of the checklist of a task and returns the task
+	ToggleChecklistItem(ctx context.Context, taskID, id int) (*models.Task, error)
+	// MoveChecklistItem puts an item of the checklist of a task at position and returns the task
+	MoveChecklistItem(ctx context.Context, taskID, id, position int) (*models.Task, error)
+	// DeleteChecklistItem deletes an item of the checklist of a task and returns the task
+	DeleteChecklistItem(ctx context.Context, taskID, id int) (*models.Task, error)
+	// Move places a task between two others in the manual order and returns the task
+	Move(ctx context.Context, id int, move *models.TaskMove) (*models.Task, error)
+	// Board returns the tasks of the workspace grouped by status
+	Board(context.Context) (*models.Board, error)
 }
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+// assignmentEvents returns an event of eventType for every user changed on the task,
+// the events keep the status of the task like every other event
+func assignmentEvents(task *models.Task, users []int, eventType string) []*models.TaskEvent {
+	events := make([]*models.TaskEvent, len(users))
+	for i, u := range users {
+		events[i] = &models.TaskEvent{TaskID: task.ID, Type: eventType, Status: task.Status, UserID: u}
+	}
+	return events
+}
+
+func (tu *taskUsecase) Assign(ctx context.Context, id int, users []int) (*models.Task, error) {
+	return tu.changeAssignees(ctx, id, users, tu.taskRepo.Assign, models.EventAssigned)
+}
+
+func (tu *taskUsecase) Unassign(ctx context.Context, id int, users []int) (*models.Task, error) {
+	return tu.changeAssignees(ctx, id, users, tu.taskRepo.Unassign, models.EventUnassigned)
+}
+
+// changeAssignees applies change and records the users it changed in the history of the task
+func (tu *taskUsecase) changeAssignees(ctx context.Context, id int, users []int,
+	change func(context.Context, int, []int) ([]int, error), eventType string) (*models.Task, error) {
+	var task *models.Task
+	err := tu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		changed, err := change(ctx, id, users)
+		if err != nil {
+			return err
+		}
+		task, err = tu.taskRepo.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		return tu.eventRepo.Add(ctx, assignmentEvents(task, changed, eventType)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+func Test_taskUsecase_Assign(t *testing.T) {
+	repo := &mocks.MockRepository{Tasks: []*models.Task{{ID: 1, Title: "Take math notes", Status: "todo", Assignees: []int{3}}}}
+	events := &mocks.MockEventRepository{}
+	tu := NewTaskUsecase(repo, events, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+	ctx := context.Background()
+	got, err := tu.Assign(ctx, 1, []int{3, 5})
+	if err != nil {
+		t.Fatalf("taskUsecase.Assign() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Assignees, []int{3, 5}) {
+		t.Errorf("taskUsecase.Assign() assignees = %v, want [3 5]", got.Assignees)
+	}
+	if _, err := tu.Unassign(ctx, 1, []int{3, 7}); err != nil {
+		t.Fatalf("taskUsecase.Unassign() error = %v", err)
+	}
+	// only the users actually changed are recorded, along with the status of the task
+	want := []*models.TaskEvent{
+		{TaskID: 1, Type: models.EventAssigned, Status: "todo", UserID: 5},
+		{TaskID: 1, Type: models.EventUnassigned, Status: "todo", UserID: 3},
+	}
+	if !reflect.DeepEqual(events.Events, want) {
+		t.Errorf("history = %v, want %v", events.Events, want)
+	}
+	if _, err := tu.Assign(ctx, 42, []int{3}); err != core.ErrRecordNotFound {
+		t.Errorf("expected record not found, got %v", err)
+	}
+}
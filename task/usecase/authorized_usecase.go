@@ -0,0 +1,355 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+)
+
+type authorizedUsecase struct {
+	taskUsecase   task.Usecase
+	taskRepo      task.Repository
+	accessUsecase access.Usecase
+}
+
+// NewAuthorizedUsecase will create a task.Usecase checking the role of the user of the
+// context before calling tu, core.ErrForbidden is returned when the role does not allow
+// the call. Calls acting on many tasks are refused as a whole when one of them is not allowed
+func NewAuthorizedUsecase(tu task.Usecase, tr task.Repository, au access.Usecase) task.Usecase {
+	return &authorizedUsecase{
+		taskUsecase:   tu,
+		taskRepo:      tr,
+		accessUsecase: au,
+	}
+}
+
+// checker checks the actions of one call against the policy of the user
+type checker struct {
+	policy   *access.Policy
+	taskRepo task.Repository
+}
+
+func (a *authorizedUsecase) checker(ctx context.Context) (*checker, error) {
+	p, err := a.accessUsecase.Policy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &checker{policy: p, taskRepo: a.taskRepo}, nil
+}
+
+// workspace checks an action on the tasks of the whole workspace
+func (c *checker) workspace(action string) error {
+	if !c.policy.Can(action, "", 0) {
+		return core.ErrForbidden
+	}
+	return nil
+}
+
+// project checks an action on a new task of project
+func (c *checker) project(action, project string) error {
+	if !c.policy.Can(action, project, 0) {
+		return core.ErrForbidden
+	}
+	return nil
+}
+
+// task checks an action on an existing task. The project of the task is only looked
+// up when the workspace and task grants are not enough. A missing task is let through,
+// the usecase reports it
+func (c *checker) task(ctx context.Context, action string, id int) error {
+	if c.policy.Can(action, "", id) {
+		return nil
+	}
+	t, err := c.taskRepo.Get(ctx, id)
+	if err == core.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !c.policy.Can(action, t.Project, id) {
+		return core.ErrForbidden
+	}
+	return nil
+}
+
+// edit checks the task where it is and where the edit moves it
+func (c *checker) edit(ctx context.Context, id int, project string) error {
+	if err := c.task(ctx, access.ActionWrite, id); err != nil {
+		return err
+	}
+	if !c.policy.Can(access.ActionWrite, project, id) {
+		return core.ErrForbidden
+	}
+	return nil
+}
+
+func (a *authorizedUsecase) Add(ctx context.Context, t *models.Task) error {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.project(access.ActionWrite, t.Project); err != nil {
+		return err
+	}
+	return a.taskUsecase.Add(ctx, t)
+}
+
+func (a *authorizedUsecase) Delete(ctx context.Context, id int) error {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.task(ctx, access.ActionDelete, id); err != nil {
+		return err
+	}
+	return a.taskUsecase.Delete(ctx, id)
+}
+
+func (a *authorizedUsecase) Edit(ctx context.Context, t *models.Task) error {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.edit(ctx, t.ID, t.Project); err != nil {
+		return err
+	}
+	return a.taskUsecase.Edit(ctx, t)
+}
+
+func (a *authorizedUsecase) EditVersion(ctx context.Context, t *models.Task, version int64) error {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.edit(ctx, t.ID, t.Project); err != nil {
+		return err
+	}
+	return a.taskUsecase.EditVersion(ctx, t, version)
+}
+
+func (a *authorizedUsecase) List(ctx context.Context, filter *models.TaskFilter) ([]*models.Task, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.workspace(access.ActionRead); err != nil {
+		return nil, err
+	}
+	return a.taskUsecase.List(ctx, filter)
+}
+
+func (a *authorizedUsecase) Export(ctx context.Context, filter *models.TaskFilter, fn func(*models.Task) error) error {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.workspace(access.ActionRead); err != nil {
+		return err
+	}
+	return a.taskUsecase.Export(ctx, filter, fn)
+}
+
+func (a *authorizedUsecase) History(ctx context.Context, id int) ([]*models.TaskEvent, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.task(ctx, access.ActionRead, id); err != nil {
+		return nil, err
+	}
+	return a.taskUsecase.History(ctx, id)
+}
+
+func (a *authorizedUsecase) Search(ctx context.Context, query *models.SearchQuery) ([]*models.SearchResult, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.workspace(access.ActionRead); err != nil {
+		return nil, err
+	}
+	return a.taskUsecase.Search(ctx, query)
+}
+
+func (a *authorizedUsecase) Sync(ctx context.Context, token string, limit int) (*models.SyncDelta, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.workspace(access.ActionRead); err != nil {
+		return nil, err
+	}
+	return a.taskUsecase.Sync(ctx, token, limit)
+}
+
+func (a *authorizedUsecase) Push(ctx context.Context, changes []*models.SyncChange) ([]*models.SyncResult, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ch := range changes {
+		switch {
+		case ch.Deleted && ch.ID == 0:
+			// invalid, reported by the usecase
+		case ch.Deleted:
+			err = c.task(ctx, access.ActionDelete, ch.ID)
+		case ch.ID == 0:
+			err = c.project(access.ActionWrite, ch.Project)
+		default:
+			err = c.edit(ctx, ch.ID, ch.Project)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a.taskUsecase.Push(ctx, changes)
+}
+
+func (a *authorizedUsecase) Import(ctx context.Context, rows []*models.ImportRow, dryRun bool) (*models.ImportResult, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if row.Task == nil {
+			continue
+		}
+		if err := c.project(access.ActionWrite, row.Task.Project); err != nil {
+			return nil, err
+		}
+	}
+	return a.taskUsecase.Import(ctx, rows, dryRun)
+}
+
+func (a *authorizedUsecase) Bulk(ctx context.Context, req *models.BulkRequest) (*models.BulkResponse, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range req.Operations {
+		switch op.Op {
+		case models.BulkCreate:
+			err = c.project(access.ActionWrite, op.Project)
+		case models.BulkUpdate, models.BulkStatus:
+			err = c.task(ctx, access.ActionWrite, op.ID)
+		case models.BulkDelete:
+			err = c.task(ctx, access.ActionDelete, op.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a.taskUsecase.Bulk(ctx, req)
+}
+
+func (a *authorizedUsecase) Assign(ctx context.Context, id int, users []int) (*models.Task, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.task(ctx, access.ActionWrite, id); err != nil {
+		return nil, err
+	}
+	return a.taskUsecase.Assign(ctx, id, users)
+}
+
+func (a *authorizedUsecase) Unassign(ctx context.Context, id int, users []int) (*models.Task, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.task(ctx, access.ActionWrite, id); err != nil {
+		return nil, err
+	}
+	return a.taskUsecase.Unassign(ctx, id, users)
+}
+
+func (a *authorizedUsecase) Get(ctx context.Context, id int) (*models.Task, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.task(ctx, access.ActionRead, id); err != nil {
+		return nil, err
+	}
+	return a.taskUsecase.Get(ctx, id)
+}
+
+func (a *authorizedUsecase) AddChecklistItem(ctx context.Context, item *models.ChecklistItem) (*models.Task, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.task(ctx, access.ActionWrite, item.TaskID); err != nil {
+		return nil, err
+	}
+	return a.taskUsecase.AddChecklistItem(ctx, item)
+}
+
+func (a *authorizedUsecase) ToggleChecklistItem(ctx context.Context, taskID, id int) (*models.Task, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.task(ctx, access.ActionWrite, taskID); err != nil {
+		return nil, err
+	}
+	return a.taskUsecase.ToggleChecklistItem(ctx, taskID, id)
+}
+
+func (a *authorizedUsecase) MoveChecklistItem(ctx context.Context, taskID, id, position int) (*models.Task, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.task(ctx, access.ActionWrite, taskID); err != nil {
+		return nil, err
+	}
+	return a.taskUsecase.MoveChecklistItem(ctx, taskID, id, position)
+}
+
+func (a *authorizedUsecase) DeleteChecklistItem(ctx context.Context, taskID, id int) (*models.Task, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.task(ctx, access.ActionWrite, taskID); err != nil {
+		return nil, err
+	}
+	return a.taskUsecase.DeleteChecklistItem(ctx, taskID, id)
+}
+
+// Move needs to write the task and to read the tasks it is placed between
+func (a *authorizedUsecase) Move(ctx context.Context, id int, move *models.TaskMove) (*models.Task, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.task(ctx, access.ActionWrite, id); err != nil {
+		return nil, err
+	}
+	for _, n := range []int{move.After, move.Before} {
+		if n == 0 {
+			continue
+		}
+		if err := c.task(ctx, access.ActionRead, n); err != nil {
+			return nil, err
+		}
+	}
+	return a.taskUsecase.Move(ctx, id, move)
+}
+
+func (a *authorizedUsecase) Board(ctx context.Context) (*models.Board, error) {
+	c, err := a.checker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.workspace(access.ActionRead); err != nil {
+		return nil, err
+	}
+	return a.taskUsecase.Board(ctx)
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	accessmocks "github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+)
+
+func TestNewAuthorizedUsecase(t *testing.T) {
+	tu := &mocks.MockUsecase{}
+	tr := &mocks.MockRepository{}
+	au := &accessmocks.MockUsecase{}
+	want := &authorizedUsecase{taskUsecase: tu, taskRepo: tr, accessUsecase: au}
+	if got := NewAuthorizedUsecase(tu, tr, au); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAuthorizedUsecase() = %v, want %v", got, want)
+	}
+}
+
+func Test_authorizedUsecase(t *testing.T) {
+	// called is returned by the wrapped usecase, getting it back means the call went through
+	called := errors.New("called")
+	viewer := &models.Grant{Role: models.RoleViewer}
+	member := &models.Grant{Role: models.RoleMember}
+	admin := &models.Grant{Role: models.RoleAdmin}
+	webMember := &models.Grant{Role: models.RoleMember, Project: "web"}
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		grants []*models.Grant
+		call   func(task.Usecase) error
+		want   error
+	}{
+		{"Normal Case1: viewer lists", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			_, err := tu.List(ctx, &models.TaskFilter{})
+			return err
+		}, called},
+		{"viewer exports", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			return tu.Export(ctx, &models.TaskFilter{}, nil)
+		}, called},
+		{"viewer searches", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			_, err := tu.Search(ctx, &models.SearchQuery{Query: "math"})
+			return err
+		}, called},
+		{"viewer syncs", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			_, err := tu.Sync(ctx, "", 10)
+			return err
+		}, called},
+		{"viewer reads the board", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			_, err := tu.Board(ctx)
+			return err
+		}, called},
+		{"project member can not read the board", []*models.Grant{webMember}, func(tu task.Usecase) error {
+			_, err := tu.Board(ctx)
+			return err
+		}, core.ErrForbidden},
+		{"viewer reads the history", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			_, err := tu.History(ctx, 1)
+			return err
+		}, called},
+		{"viewer can not add", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			return tu.Add(ctx, &models.Task{Title: "Take math notes"})
+		}, core.ErrForbidden},
+		{"viewer can not edit", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			return tu.Edit(ctx, &models.Task{ID: 1, Project: "web"})
+		}, core.ErrForbidden},
+		{"viewer can not edit a version", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			return tu.EditVersion(ctx, &models.Task{ID: 1, Project: "web"}, 1)
+		}, core.ErrForbidden},
+		{"member adds", []*models.Grant{member}, func(tu task.Usecase) error {
+			return tu.Add(ctx, &models.Task{Title: "Take math notes"})
+		}, called},
+		{"member edits", []*models.Grant{member}, func(tu task.Usecase) error {
+			return tu.Edit(ctx, &models.Task{ID: 1, Project: "api"})
+		}, called},
+		{"member edits a version", []*models.Grant{member}, func(tu task.Usecase) error {
+			return tu.EditVersion(ctx, &models.Task{ID: 1, Project: "api"}, 1)
+		}, called},
+		{"member can not delete", []*models.Grant{member}, func(tu task.Usecase) error {
+			return tu.Delete(ctx, 1)
+		}, core.ErrForbidden},
+		{"admin deletes", []*models.Grant{admin}, func(tu task.Usecase) error {
+			return tu.Delete(ctx, 1)
+		}, called},
+		{"no workspace role can not list", []*models.Grant{webMember}, func(tu task.Usecase) error {
+			_, err := tu.List(ctx, &models.TaskFilter{})
+			return err
+		}, core.ErrForbidden},
+		{"project member adds to the project", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			return tu.Add(ctx, &models.Task{Project: "web"})
+		}, called},
+		{"project member can not add to another project", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			return tu.Add(ctx, &models.Task{Project: "api"})
+		}, core.ErrForbidden},
+		{"project member edits a task of the project", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			return tu.Edit(ctx, &models.Task{ID: 1, Project: "web"})
+		}, called},
+		{"project member can not move a task out of the project", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			return tu.Edit(ctx, &models.Task{ID: 1, Project: "api"})
+		}, core.ErrForbidden},
+		{"project member can not edit a task of another project", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			return tu.Edit(ctx, &models.Task{ID: 2, Project: "api"})
+		}, core.ErrForbidden},
+		{"shared task is edited", []*models.Grant{viewer, {Role: models.RoleMember, TaskID: 2}}, func(tu task.Usecase) error {
+			return tu.Edit(ctx, &models.Task{ID: 2, Project: "api"})
+		}, called},
+		{"shared task is not deleted", []*models.Grant{viewer, {Role: models.RoleMember, TaskID: 2}}, func(tu task.Usecase) error {
+			return tu.Delete(ctx, 2)
+		}, core.ErrForbidden},
+		{"task shared as admin is deleted", []*models.Grant{viewer, {Role: models.RoleAdmin, TaskID: 2}}, func(tu task.Usecase) error {
+			return tu.Delete(ctx, 2)
+		}, called},
+		{"missing task is left to the usecase", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			return tu.Delete(ctx, 42)
+		}, called},
+		{"viewer can not assign", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			_, err := tu.Assign(ctx, 1, []int{3})
+			return err
+		}, core.ErrForbidden},
+		{"project member assigns", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			_, err := tu.Assign(ctx, 1, []int{3})
+			return err
+		}, called},
+		{"project member can not unassign on another project", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			_, err := tu.Unassign(ctx, 2, []int{3})
+			return err
+		}, core.ErrForbidden},
+		{"shared task is unassigned", []*models.Grant{{Role: models.RoleMember, TaskID: 2}}, func(tu task.Usecase) error {
+			_, err := tu.Unassign(ctx, 2, []int{3})
+			return err
+		}, called},
+		{"viewer reads a task", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			_, err := tu.Get(ctx, 1)
+			return err
+		}, called},
+		{"viewer can not check an item", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			_, err := tu.ToggleChecklistItem(ctx, 1, 2)
+			return err
+		}, core.ErrForbidden},
+		{"project member adds an item", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			_, err := tu.AddChecklistItem(ctx, &models.ChecklistItem{TaskID: 1, Text: "Read chapter 3"})
+			return err
+		}, called},
+		{"project member moves an item", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			_, err := tu.MoveChecklistItem(ctx, 1, 2, 0)
+			return err
+		}, called},
+		{"project member can not delete an item on another project", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			_, err := tu.DeleteChecklistItem(ctx, 2, 3)
+			return err
+		}, core.ErrForbidden},
+		{"project member moves a task", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			_, err := tu.Move(ctx, 1, &models.TaskMove{After: 2})
+			return err
+		}, called},
+		{"viewer can not move a task", []*models.Grant{viewer}, func(tu task.Usecase) error {
+			_, err := tu.Move(ctx, 1, &models.TaskMove{Before: 2})
+			return err
+		}, core.ErrForbidden},
+		{"project member can not move a task next to one it can not read", []*models.Grant{webMember}, func(tu task.Usecase) error {
+			_, err := tu.Move(ctx, 1, &models.TaskMove{Before: 2})
+			return err
+		}, core.ErrForbidden},
+		{"push within the project", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			_, err := tu.Push(ctx, []*models.SyncChange{{Project: "web"}, {ID: 1, Project: "web"}, {Deleted: true}})
+			return err
+		}, called},
+		{"push deleting a task", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			_, err := tu.Push(ctx, []*models.SyncChange{{Project: "web"}, {ID: 1, Deleted: true}})
+			return err
+		}, core.ErrForbidden},
+		{"import to the project", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			_, err := tu.Import(ctx, []*models.ImportRow{{Task: &models.Task{Project: "web"}}, {Error: "bad line"}}, false)
+			return err
+		}, called},
+		{"import to another project", []*models.Grant{viewer, webMember}, func(tu task.Usecase) error {
+			_, err := tu.Import(ctx, []*models.ImportRow{{Task: &models.Task{Project: "web"}}, {Task: &models.Task{Project: "api"}}}, true)
+			return err
+		}, core.ErrForbidden},
+		{"bulk within the role", []*models.Grant{member}, func(tu task.Usecase) error {
+			_, err := tu.Bulk(ctx, &models.BulkRequest{Operations: []*models.BulkOperation{
+				{Op: models.BulkCreate}, {Op: models.BulkUpdate, ID: 1}, {Op: models.BulkStatus, ID: 2}}})
+			return err
+		}, called},
+		{"bulk beyond the role", []*models.Grant{member}, func(tu task.Usecase) error {
+			_, err := tu.Bulk(ctx, &models.BulkRequest{Operations: []*models.BulkOperation{
+				{Op: models.BulkCreate}, {Op: models.BulkDelete, ID: 1}}})
+			return err
+		}, core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &mocks.MockRepository{Tasks: []*models.Task{{ID: 1, Project: "web"}, {ID: 2, Project: "api"}}}
+			tu := NewAuthorizedUsecase(&mocks.MockUsecase{Error: called}, tr, &accessmocks.MockUsecase{Grants: tt.grants})
+			if err := tt.call(tu); err != tt.want {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_authorizedUsecase_policyError(t *testing.T) {
+	tu := NewAuthorizedUsecase(&mocks.MockUsecase{}, &mocks.MockRepository{}, &accessmocks.MockUsecase{Error: core.ErrForbidden})
+	if _, err := tu.List(context.Background(), &models.TaskFilter{}); err != core.ErrForbidden {
+		t.Errorf("error = %v, want %v", err, core.ErrForbidden)
+	}
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func (tu *taskUsecase) Board(ctx context.Context) (*models.Board, error) {
+	tasks, err := tu.taskRepo.List(ctx, &models.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+	board := &models.Board{Columns: make([]*models.BoardColumn, len(models.BoardStatuses))}
+	columns := map[string]*models.BoardColumn{}
+	for i, status := range models.BoardStatuses {
+		board.Columns[i] = &models.BoardColumn{Status: status, Limit: tu.wipLimits[status], Tasks: []*models.Task{}}
+		columns[status] = board.Columns[i]
+	}
+	// the tasks come in the manual order, which every column keeps
+	for _, t := range tasks {
+		if c, ok := columns[t.Status]; ok {
+			c.Tasks = append(c.Tasks, t)
+		}
+	}
+	for _, c := range board.Columns {
+		c.Count = len(c.Tasks)
+		c.Exceeded = c.Limit > 0 && c.Count > c.Limit
+	}
+	return board, nil
+}
+
+// checkWIP checks the tasks entering the columns with a WIP limit, a task
+// without id is a new one and a task already in the column of its status does
+// not count. A missing task is core.ErrRecordNotFound, unless the tasks are a
+// batch whose write reports them one by one. The columns are locked in a stable
+// order until the end of the transaction, so that concurrent writes can not all
+// take the last place. It returns the tasks going over the limit of their
+// column, which is an error unless the limits are only flagged
+func (tu *taskUsecase) checkWIP(ctx context.Context, batch bool, tasks ...*models.Task) ([]*models.Task, error) {
+	entering := map[string][]*models.Task{}
+	for _, t := range tasks {
+		if tu.wipLimits[t.Status] <= 0 {
+			continue
+		}
+		if t.ID != 0 {
+			old, err := tu.taskRepo.Get(ctx, t.ID)
+			if batch && err == core.ErrRecordNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if old.Status == t.Status {
+				continue
+			}
+		}
+		entering[t.Status] = append(entering[t.Status], t)
+	}
+	statuses := make([]string, 0, len(entering))
+	for status := range entering {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	over := []*models.Task{}
+	for _, status := range statuses {
+		n, err := tu.taskRepo.LockStatus(ctx, status)
+		if err != nil {
+			return nil, err
+		}
+		if n+len(entering[status]) <= tu.wipLimits[status] {
+			continue
+		}
+		if tu.wipMode != models.WIPFlag {
+			return nil, core.ErrWIPLimit
+		}
+		over = append(over, entering[status]...)
+	}
+	return over, nil
+}
+
+// wipEvents returns the events flagging the tasks over the WIP limit of their column
+func wipEvents(over []*models.Task) []*models.TaskEvent {
+	events := make([]*models.TaskEvent, len(over))
+	for i, t := range over {
+		events[i] = event(t, models.EventWIPLimit)
+	}
+	return events
+}
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+func Test_taskUsecase_Board(t *testing.T) {
+	repo := &mocks.MockRepository{Tasks: []*models.Task{
+		{ID: 1, Title: "Take math notes", Status: "inprogress"},
+		{ID: 2, Title: "Read chapter 3", Status: "todo"},
+		{ID: 3, Title: "Solve exercises", Status: "inprogress"},
+	}}
+	tu := NewTaskUsecase(repo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor(),
+		WithWIPLimits(map[string]int{"inprogress": 1, "todo": 5}, models.WIPFlag))
+	got, err := tu.Board(context.Background())
+	if err != nil {
+		t.Fatalf("taskUsecase.Board() error = %v", err)
+	}
+	want := &models.Board{Columns: []*models.BoardColumn{
+		{Status: "todo", Count: 1, Limit: 5, Tasks: []*models.Task{repo.Tasks[1]}},
+		{Status: "inprogress", Count: 2, Limit: 1, Exceeded: true, Tasks: []*models.Task{repo.Tasks[0], repo.Tasks[2]}},
+		{Status: "done", Count: 0, Tasks: []*models.Task{}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("taskUsecase.Board() = %v, want %v", got, want)
+	}
+}
+
+func Test_taskUsecase_WIPLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       string
+		call       func(task.Usecase) error
+		wantErr    error
+		wantEvents []*models.TaskEvent
+	}{{
+		name: "Normal Case1: add to a column with room",
+		mode: models.WIPReject,
+		call: func(tu task.Usecase) error {
+			return tu.Add(context.Background(), &models.Task{Title: "Write summary", Status: "todo"})
+		},
+		wantEvents: []*models.TaskEvent{{TaskID: 3, Type: models.EventCreated, Status: "todo"}},
+	}, {
+		name: "add to a full column",
+		mode: models.WIPReject,
+		call: func(tu task.Usecase) error {
+			return tu.Add(context.Background(), &models.Task{Title: "Write summary", Status: "inprogress"})
+		},
+		wantErr: core.ErrWIPLimit,
+	}, {
+		name: "move into a full column",
+		mode: models.WIPReject,
+		call: func(tu task.Usecase) error {
+			return tu.Edit(context.Background(), &models.Task{ID: 2, Title: "Read chapter 3", Status: "inprogress"})
+		},
+		wantErr: core.ErrWIPLimit,
+	}, {
+		name: "edit a task of a full column",
+		mode: models.WIPReject,
+		call: func(tu task.Usecase) error {
+			return tu.Edit(context.Background(), &models.Task{ID: 1, Title: "Take notes", Status: "inprogress"})
+		},
+		wantEvents: []*models.TaskEvent{{TaskID: 1, Type: models.EventUpdated, Status: "inprogress"}},
+	}, {
+		name: "move into a full column flagged",
+		mode: models.WIPFlag,
+		call: func(tu task.Usecase) error {
+			return tu.Edit(context.Background(), &models.Task{ID: 2, Title: "Read chapter 3", Status: "inprogress"})
+		},
+		wantEvents: []*models.TaskEvent{
+			{TaskID: 2, Type: models.EventUpdated, Status: "inprogress"},
+			{TaskID: 2, Type: models.EventWIPLimit, Status: "inprogress"},
+		},
+	}, {
+		name: "move a missing task",
+		mode: models.WIPFlag,
+		call: func(tu task.Usecase) error {
+			return tu.Edit(context.Background(), &models.Task{ID: 42, Title: "Lost", Status: "inprogress"})
+		},
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockRepository{Tasks: []*models.Task{
+				{ID: 1, Title: "Take math notes", Status: "inprogress"},
+				{ID: 2, Title: "Read chapter 3", Status: "todo"},
+			}}
+			events := &mocks.MockEventRepository{}
+			tu := NewTaskUsecase(repo, events, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor(),
+				WithWIPLimits(map[string]int{"inprogress": 1}, tt.mode))
+			if err := tt.call(tu); err != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(events.Events, tt.wantEvents) {
+				t.Errorf("history = %v, want %v", events.Events, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func Test_taskUsecase_WIPLimits_everyWrite(t *testing.T) {
+	moveTo := func(status string, ids ...int) []*models.BulkOperation {
+		ops := make([]*models.BulkOperation, len(ids))
+		for i, id := range ids {
+			ops[i] = &models.BulkOperation{Op: models.BulkStatus, ID: id, Status: status}
+		}
+		return ops
+	}
+	tests := []struct {
+		name string
+		mode string
+		// call returns the outcome of the write for the single task it checks
+		call       func(task.Usecase) (string, error)
+		want       string
+		wantErr    error
+		wantLocked []string
+		wantFlags  []int
+	}{{
+		name: "Normal Case1: bulk moves a task already in the column",
+		mode: models.WIPReject,
+		call: func(tu task.Usecase) (string, error) {
+			res, err := tu.Bulk(context.Background(), &models.BulkRequest{Mode: models.BulkAtomic, Operations: moveTo("inprogress", 1)})
+			if err != nil {
+				return "", err
+			}
+			return res.Results[0].Status, nil
+		},
+		want: models.BulkOK,
+	}, {
+		name: "atomic bulk into a full column",
+		mode: models.WIPReject,
+		call: func(tu task.Usecase) (string, error) {
+			_, err := tu.Bulk(context.Background(), &models.BulkRequest{Mode: models.BulkAtomic, Operations: moveTo("inprogress", 2)})
+			return "", err
+		},
+		wantErr:    core.ErrWIPLimit,
+		wantLocked: []string{"inprogress"},
+	}, {
+		name: "best effort bulk into a full column",
+		mode: models.WIPReject,
+		call: func(tu task.Usecase) (string, error) {
+			res, err := tu.Bulk(context.Background(), &models.BulkRequest{Mode: models.BulkBestEffort, Operations: moveTo("inprogress", 2, 3)})
+			if err != nil {
+				return "", err
+			}
+			return res.Results[1].Status + ": " + res.Results[1].Error, nil
+		},
+		want:       models.BulkFailed + ": wip limit reached",
+		wantLocked: []string{"inprogress"},
+	}, {
+		name: "columns are locked in order",
+		mode: models.WIPFlag,
+		call: func(tu task.Usecase) (string, error) {
+			ops := append(moveTo("inprogress", 2), moveTo("done", 3)...)
+			res, err := tu.Bulk(context.Background(), &models.BulkRequest{Mode: models.BulkAtomic, Operations: ops})
+			if err != nil {
+				return "", err
+			}
+			return res.Results[0].Status, nil
+		},
+		want:       models.BulkOK,
+		wantLocked: []string{"done", "inprogress"},
+		wantFlags:  []int{3, 2},
+	}, {
+		name: "import into a full column",
+		mode: models.WIPReject,
+		call: func(tu task.Usecase) (string, error) {
+			rows := []*models.ImportRow{{Line: 1, Task: &models.Task{Title: "Write summary", Status: "inprogress"}}}
+			_, err := tu.Import(context.Background(), rows, false)
+			return "", err
+		},
+		wantErr:    core.ErrWIPLimit,
+		wantLocked: []string{"inprogress"},
+	}, {
+		name: "sync push into a full column",
+		mode: models.WIPReject,
+		call: func(tu task.Usecase) (string, error) {
+			changes := []*models.SyncChange{{ClientID: "a", Title: "Write summary", Status: "inprogress"}}
+			results, err := tu.Push(context.Background(), changes)
+			if err != nil {
+				return "", err
+			}
+			return results[0].Status + ": " + results[0].Error, nil
+		},
+		want:       models.SyncInvalid + ": wip limit reached",
+		wantLocked: []string{"inprogress"},
+	}, {
+		name: "auto-complete into a full column keeps the task",
+		mode: models.WIPReject,
+		call: func(tu task.Usecase) (string, error) {
+			got, err := tu.ToggleChecklistItem(context.Background(), 2, 1)
+			if err != nil {
+				return "", err
+			}
+			return got.Status, nil
+		},
+		want:       "todo",
+		wantLocked: []string{"done"},
+	}, {
+		name: "auto-complete into a full column flagged",
+		mode: models.WIPFlag,
+		call: func(tu task.Usecase) (string, error) {
+			got, err := tu.ToggleChecklistItem(context.Background(), 2, 1)
+			if err != nil {
+				return "", err
+			}
+			return got.Status, nil
+		},
+		want:       "done",
+		wantLocked: []string{"done"},
+		wantFlags:  []int{2},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockRepository{Tasks: []*models.Task{
+				{ID: 1, Title: "Take math notes", Status: "inprogress"},
+				{ID: 2, Title: "Read chapter 3", Status: "todo"},
+				{ID: 3, Title: "Solve exercises", Status: "todo"},
+				{ID: 4, Title: "Buy a notebook", Status: "done"},
+			}}
+			events := &mocks.MockEventRepository{}
+			checklist := &mocks.MockChecklistRepository{Items: []*models.ChecklistItem{{ID: 1, TaskID: 2, Text: "Read it"}}}
+			tu := NewTaskUsecase(repo, events, &mocks.MockSearchRepository{}, checklist, transaction.NewMemoryTransactor(),
+				WithAutoComplete(), WithWIPLimits(map[string]int{"inprogress": 1, "done": 1}, tt.mode))
+			got, err := tt.call(tu)
+			if err != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(repo.Locked, tt.wantLocked) {
+				t.Errorf("locked = %v, want %v", repo.Locked, tt.wantLocked)
+			}
+			var flags []int
+			for _, e := range events.Events {
+				if e.Type == models.EventWIPLimit {
+					flags = append(flags, e.TaskID)
+				}
+			}
+			if !reflect.DeepEqual(flags, tt.wantFlags) {
+				t.Errorf("flagged = %v, want %v", flags, tt.wantFlags)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+// errBulkAborted rolls back an atomic request with operations on missing tasks
+var errBulkAborted = errors.New("bulk request aborted")
+
+func (tu *taskUsecase) Bulk(ctx context.Context, req *models.BulkRequest) (*models.BulkResponse, error) {
+	results, batch := prepareBatch(req.Operations)
+	if req.Mode == models.BulkAtomic {
+		return tu.bulkAtomic(ctx, results, batch)
+	}
+	tu.bulkBestEffort(ctx, results, batch)
+	return &models.BulkResponse{Committed: true, Results: results}, nil
+}
+
+// prepareBatch validates the operations and groups the valid ones into a batch
+func prepareBatch(ops []*models.BulkOperation) ([]*models.BulkResult, *models.TaskBatch) {
+	validate := validator.New()
+	results := make([]*models.BulkResult, len(ops))
+	batch := &models.TaskBatch{Status: map[string][]int{}}
+	seen := map[int]bool{}
+	for i, op := range ops {
+		result := &models.BulkResult{Index: i, Op: op.Op, ID: op.ID}
+		results[i] = result
+		if op.Op != models.BulkCreate {
+			switch {
+			case op.ID <= 0:
+				result.Status, result.Error = models.BulkInvalid, "id is empty"
+				continue
+			case seen[op.ID]:
+				result.Status, result.Error = models.BulkInvalid, "task appears more than once"
+				continue
+			}
+			seen[op.ID] = true
+		}
+		task := &models.Task{ID: op.ID, Title: op.Title, Status: op.Status}
+		var err error
+		switch op.Op {
+		case models.BulkCreate:
+			task.ID = 0
+			task.Priority, task.Project, task.Tags = op.Priority, op.Project, op.Tags
+			task.Description, task.DueDate = op.Description, op.DueDate
+			if err = validate.Struct(task); err == nil {
+				batch.Create = append(batch.Create, task)
+			}
+		case models.BulkUpdate:
+			if err = validate.Struct(task); err == nil {
+				batch.Update = append(batch.Update, task)
+			}
+		case models.BulkStatus:
+			if err = validate.Var(op.Status, "oneof=todo inprogress done"); err == nil {
+				batch.Status[op.Status] = append(batch.Status[op.Status], op.ID)
+			}
+		case models.BulkDelete:
+			batch.Delete = append(batch.Delete, op.ID)
+		default:
+			result.Status, result.Error = models.BulkInvalid, "unknown op"
+			continue
+		}
+		if err != nil {
+			result.Status, result.Error = models.BulkInvalid, "validation error"
+		}
+	}
+	return results, batch
+}
+
+func (tu *taskUsecase) bulkAtomic(ctx context.Context, results []*models.BulkResult, batch *models.TaskBatch) (*models.BulkResponse, error) {
+	res := &models.BulkResponse{Results: results}
+	for _, r := range results {
+		if r.Status == models.BulkInvalid {
+			return abort(res), nil
+		}
+	}
+	err := tu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		over, err := tu.checkWIP(ctx, true, batchTasks(batch)...)
+		if err != nil {
+			return err
+		}
+		if err := tu.taskRepo.AddMany(ctx, batch.Create); err != nil {
+			return err
+		}
+		setCreated(results, batch.Create, nil)
+		found, err := tu.taskRepo.EditMany(ctx, batch.Update)
+		if err != nil {
+			return err
+		}
+		markFound(results, updateIDs(batch), found, nil)
+		// iterate statuses in a stable order so that row locks are taken in the same order
+		statuses := make([]string, 0, len(batch.Status))
+		for status := range batch.Status {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			found, err := tu.taskRepo.SetStatusMany(ctx, batch.Status[status], status)
+			if err != nil {
+				return err
+			}
+			markFound(results, batch.Status[status], found, nil)
+		}
+		found, err = tu.taskRepo.DeleteMany(ctx, batch.Delete)
+		if err != nil {
+			return err
+		}
+		markFound(results, batch.Delete, found, nil)
+		for _, r := range results {
+			if r.Status == models.BulkNotFound {
+				return errBulkAborted
+			}
+		}
+		return tu.eventRepo.Add(ctx, append(batchEvents(results, batch), wipEvents(over)...)...)
+	})
+	if err == errBulkAborted {
+		return abort(res), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res.Committed = true
+	setVersions(results, batch.Update)
+	return res, nil
+}
+
+// abort marks every operation applied by a rolled back request as aborted
+func abort(res *models.BulkResponse) *models.BulkResponse {
+	for _, r := range res.Results {
+		if r.Status == "" || r.Status == models.BulkOK {
+			r.Status = models.BulkAborted
+			r.Version = 0
+			if r.Op == models.BulkCreate {
+				r.ID = 0
+			}
+		}
+	}
+	return res
+}
+
+func (tu *taskUsecase) bulkBestEffort(ctx context.Context, results []*models.BulkResult, batch *models.TaskBatch) {
+	err := tu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		over, err := tu.checkWIP(ctx, true, batch.Create...)
+		if err != nil {
+			return err
+		}
+		if err := tu.taskRepo.AddMany(ctx, batch.Create); err != nil {
+			return err
+		}
+		created := make([]*models.BulkResult, 0, len(batch.Create))
+		for _, t := range batch.Create {
+			created = append(created, &models.BulkResult{Op: models.BulkCreate, ID: t.ID, Status: models.BulkOK})
+		}
+		return tu.eventRepo.Add(ctx, append(batchEvents(created, batch), wipEvents(over)...)...)
+	})
+	setCreated(results, batch.Create, err)
+	tu.bulkGroup(ctx, results, batch, updateIDs(batch), batch.Update, func(ctx context.Context) ([]int, error) {
+		return tu.taskRepo.EditMany(ctx, batch.Update)
+	})
+	setVersions(results, batch.Update)
+	for status, ids := range batch.Status {
+		status, ids := status, ids
+		tu.bulkGroup(ctx, results, batch, ids, statusTasks(ids, status), func(ctx context.Context) ([]int, error) {
+			return tu.taskRepo.SetStatusMany(ctx, ids, status)
+		})
+	}
+	tu.bulkGroup(ctx, results, batch, batch.Delete, nil, func(ctx context.Context) ([]int, error) {
+		return tu.taskRepo.DeleteMany(ctx, batch.Delete)
+	})
+}
+
+// bulkGroup applies a single statement of a best effort request along with its history
+// events, after checking the WIP limits of the columns the tasks of the group enter
+func (tu *taskUsecase) bulkGroup(ctx context.Context, results []*models.BulkResult, batch *models.TaskBatch,
+	ids []int, tasks []*models.Task, apply func(ctx context.Context) ([]int, error)) {
+	if len(ids) == 0 {
+		return
+	}
+	var found []int
+	err := tu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		over, err := tu.checkWIP(ctx, true, tasks...)
+		if err != nil {
+			return err
+		}
+		found, err = apply(ctx)
+		if err != nil {
+			return err
+		}
+		ok := make(map[int]bool, len(found))
+		for _, id := range found {
+			ok[id] = true
+		}
+		group := make([]*models.BulkResult, 0, len(found))
+		for _, r := range results {
+			if ok[r.ID] && r.Op != models.BulkCreate && r.Status == "" {
+				group = append(group, &models.BulkResult{Op: r.Op, ID: r.ID, Status: models.BulkOK})
+			}
+		}
+		return tu.eventRepo.Add(ctx, append(batchEvents(group, batch), wipEvents(over)...)...)
+	})
+	markFound(results, ids, found, err)
+}
+
+// statusTasks returns the tasks of ids moved to status
+func statusTasks(ids []int, status string) []*models.Task {
+	tasks := make([]*models.Task, len(ids))
+	for i, id := range ids {
+		tasks[i] = &models.Task{ID: id, Status: status}
+	}
+	return tasks
+}
+
+// batchTasks returns every task the batch adds or moves
+func batchTasks(batch *models.TaskBatch) []*models.Task {
+	tasks := append(append([]*models.Task{}, batch.Create...), batch.Update...)
+	for status, ids := range batch.Status {
+		tasks = append(tasks, statusTasks(ids, status)...)
+	}
+	return tasks
+}
+
+// batchEvents returns the history events of the applied operations
+func batchEvents(results []*models.BulkResult, batch *models.TaskBatch) []*models.TaskEvent {
+	statuses := map[int]string{}
+	for _, t := range batch.Create {
+		statuses[t.ID] = t.Status
+	}
+	for _, t := range batch.Update {
+		statuses[t.ID] = t.Status
+	}
+	for status, ids := range batch.Status {
+		for _, id := range ids {
+			statuses[id] = status
+		}
+	}
+	events := make([]*models.TaskEvent, 0, len(results))
+	for _, r := range results {
+		if r.Status != models.BulkOK {
+			continue
+		}
+		t := &models.Task{ID: r.ID, Status: statuses[r.ID]}
+		switch r.Op {
+		case models.BulkCreate:
+			events = append(events, event(t, models.EventCreated))
+		case models.BulkDelete:
+			events = append(events, event(t, models.EventDeleted))
+		default:
+			events = append(events, event(t, models.EventUpdated))
+		}
+	}
+	return events
+}
+
+func updateIDs(batch *models.TaskBatch) []int {
+	ids := make([]int, len(batch.Update))
+	for i, t := range batch.Update {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+// setCreated copies ids and versions of the created tasks into the results.
+// Created tasks are matched by position since they have no id up front
+func setCreated(results []*models.BulkResult, tasks []*models.Task, err error) {
+	i := 0
+	for _, r := range results {
+		if r.Op != models.BulkCreate || r.Status != "" || i >= len(tasks) {
+			continue
+		}
+		if err != nil {
+			r.Status, r.Error = models.BulkFailed, err.Error()
+		} else {
+			r.Status, r.ID, r.Version = models.BulkOK, tasks[i].ID, tasks[i].Version
+		}
+		i++
+	}
+}
+
+func setVersions(results []*models.BulkResult, tasks []*models.Task) {
+	versions := map[int]int64{}
+	for _, t := range tasks {
+		versions[t.ID] = t.Version
+	}
+	for _, r := range results {
+		if r.Op == models.BulkUpdate && r.Status == models.BulkOK {
+			r.Version = versions[r.ID]
+		}
+	}
+}
+
+// markFound sets the status of the pending results for ids from the ids the repository found.
+// Ids are unique within a request so a result is matched by id alone
+func markFound(results []*models.BulkResult, ids []int, found []int, err error) {
+	pending := map[int]bool{}
+	for _, id := range ids {
+		pending[id] = true
+	}
+	ok := map[int]bool{}
+	for _, id := range found {
+		ok[id] = true
+	}
+	for _, r := range results {
+		if r.Op == models.BulkCreate || r.Status != "" || !pending[r.ID] {
+			continue
+		}
+		switch {
+		case err != nil:
+			r.Status, r.Error = models.BulkFailed, err.Error()
+		case ok[r.ID]:
+			r.Status = models.BulkOK
+		default:
+			r.Status = models.BulkNotFound
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+func bulkStatuses(res *models.BulkResponse) []string {
+	statuses := make([]string, len(res.Results))
+	for i, r := range res.Results {
+		statuses[i] = r.Status
+	}
+	return statuses
+}
+
+func Test_taskUsecase_Bulk(t *testing.T) {
+	existing := func() []*models.Task {
+		return []*models.Task{
+			{ID: 1, Title: "Take math notes", Status: "todo"},
+			{ID: 2, Title: "do physics homework", Status: "todo"},
+		}
+	}
+	tests := []struct {
+		name          string
+		repo          *mocks.MockRepository
+		req           *models.BulkRequest
+		wantCommitted bool
+		wantStatuses  []string
+		wantErr       bool
+	}{{
+		name: "Normal Case1: atomic commit",
+		repo: &mocks.MockRepository{Tasks: existing()},
+		req: &models.BulkRequest{Mode: models.BulkAtomic, Operations: []*models.BulkOperation{
+			{Op: models.BulkCreate, Title: "buy milk", Status: "todo"},
+			{Op: models.BulkStatus, ID: 1, Status: "done"},
+			{Op: models.BulkDelete, ID: 2},
+		}},
+		wantCommitted: true,
+		wantStatuses:  []string{models.BulkOK, models.BulkOK, models.BulkOK},
+	}, {
+		name: "atomic request with a missing task is aborted",
+		repo: &mocks.MockRepository{Tasks: existing()},
+		req: &models.BulkRequest{Mode: models.BulkAtomic, Operations: []*models.BulkOperation{
+			{Op: models.BulkUpdate, ID: 1, Title: "Take math notes", Status: "done"},
+			{Op: models.BulkDelete, ID: 5},
+		}},
+		wantStatuses: []string{models.BulkAborted, models.BulkNotFound},
+	}, {
+		name: "atomic request with an invalid operation is aborted",
+		repo: &mocks.MockRepository{Tasks: existing()},
+		req: &models.BulkRequest{Mode: models.BulkAtomic, Operations: []*models.BulkOperation{
+			{Op: models.BulkStatus, ID: 1, Status: "completed"},
+			{Op: models.BulkDelete, ID: 2},
+			{Op: models.BulkDelete, ID: 2},
+		}},
+		wantStatuses: []string{models.BulkInvalid, models.BulkAborted, models.BulkInvalid},
+	}, {
+		name: "best effort applies what it can",
+		repo: &mocks.MockRepository{Tasks: existing()},
+		req: &models.BulkRequest{Mode: models.BulkBestEffort, Operations: []*models.BulkOperation{
+			{Op: models.BulkCreate, Title: "buy milk", Status: "todo"},
+			{Op: models.BulkCreate, Title: "", Status: "todo"},
+			{Op: models.BulkStatus, ID: 1, Status: "done"},
+			{Op: models.BulkStatus, ID: 7, Status: "inprogress"},
+			{Op: "archive", ID: 2},
+		}},
+		wantCommitted: true,
+		wantStatuses: []string{models.BulkOK, models.BulkInvalid, models.BulkOK,
+			models.BulkNotFound, models.BulkInvalid},
+	}, {
+		name: "best effort reports repository errors per item",
+		repo: &mocks.MockRepository{Error: errors.New("db error")},
+		req: &models.BulkRequest{Mode: models.BulkBestEffort, Operations: []*models.BulkOperation{
+			{Op: models.BulkDelete, ID: 1},
+		}},
+		wantCommitted: true,
+		wantStatuses:  []string{models.BulkFailed},
+	}, {
+		name: "atomic repository error",
+		repo: &mocks.MockRepository{Error: errors.New("db error")},
+		req: &models.BulkRequest{Mode: models.BulkAtomic, Operations: []*models.BulkOperation{
+			{Op: models.BulkDelete, ID: 1},
+		}},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tu := NewTaskUsecase(tt.repo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+			got, err := tu.Bulk(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("taskUsecase.Bulk() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Committed != tt.wantCommitted {
+				t.Errorf("taskUsecase.Bulk() committed = %v, want %v", got.Committed, tt.wantCommitted)
+			}
+			statuses := bulkStatuses(got)
+			for i := range tt.wantStatuses {
+				if statuses[i] != tt.wantStatuses[i] {
+					t.Errorf("operation %d: got status %s, want %s", i, statuses[i], tt.wantStatuses[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func (tu *taskUsecase) Get(ctx context.Context, id int) (*models.Task, error) {
+	t, err := tu.taskRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.Checklist, err = tu.checklistRepo.List(ctx, id); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (tu *taskUsecase) AddChecklistItem(ctx context.Context, item *models.ChecklistItem) (*models.Task, error) {
+	return tu.changeChecklist(ctx, item.TaskID, func(ctx context.Context) error {
+		return tu.checklistRepo.Add(ctx, item)
+	})
+}
+
+func (tu *taskUsecase) ToggleChecklistItem(ctx context.Context, taskID, id int) (*models.Task, error) {
+	return tu.changeChecklist(ctx, taskID, func(ctx context.Context) error {
+		_, err := tu.checklistRepo.Toggle(ctx, taskID, id)
+		return err
+	})
+}
+
+func (tu *taskUsecase) MoveChecklistItem(ctx context.Context, taskID, id, position int) (*models.Task, error) {
+	return tu.changeChecklist(ctx, taskID, func(ctx context.Context) error {
+		return tu.checklistRepo.Move(ctx, taskID, id, position)
+	})
+}
+
+func (tu *taskUsecase) DeleteChecklistItem(ctx context.Context, taskID, id int) (*models.Task, error) {
+	return tu.changeChecklist(ctx, taskID, func(ctx context.Context) error {
+		return tu.checklistRepo.Delete(ctx, taskID, id)
+	})
+}
+
+// changeChecklist applies change to the checklist of the task and returns the task
+// as it is afterwards, completed when the option is set and every item is checked.
+// A full done column keeps the task where it is unless the WIP limits are only flagged
+func (tu *taskUsecase) changeChecklist(ctx context.Context, taskID int, change func(context.Context) error) (*models.Task, error) {
+	var task *models.Task
+	err := tu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := change(ctx); err != nil {
+			return err
+		}
+		var err error
+		if task, err = tu.Get(ctx, taskID); err != nil {
+			return err
+		}
+		if !tu.autoComplete || task.Status == "done" || !completed(task.Checklist) {
+			return nil
+		}
+		done := *task
+		done.Status = "done"
+		over, err := tu.checkWIP(ctx, false, &done)
+		if err == core.ErrWIPLimit {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		task.Status = "done"
+		if err := tu.taskRepo.Edit(ctx, task); err != nil {
+			return err
+		}
+		return tu.eventRepo.Add(ctx, append([]*models.TaskEvent{event(task, models.EventUpdated)}, wipEvents(over)...)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// completed tells whether there are items and all of them are checked
+func completed(items []*models.ChecklistItem) bool {
+	for _, item := range items {
+		if !item.Checked {
+			return false
+		}
+	}
+	return len(items) > 0
+}
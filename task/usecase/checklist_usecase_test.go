@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+func Test_taskUsecase_Checklist(t *testing.T) {
+	repo := &mocks.MockRepository{Tasks: []*models.Task{{ID: 1, Title: "Take math notes", Status: "todo"}}}
+	items := &mocks.MockChecklistRepository{}
+	tu := NewTaskUsecase(repo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, items, transaction.NewMemoryTransactor())
+	ctx := context.Background()
+	for _, text := range []string{"Read chapter 3", "Take notes", "Solve exercises"} {
+		if _, err := tu.AddChecklistItem(ctx, &models.ChecklistItem{TaskID: 1, Text: text}); err != nil {
+			t.Fatalf("taskUsecase.AddChecklistItem() error = %v", err)
+		}
+	}
+	got, err := tu.MoveChecklistItem(ctx, 1, 3, 0)
+	if err != nil {
+		t.Fatalf("taskUsecase.MoveChecklistItem() error = %v", err)
+	}
+	if got, want := texts(got.Checklist), []string{"Solve exercises", "Read chapter 3", "Take notes"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("checklist = %v, want %v", got, want)
+	}
+	if got, err = tu.DeleteChecklistItem(ctx, 1, 1); err != nil {
+		t.Fatalf("taskUsecase.DeleteChecklistItem() error = %v", err)
+	}
+	if got, want := texts(got.Checklist), []string{"Solve exercises", "Take notes"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("checklist = %v, want %v", got, want)
+	}
+	if got, err = tu.Get(ctx, 1); err != nil || len(got.Checklist) != 2 {
+		t.Errorf("taskUsecase.Get() = %v, %v, want 2 items", got, err)
+	}
+	// no auto-complete without the option
+	tu.ToggleChecklistItem(ctx, 1, 2)
+	if got, _ = tu.ToggleChecklistItem(ctx, 1, 3); got.Status != "todo" {
+		t.Errorf("status = %s, want todo", got.Status)
+	}
+	if _, err := tu.ToggleChecklistItem(ctx, 1, 1); err != core.ErrRecordNotFound {
+		t.Errorf("expected record not found, got %v", err)
+	}
+	// the item of a missing task is rolled back
+	if _, err := tu.AddChecklistItem(ctx, &models.ChecklistItem{TaskID: 42, Text: "Lost"}); err != core.ErrRecordNotFound {
+		t.Errorf("expected record not found, got %v", err)
+	}
+	if len(items.Items) != 2 {
+		t.Errorf("items = %d, want 2", len(items.Items))
+	}
+}
+
+func Test_taskUsecase_ChecklistAutoComplete(t *testing.T) {
+	repo := &mocks.MockRepository{Tasks: []*models.Task{{ID: 1, Title: "Take math notes", Status: "todo"}}}
+	items := &mocks.MockChecklistRepository{Items: []*models.ChecklistItem{
+		{ID: 1, TaskID: 1, Text: "Read chapter 3", Checked: true, Position: 0},
+		{ID: 2, TaskID: 1, Text: "Take notes", Position: 1},
+	}}
+	events := &mocks.MockEventRepository{}
+	tu := NewTaskUsecase(repo, events, &mocks.MockSearchRepository{}, items, transaction.NewMemoryTransactor(), WithAutoComplete())
+	got, err := tu.ToggleChecklistItem(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("taskUsecase.ToggleChecklistItem() error = %v", err)
+	}
+	if got.Status != "done" {
+		t.Errorf("status = %s, want done", got.Status)
+	}
+	want := []*models.TaskEvent{{TaskID: 1, Type: models.EventUpdated, Status: "done"}}
+	if !reflect.DeepEqual(events.Events, want) {
+		t.Errorf("history = %v, want %v", events.Events, want)
+	}
+	// a done task is not completed again
+	tu.ToggleChecklistItem(context.Background(), 1, 2)
+	tu.ToggleChecklistItem(context.Background(), 1, 2)
+	if len(events.Events) != 1 {
+		t.Errorf("history = %v, want one event", events.Events)
+	}
+}
+
+func texts(items []*models.ChecklistItem) []string {
+	texts := make([]string, len(items))
+	for i, item := range items {
+		texts[i] = item.Text
+	}
+	return texts
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+// newTaskValidator returns a validator reporting fields by their json name
+func newTaskValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+	})
+	return validate
+}
+
+// validationMessages turns a validation error into one message per field
+func validationMessages(err error) []string {
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return []string{err.Error()}
+	}
+	messages := make([]string, 0, len(errs))
+	for _, fe := range errs {
+		msg := fmt.Sprintf("%s failed on %s", fe.Field(), fe.Tag())
+		if fe.Param() != "" {
+			msg += "=" + fe.Param()
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
+func (tu *taskUsecase) Import(ctx context.Context, rows []*models.ImportRow, dryRun bool) (*models.ImportResult, error) {
+	validate := newTaskValidator()
+	result := &models.ImportResult{DryRun: dryRun, Rows: make([]*models.ImportRowResult, len(rows))}
+	tasks := make([]*models.Task, 0, len(rows))
+	valid := true
+	for i, row := range rows {
+		res := &models.ImportRowResult{Line: row.Line, Status: models.ImportValid}
+		result.Rows[i] = res
+		if row.Task != nil {
+			res.Title = row.Task.Title
+		}
+		switch {
+		case row.Error != "":
+			res.Errors = []string{row.Error}
+		case row.Task == nil:
+			res.Errors = []string{"empty row"}
+		default:
+			if err := validate.Struct(row.Task); err != nil {
+				res.Errors = validationMessages(err)
+			}
+		}
+		if len(res.Errors) > 0 {
+			res.Status = models.ImportInvalid
+			valid = false
+			continue
+		}
+		tasks = append(tasks, row.Task)
+	}
+	if dryRun || !valid || len(tasks) == 0 {
+		return result, nil
+	}
+	err := tu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		over, err := tu.checkWIP(ctx, true, tasks...)
+		if err != nil {
+			return err
+		}
+		if err := tu.taskRepo.AddMany(ctx, tasks); err != nil {
+			return err
+		}
+		events := make([]*models.TaskEvent, len(tasks))
+		for i, t := range tasks {
+			events[i] = event(t, models.EventCreated)
+		}
+		return tu.eventRepo.Add(ctx, append(events, wipEvents(over)...)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, row := range rows {
+		result.Rows[i].ID = row.Task.ID
+		result.Rows[i].Status = models.ImportCreated
+	}
+	result.Committed = true
+	result.Imported = len(tasks)
+	return result, nil
+}
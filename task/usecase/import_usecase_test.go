@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+func Test_taskUsecase_Import(t *testing.T) {
+	rows := func() []*models.ImportRow {
+		return []*models.ImportRow{
+			{Line: 2, Task: &models.Task{Title: "buy milk", Status: "todo", Tags: []string{}}},
+			{Line: 3, Task: &models.Task{Title: "Call the bank", Status: "done", Priority: 1, Project: "finance",
+				Tags: []string{"phone"}}},
+		}
+	}
+	invalid := func() []*models.ImportRow {
+		return append(rows(), &models.ImportRow{Line: 4, Task: &models.Task{Title: "", Status: "later"}},
+			&models.ImportRow{Line: 5, Error: "invalid priority \"high\""})
+	}
+	tests := []struct {
+		name          string
+		repo          *mocks.MockRepository
+		rows          []*models.ImportRow
+		dryRun        bool
+		wantCommitted bool
+		wantStatuses  []string
+		wantTasks     int
+		wantErr       bool
+	}{{
+		name:          "Normal Case1: all rows imported",
+		repo:          &mocks.MockRepository{},
+		rows:          rows(),
+		wantCommitted: true,
+		wantStatuses:  []string{models.ImportCreated, models.ImportCreated},
+		wantTasks:     2,
+	}, {
+		name:         "dry run adds nothing",
+		repo:         &mocks.MockRepository{},
+		rows:         rows(),
+		dryRun:       true,
+		wantStatuses: []string{models.ImportValid, models.ImportValid},
+	}, {
+		name:         "invalid rows prevent the import",
+		repo:         &mocks.MockRepository{},
+		rows:         invalid(),
+		wantStatuses: []string{models.ImportValid, models.ImportValid, models.ImportInvalid, models.ImportInvalid},
+	}, {
+		name:    "repository error",
+		repo:    &mocks.MockRepository{Error: errors.New("db error")},
+		rows:    rows(),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tu := NewTaskUsecase(tt.repo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+			got, err := tu.Import(context.Background(), tt.rows, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("taskUsecase.Import() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Committed != tt.wantCommitted {
+				t.Errorf("taskUsecase.Import() committed = %v, want %v", got.Committed, tt.wantCommitted)
+			}
+			if got.Imported != tt.wantTasks {
+				t.Errorf("taskUsecase.Import() imported %d tasks, want %d", got.Imported, tt.wantTasks)
+			}
+			for i, want := range tt.wantStatuses {
+				if want == models.ImportCreated && got.Rows[i].ID == 0 {
+					t.Errorf("row %d: created without an id", i)
+				}
+				if got.Rows[i].Status != want {
+					t.Errorf("row %d: got status %s, want %s (%v)", i, got.Rows[i].Status, want, got.Rows[i].Errors)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/rank"
+)
+
+func (tu *taskUsecase) Move(ctx context.Context, id int, m *models.TaskMove) (*models.Task, error) {
+	if m.After == id || m.Before == id {
+		return nil, core.ErrInvalidMove
+	}
+	err := tu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		key, err := tu.between(ctx, id, m)
+		if err == core.ErrUnranked || err == rank.ErrOrder {
+			// the neighbours have no rank yet or share one, both go away once
+			// the ranks are spread again
+			if err := tu.taskRepo.Rebalance(ctx); err != nil {
+				return err
+			}
+			key, err = tu.between(ctx, id, m)
+		}
+		if err == rank.ErrOrder {
+			return core.ErrInvalidMove
+		}
+		if err != nil {
+			return err
+		}
+		if err := tu.taskRepo.SetRank(ctx, id, key); err != nil {
+			return err
+		}
+		if len(key) > rank.MaxLength {
+			return tu.taskRepo.Rebalance(ctx)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tu.Get(ctx, id)
+}
+
+// between returns a key for the task between the neighbours of the move
+func (tu *taskUsecase) between(ctx context.Context, id int, m *models.TaskMove) (string, error) {
+	lower, upper, err := tu.taskRepo.Bounds(ctx, id, m.After, m.Before)
+	if err != nil {
+		return "", err
+	}
+	return rank.Between(lower, upper)
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/rank"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+// order returns the ids of the tasks of repo by rank, the unranked ones last
+func order(repo *mocks.MockRepository) []int {
+	ids := []int{}
+	for _, t := range repo.Tasks {
+		ids = append(ids, t.ID)
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		ri, iok := repo.Ranks[ids[i]]
+		rj, jok := repo.Ranks[ids[j]]
+		if iok != jok {
+			return iok
+		}
+		return ri < rj
+	})
+	return ids
+}
+
+func Test_taskUsecase_Move(t *testing.T) {
+	tests := []struct {
+		name    string
+		ranks   map[int]string
+		move    models.TaskMove
+		id      int
+		want    []int
+		wantErr error
+	}{{
+		name:  "Normal Case1: after a task",
+		ranks: map[int]string{1: "F", 2: "V", 3: "k"},
+		id:    3,
+		move:  models.TaskMove{After: 1},
+		want:  []int{1, 3, 2},
+	}, {
+		name:  "before the first task",
+		ranks: map[int]string{1: "F", 2: "V", 3: "k"},
+		id:    3,
+		move:  models.TaskMove{Before: 1},
+		want:  []int{3, 1, 2},
+	}, {
+		name:  "between two tasks",
+		ranks: map[int]string{1: "F", 2: "V", 3: "k"},
+		id:    1,
+		move:  models.TaskMove{After: 2, Before: 3},
+		want:  []int{2, 1, 3},
+	}, {
+		name: "after an unranked task",
+		id:   1,
+		move: models.TaskMove{After: 3},
+		want: []int{2, 3, 1},
+	}, {
+		name:  "tasks sharing a rank",
+		ranks: map[int]string{1: "F", 2: "V", 3: "V"},
+		id:    1,
+		move:  models.TaskMove{After: 2, Before: 3},
+		want:  []int{2, 1, 3},
+	}, {
+		name:    "before comes first",
+		ranks:   map[int]string{1: "F", 2: "V", 3: "k"},
+		id:      1,
+		move:    models.TaskMove{After: 3, Before: 2},
+		wantErr: core.ErrInvalidMove,
+	}, {
+		name:    "after itself",
+		id:      1,
+		move:    models.TaskMove{After: 1},
+		wantErr: core.ErrInvalidMove,
+	}, {
+		name:    "neighbour not found",
+		ranks:   map[int]string{1: "F", 2: "V", 3: "k"},
+		id:      1,
+		move:    models.TaskMove{After: 42},
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockRepository{Ranks: tt.ranks, Tasks: []*models.Task{
+				{ID: 1, Title: "Take math notes", Status: "todo"},
+				{ID: 2, Title: "Read chapter 3", Status: "todo"},
+				{ID: 3, Title: "Solve exercises", Status: "todo"},
+			}}
+			tu := NewTaskUsecase(repo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+			got, err := tu.Move(context.Background(), tt.id, &tt.move)
+			if err != tt.wantErr {
+				t.Fatalf("taskUsecase.Move() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.ID != tt.id {
+				t.Errorf("taskUsecase.Move() = task %d, want %d", got.ID, tt.id)
+			}
+			if order := order(repo); !reflect.DeepEqual(order, tt.want) {
+				t.Errorf("order = %v, want %v", order, tt.want)
+			}
+		})
+	}
+}
+
+func Test_taskUsecase_MoveRebalance(t *testing.T) {
+	repo := &mocks.MockRepository{Tasks: []*models.Task{
+		{ID: 1, Title: "Take math notes", Status: "todo"},
+		{ID: 2, Title: "Read chapter 3", Status: "todo"},
+	}}
+	tu := NewTaskUsecase(repo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+	// moving the tasks over each other again and again halves the gap every time
+	for i := 0; i < 200; i++ {
+		id, other := 1+i%2, 2-i%2
+		if _, err := tu.Move(context.Background(), id, &models.TaskMove{Before: other}); err != nil {
+			t.Fatalf("taskUsecase.Move() error = %v", err)
+		}
+	}
+	for id, r := range repo.Ranks {
+		if len(r) > rank.MaxLength || strings.HasSuffix(r, "0") {
+			t.Errorf("rank of %d = %q", id, r)
+		}
+	}
+	if order := order(repo); !reflect.DeepEqual(order, []int{2, 1}) {
+		t.Errorf("order = %v, want [2 1]", order)
+	}
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+const (
+	defaultSyncLimit = 500
+	// v1 tokens only held the change sequence, they are rejected so that
+	// their clients start over instead of skipping changes
+	syncTokenPrefix = "v2:"
+)
+
+// encodeSyncToken hides the sync cursor behind an opaque token
+func encodeSyncToken(cursor models.SyncCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s%d:%d", syncTokenPrefix, cursor.TxID, cursor.Seq)))
+}
+
+// decodeSyncToken returns the sync cursor of the token, an empty token starts from scratch
+func decodeSyncToken(token string) (models.SyncCursor, error) {
+	var cursor models.SyncCursor
+	if token == "" {
+		return cursor, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, core.ErrInvalidSyncToken
+	}
+	if _, err := fmt.Sscanf(string(b), syncTokenPrefix+"%d:%d", &cursor.TxID, &cursor.Seq); err != nil || cursor.TxID < 0 || cursor.Seq < 0 {
+		return models.SyncCursor{}, core.ErrInvalidSyncToken
+	}
+	return cursor, nil
+}
+
+func (tu *taskUsecase) Sync(ctx context.Context, token string, limit int) (*models.SyncDelta, error) {
+	since, err := decodeSyncToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > defaultSyncLimit {
+		limit = defaultSyncLimit
+	}
+	tasks, err := tu.taskRepo.Changes(ctx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	delta := &models.SyncDelta{
+		Created: []*models.Task{},
+		Updated: []*models.Task{},
+		Deleted: []int{},
+		Token:   encodeSyncToken(since),
+		HasMore: len(tasks) == limit,
+	}
+	for _, t := range tasks {
+		delta.Token = encodeSyncToken(models.SyncCursor{TxID: t.ChangeTxID, Seq: t.Version})
+		created := models.SyncCursor{TxID: t.CreatedTxID, Seq: t.CreatedVersion}
+		switch {
+		case t.Deleted:
+			// a fresh client never saw the task, so it does not need the tombstone
+			if since != (models.SyncCursor{}) && !since.Before(created) {
+				delta.Deleted = append(delta.Deleted, t.ID)
+			}
+		case since.Before(created):
+			delta.Created = append(delta.Created, t)
+		default:
+			delta.Updated = append(delta.Updated, t)
+		}
+	}
+	return delta, nil
+}
+
+func (tu *taskUsecase) Push(ctx context.Context, changes []*models.SyncChange) ([]*models.SyncResult, error) {
+	validate := validator.New()
+	results := make([]*models.SyncResult, 0, len(changes))
+	for _, c := range changes {
+		result := &models.SyncResult{ClientID: c.ClientID, ID: c.ID}
+		results = append(results, result)
+		t := &models.Task{ID: c.ID, Title: c.Title, Status: c.Status, Description: c.Description,
+			Priority: c.Priority, Project: c.Project, Tags: c.Tags, DueDate: c.DueDate}
+		if !c.Deleted {
+			if err := validate.Struct(t); err != nil {
+				result.Status = models.SyncInvalid
+				result.Error = "validation error"
+				continue
+			}
+		}
+		if c.Deleted && c.ID == 0 {
+			result.Status = models.SyncInvalid
+			result.Error = "id is empty"
+			continue
+		}
+		err := tu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return tu.applyChange(ctx, c, t)
+		})
+		switch err {
+		case nil:
+			result.Status = models.SyncApplied
+			result.ID = t.ID
+			result.Version = t.Version
+		case core.ErrRecordNotFound:
+			result.Status = models.SyncNotFound
+		case core.ErrWIPLimit:
+			result.Status = models.SyncInvalid
+			result.Error = err.Error()
+		case core.ErrConflict:
+			result.Status = models.SyncConflict
+			server, err := tu.taskRepo.Get(ctx, c.ID)
+			if err == core.ErrRecordNotFound {
+				result.Status = models.SyncNotFound
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			result.Task = server
+			result.Version = server.Version
+		default:
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// applyChange applies a single change along with its history events
+func (tu *taskUsecase) applyChange(ctx context.Context, c *models.SyncChange, t *models.Task) error {
+	var over []*models.Task
+	if !c.Deleted {
+		var err error
+		if over, err = tu.checkWIP(ctx, false, t); err != nil {
+			return err
+		}
+	}
+	var err error
+	eventType := models.EventUpdated
+	switch {
+	case c.Deleted:
+		eventType = models.EventDeleted
+		err = tu.taskRepo.DeleteVersion(ctx, c.ID, c.BaseVersion)
+	case c.ID == 0:
+		eventType = models.EventCreated
+		err = tu.taskRepo.Add(ctx, t)
+	default:
+		err = tu.taskRepo.EditVersion(ctx, t, c.BaseVersion)
+	}
+	if err != nil {
+		return err
+	}
+	return tu.eventRepo.Add(ctx, append([]*models.TaskEvent{event(t, eventType)}, wipEvents(over)...)...)
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/task/mocks"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+func Test_syncToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    models.SyncCursor
+		wantErr error
+	}{{
+		name:  "Normal Case1: round trip",
+		token: encodeSyncToken(models.SyncCursor{TxID: 901, Seq: 42}),
+		want:  models.SyncCursor{TxID: 901, Seq: 42},
+	}, {
+		name:  "empty token starts from scratch",
+		token: "",
+	}, {
+		name:    "v1 token",
+		token:   "djE6MTI",
+		wantErr: core.ErrInvalidSyncToken,
+	}, {
+		name:    "not base64",
+		token:   "%%%",
+		wantErr: core.ErrInvalidSyncToken,
+	}, {
+		name:    "unknown prefix",
+		token:   "djM6MToy",
+		wantErr: core.ErrInvalidSyncToken,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeSyncToken(tt.token)
+			if err != tt.wantErr {
+				t.Fatalf("decodeSyncToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("decodeSyncToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_taskUsecase_Sync(t *testing.T) {
+	changes := []*models.Task{
+		{ID: 1, Title: "Take math notes", Status: "todo", Version: 6, CreatedVersion: 6, ChangeTxID: 20, CreatedTxID: 20},
+		{ID: 2, Title: "do physics homework", Status: "done", Version: 7, CreatedVersion: 2, ChangeTxID: 21, CreatedTxID: 12},
+		{ID: 3, Title: "buy milk", Status: "done", Version: 8, CreatedVersion: 3, ChangeTxID: 22, CreatedTxID: 13, Deleted: true},
+		{ID: 4, Title: "draft", Status: "todo", Version: 9, CreatedVersion: 9, ChangeTxID: 23, CreatedTxID: 23, Deleted: true},
+	}
+	since := models.SyncCursor{TxID: 15, Seq: 5}
+	tests := []struct {
+		name    string
+		repo    task.Repository
+		token   string
+		want    *models.SyncDelta
+		wantErr bool
+	}{{
+		name:  "Normal Case1: created, updated and deleted since token",
+		repo:  &mocks.MockRepository{Tasks: changes},
+		token: encodeSyncToken(since),
+		want: &models.SyncDelta{
+			Created: []*models.Task{changes[0]},
+			Updated: []*models.Task{changes[1]},
+			Deleted: []int{3},
+			Token:   encodeSyncToken(models.SyncCursor{TxID: 23, Seq: 9}),
+		},
+	}, {
+		name:  "no changes keeps the token",
+		repo:  &mocks.MockRepository{Tasks: []*models.Task{}},
+		token: encodeSyncToken(since),
+		want: &models.SyncDelta{
+			Created: []*models.Task{},
+			Updated: []*models.Task{},
+			Deleted: []int{},
+			Token:   encodeSyncToken(since),
+		},
+	}, {
+		name:    "invalid token",
+		repo:    &mocks.MockRepository{},
+		token:   "%%%",
+		wantErr: true,
+	}, {
+		name:    "repository error",
+		repo:    &mocks.MockRepository{Error: errors.New("Repository.Error()")},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tu := NewTaskUsecase(tt.repo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+			got, err := tu.Sync(context.Background(), tt.token, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("taskUsecase.Sync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("taskUsecase.Sync() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_taskUsecase_Sync_interleaved has writer A take sequence 1 in
+// transaction 11 and stay in flight while writer B takes sequence 2 in
+// transaction 10 and commits. The repository only returns B until A commits,
+// the next sync must still return A although its sequence is lower
+func Test_taskUsecase_Sync_interleaved(t *testing.T) {
+	a := &models.Task{ID: 1, Title: "Take math notes", Status: "todo", Version: 1, CreatedVersion: 1, ChangeTxID: 11, CreatedTxID: 11}
+	b := &models.Task{ID: 2, Title: "do physics homework", Status: "todo", Version: 2, CreatedVersion: 2, ChangeTxID: 10, CreatedTxID: 10}
+	repo := &mocks.MockRepository{Tasks: []*models.Task{b}}
+	tu := NewTaskUsecase(repo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+	first, err := tu.Sync(context.Background(), "", 0)
+	if err != nil {
+		t.Fatalf("taskUsecase.Sync() error = %v", err)
+	}
+	if !reflect.DeepEqual(first.Created, []*models.Task{b}) {
+		t.Fatalf("first sync got %v, want the task of B", first.Created)
+	}
+	repo.Tasks = []*models.Task{b, a}
+	second, err := tu.Sync(context.Background(), first.Token, 0)
+	if err != nil {
+		t.Fatalf("taskUsecase.Sync() error = %v", err)
+	}
+	if !reflect.DeepEqual(second.Created, []*models.Task{a}) {
+		t.Errorf("second sync got %v, want the task of A", second.Created)
+	}
+}
+
+func Test_taskUsecase_Push(t *testing.T) {
+	server := &models.Task{ID: 2, Title: "do physics homework", Status: "done", Version: 7}
+	repo := &mocks.MockRepository{Tasks: []*models.Task{
+		{ID: 1, Title: "Take math notes", Status: "todo", Version: 3},
+		server,
+	}}
+	changes := []*models.SyncChange{
+		{ClientID: "a", Title: "buy milk", Status: "todo"},
+		{ClientID: "b", ID: 1, BaseVersion: 3, Title: "Take math notes", Status: "done"},
+		{ClientID: "c", ID: 2, BaseVersion: 5, Title: "physics", Status: "todo"},
+		{ClientID: "d", ID: 9, BaseVersion: 1, Deleted: true},
+		{ClientID: "e", Title: "bad", Status: "completed"},
+	}
+	want := []string{models.SyncApplied, models.SyncApplied, models.SyncConflict,
+		models.SyncNotFound, models.SyncInvalid}
+	tu := NewTaskUsecase(repo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+	results, err := tu.Push(context.Background(), changes)
+	if err != nil {
+		t.Fatalf("taskUsecase.Push() error = %v", err)
+	}
+	for i, r := range results {
+		if r.Status != want[i] {
+			t.Errorf("change %s: got status %s, want %s", r.ClientID, r.Status, want[i])
+		}
+	}
+	if results[2].Task != server {
+		t.Errorf("conflict should return the server copy, got %v", results[2].Task)
+	}
+	repo = &mocks.MockRepository{Error: errors.New("db error")}
+	tu = NewTaskUsecase(repo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+	if _, err := tu.Push(context.Background(), changes[:1]); err == nil {
+		t.Errorf("expected repository error")
+	}
+}
@@ -1,33 +1,143 @@
 package usecase
 
 import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
 	"github.com/pratheeshm/todo-golang/models"
 	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/timetrack"
 )
 
 type taskUsecase struct {
-	taskRepo task.Repository
+	taskRepo      task.Repository
+	eventRepo     task.EventRepository
+	searchRepo    task.SearchRepository
+	checklistRepo task.ChecklistRepository
+	transactor    core.Transactor
+	// autoComplete moves a task to done once every item of its checklist is checked
+	autoComplete bool
+	// wipLimits are the WIP limits by status, wipMode tells what going over them does
+	wipLimits map[string]int
+	wipMode   string
+	// timeRepo stops the timers of a task edited to done, when set
+	timeRepo timetrack.Repository
+}
+
+// Option configures a taskUsecase
+type Option func(*taskUsecase)
+
+// WithAutoComplete makes the usecase move a task to done when the last unchecked
+// item of its checklist is checked or deleted
+func WithAutoComplete() Option {
+	return func(tu *taskUsecase) {
+		tu.autoComplete = true
+	}
+}
+
+// WithWIPLimits limits the number of tasks by status. Adding a task to a full
+// column or moving one into it fails with core.ErrWIPLimit in the reject mode
+// and is recorded as models.EventWIPLimit in the flag mode. Every write is
+// checked: bulk requests, imports, sync pushes and checklist auto-completes too
+func WithWIPLimits(limits map[string]int, mode string) Option {
+	return func(tu *taskUsecase) {
+		tu.wipLimits = limits
+		tu.wipMode = mode
+	}
+}
+
+// WithTimers makes Edit stop the running timers of a task it moves to done
+func WithTimers(r timetrack.Repository) Option {
+	return func(tu *taskUsecase) {
+		tu.timeRepo = r
+	}
 }
 
 // NewTaskUsecase will create new a taskUsecase object representation of task.Usecase interface
-func NewTaskUsecase(tr task.Repository) task.Usecase {
-	return &taskUsecase{
-		taskRepo: tr,
+func NewTaskUsecase(tr task.Repository, er task.EventRepository, sr task.SearchRepository, cr task.ChecklistRepository, tx core.Transactor, opts ...Option) task.Usecase {
+	tu := &taskUsecase{
+		taskRepo:      tr,
+		eventRepo:     er,
+		searchRepo:    sr,
+		checklistRepo: cr,
+		transactor:    tx,
+	}
+	for _, opt := range opts {
+		opt(tu)
 	}
+	return tu
 }
-func (tu *taskUsecase) Add(task *models.Task) error {
-	err := tu.taskRepo.Add(task)
-	return err
+
+func event(t *models.Task, eventType string) *models.TaskEvent {
+	e := &models.TaskEvent{TaskID: t.ID, Type: eventType, Status: t.Status}
+	if eventType == models.EventDeleted {
+		e.Status = ""
+	}
+	return e
+}
+
+func (tu *taskUsecase) Add(ctx context.Context, task *models.Task) error {
+	return tu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		over, err := tu.checkWIP(ctx, false, task)
+		if err != nil {
+			return err
+		}
+		if err := tu.taskRepo.Add(ctx, task); err != nil {
+			return err
+		}
+		return tu.eventRepo.Add(ctx, append([]*models.TaskEvent{event(task, models.EventCreated)}, wipEvents(over)...)...)
+	})
+}
+func (tu *taskUsecase) Delete(ctx context.Context, id int) error {
+	return tu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := tu.taskRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return tu.eventRepo.Add(ctx, event(&models.Task{ID: id}, models.EventDeleted))
+	})
+}
+func (tu *taskUsecase) Edit(ctx context.Context, task *models.Task) error {
+	return tu.edit(ctx, task, tu.taskRepo.Edit)
 }
-func (tu *taskUsecase) Delete(id int) error {
-	err := tu.taskRepo.Delete(id)
-	return err
+func (tu *taskUsecase) EditVersion(ctx context.Context, task *models.Task, version int64) error {
+	return tu.edit(ctx, task, func(ctx context.Context, task *models.Task) error {
+		return tu.taskRepo.EditVersion(ctx, task, version)
+	})
 }
-func (tu *taskUsecase) Edit(task *models.Task) error {
-	err := tu.taskRepo.Edit(task)
-	return err
+
+// edit saves the task with save along with the checks and the events of an edit
+func (tu *taskUsecase) edit(ctx context.Context, task *models.Task, save func(context.Context, *models.Task) error) error {
+	return tu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		over, err := tu.checkWIP(ctx, false, task)
+		if err != nil {
+			return err
+		}
+		if err := save(ctx, task); err != nil {
+			return err
+		}
+		if task.Status == "done" && tu.timeRepo != nil {
+			if _, err := tu.timeRepo.StopTask(ctx, task.ID, time.Now()); err != nil {
+				return err
+			}
+		}
+		return tu.eventRepo.Add(ctx, append([]*models.TaskEvent{event(task, models.EventUpdated)}, wipEvents(over)...)...)
+	})
 }
-func (tu *taskUsecase) List() ([]*models.Task, error) {
-	tasks, err := tu.taskRepo.List()
+func (tu *taskUsecase) List(ctx context.Context, filter *models.TaskFilter) ([]*models.Task, error) {
+	tasks, err := tu.taskRepo.List(ctx, filter)
 	return tasks, err
 }
+func (tu *taskUsecase) Export(ctx context.Context, filter *models.TaskFilter, fn func(*models.Task) error) error {
+	return tu.taskRepo.Each(ctx, filter, fn)
+}
+func (tu *taskUsecase) History(ctx context.Context, id int) ([]*models.TaskEvent, error) {
+	if _, err := tu.taskRepo.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	return tu.eventRepo.List(ctx, id)
+}
+
+func (tu *taskUsecase) Search(ctx context.Context, query *models.SearchQuery) ([]*models.SearchResult, error) {
+	return tu.searchRepo.Search(ctx, query)
+}
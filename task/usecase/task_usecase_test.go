@@ -1,31 +1,63 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/pratheeshm/todo-golang/core"
 	"github.com/pratheeshm/todo-golang/models"
 	"github.com/pratheeshm/todo-golang/task"
 	"github.com/pratheeshm/todo-golang/task/mocks"
+	timemocks "github.com/pratheeshm/todo-golang/timetrack/mocks"
+	"github.com/pratheeshm/todo-golang/transaction"
 )
 
 func TestNewTaskUsecase(t *testing.T) {
 	type args struct {
-		tr task.Repository
+		tr   task.Repository
+		er   task.EventRepository
+		sr   task.SearchRepository
+		cr   task.ChecklistRepository
+		tx   core.Transactor
+		opts []Option
 	}
+	tx := transaction.NewMemoryTransactor()
 	tests := []struct {
 		name string
 		args args
 		want task.Usecase
 	}{{
 		name: "Normal Test1: Returning value of type task.Usecase",
-		args: args{tr: &mocks.MockRepository{}},
-		want: &taskUsecase{taskRepo: &mocks.MockRepository{}},
+		args: args{tr: &mocks.MockRepository{}, er: &mocks.MockEventRepository{}, sr: &mocks.MockSearchRepository{}, cr: &mocks.MockChecklistRepository{}, tx: tx},
+		want: &taskUsecase{taskRepo: &mocks.MockRepository{}, eventRepo: &mocks.MockEventRepository{},
+			searchRepo: &mocks.MockSearchRepository{}, checklistRepo: &mocks.MockChecklistRepository{}, transactor: tx},
+	}, {
+		name: "Normal Test2: auto-complete option",
+		args: args{tr: &mocks.MockRepository{}, er: &mocks.MockEventRepository{}, sr: &mocks.MockSearchRepository{}, cr: &mocks.MockChecklistRepository{}, tx: tx,
+			opts: []Option{WithAutoComplete()}},
+		want: &taskUsecase{taskRepo: &mocks.MockRepository{}, eventRepo: &mocks.MockEventRepository{},
+			searchRepo: &mocks.MockSearchRepository{}, checklistRepo: &mocks.MockChecklistRepository{}, transactor: tx, autoComplete: true},
+	}, {
+		name: "Normal Test3: wip limits option",
+		args: args{tr: &mocks.MockRepository{}, er: &mocks.MockEventRepository{}, sr: &mocks.MockSearchRepository{}, cr: &mocks.MockChecklistRepository{}, tx: tx,
+			opts: []Option{WithWIPLimits(map[string]int{"inprogress": 3}, models.WIPFlag)}},
+		want: &taskUsecase{taskRepo: &mocks.MockRepository{}, eventRepo: &mocks.MockEventRepository{},
+			searchRepo: &mocks.MockSearchRepository{}, checklistRepo: &mocks.MockChecklistRepository{}, transactor: tx,
+			wipLimits: map[string]int{"inprogress": 3}, wipMode: models.WIPFlag},
+	}, {
+		name: "Normal Test4: timers option",
+		args: args{tr: &mocks.MockRepository{}, er: &mocks.MockEventRepository{}, sr: &mocks.MockSearchRepository{}, cr: &mocks.MockChecklistRepository{}, tx: tx,
+			opts: []Option{WithTimers(&timemocks.MockRepository{})}},
+		want: &taskUsecase{taskRepo: &mocks.MockRepository{}, eventRepo: &mocks.MockEventRepository{},
+			searchRepo: &mocks.MockSearchRepository{}, checklistRepo: &mocks.MockChecklistRepository{}, transactor: tx,
+			timeRepo: &timemocks.MockRepository{}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewTaskUsecase(tt.args.tr); !reflect.DeepEqual(got, tt.want) {
+			if got := NewTaskUsecase(tt.args.tr, tt.args.er, tt.args.sr, tt.args.cr, tt.args.tx, tt.args.opts...); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewTaskUsecase() = %v, want %v", got, tt.want)
 			}
 		})
@@ -75,8 +107,8 @@ func Test_taskUsecase_Add(t *testing.T) {
 		}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tu := NewTaskUsecase(tt.fields.taskRepo)
-			if err := tu.Add(tt.args.task); (err != nil) != tt.wantErr {
+			tu := NewTaskUsecase(tt.fields.taskRepo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+			if err := tu.Add(context.Background(), tt.args.task); (err != nil) != tt.wantErr {
 				t.Errorf("taskUsecase.Add() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -105,8 +137,8 @@ func Test_taskUsecase_Delete(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tu := NewTaskUsecase(tt.fields.taskRepo)
-			if err := tu.Delete(tt.args.id); (err != nil) != tt.wantErr {
+			tu := NewTaskUsecase(tt.fields.taskRepo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+			if err := tu.Delete(context.Background(), tt.args.id); (err != nil) != tt.wantErr {
 				t.Errorf("taskUsecase.Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -140,14 +172,80 @@ func Test_taskUsecase_Edit(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tu := NewTaskUsecase(tt.fields.taskRepo)
-			if err := tu.Edit(tt.args.task); (err != nil) != tt.wantErr {
+			tu := NewTaskUsecase(tt.fields.taskRepo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+			if err := tu.Edit(context.Background(), tt.args.task); (err != nil) != tt.wantErr {
 				t.Errorf("taskUsecase.Edit() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
+func Test_taskUsecase_EditVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   int64
+		wantErr   error
+		wantTitle string
+	}{
+		{name: "Normal Case1: same version", version: 4, wantTitle: "Take notes"},
+		{name: "changed since", version: 3, wantErr: core.ErrConflict, wantTitle: "Take math notes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := &mocks.MockRepository{Tasks: []*models.Task{{ID: 1, Title: "Take math notes", Status: "todo", Version: 4}}}
+			events := &mocks.MockEventRepository{}
+			tu := NewTaskUsecase(tasks, events, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+			if err := tu.EditVersion(context.Background(), &models.Task{ID: 1, Title: "Take notes", Status: "todo"}, tt.version); err != tt.wantErr {
+				t.Fatalf("taskUsecase.EditVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := tasks.Tasks[0].Title; got != tt.wantTitle {
+				t.Errorf("title = %q, want %q", got, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func Test_taskUsecase_Edit_stopsTimers(t *testing.T) {
+	nine := time.Now().Add(-time.Hour)
+	ten := nine.Add(30 * time.Minute)
+	tests := []struct {
+		name    string
+		status  string
+		running int
+	}{
+		{name: "done stops the timers of the task", status: "done", running: 0},
+		{name: "other statuses leave them running", status: "inprogress", running: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timers := &timemocks.MockRepository{Entries: []*models.TimeEntry{
+				{ID: 1, TaskID: 1, UserID: 3, StartedAt: nine},
+				{ID: 2, TaskID: 1, UserID: 5, StartedAt: nine},
+				{ID: 3, TaskID: 1, UserID: 6, StartedAt: nine, StoppedAt: &ten},
+				{ID: 4, TaskID: 2, UserID: 7, StartedAt: nine},
+			}}
+			tasks := &mocks.MockRepository{Tasks: []*models.Task{{ID: 1, Title: "Take math notes", Status: "inprogress"}}}
+			tu := NewTaskUsecase(tasks, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{},
+				transaction.NewMemoryTransactor(), WithTimers(timers))
+			if err := tu.Edit(context.Background(), &models.Task{ID: 1, Title: "Take math notes", Status: tt.status}); err != nil {
+				t.Fatalf("taskUsecase.Edit() error = %v", err)
+			}
+			running := 0
+			for _, e := range timers.Entries {
+				if e.TaskID == 1 && e.StoppedAt == nil {
+					running++
+				}
+			}
+			if running != tt.running {
+				t.Errorf("%d timers running, want %d", running, tt.running)
+			}
+			if timers.Entries[3].StoppedAt != nil {
+				t.Errorf("timer of another task stopped")
+			}
+		})
+	}
+}
+
 func Test_taskUsecase_List(t *testing.T) {
 	type fields struct {
 		taskRepo task.Repository
@@ -181,8 +279,8 @@ func Test_taskUsecase_List(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tu := NewTaskUsecase(tt.fields.taskRepo)
-			got, err := tu.List()
+			tu := NewTaskUsecase(tt.fields.taskRepo, &mocks.MockEventRepository{}, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+			got, err := tu.List(context.Background(), &models.TaskFilter{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("taskUsecase.List() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -193,3 +291,73 @@ func Test_taskUsecase_List(t *testing.T) {
 		})
 	}
 }
+
+func Test_taskUsecase_Add_rollback(t *testing.T) {
+	repo := &mocks.MockRepository{}
+	events := &mocks.MockEventRepository{Error: errors.New("Repository.Error()")}
+	tu := NewTaskUsecase(repo, events, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+	err := tu.Add(context.Background(), &models.Task{Title: "Take maths note", Status: "todo"})
+	if err == nil {
+		t.Fatalf("expected the history error")
+	}
+	if len(repo.Tasks) != 0 {
+		t.Errorf("task should be rolled back with its history, got %v", repo.Tasks)
+	}
+}
+
+func Test_taskUsecase_History(t *testing.T) {
+	events := &mocks.MockEventRepository{}
+	tu := NewTaskUsecase(&mocks.MockRepository{}, events, &mocks.MockSearchRepository{}, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+	task := &models.Task{Title: "Take maths note", Status: "todo"}
+	if err := tu.Add(context.Background(), task); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	task.Status = "done"
+	if err := tu.Edit(context.Background(), task); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	got, err := tu.History(context.Background(), task.ID)
+	if err != nil {
+		t.Fatalf("taskUsecase.History() error = %v", err)
+	}
+	want := []*models.TaskEvent{
+		{TaskID: task.ID, Type: models.EventCreated, Status: "todo"},
+		{TaskID: task.ID, Type: models.EventUpdated, Status: "done"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("taskUsecase.History() = %v, want %v", got, want)
+	}
+	if _, err := tu.History(context.Background(), 42); err != core.ErrRecordNotFound {
+		t.Errorf("expected record not found, got %v", err)
+	}
+}
+
+func Test_taskUsecase_Search(t *testing.T) {
+	results := []*models.SearchResult{{Task: &models.Task{ID: 1, Title: "Take math notes"}, Rank: 1}}
+	tests := []struct {
+		name    string
+		search  *mocks.MockSearchRepository
+		want    []*models.SearchResult
+		wantErr bool
+	}{{
+		name:   "Normal Case1: results of the search repository",
+		search: &mocks.MockSearchRepository{Results: results},
+		want:   results,
+	}, {
+		name:    "search error",
+		search:  &mocks.MockSearchRepository{Error: errors.New("db error")},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tu := NewTaskUsecase(&mocks.MockRepository{}, &mocks.MockEventRepository{}, tt.search, &mocks.MockChecklistRepository{}, transaction.NewMemoryTransactor())
+			got, err := tu.Search(context.Background(), &models.SearchQuery{Query: "math", Limit: 10})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("taskUsecase.Search() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("taskUsecase.Search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	nethttp "net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/template"
+	"github.com/sirupsen/logrus"
+)
+
+//TemplateHandler represents http handler for task templates
+type TemplateHandler struct {
+	TemplateUsecase template.Usecase
+}
+
+// NewTemplateHandler will initialize the templates/ resources endpoint
+func NewTemplateHandler(tu template.Usecase) nethttp.Handler {
+	r := chi.NewMux()
+	templateHandler := &TemplateHandler{
+		TemplateUsecase: tu,
+	}
+	r.Get("/", templateHandler.List)
+	r.Post("/", templateHandler.Add)
+	r.Get("/{id:[0-9]+}", templateHandler.Get)
+	r.Put("/{id:[0-9]+}", templateHandler.Edit)
+	r.Delete("/{id:[0-9]+}", templateHandler.Delete)
+	r.Post("/{id:[0-9]+}/instantiate", templateHandler.Instantiate)
+	return r
+}
+
+func writeJSON(w nethttp.ResponseWriter, status int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	res, _ := json.Marshal(body)
+	w.Write(res)
+}
+
+// writeError answers the errors shared by the template endpoints
+func writeError(w nethttp.ResponseWriter, err error) {
+	switch err {
+	case core.ErrForbidden:
+		w.WriteHeader(nethttp.StatusForbidden)
+		w.Write([]byte("forbidden"))
+	case core.ErrRecordNotFound:
+		w.WriteHeader(nethttp.StatusNotFound)
+		w.Write([]byte("not found"))
+	case core.ErrInvalidTemplate, core.ErrTemplateValue, core.ErrWIPLimit:
+		w.WriteHeader(nethttp.StatusUnprocessableEntity)
+		w.Write([]byte(err.Error()))
+	default:
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+	}
+}
+
+// writeTemplate answers the template, or the error of the call that returned it
+func writeTemplate(w nethttp.ResponseWriter, status int, t *models.Template, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, map[string]interface{}{
+		"message":  "success",
+		"template": t,
+	})
+}
+
+// decode reads and validates v from the request body, an empty body is
+// accepted when empty is set
+func decode(w nethttp.ResponseWriter, r *nethttp.Request, v interface{}, empty bool) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !(empty && err == io.EOF) {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return false
+	}
+	validate := validator.New()
+	if err := validate.Struct(v); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return false
+	}
+	return true
+}
+
+func id(r *nethttp.Request) int {
+	id, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	return id
+}
+
+//List handler returns the templates of the workspace ordered by name
+func (h *TemplateHandler) List(w nethttp.ResponseWriter, r *nethttp.Request) {
+	templates, err := h.TemplateUsecase.List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message":   "success",
+		"templates": templates,
+	})
+}
+
+//Add template handler
+func (h *TemplateHandler) Add(w nethttp.ResponseWriter, r *nethttp.Request) {
+	t := &models.Template{}
+	if !decode(w, r, t, false) {
+		return
+	}
+	err := h.TemplateUsecase.Add(r.Context(), t)
+	writeTemplate(w, nethttp.StatusCreated, t, err)
+}
+
+//Get template handler
+func (h *TemplateHandler) Get(w nethttp.ResponseWriter, r *nethttp.Request) {
+	t, err := h.TemplateUsecase.Get(r.Context(), id(r))
+	writeTemplate(w, nethttp.StatusOK, t, err)
+}
+
+//Edit template handler
+func (h *TemplateHandler) Edit(w nethttp.ResponseWriter, r *nethttp.Request) {
+	t := &models.Template{}
+	if !decode(w, r, t, false) {
+		return
+	}
+	t.ID = id(r)
+	err := h.TemplateUsecase.Edit(r.Context(), t)
+	writeTemplate(w, nethttp.StatusOK, t, err)
+}
+
+//Delete template handler, the tasks created from the template stay
+func (h *TemplateHandler) Delete(w nethttp.ResponseWriter, r *nethttp.Request) {
+	if err := h.TemplateUsecase.Delete(r.Context(), id(r)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	w.Write([]byte("success"))
+}
+
+// instanceDay returns the day of the instance, the day of now in UTC when it has no date
+func instanceDay(in *models.TemplateInstance, now time.Time) (time.Time, error) {
+	if in.Date == "" {
+		return now.UTC().Truncate(24 * time.Hour), nil
+	}
+	return time.Parse("2006-01-02", in.Date)
+}
+
+//Instantiate handler creates the tasks of the template for the "date" of the
+//body, today in UTC without one, the "values" fill the other placeholders
+func (h *TemplateHandler) Instantiate(w nethttp.ResponseWriter, r *nethttp.Request) {
+	in := &models.TemplateInstance{}
+	if !decode(w, r, in, true) {
+		return
+	}
+	on, err := instanceDay(in, time.Now())
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return
+	}
+	tasks, err := h.TemplateUsecase.Instantiate(r.Context(), id(r), on, in.Values)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusCreated, map[string]interface{}{
+		"message": "success",
+		"tasks":   tasks,
+	})
+}
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/template/mocks"
+)
+
+const release = `{"name":"Release","tasks":[{"title":"Release {{version}}","checklist":["Tag {{version}}"]}]}`
+
+func TestNewTemplateHandler(t *testing.T) {
+	h := NewTemplateHandler(&mocks.MockUsecase{})
+	tests := []struct {
+		method     string
+		url        string
+		body       string
+		statusCode int
+	}{
+		{"GET", "/", "", 200},
+		{"POST", "/", release, 201},
+		{"GET", "/1", "", 200},
+		{"PUT", "/1", release, 200},
+		{"DELETE", "/1", "", 200},
+		{"POST", "/1/instantiate", `{"date":"2026-10-19","values":{"version":"1.2"}}`, 201},
+		{"POST", "/1/instantiate", "", 201},
+		{"GET", "/abc", "", 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Errorf("got statuscode %d but expected %d", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestTemplateHandler_Add(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    *mocks.MockUsecase
+		body       string
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, body: release, statusCode: 201},
+		{name: "no tasks", usecase: &mocks.MockUsecase{}, body: `{"name":"Release","tasks":[]}`, statusCode: 400},
+		{name: "task without title", usecase: &mocks.MockUsecase{}, body: `{"name":"Release","tasks":[{"status":"todo"}]}`, statusCode: 400},
+		{name: "invalid status", usecase: &mocks.MockUsecase{}, body: `{"name":"Release","tasks":[{"title":"Release","status":"later"}]}`, statusCode: 400},
+		{name: "body parse error", usecase: &mocks.MockUsecase{}, body: `[]`, statusCode: 400},
+		{name: "malformed placeholder", usecase: &mocks.MockUsecase{Error: core.ErrInvalidTemplate}, body: release, statusCode: 422},
+		{name: "forbidden", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, body: release, statusCode: 403},
+		{name: "usecase error", usecase: &mocks.MockUsecase{Error: errors.New("Usecase.Error()")}, body: release, statusCode: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewTemplateHandler(tt.usecase).ServeHTTP(rec, httptest.NewRequest("POST", "/", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestTemplateHandler_Edit(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    *mocks.MockUsecase
+		body       string
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, body: release, statusCode: 200},
+		{name: "missing name", usecase: &mocks.MockUsecase{}, body: `{"tasks":[{"title":"Release"}]}`, statusCode: 400},
+		{name: "template not found", usecase: &mocks.MockUsecase{Error: core.ErrRecordNotFound}, body: release, statusCode: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewTemplateHandler(tt.usecase).ServeHTTP(rec, httptest.NewRequest("PUT", "/1", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func Test_instanceDay(t *testing.T) {
+	now := time.Date(2026, 10, 19, 22, 30, 0, 0, time.FixedZone("", -4*60*60))
+	tests := []struct {
+		name    string
+		date    string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "Normal Case 1: date", date: "2026-11-02", want: time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "today in UTC without a date", want: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+		{name: "invalid date", date: "02/11/2026", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := instanceDay(&models.TemplateInstance{Date: tt.date}, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("instanceDay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("instanceDay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateHandler_Instantiate(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    *mocks.MockUsecase
+		body       string
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{Tasks: []*models.Task{{ID: 4, Title: "Release 1.2"}}},
+			body: `{"date":"2026-11-02","values":{"version":"1.2"}}`, statusCode: 201},
+		{name: "without a date", usecase: &mocks.MockUsecase{}, body: `{}`, statusCode: 201},
+		{name: "invalid date", usecase: &mocks.MockUsecase{}, body: `{"date":"02/11/2026"}`, statusCode: 400},
+		{name: "body parse error", usecase: &mocks.MockUsecase{}, body: `[]`, statusCode: 400},
+		{name: "missing value", usecase: &mocks.MockUsecase{Error: core.ErrTemplateValue}, body: `{}`, statusCode: 422},
+		{name: "invalid rendered task", usecase: &mocks.MockUsecase{Error: core.ErrInvalidTemplate}, body: `{}`, statusCode: 422},
+		{name: "wip limit", usecase: &mocks.MockUsecase{Error: core.ErrWIPLimit}, body: `{}`, statusCode: 422},
+		{name: "template not found", usecase: &mocks.MockUsecase{Error: core.ErrRecordNotFound}, body: `{}`, statusCode: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewTemplateHandler(tt.usecase).ServeHTTP(rec, httptest.NewRequest("POST", "/1/instantiate", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if tt.statusCode != 201 {
+				return
+			}
+			res := struct {
+				Tasks []*models.Task `json:"tasks"`
+			}{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil || !reflect.DeepEqual(res.Tasks, tt.usecase.Tasks) {
+				t.Errorf("Test - %s , got body %s", tt.name, rec.Body.String())
+			}
+		})
+	}
+	u := &mocks.MockUsecase{}
+	NewTemplateHandler(u).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/1/instantiate", bytes.NewBufferString(`{"values":{"version":"1.2"}}`)))
+	if want := map[string]string{"version": "1.2"}; !reflect.DeepEqual(u.Values, want) {
+		t.Errorf("instantiated with %v, want %v", u.Values, want)
+	}
+}
@@ -0,0 +1,84 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockRepository implements inerface template.Repository
+type MockRepository struct {
+	Error     error
+	Templates []*models.Template
+}
+
+func (m *MockRepository) find(id int) *models.Template {
+	for _, t := range m.Templates {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+//Add appends a copy of the template to Templates
+func (m *MockRepository) Add(ctx context.Context, t *models.Template) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	t.ID = len(m.Templates) + 1
+	t.CreatedAt = time.Now()
+	t.UpdatedAt = t.CreatedAt
+	stored := *t
+	m.Templates = append(m.Templates, &stored)
+	return nil
+}
+
+//Get returns a copy of the template of Templates with the id
+func (m *MockRepository) Get(ctx context.Context, id int) (*models.Template, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if t := m.find(id); t != nil {
+		found := *t
+		return &found, nil
+	}
+	return nil, core.ErrRecordNotFound
+}
+
+//List returns Templates
+func (m *MockRepository) List(ctx context.Context) ([]*models.Template, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	return m.Templates, nil
+}
+
+//Edit replaces the name and tasks of the template
+func (m *MockRepository) Edit(ctx context.Context, t *models.Template) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	old := m.find(t.ID)
+	if old == nil {
+		return core.ErrRecordNotFound
+	}
+	old.Name, old.Tasks, old.UpdatedAt = t.Name, t.Tasks, time.Now()
+	return nil
+}
+
+//Delete removes the template from Templates
+func (m *MockRepository) Delete(ctx context.Context, id int) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for i, t := range m.Templates {
+		if t.ID == id {
+			m.Templates = append(m.Templates[:i], m.Templates[i+1:]...)
+			return nil
+		}
+	}
+	return core.ErrRecordNotFound
+}
@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockUsecase implements inerface template.Usecase
+type MockUsecase struct {
+	Error     error
+	Templates []*models.Template
+	Tasks     []*models.Task
+	// On and Values are the arguments of the last call to Instantiate
+	On     time.Time
+	Values map[string]string
+}
+
+//Add template
+func (m *MockUsecase) Add(ctx context.Context, t *models.Template) error {
+	return m.Error
+}
+
+//Get returns the first template of Templates
+func (m *MockUsecase) Get(ctx context.Context, id int) (*models.Template, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if len(m.Templates) == 0 {
+		return &models.Template{}, nil
+	}
+	return m.Templates[0], nil
+}
+
+//List returns Templates
+func (m *MockUsecase) List(ctx context.Context) ([]*models.Template, error) {
+	return m.Templates, m.Error
+}
+
+//Edit template
+func (m *MockUsecase) Edit(ctx context.Context, t *models.Template) error {
+	return m.Error
+}
+
+//Delete template
+func (m *MockUsecase) Delete(ctx context.Context, id int) error {
+	return m.Error
+}
+
+//Instantiate returns Tasks
+func (m *MockUsecase) Instantiate(ctx context.Context, id int, on time.Time, values map[string]string) ([]*models.Task, error) {
+	m.On, m.Values = on, values
+	return m.Tasks, m.Error
+}
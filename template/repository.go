@@ -0,0 +1,17 @@
+package template
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Repository represents task template's interface
+type Repository interface {
+	Add(context.Context, *models.Template) error
+	Get(context.Context, int) (*models.Template, error)
+	// List returns the templates of the workspace ordered by name
+	List(context.Context) ([]*models.Template, error)
+	Edit(context.Context, *models.Template) error
+	Delete(context.Context, int) error
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/template"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+const templateColumns = "id_template, name, tasks, created_at, updated_at"
+
+type postgresTemplateRepository struct {
+	*sql.DB
+}
+
+// NewPostgresTemplateRepository will create an object that represent the template.Repository interface
+func NewPostgresTemplateRepository(db *sql.DB) template.Repository {
+	return &postgresTemplateRepository{db}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTemplate(s scanner) (*models.Template, error) {
+	t := &models.Template{}
+	var tasks []byte
+	if err := s.Scan(&t.ID, &t.Name, &tasks, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return t, json.Unmarshal(tasks, &t.Tasks)
+}
+
+func (p *postgresTemplateRepository) Add(ctx context.Context, t *models.Template) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	tasks, err := json.Marshal(t.Tasks)
+	if err != nil {
+		return err
+	}
+	return transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "INSERT INTO task_template(name, tasks, id_workspace) VALUES ($1, $2, $3) RETURNING id_template, created_at, updated_at",
+		t.Name, tasks, workspace).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
+}
+
+func (p *postgresTemplateRepository) Get(ctx context.Context, id int) (*models.Template, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "SELECT "+templateColumns+" FROM task_template WHERE id_template = $1 AND id_workspace = $2", id, workspace)
+	t, err := scanTemplate(row)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	return t, err
+}
+
+func (p *postgresTemplateRepository) List(ctx context.Context) ([]*models.Template, error) {
+	templates := make([]*models.Template, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return templates, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT "+templateColumns+" FROM task_template WHERE id_workspace = $1 ORDER BY name, id_template", workspace)
+	if err != nil {
+		return templates, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return []*models.Template{}, err
+		}
+		templates = append(templates, t)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.Template{}, err
+	}
+	return templates, nil
+}
+
+func (p *postgresTemplateRepository) Edit(ctx context.Context, t *models.Template) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	tasks, err := json.Marshal(t.Tasks)
+	if err != nil {
+		return err
+	}
+	err = transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "UPDATE task_template SET name = $1, tasks = $2, updated_at = now() WHERE id_template = $3 AND id_workspace = $4 RETURNING created_at, updated_at",
+		t.Name, tasks, t.ID, workspace).Scan(&t.CreatedAt, &t.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresTemplateRepository) Delete(ctx context.Context, id int) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "DELETE FROM task_template WHERE id_template = $1 AND id_workspace = $2", id, workspace)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
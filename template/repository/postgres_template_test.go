@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+var templateRowColumns = []string{"id_template", "name", "tasks", "created_at", "updated_at"}
+
+var (
+	releaseTasks = []*models.TemplateTask{{Title: "Release {{version}}", Checklist: []string{"Tag {{version}}"}}}
+	releaseJSON  = []byte(`[{"title":"Release {{version}}","description":"","status":"","priority":0,"project":"","tags":null,"checklist":["Tag {{version}}"]}]`)
+)
+
+func Test_postgresTemplateRepository_Add(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO task_template(name, tasks, id_workspace) VALUES ($1, $2, $3) RETURNING id_template, created_at, updated_at")).
+		WithArgs("Release", releaseJSON, coremocks.Tenant).
+		WillReturnRows(sqlmock.NewRows([]string{"id_template", "created_at", "updated_at"}).AddRow(3, time.Time{}, time.Time{}))
+	tpl := &models.Template{Name: "Release", Tasks: releaseTasks}
+	if err := NewPostgresTemplateRepository(db).Add(coremocks.TenantContext(), tpl); err != nil || tpl.ID != 3 {
+		t.Errorf("Add() = template %d, %v, want template 3", tpl.ID, err)
+	}
+}
+
+func Test_postgresTemplateRepository_Get(t *testing.T) {
+	query := "SELECT id_template, name, tasks, created_at, updated_at FROM task_template WHERE id_template = $1 AND id_workspace = $2"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *models.Template
+		wantErr error
+	}{{
+		name: "Normal Case 1: template found",
+		rows: sqlmock.NewRows(templateRowColumns).AddRow(3, "Release", releaseJSON, time.Time{}, time.Time{}),
+		want: &models.Template{ID: 3, Name: "Release", Tasks: releaseTasks},
+	}, {
+		name:    "template not found",
+		rows:    sqlmock.NewRows(templateRowColumns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(3, coremocks.Tenant).WillReturnRows(tt.rows)
+			got, err := NewPostgresTemplateRepository(db).Get(coremocks.TenantContext(), 3)
+			if err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresTemplateRepository_List(t *testing.T) {
+	query := "SELECT id_template, name, tasks, created_at, updated_at FROM task_template WHERE id_workspace = $1 ORDER BY name, id_template"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbError error
+		want    []*models.Template
+		wantErr bool
+	}{{
+		name: "Normal Case 1: ordered by name",
+		rows: sqlmock.NewRows(templateRowColumns).
+			AddRow(4, "Onboarding", []byte(`[{"title":"Welcome {{name}}"}]`), time.Time{}, time.Time{}).
+			AddRow(3, "Release", releaseJSON, time.Time{}, time.Time{}),
+		want: []*models.Template{
+			{ID: 4, Name: "Onboarding", Tasks: []*models.TemplateTask{{Title: "Welcome {{name}}"}}},
+			{ID: 3, Name: "Release", Tasks: releaseTasks},
+		},
+	}, {
+		name:    "malformed tasks",
+		rows:    sqlmock.NewRows(templateRowColumns).AddRow(3, "Release", []byte(`{`), time.Time{}, time.Time{}),
+		want:    []*models.Template{},
+		wantErr: true,
+	}, {
+		name:    "db error",
+		rows:    sqlmock.NewRows(templateRowColumns),
+		dbError: errors.New("db error"),
+		want:    []*models.Template{},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant).WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			got, err := NewPostgresTemplateRepository(db).List(coremocks.TenantContext())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresTemplateRepository_Edit(t *testing.T) {
+	query := "UPDATE task_template SET name = $1, tasks = $2, updated_at = now() WHERE id_template = $3 AND id_workspace = $4 RETURNING created_at, updated_at"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr error
+	}{
+		{name: "Normal Case 1: template edited", rows: sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Time{}, time.Time{})},
+		{name: "template not found", rows: sqlmock.NewRows([]string{"created_at", "updated_at"}), wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("Release", releaseJSON, 3, coremocks.Tenant).WillReturnRows(tt.rows)
+			tpl := &models.Template{ID: 3, Name: "Release", Tasks: releaseTasks}
+			if err := NewPostgresTemplateRepository(db).Edit(coremocks.TenantContext(), tpl); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresTemplateRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "Normal Case 1: template deleted", affected: 1},
+		{name: "template not found", affected: 0, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta("DELETE FROM task_template WHERE id_template = $1 AND id_workspace = $2")).WithArgs(3, coremocks.Tenant).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if err := NewPostgresTemplateRepository(db).Delete(coremocks.TenantContext(), 3); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresTemplateRepository_withoutTenant(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	ctx := context.Background()
+	p := NewPostgresTemplateRepository(db)
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Add", func() error { return p.Add(ctx, &models.Template{Name: "Release", Tasks: releaseTasks}) }},
+		{"Get", func() error { _, err := p.Get(ctx, 1); return err }},
+		{"List", func() error { _, err := p.List(ctx); return err }},
+		{"Edit", func() error { return p.Edit(ctx, &models.Template{ID: 1, Name: "Release", Tasks: releaseTasks}) }},
+		{"Delete", func() error { return p.Delete(ctx, 1) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != core.ErrNoTenant {
+				t.Errorf("expected %v, got %v", core.ErrNoTenant, err)
+			}
+		})
+	}
+}
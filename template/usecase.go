@@ -0,0 +1,22 @@
+package template
+
+import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Usecase represents task template's usecases
+type Usecase interface {
+	// Add stores the template, core.ErrInvalidTemplate is returned when a placeholder is malformed
+	Add(context.Context, *models.Template) error
+	Get(context.Context, int) (*models.Template, error)
+	List(context.Context) ([]*models.Template, error)
+	// Edit replaces the template, core.ErrInvalidTemplate is returned when a placeholder is malformed
+	Edit(context.Context, *models.Template) error
+	Delete(context.Context, int) error
+	// Instantiate creates the tasks of a template for the day on, along with
+	// their checklists, in one transaction and returns them
+	Instantiate(ctx context.Context, id int, on time.Time, values map[string]string) ([]*models.Task, error)
+}
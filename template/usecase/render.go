@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+// placeholder matches the placeholders of a pattern, a name between double braces
+var placeholder = regexp.MustCompile(`\{\{([^{}]*)\}\}`)
+
+var placeholderName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// builtins returns the values of the placeholders that come from the day of the instance
+func builtins(on time.Time) map[string]string {
+	_, week := on.ISOWeek()
+	return map[string]string{
+		"date":  on.Format("2006-01-02"),
+		"year":  on.Format("2006"),
+		"month": on.Format("January"),
+		"week":  strconv.Itoa(week),
+	}
+}
+
+// check returns core.ErrInvalidTemplate when a placeholder of the pattern is not a name
+func check(pattern string) error {
+	for _, m := range placeholder.FindAllStringSubmatch(pattern, -1) {
+		if !placeholderName.MatchString(strings.TrimSpace(m[1])) {
+			return core.ErrInvalidTemplate
+		}
+	}
+	return nil
+}
+
+// render replaces the placeholders of the pattern with their values,
+// core.ErrTemplateValue is returned when one of them has none
+func render(pattern string, values map[string]string) (string, error) {
+	var err error
+	s := placeholder.ReplaceAllStringFunc(pattern, func(m string) string {
+		v, ok := values[strings.TrimSpace(m[2:len(m)-2])]
+		if !ok {
+			err = core.ErrTemplateValue
+		}
+		return v
+	})
+	return s, err
+}
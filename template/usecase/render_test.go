@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+func Test_builtins(t *testing.T) {
+	want := map[string]string{"date": "2026-10-19", "year": "2026", "month": "October", "week": "43"}
+	if got := builtins(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)); !reflect.DeepEqual(got, want) {
+		t.Errorf("builtins() = %v, want %v", got, want)
+	}
+}
+
+func Test_check(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		wantErr error
+	}{
+		{name: "Normal Case 1: placeholders", pattern: "Release {{version}} on {{ date }}"},
+		{name: "no placeholder", pattern: "Weekly review"},
+		{name: "single braces", pattern: "Review {date}"},
+		{name: "empty placeholder", pattern: "Review {{}}", wantErr: core.ErrInvalidTemplate},
+		{name: "upper case name", pattern: "Review {{Date}}", wantErr: core.ErrInvalidTemplate},
+		{name: "expression", pattern: "Review {{date + 1}}", wantErr: core.ErrInvalidTemplate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := check(tt.pattern); err != tt.wantErr {
+				t.Errorf("check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_render(t *testing.T) {
+	values := map[string]string{"date": "2026-10-19", "version": "1.2"}
+	tests := []struct {
+		name    string
+		pattern string
+		want    string
+		wantErr error
+	}{
+		{name: "Normal Case 1: placeholders", pattern: "Release {{version}} on {{ date }}", want: "Release 1.2 on 2026-10-19"},
+		{name: "repeated placeholder", pattern: "{{version}}/{{version}}", want: "1.2/1.2"},
+		{name: "no placeholder", pattern: "Weekly review", want: "Weekly review"},
+		{name: "missing value", pattern: "Welcome {{name}}", wantErr: core.ErrTemplateValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := render(tt.pattern, values)
+			if err != tt.wantErr {
+				t.Fatalf("render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got != tt.want {
+				t.Errorf("render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/template"
+)
+
+type templateUsecase struct {
+	templateRepo  template.Repository
+	taskUsecase   task.Usecase
+	accessUsecase access.Usecase
+	transactor    core.Transactor
+}
+
+// NewTemplateUsecase will create new a templateUsecase object representation of template.Usecase interface.
+// Templates belong to the whole workspace like the sprints do, the tasks of an instance are created
+// through tu so that they are checked against the role of the user on their project
+func NewTemplateUsecase(tr template.Repository, tu task.Usecase, au access.Usecase, t core.Transactor) template.Usecase {
+	return &templateUsecase{
+		templateRepo:  tr,
+		taskUsecase:   tu,
+		accessUsecase: au,
+		transactor:    t,
+	}
+}
+
+// checkTemplate checks the placeholders of every pattern of the template
+func checkTemplate(t *models.Template) error {
+	for _, tt := range t.Tasks {
+		patterns := append([]string{tt.Title, tt.Description}, tt.Checklist...)
+		for _, p := range patterns {
+			if err := check(p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// instance renders a task of a template and its checklist with the values
+func instance(tt *models.TemplateTask, on time.Time, values map[string]string) (*models.Task, []string, error) {
+	t := &models.Task{Status: tt.Status, Priority: tt.Priority, Project: tt.Project, Tags: tt.Tags}
+	if t.Status == "" {
+		t.Status = "todo"
+	}
+	if tt.DueInDays != nil {
+		due := on.AddDate(0, 0, *tt.DueInDays)
+		t.DueDate = &due
+	}
+	var err error
+	if t.Title, err = render(tt.Title, values); err != nil {
+		return nil, nil, err
+	}
+	if t.Description, err = render(tt.Description, values); err != nil {
+		return nil, nil, err
+	}
+	checklist := make([]string, len(tt.Checklist))
+	for i, text := range tt.Checklist {
+		if checklist[i], err = render(text, values); err != nil {
+			return nil, nil, err
+		}
+	}
+	return t, checklist, nil
+}
+
+func (tu *templateUsecase) Add(ctx context.Context, t *models.Template) error {
+	if err := access.Allow(ctx, tu.accessUsecase, access.ActionWrite); err != nil {
+		return err
+	}
+	if err := checkTemplate(t); err != nil {
+		return err
+	}
+	return tu.templateRepo.Add(ctx, t)
+}
+
+func (tu *templateUsecase) Get(ctx context.Context, id int) (*models.Template, error) {
+	if err := access.Allow(ctx, tu.accessUsecase, access.ActionRead); err != nil {
+		return nil, err
+	}
+	return tu.templateRepo.Get(ctx, id)
+}
+
+func (tu *templateUsecase) List(ctx context.Context) ([]*models.Template, error) {
+	if err := access.Allow(ctx, tu.accessUsecase, access.ActionRead); err != nil {
+		return nil, err
+	}
+	return tu.templateRepo.List(ctx)
+}
+
+func (tu *templateUsecase) Edit(ctx context.Context, t *models.Template) error {
+	if err := access.Allow(ctx, tu.accessUsecase, access.ActionWrite); err != nil {
+		return err
+	}
+	if err := checkTemplate(t); err != nil {
+		return err
+	}
+	return tu.templateRepo.Edit(ctx, t)
+}
+
+func (tu *templateUsecase) Delete(ctx context.Context, id int) error {
+	if err := access.Allow(ctx, tu.accessUsecase, access.ActionDelete); err != nil {
+		return err
+	}
+	return tu.templateRepo.Delete(ctx, id)
+}
+
+// Instantiate renders every task before creating any, the values override the
+// placeholders that come from the day. core.ErrInvalidTemplate is returned when
+// a rendered task is invalid, a title too long for instance
+func (tu *templateUsecase) Instantiate(ctx context.Context, id int, on time.Time, values map[string]string) ([]*models.Task, error) {
+	if err := access.Allow(ctx, tu.accessUsecase, access.ActionRead); err != nil {
+		return nil, err
+	}
+	t, err := tu.templateRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	all := builtins(on)
+	for name, v := range values {
+		all[name] = v
+	}
+	validate := validator.New()
+	tasks := make([]*models.Task, len(t.Tasks))
+	checklists := make([][]string, len(t.Tasks))
+	for i, tt := range t.Tasks {
+		if tasks[i], checklists[i], err = instance(tt, on, all); err != nil {
+			return nil, err
+		}
+		if err := validate.Struct(tasks[i]); err != nil {
+			return nil, core.ErrInvalidTemplate
+		}
+		for _, text := range checklists[i] {
+			if err := validate.Struct(&models.ChecklistItem{Text: text}); err != nil {
+				return nil, core.ErrInvalidTemplate
+			}
+		}
+	}
+	err = tu.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i, task := range tasks {
+			if err := tu.taskUsecase.Add(ctx, task); err != nil {
+				return err
+			}
+			for _, text := range checklists[i] {
+				withItem, err := tu.taskUsecase.AddChecklistItem(ctx, &models.ChecklistItem{TaskID: task.ID, Text: text})
+				if err != nil {
+					return err
+				}
+				tasks[i] = withItem
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	accessmocks "github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	taskmocks "github.com/pratheeshm/todo-golang/task/mocks"
+	taskusecase "github.com/pratheeshm/todo-golang/task/usecase"
+	"github.com/pratheeshm/todo-golang/template/mocks"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+var today = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+type fixture struct {
+	templates *mocks.MockRepository
+	tasks     *taskmocks.MockRepository
+	items     *taskmocks.MockChecklistRepository
+	usecase   *templateUsecase
+}
+
+// template 1 releases a version, its review goes over the WIP limit of
+// inprogress when a task is in progress already
+func newFixture(role string, inProgress ...*models.Task) *fixture {
+	f := &fixture{
+		templates: &mocks.MockRepository{Templates: []*models.Template{{ID: 1, Name: "Release", Tasks: []*models.TemplateTask{
+			{Title: "Release {{version}}", Project: "todo", DueInDays: intPtr(2), Checklist: []string{"Tag {{version}}", "Announce on {{date}}"}},
+			{Title: "Review week {{week}}", Status: "inprogress"},
+		}}}},
+		tasks: &taskmocks.MockRepository{Tasks: inProgress},
+		items: &taskmocks.MockChecklistRepository{},
+	}
+	tx := transaction.NewMemoryTransactor()
+	tu := taskusecase.NewTaskUsecase(f.tasks, &taskmocks.MockEventRepository{}, &taskmocks.MockSearchRepository{}, f.items, tx,
+		taskusecase.WithWIPLimits(map[string]int{"inprogress": 1}, models.WIPReject))
+	access := accessmocks.RoleUsecase(role)
+	f.usecase = NewTemplateUsecase(f.templates, taskusecase.NewAuthorizedUsecase(tu, f.tasks, access), access, tx).(*templateUsecase)
+	return f
+}
+
+func TestNewTemplateUsecase(t *testing.T) {
+	tr := &mocks.MockRepository{}
+	tu := &taskmocks.MockUsecase{}
+	au := &accessmocks.MockUsecase{}
+	tx := transaction.NewMemoryTransactor()
+	want := &templateUsecase{templateRepo: tr, taskUsecase: tu, accessUsecase: au, transactor: tx}
+	if got := NewTemplateUsecase(tr, tu, au, tx); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewTemplateUsecase() = %v, want %v", got, want)
+	}
+}
+
+func Test_templateUsecase_Add(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		template *models.Template
+		wantErr  error
+	}{
+		{name: "Normal Case 1: template added", role: models.RoleMember,
+			template: &models.Template{Name: "Onboarding", Tasks: []*models.TemplateTask{{Title: "Welcome {{name}}"}}}},
+		{name: "malformed checklist item", role: models.RoleMember,
+			template: &models.Template{Name: "Onboarding", Tasks: []*models.TemplateTask{{Title: "Welcome", Checklist: []string{"Call {{Name}}"}}}},
+			wantErr:  core.ErrInvalidTemplate},
+		{name: "viewer can not add", role: models.RoleViewer,
+			template: &models.Template{Name: "Onboarding", Tasks: []*models.TemplateTask{{Title: "Welcome"}}}, wantErr: core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.role)
+			if err := f.usecase.Add(accessmocks.GrantedContext(), tt.template); err != tt.wantErr {
+				t.Fatalf("templateUsecase.Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if want := map[bool]int{true: 2, false: 1}[tt.wantErr == nil]; len(f.templates.Templates) != want {
+				t.Errorf("templates = %d, want %d", len(f.templates.Templates), want)
+			}
+		})
+	}
+}
+
+func Test_templateUsecase_Edit(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		template *models.Template
+		wantErr  error
+	}{
+		{name: "Normal Case 1: template edited", role: models.RoleMember,
+			template: &models.Template{ID: 1, Name: "Release", Tasks: []*models.TemplateTask{{Title: "Release {{version}}"}}}},
+		{name: "malformed description", role: models.RoleMember,
+			template: &models.Template{ID: 1, Name: "Release", Tasks: []*models.TemplateTask{{Title: "Release", Description: "{{}}"}}},
+			wantErr:  core.ErrInvalidTemplate},
+		{name: "template not found", role: models.RoleMember,
+			template: &models.Template{ID: 9, Name: "Release", Tasks: []*models.TemplateTask{{Title: "Release"}}}, wantErr: core.ErrRecordNotFound},
+		{name: "viewer can not edit", role: models.RoleViewer,
+			template: &models.Template{ID: 1, Name: "Release", Tasks: []*models.TemplateTask{{Title: "Release"}}}, wantErr: core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.role)
+			if err := f.usecase.Edit(accessmocks.GrantedContext(), tt.template); err != tt.wantErr {
+				t.Errorf("templateUsecase.Edit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_templateUsecase_Get(t *testing.T) {
+	f := newFixture(models.RoleViewer)
+	got, err := f.usecase.Get(accessmocks.GrantedContext(), 1)
+	if err != nil || got.Name != "Release" {
+		t.Errorf("templateUsecase.Get() = %v, %v, want Release", got, err)
+	}
+	if _, err := f.usecase.Get(accessmocks.GrantedContext(), 9); err != core.ErrRecordNotFound {
+		t.Errorf("templateUsecase.Get() error = %v, want %v", err, core.ErrRecordNotFound)
+	}
+}
+
+func Test_templateUsecase_List(t *testing.T) {
+	f := newFixture(models.RoleViewer)
+	if got, err := f.usecase.List(accessmocks.GrantedContext()); err != nil || len(got) != 1 {
+		t.Errorf("templateUsecase.List() = %v, %v, want 1 template", got, err)
+	}
+}
+
+func Test_templateUsecase_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		wantErr error
+	}{
+		{name: "Normal Case 1: template deleted", role: models.RoleAdmin},
+		{name: "viewer can not delete", role: models.RoleViewer, wantErr: core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.role)
+			if err := f.usecase.Delete(accessmocks.GrantedContext(), 1); err != tt.wantErr {
+				t.Errorf("templateUsecase.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_templateUsecase_Instantiate(t *testing.T) {
+	f := newFixture(models.RoleMember)
+	got, err := f.usecase.Instantiate(accessmocks.GrantedContext(), 1, today, map[string]string{"version": "1.2", "week": "W43"})
+	if err != nil {
+		t.Fatalf("templateUsecase.Instantiate() error = %v", err)
+	}
+	if len(got) != 2 || len(f.tasks.Tasks) != 2 {
+		t.Fatalf("templateUsecase.Instantiate() = %d tasks, %d stored, want 2", len(got), len(f.tasks.Tasks))
+	}
+	due := today.AddDate(0, 0, 2)
+	release := got[0]
+	if release.Title != "Release 1.2" || release.Status != "todo" || release.Project != "todo" || release.DueDate == nil || !release.DueDate.Equal(due) {
+		t.Errorf("first task = %+v, want Release 1.2 due on %v", release, due)
+	}
+	var texts []string
+	for _, item := range release.Checklist {
+		texts = append(texts, item.Text)
+	}
+	if want := []string{"Tag 1.2", "Announce on 2026-10-19"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("checklist = %v, want %v", texts, want)
+	}
+	// the values override the placeholders of the day
+	if review := got[1]; review.Title != "Review week W43" || review.Status != "inprogress" || review.DueDate != nil {
+		t.Errorf("second task = %+v, want Review week W43", review)
+	}
+}
+
+func Test_templateUsecase_Instantiate_errors(t *testing.T) {
+	long := &models.Template{ID: 2, Name: "Long", Tasks: []*models.TemplateTask{{Title: "Release {{version}}"}}}
+	tests := []struct {
+		name       string
+		role       string
+		id         int
+		values     map[string]string
+		inProgress []*models.Task
+		wantErr    error
+	}{
+		{name: "missing value", role: models.RoleMember, id: 1, wantErr: core.ErrTemplateValue},
+		{name: "rendered title too long", role: models.RoleMember, id: 2,
+			values: map[string]string{"version": "1.2 with a name much longer than a title can be"}, wantErr: core.ErrInvalidTemplate},
+		{name: "wip limit rolls back the first task", role: models.RoleMember, id: 1, values: map[string]string{"version": "1.2"},
+			inProgress: []*models.Task{{ID: 1, Title: "Take math notes", Status: "inprogress"}}, wantErr: core.ErrWIPLimit},
+		{name: "template not found", role: models.RoleMember, id: 9, wantErr: core.ErrRecordNotFound},
+		{name: "viewer can not create tasks", role: models.RoleViewer, id: 1, values: map[string]string{"version": "1.2"}, wantErr: core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.role, tt.inProgress...)
+			f.templates.Templates = append(f.templates.Templates, long)
+			if _, err := f.usecase.Instantiate(accessmocks.GrantedContext(), tt.id, today, tt.values); err != tt.wantErr {
+				t.Fatalf("templateUsecase.Instantiate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(f.tasks.Tasks) != len(tt.inProgress) || len(f.items.Items) != 0 {
+				t.Errorf("%d tasks and %d items left, want none created", len(f.tasks.Tasks), len(f.items.Items))
+			}
+		})
+	}
+}
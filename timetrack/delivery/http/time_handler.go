@@ -0,0 +1,205 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/timetrack"
+	"github.com/sirupsen/logrus"
+)
+
+//TimeHandler represents http handler for time tracking
+type TimeHandler struct {
+	TimeUsecase timetrack.Usecase
+}
+
+// NewTimeHandler will initialize the time entries resources endpoint of a task
+func NewTimeHandler(tu timetrack.Usecase) nethttp.Handler {
+	r := chi.NewMux()
+	timeHandler := &TimeHandler{
+		TimeUsecase: tu,
+	}
+	r.Get("/", timeHandler.List)
+	r.Post("/", timeHandler.Add)
+	r.Post("/start", timeHandler.Start)
+	r.Post("/stop", timeHandler.Stop)
+	r.Delete("/{entry:[0-9]+}", timeHandler.Delete)
+	return r
+}
+
+// NewReportHandler will initialize the time report endpoint
+func NewReportHandler(tu timetrack.Usecase) nethttp.Handler {
+	r := chi.NewMux()
+	timeHandler := &TimeHandler{
+		TimeUsecase: tu,
+	}
+	r.Get("/report", timeHandler.Report)
+	return r
+}
+
+func writeJSON(w nethttp.ResponseWriter, status int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	res, _ := json.Marshal(body)
+	w.Write(res)
+}
+
+// writeError answers the errors shared by the time endpoints
+func writeError(w nethttp.ResponseWriter, err error) {
+	switch err {
+	case core.ErrForbidden:
+		w.WriteHeader(nethttp.StatusForbidden)
+		w.Write([]byte("forbidden"))
+	case core.ErrRecordNotFound:
+		w.WriteHeader(nethttp.StatusNotFound)
+		w.Write([]byte("not found"))
+	case core.ErrOverlap:
+		w.WriteHeader(nethttp.StatusConflict)
+		w.Write([]byte("time entry overlaps another one"))
+	default:
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+	}
+}
+
+// ids returns the ids of the task and of the entry of the url, the routes only match digits
+func ids(r *nethttp.Request) (int, int) {
+	taskID, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	id, _ := strconv.Atoi(chi.URLParam(r, "entry"))
+	return taskID, id
+}
+
+// writeEntry answers the entry, or the error of the call that returned it
+func writeEntry(w nethttp.ResponseWriter, status int, e *models.TimeEntry, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, map[string]interface{}{
+		"message": "success",
+		"entry":   e,
+	})
+}
+
+//List handler returns the time entries of the task, oldest first
+func (h *TimeHandler) List(w nethttp.ResponseWriter, r *nethttp.Request) {
+	taskID, _ := ids(r)
+	entries, err := h.TimeUsecase.List(r.Context(), taskID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"entries": entries,
+	})
+}
+
+//Start handler starts a timer of the authenticated user on the task
+func (h *TimeHandler) Start(w nethttp.ResponseWriter, r *nethttp.Request) {
+	taskID, _ := ids(r)
+	e, err := h.TimeUsecase.Start(r.Context(), taskID)
+	writeEntry(w, nethttp.StatusCreated, e, err)
+}
+
+//Stop handler stops the timer of the authenticated user on the task
+func (h *TimeHandler) Stop(w nethttp.ResponseWriter, r *nethttp.Request) {
+	taskID, _ := ids(r)
+	e, err := h.TimeUsecase.Stop(r.Context(), taskID)
+	writeEntry(w, nethttp.StatusOK, e, err)
+}
+
+//Add handler stores time the authenticated user spent on the task, the entry
+//needs both its start and stop times
+func (h *TimeHandler) Add(w nethttp.ResponseWriter, r *nethttp.Request) {
+	e := &models.TimeEntry{}
+	if err := json.NewDecoder(r.Body).Decode(e); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return
+	}
+	validate := validator.New()
+	if err := validate.Struct(e); err != nil || e.StoppedAt.Before(e.StartedAt) {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return
+	}
+	e.TaskID, _ = ids(r)
+	err := h.TimeUsecase.Add(r.Context(), e)
+	writeEntry(w, nethttp.StatusCreated, e, err)
+}
+
+//Delete handler deletes a time entry
+func (h *TimeHandler) Delete(w nethttp.ResponseWriter, r *nethttp.Request) {
+	taskID, id := ids(r)
+	if err := h.TimeUsecase.Delete(r.Context(), taskID, id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	w.Write([]byte("success"))
+}
+
+// parseTime reads a time of the query string, either a day or a RFC 3339 time
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// parseReport reads the report query from the query string, the report
+// groups by task unless group says otherwise
+func parseReport(r *nethttp.Request) (*models.TimeReportQuery, error) {
+	q := r.URL.Query()
+	rq := &models.TimeReportQuery{GroupBy: q.Get("group"), Project: q.Get("project")}
+	if rq.GroupBy == "" {
+		rq.GroupBy = models.TimeByTask
+	}
+	var err error
+	if rq.From, err = parseTime(q.Get("from")); err != nil {
+		return nil, err
+	}
+	if rq.To, err = parseTime(q.Get("to")); err != nil {
+		return nil, err
+	}
+	if s := q.Get("id_task"); s != "" {
+		if rq.TaskID, err = strconv.Atoi(s); err != nil {
+			return nil, err
+		}
+	}
+	if s := q.Get("id_user"); s != "" {
+		if rq.UserID, err = strconv.Atoi(s); err != nil {
+			return nil, err
+		}
+	}
+	validate := validator.New()
+	return rq, validate.Struct(rq)
+}
+
+//Report handler sums the time spent from the day or time "from" to the one "to",
+//by task, project, user or day as set by "group"
+func (h *TimeHandler) Report(w nethttp.ResponseWriter, r *nethttp.Request) {
+	q, err := parseReport(r)
+	if err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("invalid report query"))
+		return
+	}
+	rows, err := h.TimeUsecase.Report(r.Context(), q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"report":  rows,
+	})
+}
@@ -0,0 +1,209 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/timetrack"
+	"github.com/pratheeshm/todo-golang/timetrack/mocks"
+)
+
+func withIDs(r *nethttp.Request, taskID, id string) *nethttp.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", taskID)
+	rctx.URLParams.Add("entry", id)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestNewTimeHandler(t *testing.T) {
+	h := NewTimeHandler(&mocks.MockUsecase{})
+	tests := []struct {
+		method     string
+		url        string
+		body       string
+		statusCode int
+	}{
+		{"GET", "/", "", 200},
+		{"POST", "/", `{"started_at":"2026-10-01T09:00:00Z","stopped_at":"2026-10-01T10:00:00Z"}`, 201},
+		{"POST", "/start", "", 201},
+		{"POST", "/stop", "", 200},
+		{"DELETE", "/2", "", 200},
+		{"DELETE", "/abc", "", 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Errorf("got statuscode %d but expected %d", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestTimeHandler_Start(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    timetrack.Usecase
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, statusCode: 201},
+		{name: "timer running", usecase: &mocks.MockUsecase{Error: core.ErrOverlap}, statusCode: 409},
+		{name: "forbidden", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, statusCode: 403},
+		{name: "task not found", usecase: &mocks.MockUsecase{Error: core.ErrRecordNotFound}, statusCode: 404},
+		{name: "usecase error", usecase: &mocks.MockUsecase{Error: errors.New("Usecase.Error()")}, statusCode: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TimeHandler{TimeUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Start(rec, withIDs(httptest.NewRequest("POST", "/task/1/time/start", nil), "1", ""))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestTimeHandler_Stop(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    timetrack.Usecase
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, statusCode: 200},
+		{name: "no timer running", usecase: &mocks.MockUsecase{Error: core.ErrRecordNotFound}, statusCode: 404},
+		{name: "forbidden", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, statusCode: 403},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TimeHandler{TimeUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Stop(rec, withIDs(httptest.NewRequest("POST", "/task/1/time/stop", nil), "1", ""))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestTimeHandler_Add(t *testing.T) {
+	body := `{"started_at":"2026-10-01T09:00:00Z","stopped_at":"2026-10-01T10:00:00Z","note":"Chapter 3"}`
+	tests := []struct {
+		name       string
+		usecase    timetrack.Usecase
+		body       string
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, body: body, statusCode: 201},
+		{name: "missing stop time", usecase: &mocks.MockUsecase{}, body: `{"started_at":"2026-10-01T09:00:00Z"}`, statusCode: 400},
+		{name: "stopped before started", usecase: &mocks.MockUsecase{}, body: `{"started_at":"2026-10-01T10:00:00Z","stopped_at":"2026-10-01T09:00:00Z"}`, statusCode: 400},
+		{name: "body parse error", usecase: &mocks.MockUsecase{}, body: `[]`, statusCode: 400},
+		{name: "overlapping entry", usecase: &mocks.MockUsecase{Error: core.ErrOverlap}, body: body, statusCode: 409},
+		{name: "forbidden", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, body: body, statusCode: 403},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TimeHandler{TimeUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Add(rec, withIDs(httptest.NewRequest("POST", "/task/1/time", bytes.NewBufferString(tt.body)), "1", ""))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestTimeHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    timetrack.Usecase
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, statusCode: 200},
+		{name: "forbidden", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, statusCode: 403},
+		{name: "entry not found", usecase: &mocks.MockUsecase{Error: core.ErrRecordNotFound}, statusCode: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TimeHandler{TimeUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Delete(rec, withIDs(httptest.NewRequest("DELETE", "/task/1/time/2", nil), "1", "2"))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestTimeHandler_Report(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		url        string
+		usecase    *mocks.MockUsecase
+		statusCode int
+		want       *models.TimeReportQuery
+	}{{
+		name:       "Success case",
+		url:        "/report?from=2026-10-01&to=2026-11-01",
+		usecase:    &mocks.MockUsecase{},
+		statusCode: 200,
+		want:       &models.TimeReportQuery{From: from, To: to, GroupBy: models.TimeByTask},
+	}, {
+		name:       "filters and times",
+		url:        "/report?from=2026-10-01T00:00:00Z&to=2026-11-01&group=day&project=school&id_task=1&id_user=5",
+		usecase:    &mocks.MockUsecase{},
+		statusCode: 200,
+		want:       &models.TimeReportQuery{From: from, To: to, GroupBy: models.TimeByDay, Project: "school", TaskID: 1, UserID: 5},
+	}, {
+		name:       "missing range",
+		url:        "/report?from=2026-10-01",
+		usecase:    &mocks.MockUsecase{},
+		statusCode: 400,
+	}, {
+		name:       "reversed range",
+		url:        "/report?from=2026-11-01&to=2026-10-01",
+		usecase:    &mocks.MockUsecase{},
+		statusCode: 400,
+	}, {
+		name:       "unknown group",
+		url:        "/report?from=2026-10-01&to=2026-11-01&group=week",
+		usecase:    &mocks.MockUsecase{},
+		statusCode: 400,
+	}, {
+		name:       "invalid task",
+		url:        "/report?from=2026-10-01&to=2026-11-01&id_task=abc",
+		usecase:    &mocks.MockUsecase{},
+		statusCode: 400,
+	}, {
+		name:       "forbidden",
+		url:        "/report?from=2026-10-01&to=2026-11-01",
+		usecase:    &mocks.MockUsecase{Error: core.ErrForbidden},
+		statusCode: 403,
+		want:       &models.TimeReportQuery{From: from, To: to, GroupBy: models.TimeByTask},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewReportHandler(tt.usecase)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if !reflect.DeepEqual(tt.usecase.Query, tt.want) {
+				t.Errorf("Test - %s , got query %+v but expected %+v", tt.name, tt.usecase.Query, tt.want)
+			}
+		})
+	}
+}
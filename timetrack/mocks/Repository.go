@@ -0,0 +1,127 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+//MockRepository implements inerface timetrack.Repository
+type MockRepository struct {
+	Error   error
+	Entries []*models.TimeEntry
+	// Query is the query of the last call to Report, which returns Rows
+	Query *models.TimeReportQuery
+	Rows  []*models.TimeReportRow
+}
+
+// end returns the stop time of the entry, a running timer never ends
+func end(e *models.TimeEntry) time.Time {
+	if e.StoppedAt == nil {
+		return time.Unix(1<<62, 0)
+	}
+	return *e.StoppedAt
+}
+
+//Add appends a copy of the entry to Entries unless it overlaps an entry of the same user,
+//the append is undone when the transaction of ctx rolls back
+func (m *MockRepository) Add(ctx context.Context, e *models.TimeEntry) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for _, o := range m.Entries {
+		if o.UserID == e.UserID && o.StartedAt.Before(end(e)) && e.StartedAt.Before(end(o)) {
+			return core.ErrOverlap
+		}
+	}
+	e.ID = len(m.Entries) + 1
+	e.CreatedAt = time.Now()
+	stored := *e
+	m.Entries = append(m.Entries, &stored)
+	transaction.OnRollback(ctx, func() {
+		m.Entries = m.Entries[:len(m.Entries)-1]
+	})
+	return nil
+}
+
+//Get returns a copy of the entry of Entries with the id
+func (m *MockRepository) Get(ctx context.Context, id int) (*models.TimeEntry, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	for _, e := range m.Entries {
+		if e.ID == id {
+			found := *e
+			return &found, nil
+		}
+	}
+	return nil, core.ErrRecordNotFound
+}
+
+//List returns copies of the entries of the task
+func (m *MockRepository) List(ctx context.Context, taskID int) ([]*models.TimeEntry, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	entries := []*models.TimeEntry{}
+	for _, e := range m.Entries {
+		if e.TaskID == taskID {
+			found := *e
+			entries = append(entries, &found)
+		}
+	}
+	return entries, nil
+}
+
+//Stop stops the running entry of the user on the task
+func (m *MockRepository) Stop(ctx context.Context, taskID, userID int, at time.Time) (*models.TimeEntry, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	for _, e := range m.Entries {
+		if e.TaskID == taskID && e.UserID == userID && e.StoppedAt == nil {
+			e.StoppedAt = &at
+			found := *e
+			return &found, nil
+		}
+	}
+	return nil, core.ErrRecordNotFound
+}
+
+//StopTask stops the running entries of the task
+func (m *MockRepository) StopTask(ctx context.Context, taskID int, at time.Time) (int64, error) {
+	if m.Error != nil {
+		return 0, m.Error
+	}
+	var n int64
+	for _, e := range m.Entries {
+		if e.TaskID == taskID && e.StoppedAt == nil {
+			e.StoppedAt = &at
+			n++
+		}
+	}
+	return n, nil
+}
+
+//Delete removes the entry from Entries
+func (m *MockRepository) Delete(ctx context.Context, id int) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for i, e := range m.Entries {
+		if e.ID == id {
+			m.Entries = append(m.Entries[:i], m.Entries[i+1:]...)
+			return nil
+		}
+	}
+	return core.ErrRecordNotFound
+}
+
+//Report records the query and returns Rows
+func (m *MockRepository) Report(ctx context.Context, q *models.TimeReportQuery) ([]*models.TimeReportRow, error) {
+	m.Query = q
+	return m.Rows, m.Error
+}
@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockUsecase implements inerface timetrack.Usecase
+type MockUsecase struct {
+	Error   error
+	Entries []*models.TimeEntry
+	Rows    []*models.TimeReportRow
+	// Query is the query of the last call to Report
+	Query *models.TimeReportQuery
+}
+
+//List returns Entries
+func (m *MockUsecase) List(ctx context.Context, taskID int) ([]*models.TimeEntry, error) {
+	return m.Entries, m.Error
+}
+
+//Start returns the first entry of Entries
+func (m *MockUsecase) Start(ctx context.Context, taskID int) (*models.TimeEntry, error) {
+	return m.first()
+}
+
+//Stop returns the first entry of Entries
+func (m *MockUsecase) Stop(ctx context.Context, taskID int) (*models.TimeEntry, error) {
+	return m.first()
+}
+
+//Add entry
+func (m *MockUsecase) Add(ctx context.Context, e *models.TimeEntry) error {
+	return m.Error
+}
+
+//Delete entry
+func (m *MockUsecase) Delete(ctx context.Context, taskID, id int) error {
+	return m.Error
+}
+
+//Report records the query and returns Rows
+func (m *MockUsecase) Report(ctx context.Context, q *models.TimeReportQuery) ([]*models.TimeReportRow, error) {
+	m.Query = q
+	return m.Rows, m.Error
+}
+
+func (m *MockUsecase) first() (*models.TimeEntry, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if len(m.Entries) == 0 {
+		return &models.TimeEntry{}, nil
+	}
+	return m.Entries[0], nil
+}
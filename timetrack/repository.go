@@ -0,0 +1,25 @@
+package timetrack
+
+import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Repository represents time entry's interface
+type Repository interface {
+	// Add stores an entry of a task of the workspace, a running timer when it has no
+	// stop time. core.ErrOverlap is returned when it overlaps an entry of the user
+	Add(context.Context, *models.TimeEntry) error
+	Get(ctx context.Context, id int) (*models.TimeEntry, error)
+	// List returns the entries of a task, oldest first
+	List(ctx context.Context, taskID int) ([]*models.TimeEntry, error)
+	// Stop stops the running timer of the user on the task and returns it
+	Stop(ctx context.Context, taskID, userID int, at time.Time) (*models.TimeEntry, error)
+	// StopTask stops the running timers of every user on the task and returns how many it stopped
+	StopTask(ctx context.Context, taskID int, at time.Time) (int64, error)
+	Delete(ctx context.Context, id int) error
+	// Report sums the time spent by group within the range of the query
+	Report(context.Context, *models.TimeReportQuery) ([]*models.TimeReportRow, error)
+}
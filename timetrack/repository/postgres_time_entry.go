@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/timetrack"
+	"github.com/pratheeshm/todo-golang/transaction"
+)
+
+// exclusionViolation is the postgres error code of a row overlapping another one
+const exclusionViolation = "23P01"
+
+const entryColumns = "id_entry, id_task, id_user, started_at, stopped_at, note, extract(epoch FROM COALESCE(stopped_at, now()) - started_at)::bigint, created_at"
+
+// reportSum is the time the entries spent within [$2, $3), a running timer runs until now
+const reportSum = "COALESCE(sum(extract(epoch FROM greatest(least(COALESCE(e.stopped_at, now()), $3) - greatest(e.started_at, $2), interval '0'))), 0)::bigint"
+
+// reportGroup is a grouping of the report, the columns it groups by and the fields they scan into
+type reportGroup struct {
+	columns string
+	fields  func(*models.TimeReportRow) []interface{}
+}
+
+var reportGroups = map[string]reportGroup{
+	models.TimeByTask: {"e.id_task, t.title, t.project", func(r *models.TimeReportRow) []interface{} {
+		return []interface{}{&r.TaskID, &r.Title, &r.Project}
+	}},
+	models.TimeByProject: {"t.project", func(r *models.TimeReportRow) []interface{} {
+		return []interface{}{&r.Project}
+	}},
+	models.TimeByUser: {"e.id_user", func(r *models.TimeReportRow) []interface{} {
+		return []interface{}{&r.UserID}
+	}},
+	models.TimeByDay: {"to_char(e.started_at AT TIME ZONE 'UTC', 'YYYY-MM-DD')", func(r *models.TimeReportRow) []interface{} {
+		return []interface{}{&r.Day}
+	}},
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type postgresTimeEntryRepository struct {
+	*sql.DB
+}
+
+// NewPostgresTimeEntryRepository will create an object that represent the timetrack.Repository interface
+func NewPostgresTimeEntryRepository(db *sql.DB) timetrack.Repository {
+	return &postgresTimeEntryRepository{db}
+}
+
+func scanEntry(s scanner) (*models.TimeEntry, error) {
+	e := &models.TimeEntry{}
+	err := s.Scan(&e.ID, &e.TaskID, &e.UserID, &e.StartedAt, &e.StoppedAt, &e.Note, &e.Seconds, &e.CreatedAt)
+	return e, err
+}
+
+// Add only adds entries to the tasks of the workspace that are not deleted
+func (p *postgresTimeEntryRepository) Add(ctx context.Context, e *models.TimeEntry) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	err = transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "INSERT INTO time_entry(id_task, id_workspace, id_user, started_at, stopped_at, note) SELECT $1, $2, $3, $4, $5, $6 "+
+		"WHERE EXISTS (SELECT 1 FROM task WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted) "+
+		"RETURNING id_entry, extract(epoch FROM COALESCE(stopped_at, now()) - started_at)::bigint, created_at",
+		e.TaskID, workspace, e.UserID, e.StartedAt, e.StoppedAt, e.Note).Scan(&e.ID, &e.Seconds, &e.CreatedAt)
+	if err == sql.ErrNoRows {
+		return core.ErrRecordNotFound
+	}
+	if perr, ok := err.(*pq.Error); ok && perr.Code == exclusionViolation {
+		return core.ErrOverlap
+	}
+	return err
+}
+
+func (p *postgresTimeEntryRepository) Get(ctx context.Context, id int) (*models.TimeEntry, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "SELECT "+entryColumns+" FROM time_entry WHERE id_entry = $1 AND id_workspace = $2", id, workspace)
+	e, err := scanEntry(row)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	return e, err
+}
+
+func (p *postgresTimeEntryRepository) List(ctx context.Context, taskID int) ([]*models.TimeEntry, error) {
+	entries := make([]*models.TimeEntry, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return entries, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT "+entryColumns+" FROM time_entry WHERE id_task = $1 AND id_workspace = $2 ORDER BY started_at, id_entry",
+		taskID, workspace)
+	if err != nil {
+		return entries, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		e, err := scanEntry(rows)
+		if err != nil {
+			return []*models.TimeEntry{}, err
+		}
+		entries = append(entries, e)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.TimeEntry{}, err
+	}
+	return entries, nil
+}
+
+// Stop never stops a timer before it started, shrinking an entry can not make it overlap
+func (p *postgresTimeEntryRepository) Stop(ctx context.Context, taskID, userID int, at time.Time) (*models.TimeEntry, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "UPDATE time_entry SET stopped_at = greatest($1, started_at) WHERE id_task = $2 AND id_user = $3 AND id_workspace = $4 AND stopped_at IS NULL RETURNING "+entryColumns,
+		at, taskID, userID, workspace)
+	e, err := scanEntry(row)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	return e, err
+}
+
+func (p *postgresTimeEntryRepository) StopTask(ctx context.Context, taskID int, at time.Time) (int64, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "UPDATE time_entry SET stopped_at = greatest($1, started_at) WHERE id_task = $2 AND id_workspace = $3 AND stopped_at IS NULL",
+		at, taskID, workspace)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (p *postgresTimeEntryRepository) Delete(ctx context.Context, id int) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "DELETE FROM time_entry WHERE id_entry = $1 AND id_workspace = $2", id, workspace)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+// Report keeps the time of deleted tasks, it has been spent all the same
+func (p *postgresTimeEntryRepository) Report(ctx context.Context, q *models.TimeReportQuery) ([]*models.TimeReportRow, error) {
+	report := make([]*models.TimeReportRow, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return report, err
+	}
+	group, ok := reportGroups[q.GroupBy]
+	if !ok {
+		return report, fmt.Errorf("unknown time report grouping %q", q.GroupBy)
+	}
+	where := "e.id_workspace = $1 AND e.started_at < $3 AND COALESCE(e.stopped_at, 'infinity') > $2"
+	args := []interface{}{workspace, q.From, q.To}
+	if q.Project != "" {
+		args = append(args, q.Project)
+		where += fmt.Sprintf(" AND t.project = $%d", len(args))
+	}
+	if q.TaskID != 0 {
+		args = append(args, q.TaskID)
+		where += fmt.Sprintf(" AND e.id_task = $%d", len(args))
+	}
+	if q.UserID != 0 {
+		args = append(args, q.UserID)
+		where += fmt.Sprintf(" AND e.id_user = $%d", len(args))
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT "+group.columns+", count(*), "+reportSum+
+		" FROM time_entry e JOIN task t ON t.id_task = e.id_task WHERE "+where+
+		" GROUP BY "+group.columns+" ORDER BY "+group.columns, args...)
+	if err != nil {
+		return report, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		r := &models.TimeReportRow{}
+		if err := rows.Scan(append(group.fields(r), &r.Entries, &r.Seconds)...); err != nil {
+			return []*models.TimeReportRow{}, err
+		}
+		report = append(report, r)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.TimeReportRow{}, err
+	}
+	return report, nil
+}
@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+var entryRowColumns = []string{"id_entry", "id_task", "id_user", "started_at", "stopped_at", "note", "seconds", "created_at"}
+
+var (
+	nine = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	ten  = time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+)
+
+func Test_postgresTimeEntryRepository_Add(t *testing.T) {
+	query := "INSERT INTO time_entry(id_task, id_workspace, id_user, started_at, stopped_at, note) SELECT $1, $2, $3, $4, $5, $6 " +
+		"WHERE EXISTS (SELECT 1 FROM task WHERE id_task = $1 AND id_workspace = $2 AND NOT deleted) " +
+		"RETURNING id_entry, extract(epoch FROM COALESCE(stopped_at, now()) - started_at)::bigint, created_at"
+	tests := []struct {
+		name        string
+		rows        *sqlmock.Rows
+		dbError     error
+		wantID      int
+		wantSeconds int64
+		wantErr     error
+	}{{
+		name:        "Normal Case 1: entry added",
+		rows:        sqlmock.NewRows([]string{"id_entry", "seconds", "created_at"}).AddRow(3, 3600, time.Time{}),
+		wantID:      3,
+		wantSeconds: 3600,
+	}, {
+		name:    "task not found",
+		rows:    sqlmock.NewRows([]string{"id_entry", "seconds", "created_at"}),
+		wantErr: core.ErrRecordNotFound,
+	}, {
+		name:    "overlapping entry",
+		rows:    sqlmock.NewRows([]string{"id_entry", "seconds", "created_at"}),
+		dbError: &pq.Error{Code: "23P01"},
+		wantErr: core.ErrOverlap,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant, 5, nine, &ten, "Chapter 3").WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			e := &models.TimeEntry{TaskID: 1, UserID: 5, StartedAt: nine, StoppedAt: &ten, Note: "Chapter 3"}
+			if err := NewPostgresTimeEntryRepository(db).Add(coremocks.TenantContext(), e); err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if e.ID != tt.wantID || e.Seconds != tt.wantSeconds {
+				t.Errorf("Test %s - got entry %d of %ds, want %d of %ds", tt.name, e.ID, e.Seconds, tt.wantID, tt.wantSeconds)
+			}
+		})
+	}
+}
+
+func Test_postgresTimeEntryRepository_Get(t *testing.T) {
+	query := "SELECT id_entry, id_task, id_user, started_at, stopped_at, note, extract(epoch FROM COALESCE(stopped_at, now()) - started_at)::bigint, created_at FROM time_entry WHERE id_entry = $1 AND id_workspace = $2"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *models.TimeEntry
+		wantErr error
+	}{{
+		name: "Normal Case 1: running timer",
+		rows: sqlmock.NewRows(entryRowColumns).AddRow(3, 1, 5, nine, nil, "", 60, time.Time{}),
+		want: &models.TimeEntry{ID: 3, TaskID: 1, UserID: 5, StartedAt: nine, Seconds: 60},
+	}, {
+		name:    "entry not found",
+		rows:    sqlmock.NewRows(entryRowColumns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(3, coremocks.Tenant).WillReturnRows(tt.rows)
+			got, err := NewPostgresTimeEntryRepository(db).Get(coremocks.TenantContext(), 3)
+			if err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresTimeEntryRepository_List(t *testing.T) {
+	query := "SELECT id_entry, id_task, id_user, started_at, stopped_at, note, extract(epoch FROM COALESCE(stopped_at, now()) - started_at)::bigint, created_at FROM time_entry WHERE id_task = $1 AND id_workspace = $2 ORDER BY started_at, id_entry"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbError error
+		want    []*models.TimeEntry
+		wantErr bool
+	}{{
+		name: "Normal Case 1: entries of the task",
+		rows: sqlmock.NewRows(entryRowColumns).
+			AddRow(3, 1, 5, nine, ten, "Chapter 3", 3600, time.Time{}).
+			AddRow(4, 1, 6, ten, nil, "", 60, time.Time{}),
+		want: []*models.TimeEntry{
+			{ID: 3, TaskID: 1, UserID: 5, StartedAt: nine, StoppedAt: &ten, Note: "Chapter 3", Seconds: 3600},
+			{ID: 4, TaskID: 1, UserID: 6, StartedAt: ten, Seconds: 60},
+		},
+	}, {
+		name:    "db error",
+		rows:    sqlmock.NewRows(entryRowColumns),
+		dbError: errors.New("db error"),
+		want:    []*models.TimeEntry{},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant).WillReturnRows(tt.rows).WillReturnError(tt.dbError)
+			got, err := NewPostgresTimeEntryRepository(db).List(coremocks.TenantContext(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresTimeEntryRepository_Stop(t *testing.T) {
+	query := "UPDATE time_entry SET stopped_at = greatest($1, started_at) WHERE id_task = $2 AND id_user = $3 AND id_workspace = $4 AND stopped_at IS NULL RETURNING " +
+		"id_entry, id_task, id_user, started_at, stopped_at, note, extract(epoch FROM COALESCE(stopped_at, now()) - started_at)::bigint, created_at"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *models.TimeEntry
+		wantErr error
+	}{{
+		name: "Normal Case 1: timer stopped",
+		rows: sqlmock.NewRows(entryRowColumns).AddRow(3, 1, 5, nine, ten, "", 3600, time.Time{}),
+		want: &models.TimeEntry{ID: 3, TaskID: 1, UserID: 5, StartedAt: nine, StoppedAt: &ten, Seconds: 3600},
+	}, {
+		name:    "no running timer",
+		rows:    sqlmock.NewRows(entryRowColumns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(ten, 1, 5, coremocks.Tenant).WillReturnRows(tt.rows)
+			got, err := NewPostgresTimeEntryRepository(db).Stop(coremocks.TenantContext(), 1, 5, ten)
+			if err != tt.wantErr {
+				t.Fatalf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresTimeEntryRepository_StopTask(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE time_entry SET stopped_at = greatest($1, started_at) WHERE id_task = $2 AND id_workspace = $3 AND stopped_at IS NULL")).
+		WithArgs(ten, 1, coremocks.Tenant).WillReturnResult(sqlmock.NewResult(0, 2))
+	got, err := NewPostgresTimeEntryRepository(db).StopTask(coremocks.TenantContext(), 1, ten)
+	if err != nil || got != 2 {
+		t.Errorf("StopTask() = %d, %v, want 2 timers stopped", got, err)
+	}
+}
+
+func Test_postgresTimeEntryRepository_Delete(t *testing.T) {
+	query := "DELETE FROM time_entry WHERE id_entry = $1 AND id_workspace = $2"
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "Normal Case 1: entry deleted", affected: 1},
+		{name: "entry not found", affected: 0, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(3, coremocks.Tenant).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if err := NewPostgresTimeEntryRepository(db).Delete(coremocks.TenantContext(), 3); err != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresTimeEntryRepository_Report(t *testing.T) {
+	sum := "count(*), COALESCE(sum(extract(epoch FROM greatest(least(COALESCE(e.stopped_at, now()), $3) - greatest(e.started_at, $2), interval '0'))), 0)::bigint " +
+		"FROM time_entry e JOIN task t ON t.id_task = e.id_task WHERE e.id_workspace = $1 AND e.started_at < $3 AND COALESCE(e.stopped_at, 'infinity') > $2"
+	from, to := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		query *models.TimeReportQuery
+		sql   string
+		args  []interface{}
+		rows  *sqlmock.Rows
+		want  []*models.TimeReportRow
+	}{{
+		name:  "Normal Case 1: by task",
+		query: &models.TimeReportQuery{From: from, To: to, GroupBy: models.TimeByTask},
+		sql:   "SELECT e.id_task, t.title, t.project, " + sum + " GROUP BY e.id_task, t.title, t.project ORDER BY e.id_task, t.title, t.project",
+		args:  []interface{}{coremocks.Tenant, from, to},
+		rows:  sqlmock.NewRows([]string{"id_task", "title", "project", "count", "seconds"}).AddRow(1, "Take math notes", "school", 2, 5400),
+		want:  []*models.TimeReportRow{{TaskID: 1, Title: "Take math notes", Project: "school", Entries: 2, Seconds: 5400}},
+	}, {
+		name:  "by project of a user",
+		query: &models.TimeReportQuery{From: from, To: to, GroupBy: models.TimeByProject, UserID: 5},
+		sql:   "SELECT t.project, " + sum + " AND e.id_user = $4 GROUP BY t.project ORDER BY t.project",
+		args:  []interface{}{coremocks.Tenant, from, to, 5},
+		rows:  sqlmock.NewRows([]string{"project", "count", "seconds"}).AddRow("school", 3, 7200).AddRow("web", 1, 600),
+		want:  []*models.TimeReportRow{{Project: "school", Entries: 3, Seconds: 7200}, {Project: "web", Entries: 1, Seconds: 600}},
+	}, {
+		name:  "by day of a task of a project",
+		query: &models.TimeReportQuery{From: from, To: to, GroupBy: models.TimeByDay, Project: "school", TaskID: 1},
+		sql: "SELECT to_char(e.started_at AT TIME ZONE 'UTC', 'YYYY-MM-DD'), " + sum + " AND t.project = $4 AND e.id_task = $5 " +
+			"GROUP BY to_char(e.started_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') ORDER BY to_char(e.started_at AT TIME ZONE 'UTC', 'YYYY-MM-DD')",
+		args: []interface{}{coremocks.Tenant, from, to, "school", 1},
+		rows: sqlmock.NewRows([]string{"day", "count", "seconds"}).AddRow("2026-10-01", 2, 5400),
+		want: []*models.TimeReportRow{{Day: "2026-10-01", Entries: 2, Seconds: 5400}},
+	}, {
+		name:  "by user",
+		query: &models.TimeReportQuery{From: from, To: to, GroupBy: models.TimeByUser},
+		sql:   "SELECT e.id_user, " + sum + " GROUP BY e.id_user ORDER BY e.id_user",
+		args:  []interface{}{coremocks.Tenant, from, to},
+		rows:  sqlmock.NewRows([]string{"id_user", "count", "seconds"}),
+		want:  []*models.TimeReportRow{},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			args := make([]driver.Value, len(tt.args))
+			for i, a := range tt.args {
+				args[i] = a
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(tt.sql)).WithArgs(args...).WillReturnRows(tt.rows)
+			got, err := NewPostgresTimeEntryRepository(db).Report(coremocks.TenantContext(), tt.query)
+			if err != nil {
+				t.Fatalf("Test %s - error = %v", tt.name, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Test %s - got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresTimeEntryRepository_withoutTenant(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	ctx := context.Background()
+	p := NewPostgresTimeEntryRepository(db)
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Add", func() error { return p.Add(ctx, &models.TimeEntry{TaskID: 1, StartedAt: nine}) }},
+		{"Get", func() error { _, err := p.Get(ctx, 1); return err }},
+		{"List", func() error { _, err := p.List(ctx, 1); return err }},
+		{"Stop", func() error { _, err := p.Stop(ctx, 1, 5, ten); return err }},
+		{"StopTask", func() error { _, err := p.StopTask(ctx, 1, ten); return err }},
+		{"Delete", func() error { return p.Delete(ctx, 1) }},
+		{"Report", func() error {
+			_, err := p.Report(ctx, &models.TimeReportQuery{From: nine, To: ten, GroupBy: models.TimeByTask})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != core.ErrNoTenant {
+				t.Errorf("expected %v, got %v", core.ErrNoTenant, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package timetrack
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Usecase represents time tracking's usecases, the entries and timers
+//are the ones of the user of the context
+type Usecase interface {
+	// List returns the entries of a task
+	List(ctx context.Context, taskID int) ([]*models.TimeEntry, error)
+	// Start starts a timer of the user on a task
+	Start(ctx context.Context, taskID int) (*models.TimeEntry, error)
+	// Stop stops the timer of the user on a task
+	Stop(ctx context.Context, taskID int) (*models.TimeEntry, error)
+	// Add stores an entry of the user with its start and stop times
+	Add(context.Context, *models.TimeEntry) error
+	// Delete deletes an entry of the user, the admins of the task delete any entry
+	Delete(ctx context.Context, taskID, id int) error
+	// Report sums the time spent on the tasks
+	Report(context.Context, *models.TimeReportQuery) ([]*models.TimeReportRow, error)
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/timetrack"
+)
+
+type timetrackUsecase struct {
+	timeRepo      timetrack.Repository
+	taskRepo      task.Repository
+	accessUsecase access.Usecase
+}
+
+// NewTimetrackUsecase will create new a timetrackUsecase object representation of timetrack.Usecase interface.
+// Time follows the role of the user on the task: viewers read the entries, members track their
+// own time and admins delete the entries of others
+func NewTimetrackUsecase(tr timetrack.Repository, taskRepo task.Repository, au access.Usecase) timetrack.Usecase {
+	return &timetrackUsecase{
+		timeRepo:      tr,
+		taskRepo:      taskRepo,
+		accessUsecase: au,
+	}
+}
+
+func (tu *timetrackUsecase) List(ctx context.Context, taskID int) ([]*models.TimeEntry, error) {
+	if err := access.AllowTask(ctx, tu.accessUsecase, tu.taskRepo, access.ActionRead, taskID); err != nil {
+		return nil, err
+	}
+	return tu.timeRepo.List(ctx, taskID)
+}
+
+// Start fails with core.ErrOverlap while the user has a timer running, on any task
+func (tu *timetrackUsecase) Start(ctx context.Context, taskID int) (*models.TimeEntry, error) {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := access.AllowTask(ctx, tu.accessUsecase, tu.taskRepo, access.ActionWrite, taskID); err != nil {
+		return nil, err
+	}
+	e := &models.TimeEntry{TaskID: taskID, UserID: u.ID, StartedAt: time.Now()}
+	if err := tu.timeRepo.Add(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (tu *timetrackUsecase) Stop(ctx context.Context, taskID int) (*models.TimeEntry, error) {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := access.AllowTask(ctx, tu.accessUsecase, tu.taskRepo, access.ActionWrite, taskID); err != nil {
+		return nil, err
+	}
+	return tu.timeRepo.Stop(ctx, taskID, u.ID, time.Now())
+}
+
+func (tu *timetrackUsecase) Add(ctx context.Context, e *models.TimeEntry) error {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return err
+	}
+	if err := access.AllowTask(ctx, tu.accessUsecase, tu.taskRepo, access.ActionWrite, e.TaskID); err != nil {
+		return err
+	}
+	e.UserID = u.ID
+	return tu.timeRepo.Add(ctx, e)
+}
+
+func (tu *timetrackUsecase) Delete(ctx context.Context, taskID, id int) error {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return err
+	}
+	e, err := tu.timeRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if e.TaskID != taskID {
+		return core.ErrRecordNotFound
+	}
+	action := access.ActionDelete
+	if e.UserID == u.ID {
+		action = access.ActionWrite
+	}
+	if err := access.AllowTask(ctx, tu.accessUsecase, tu.taskRepo, action, taskID); err != nil {
+		return err
+	}
+	return tu.timeRepo.Delete(ctx, id)
+}
+
+// Report needs to read the project of the query, or the whole workspace without one
+func (tu *timetrackUsecase) Report(ctx context.Context, q *models.TimeReportQuery) ([]*models.TimeReportRow, error) {
+	p, err := tu.accessUsecase.Policy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Can(access.ActionRead, q.Project, 0) {
+		return nil, core.ErrForbidden
+	}
+	return tu.timeRepo.Report(ctx, q)
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	accessmocks "github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	taskmocks "github.com/pratheeshm/todo-golang/task/mocks"
+	"github.com/pratheeshm/todo-golang/timetrack/mocks"
+)
+
+// alice (id 3) tracks time in workspace 7, bob (id 5) is another member
+type fixture struct {
+	entries *mocks.MockRepository
+	usecase *timetrackUsecase
+}
+
+func newFixture(role string) *fixture {
+	f := &fixture{entries: &mocks.MockRepository{}}
+	tasks := &taskmocks.MockRepository{Tasks: []*models.Task{
+		{ID: 1, Title: "Take math notes", Project: "school"},
+		{ID: 2, Title: "Buy groceries", Project: "home"},
+	}}
+	access := accessmocks.RoleUsecase(role)
+	f.usecase = NewTimetrackUsecase(f.entries, tasks, access).(*timetrackUsecase)
+	return f
+}
+
+func TestNewTimetrackUsecase(t *testing.T) {
+	tr := &mocks.MockRepository{}
+	taskRepo := &taskmocks.MockRepository{}
+	au := &accessmocks.MockUsecase{}
+	want := &timetrackUsecase{timeRepo: tr, taskRepo: taskRepo, accessUsecase: au}
+	if got := NewTimetrackUsecase(tr, taskRepo, au); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewTimetrackUsecase() = %v, want %v", got, want)
+	}
+}
+
+func Test_timetrackUsecase_Start_Stop(t *testing.T) {
+	f := newFixture(models.RoleMember)
+	ctx := accessmocks.GrantedContext()
+	e, err := f.usecase.Start(ctx, 1)
+	if err != nil {
+		t.Fatalf("timetrackUsecase.Start() error = %v", err)
+	}
+	if e.UserID != 3 || e.TaskID != 1 || e.StoppedAt != nil {
+		t.Errorf("timetrackUsecase.Start() = %+v", e)
+	}
+	// one timer at a time, whatever the task
+	if _, err := f.usecase.Start(ctx, 2); err != core.ErrOverlap {
+		t.Errorf("second timer error = %v, want %v", err, core.ErrOverlap)
+	}
+	stopped, err := f.usecase.Stop(ctx, 1)
+	if err != nil {
+		t.Fatalf("timetrackUsecase.Stop() error = %v", err)
+	}
+	if stopped.ID != e.ID || stopped.StoppedAt == nil {
+		t.Errorf("timetrackUsecase.Stop() = %+v", stopped)
+	}
+	if _, err := f.usecase.Stop(ctx, 1); err != core.ErrRecordNotFound {
+		t.Errorf("stop without timer error = %v, want %v", err, core.ErrRecordNotFound)
+	}
+}
+
+func Test_timetrackUsecase_Start_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		ctx     context.Context
+		taskID  int
+		wantErr error
+	}{
+		{name: "viewer can not track time", role: models.RoleViewer, ctx: accessmocks.GrantedContext(), taskID: 1, wantErr: core.ErrForbidden},
+		{name: "no user", role: models.RoleMember, ctx: core.WithTenant(context.Background(), accessmocks.Workspace), taskID: 1, wantErr: core.ErrForbidden},
+		{name: "task not found", role: models.RoleMember, ctx: accessmocks.GrantedContext(), taskID: 42, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.role)
+			if _, err := f.usecase.Start(tt.ctx, tt.taskID); err != tt.wantErr {
+				t.Errorf("timetrackUsecase.Start() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(f.entries.Entries) != 0 {
+				t.Errorf("entry stored: %v", f.entries.Entries)
+			}
+		})
+	}
+}
+
+func Test_timetrackUsecase_Add(t *testing.T) {
+	nine := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	ten := nine.Add(time.Hour)
+	f := newFixture(models.RoleMember)
+	f.entries.Entries = []*models.TimeEntry{{ID: 1, TaskID: 1, UserID: 5, StartedAt: nine, StoppedAt: &ten}}
+	// bob's entry does not overlap alice's
+	e := &models.TimeEntry{TaskID: 1, UserID: 5, StartedAt: nine, StoppedAt: &ten, Note: "Chapter 3"}
+	if err := f.usecase.Add(accessmocks.GrantedContext(), e); err != nil {
+		t.Fatalf("timetrackUsecase.Add() error = %v", err)
+	}
+	if e.UserID != 3 {
+		t.Errorf("entry of user %d, want 3", e.UserID)
+	}
+	if err := f.usecase.Add(accessmocks.GrantedContext(), &models.TimeEntry{TaskID: 2, StartedAt: nine.Add(30 * time.Minute), StoppedAt: &ten}); err != core.ErrOverlap {
+		t.Errorf("overlapping entry error = %v, want %v", err, core.ErrOverlap)
+	}
+	got, err := f.usecase.List(accessmocks.GrantedContext(), 1)
+	if err != nil || len(got) != 2 {
+		t.Errorf("timetrackUsecase.List() = %v, %v, want 2 entries", got, err)
+	}
+}
+
+func Test_timetrackUsecase_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		taskID  int
+		id      int
+		wantErr error
+	}{
+		{name: "member deletes their own entry", role: models.RoleMember, taskID: 1, id: 1},
+		{name: "member can not delete an entry of someone else", role: models.RoleMember, taskID: 1, id: 2, wantErr: core.ErrForbidden},
+		{name: "admin deletes an entry of someone else", role: models.RoleAdmin, taskID: 1, id: 2},
+		{name: "entry of another task", role: models.RoleAdmin, taskID: 2, id: 1, wantErr: core.ErrRecordNotFound},
+		{name: "entry not found", role: models.RoleAdmin, taskID: 1, id: 42, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(tt.role)
+			f.entries.Entries = []*models.TimeEntry{{ID: 1, TaskID: 1, UserID: 3}, {ID: 2, TaskID: 1, UserID: 5}}
+			if err := f.usecase.Delete(accessmocks.GrantedContext(), tt.taskID, tt.id); err != tt.wantErr {
+				t.Errorf("timetrackUsecase.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_timetrackUsecase_Report(t *testing.T) {
+	rows := []*models.TimeReportRow{{Project: "school", Entries: 2, Seconds: 5400}}
+	tests := []struct {
+		name    string
+		grant   *models.Grant
+		project string
+		want    []*models.TimeReportRow
+		wantErr error
+	}{
+		{name: "Normal Case 1: workspace viewer", grant: &models.Grant{UserID: 3, Role: models.RoleViewer}, want: rows},
+		{name: "project viewer reports the project", grant: &models.Grant{UserID: 3, Role: models.RoleViewer, Project: "school"}, project: "school", want: rows},
+		{name: "project viewer can not report the workspace", grant: &models.Grant{UserID: 3, Role: models.RoleViewer, Project: "school"}, wantErr: core.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &mocks.MockRepository{Rows: rows}
+			u := NewTimetrackUsecase(tr, &taskmocks.MockRepository{}, &accessmocks.MockUsecase{Grants: []*models.Grant{tt.grant}})
+			q := &models.TimeReportQuery{GroupBy: models.TimeByProject, Project: tt.project}
+			got, err := u.Report(accessmocks.GrantedContext(), q)
+			if err != tt.wantErr {
+				t.Fatalf("timetrackUsecase.Report() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("timetrackUsecase.Report() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package transaction
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+type undoKey struct{}
+
+// undoLog keeps the compensating actions of an in-memory transaction
+type undoLog struct {
+	mu    sync.Mutex
+	undos []func()
+}
+
+type memoryTransactor struct{}
+
+// NewMemoryTransactor will create a core.Transactor for in-memory repositories.
+// Repositories register how to revert their changes with OnRollback
+func NewMemoryTransactor() core.Transactor {
+	return &memoryTransactor{}
+}
+
+// OnRollback registers undo to be called if the transaction carried by ctx is
+// rolled back. Undo actions run in reverse order, outside of a transaction it is a no-op
+func OnRollback(ctx context.Context, undo func()) {
+	if log, ok := ctx.Value(undoKey{}).(*undoLog); ok {
+		log.mu.Lock()
+		log.undos = append(log.undos, undo)
+		log.mu.Unlock()
+	}
+}
+
+func (m *memoryTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(undoKey{}).(*undoLog); ok {
+		return fn(ctx)
+	}
+	log := &undoLog{}
+	defer func() {
+		if r := recover(); r != nil {
+			log.rollback()
+			panic(r)
+		}
+		if err != nil {
+			log.rollback()
+		}
+	}()
+	return fn(context.WithValue(ctx, undoKey{}, log))
+}
+
+func (l *undoLog) rollback() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i := len(l.undos) - 1; i >= 0; i-- {
+		l.undos[i]()
+	}
+	l.undos = nil
+}
@@ -0,0 +1,80 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_memoryTransactor_WithinTransaction(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func(ctx context.Context, state *[]int) error
+		panics  bool
+		want    []int
+		wantErr bool
+	}{{
+		name: "Normal Case 1: changes are kept",
+		fn: func(ctx context.Context, state *[]int) error {
+			push(ctx, state, 1)
+			push(ctx, state, 2)
+			return nil
+		},
+		want: []int{0, 1, 2},
+	}, {
+		name: "error undoes the changes",
+		fn: func(ctx context.Context, state *[]int) error {
+			push(ctx, state, 1)
+			push(ctx, state, 2)
+			return errors.New("usecase error")
+		},
+		want:    []int{0},
+		wantErr: true,
+	}, {
+		name: "panic undoes the changes",
+		fn: func(ctx context.Context, state *[]int) error {
+			push(ctx, state, 1)
+			panic("boom")
+		},
+		panics: true,
+		want:   []int{0},
+	}, {
+		name: "nested call joins the outer transaction",
+		fn: func(ctx context.Context, state *[]int) error {
+			NewMemoryTransactor().WithinTransaction(ctx, func(ctx context.Context) error {
+				push(ctx, state, 1)
+				return nil
+			})
+			return errors.New("usecase error")
+		},
+		want:    []int{0},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := []int{0}
+			defer func() {
+				if r := recover(); (r != nil) != tt.panics {
+					t.Errorf("Test %s - recovered %v, panics %v", tt.name, r, tt.panics)
+				}
+				if !reflect.DeepEqual(state, tt.want) {
+					t.Errorf("Test %s - state = %v, want %v", tt.name, state, tt.want)
+				}
+			}()
+			err := NewMemoryTransactor().WithinTransaction(context.Background(), func(ctx context.Context) error {
+				return tt.fn(ctx, &state)
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// push appends v to state the way an in-memory repository would
+func push(ctx context.Context, state *[]int, v int) {
+	n := len(*state)
+	*state = append(*state, v)
+	OnRollback(ctx, func() { *state = (*state)[:n] })
+}
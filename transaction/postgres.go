@@ -0,0 +1,56 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+type txKey struct{}
+
+// Executor is satisfied by both *sql.DB and *sql.Tx
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type postgresTransactor struct {
+	*sql.DB
+}
+
+// NewPostgresTransactor will create an object that represent the core.Transactor interface
+func NewPostgresTransactor(db *sql.DB) core.Transactor {
+	return &postgresTransactor{db}
+}
+
+// Conn returns the transaction carried by ctx, or db when there is none
+func Conn(ctx context.Context, db *sql.DB) Executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+func (p *postgresTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	return fn(context.WithValue(ctx, txKey{}, tx))
+}
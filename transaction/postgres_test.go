@@ -0,0 +1,93 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_postgresTransactor_WithinTransaction(t *testing.T) {
+	tests := []struct {
+		name      string
+		fn        func(ctx context.Context) error
+		panics    bool
+		wantErr   bool
+		wantAbort bool
+	}{{
+		name: "Normal Case 1: commit",
+		fn: func(ctx context.Context) error {
+			if _, ok := Conn(ctx, nil).(*sql.Tx); !ok {
+				return errors.New("context does not carry the transaction")
+			}
+			return nil
+		},
+	}, {
+		name:      "error rolls back",
+		fn:        func(ctx context.Context) error { return errors.New("usecase error") },
+		wantErr:   true,
+		wantAbort: true,
+	}, {
+		name:      "panic rolls back",
+		fn:        func(ctx context.Context) error { panic("boom") },
+		panics:    true,
+		wantAbort: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectBegin()
+			if tt.wantAbort {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+			defer func() {
+				if r := recover(); (r != nil) != tt.panics {
+					t.Errorf("Test %s - recovered %v, panics %v", tt.name, r, tt.panics)
+				}
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("Test %s - %v", tt.name, err)
+				}
+			}()
+			err = NewPostgresTransactor(db).WithinTransaction(context.Background(), tt.fn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Test %s - error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresTransactor_nested(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	tr := NewPostgresTransactor(db)
+	err = tr.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		return tr.WithinTransaction(ctx, func(ctx context.Context) error { return nil })
+	})
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("nested call should join the outer transaction: %v", err)
+	}
+}
+
+func TestConn(t *testing.T) {
+	db := &sql.DB{}
+	if got := Conn(context.Background(), db); got != db {
+		t.Errorf("Conn() without a transaction = %v, want the db", got)
+	}
+}
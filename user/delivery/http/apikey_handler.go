@@ -0,0 +1,83 @@
+package http
+
+import (
+	nethttp "net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/user"
+)
+
+//APIKeyHandler represents http handler for API keys
+type APIKeyHandler struct {
+	UserUsecase user.Usecase
+}
+
+// NewAPIKeyHandler will initialize the apikeys/ resources endpoint, the requests
+// have to be authenticated with an access token, API keys can not manage keys
+func NewAPIKeyHandler(uu user.Usecase) nethttp.Handler {
+	r := chi.NewMux()
+	apiKeyHandler := &APIKeyHandler{
+		UserUsecase: uu,
+	}
+	r.Get("/", apiKeyHandler.List)
+	r.Post("/", apiKeyHandler.Create)
+	r.Delete("/{id:[0-9a-f]{32}}", apiKeyHandler.Revoke)
+	return r
+}
+
+// writeKeyError answers the errors shared by the API key endpoints
+func writeKeyError(w nethttp.ResponseWriter, err error) {
+	switch err {
+	case core.ErrForbidden:
+		w.WriteHeader(nethttp.StatusForbidden)
+		w.Write([]byte("forbidden"))
+	case core.ErrRecordNotFound:
+		w.WriteHeader(nethttp.StatusNotFound)
+		w.Write([]byte("api key not found"))
+	default:
+		writeInternalError(w, err)
+	}
+}
+
+//List handler returns the API keys of the user, without their secret
+func (h *APIKeyHandler) List(w nethttp.ResponseWriter, r *nethttp.Request) {
+	keys, err := h.UserUsecase.ListKeys(r.Context())
+	if err != nil {
+		writeKeyError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message":  "success",
+		"api_keys": keys,
+	})
+}
+
+//Create handler creates an API key on the workspace of the request,
+//the key is only ever returned by this response
+func (h *APIKeyHandler) Create(w nethttp.ResponseWriter, r *nethttp.Request) {
+	k := &models.APIKey{}
+	if !decode(w, r, k) {
+		return
+	}
+	if err := h.UserUsecase.CreateKey(r.Context(), k); err != nil {
+		writeKeyError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, nethttp.StatusCreated, map[string]interface{}{
+		"message": "success",
+		"api_key": k,
+	})
+}
+
+//Revoke handler revokes an API key, it is refused from then on
+func (h *APIKeyHandler) Revoke(w nethttp.ResponseWriter, r *nethttp.Request) {
+	if err := h.UserUsecase.RevokeKey(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeKeyError(w, err)
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	w.Write([]byte("success"))
+}
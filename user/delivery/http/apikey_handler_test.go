@@ -0,0 +1,126 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/user"
+	"github.com/pratheeshm/todo-golang/user/mocks"
+)
+
+func TestNewAPIKeyHandler(t *testing.T) {
+	h := NewAPIKeyHandler(&mocks.MockUsecase{})
+	id := "0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		method     string
+		url        string
+		body       string
+		statusCode int
+	}{
+		{"GET", "/", "", 200},
+		{"POST", "/", `{"name":"ci","scope":"read-only"}`, 201},
+		{"DELETE", "/" + id, "", 200},
+		{"DELETE", "/abc", "", 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Errorf("got statuscode %d but expected %d", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestAPIKeyHandler_Create(t *testing.T) {
+	body := `{"name":"ci","scope":"read-write"}`
+	tests := []struct {
+		name       string
+		usecase    user.Usecase
+		body       string
+		statusCode int
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{},
+		body:       body,
+		statusCode: 201,
+	}, {
+		name:       "invalid body",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{"name":`,
+		statusCode: 400,
+	}, {
+		name:       "invalid scope",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{"name":"ci","scope":"root"}`,
+		statusCode: 400,
+	}, {
+		name:       "missing name",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{"scope":"admin"}`,
+		statusCode: 400,
+	}, {
+		name:       "created with an API key",
+		usecase:    &mocks.MockUsecase{Error: core.ErrForbidden},
+		body:       body,
+		statusCode: 403,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("db error")},
+		body:       body,
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h := &APIKeyHandler{UserUsecase: tt.usecase}
+			h.Create(rec, httptest.NewRequest("POST", "/", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if rec.Code != 201 {
+				return
+			}
+			var got struct {
+				APIKey *models.APIKey `json:"api_key"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("can not decode response: %v", err)
+			}
+			if got.APIKey == nil || got.APIKey.Key == "" {
+				t.Errorf("expected the key in the response, got %+v", got.APIKey)
+			}
+			if rec.Header().Get("Cache-Control") != "no-store" {
+				t.Errorf("the response with the key must not be cached")
+			}
+		})
+	}
+}
+
+func TestAPIKeyHandler_Revoke(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    user.Usecase
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, statusCode: 200},
+		{name: "unknown key", usecase: &mocks.MockUsecase{Error: core.ErrRecordNotFound}, statusCode: 404},
+		{name: "revoked with an API key", usecase: &mocks.MockUsecase{Error: core.ErrForbidden}, statusCode: 403},
+		{name: "usecase error", usecase: &mocks.MockUsecase{Error: errors.New("db error")}, statusCode: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewAPIKeyHandler(tt.usecase).ServeHTTP(rec, httptest.NewRequest("DELETE", "/0123456789abcdef0123456789abcdef", nil))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	nethttp "net/http"
+	"strings"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/user"
+	"github.com/sirupsen/logrus"
+)
+
+// bearerToken returns the token of the Authorization header
+func bearerToken(r *nethttp.Request) (string, bool) {
+	parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") || parts[1] == "" {
+		return "", false
+	}
+	return strings.TrimSpace(parts[1]), true
+}
+
+func writeUnauthorized(w nethttp.ResponseWriter, challenge string) {
+	w.Header().Set("WWW-Authenticate", challenge)
+	w.WriteHeader(nethttp.StatusUnauthorized)
+	w.Write([]byte("unauthorized"))
+}
+
+// NewAuthMiddleware will create a middleware only letting through the requests sent with a
+// valid access token or API key as "Authorization: Bearer <token>". The user of the token is
+// put in the request context and the context is scoped to the workspace of the user,
+// see core.UserFromContext and core.TenantFromContext
+func NewAuthMiddleware(uu user.Usecase) func(nethttp.Handler) nethttp.Handler {
+	return func(next nethttp.Handler) nethttp.Handler {
+		return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				writeUnauthorized(w, "Bearer")
+				return
+			}
+			u, err := uu.Authenticate(r.Context(), token)
+			if err == core.ErrInvalidToken {
+				writeUnauthorized(w, `Bearer error="invalid_token"`)
+				return
+			}
+			if err != nil {
+				logrus.Error(err)
+				w.WriteHeader(nethttp.StatusInternalServerError)
+				w.Write([]byte("internal server error"))
+				return
+			}
+			ctx := core.WithTenant(core.WithUser(r.Context(), u), u.WorkspaceID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/user"
+	"github.com/pratheeshm/todo-golang/user/mocks"
+)
+
+func TestNewAuthMiddleware(t *testing.T) {
+	alice := &models.User{ID: 1, Email: "alice@example.com", WorkspaceID: 4}
+	tests := []struct {
+		name          string
+		usecase       user.Usecase
+		authorization string
+		statusCode    int
+		challenge     string
+	}{{
+		name:          "Success case",
+		usecase:       &mocks.MockUsecase{User: alice},
+		authorization: "Bearer access",
+		statusCode:    200,
+	}, {
+		name:          "lower case scheme",
+		usecase:       &mocks.MockUsecase{User: alice},
+		authorization: "bearer access",
+		statusCode:    200,
+	}, {
+		name:       "no token",
+		usecase:    &mocks.MockUsecase{User: alice},
+		statusCode: 401,
+		challenge:  "Bearer",
+	}, {
+		name:          "basic auth",
+		usecase:       &mocks.MockUsecase{User: alice},
+		authorization: "Basic YWxpY2U6cGFzcw==",
+		statusCode:    401,
+		challenge:     "Bearer",
+	}, {
+		name:          "invalid token",
+		usecase:       &mocks.MockUsecase{Error: core.ErrInvalidToken},
+		authorization: "Bearer expired",
+		statusCode:    401,
+		challenge:     `Bearer error="invalid_token"`,
+	}, {
+		name:          "usecase error",
+		usecase:       &mocks.MockUsecase{Error: errors.New("db error")},
+		authorization: "Bearer access",
+		statusCode:    500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *models.User
+			var tenant int
+			h := NewAuthMiddleware(tt.usecase)(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+				got, _ = core.UserFromContext(r.Context())
+				tenant, _ = core.TenantFromContext(r.Context())
+			}))
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/list", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if c := rec.Header().Get("WWW-Authenticate"); c != tt.challenge {
+				t.Errorf("WWW-Authenticate = %q, want %q", c, tt.challenge)
+			}
+			if tt.statusCode == 200 && (got != alice || tenant != alice.WorkspaceID) {
+				t.Errorf("user in context = %v of workspace %d, want %v", got, tenant, alice)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/user"
+	"github.com/sirupsen/logrus"
+)
+
+//UserHandler represents http handler for user accounts
+type UserHandler struct {
+	UserUsecase user.Usecase
+}
+
+// NewUserHandler will initialize the auth/ resources endpoint,
+// every endpoint but logout is reachable without a token
+func NewUserHandler(uu user.Usecase) nethttp.Handler {
+	r := chi.NewMux()
+	userHandler := &UserHandler{
+		UserUsecase: uu,
+	}
+	r.Post("/register", userHandler.Register)
+	r.Post("/login", userHandler.Login)
+	r.Post("/refresh", userHandler.Refresh)
+	r.With(NewAuthMiddleware(uu)).Post("/logout", userHandler.Logout)
+	return r
+}
+
+func writeJSON(w nethttp.ResponseWriter, status int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	res, _ := json.Marshal(body)
+	w.Write(res)
+}
+
+func writeInternalError(w nethttp.ResponseWriter, err error) {
+	logrus.Error(err)
+	w.WriteHeader(nethttp.StatusInternalServerError)
+	w.Write([]byte("internal server error"))
+}
+
+// decode reads and validates the request body into v
+func decode(w nethttp.ResponseWriter, r *nethttp.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return false
+	}
+	validate := validator.New()
+	if err := validate.Struct(v); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return false
+	}
+	return true
+}
+
+//Register handler creates a user
+func (h *UserHandler) Register(w nethttp.ResponseWriter, r *nethttp.Request) {
+	c := &models.Credentials{}
+	if !decode(w, r, c) {
+		return
+	}
+	u, err := h.UserUsecase.Register(r.Context(), c)
+	if err == core.ErrAlreadyExists {
+		w.WriteHeader(nethttp.StatusConflict)
+		w.Write([]byte("email already registered"))
+		return
+	}
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusCreated, map[string]interface{}{
+		"message": "success",
+		"user":    u,
+	})
+}
+
+//Login handler returns an access and a refresh token
+func (h *UserHandler) Login(w nethttp.ResponseWriter, r *nethttp.Request) {
+	c := &models.Credentials{}
+	if !decode(w, r, c) {
+		return
+	}
+	tokens, err := h.UserUsecase.Login(r.Context(), c)
+	if err == core.ErrInvalidCredentials {
+		w.WriteHeader(nethttp.StatusUnauthorized)
+		w.Write([]byte("invalid credentials"))
+		return
+	}
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"tokens":  tokens,
+	})
+}
+
+//Refresh handler exchanges a refresh token for a new pair of tokens
+func (h *UserHandler) Refresh(w nethttp.ResponseWriter, r *nethttp.Request) {
+	body := &struct {
+		RefreshToken string `json:"refresh_token" validate:"required,max=200"`
+	}{}
+	if !decode(w, r, body) {
+		return
+	}
+	tokens, err := h.UserUsecase.Refresh(r.Context(), body.RefreshToken)
+	if err == core.ErrInvalidToken {
+		w.WriteHeader(nethttp.StatusUnauthorized)
+		w.Write([]byte("invalid token"))
+		return
+	}
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"tokens":  tokens,
+	})
+}
+
+//Logout handler revokes the session of the access token
+func (h *UserHandler) Logout(w nethttp.ResponseWriter, r *nethttp.Request) {
+	token, _ := bearerToken(r)
+	if err := h.UserUsecase.Logout(r.Context(), token); err != nil {
+		if err == core.ErrInvalidToken {
+			w.WriteHeader(nethttp.StatusUnauthorized)
+			w.Write([]byte("invalid token"))
+			return
+		}
+		writeInternalError(w, err)
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	w.Write([]byte("success"))
+}
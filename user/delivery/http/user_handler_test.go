@@ -0,0 +1,213 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/user"
+	"github.com/pratheeshm/todo-golang/user/mocks"
+)
+
+func TestNewUserHandler(t *testing.T) {
+	h := NewUserHandler(&mocks.MockUsecase{User: &models.User{ID: 1}, Tokens: &models.TokenPair{}})
+	tests := []struct {
+		url        string
+		body       string
+		token      string
+		statusCode int
+	}{
+		{"/register", `{"email":"alice@example.com","password":"correct horse"}`, "", 201},
+		{"/login", `{"email":"alice@example.com","password":"correct horse"}`, "", 200},
+		{"/refresh", `{"refresh_token":"abc.def"}`, "", 200},
+		{"/logout", "", "access", 200},
+		{"/logout", "", "", 401},
+		{"/abc", "", "", 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", tt.url, bytes.NewBufferString(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.statusCode {
+				t.Errorf("got statuscode %d but expected %d", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestUserHandler_Register(t *testing.T) {
+	body := `{"email":"alice@example.com","password":"correct horse"}`
+	tests := []struct {
+		name       string
+		usecase    user.Usecase
+		body       string
+		statusCode int
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{User: &models.User{ID: 1, Email: "alice@example.com", PasswordHash: "hash"}},
+		body:       body,
+		statusCode: 201,
+	}, {
+		name:       "invalid body",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{"email":`,
+		statusCode: 400,
+	}, {
+		name:       "invalid email",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{"email":"alice","password":"correct horse"}`,
+		statusCode: 400,
+	}, {
+		name:       "short password",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{"email":"alice@example.com","password":"short"}`,
+		statusCode: 400,
+	}, {
+		name:       "email taken",
+		usecase:    &mocks.MockUsecase{Error: core.ErrAlreadyExists},
+		body:       body,
+		statusCode: 409,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("db error")},
+		body:       body,
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &UserHandler{UserUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Register(rec, httptest.NewRequest("POST", "/register", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if tt.statusCode == 201 && bytes.Contains(rec.Body.Bytes(), []byte("hash")) {
+				t.Errorf("the password hash must not be returned, got %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestUserHandler_Login(t *testing.T) {
+	body := `{"email":"alice@example.com","password":"correct horse"}`
+	tokens := &models.TokenPair{AccessToken: "access", RefreshToken: "refresh", TokenType: models.TokenTypeBearer, ExpiresIn: 900}
+	tests := []struct {
+		name       string
+		usecase    user.Usecase
+		body       string
+		statusCode int
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{Tokens: tokens},
+		body:       body,
+		statusCode: 200,
+	}, {
+		name:       "invalid body",
+		usecase:    &mocks.MockUsecase{},
+		body:       `[]`,
+		statusCode: 400,
+	}, {
+		name:       "invalid credentials",
+		usecase:    &mocks.MockUsecase{Error: core.ErrInvalidCredentials},
+		body:       body,
+		statusCode: 401,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("db error")},
+		body:       body,
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &UserHandler{UserUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Login(rec, httptest.NewRequest("POST", "/login", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if tt.statusCode != 200 {
+				return
+			}
+			res := struct {
+				Tokens *models.TokenPair `json:"tokens"`
+			}{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			if *res.Tokens != *tokens {
+				t.Errorf("tokens = %v, want %v", res.Tokens, tokens)
+			}
+		})
+	}
+}
+
+func TestUserHandler_Refresh(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    user.Usecase
+		body       string
+		statusCode int
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{Tokens: &models.TokenPair{}},
+		body:       `{"refresh_token":"abc.def"}`,
+		statusCode: 200,
+	}, {
+		name:       "missing token",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{}`,
+		statusCode: 400,
+	}, {
+		name:       "invalid token",
+		usecase:    &mocks.MockUsecase{Error: core.ErrInvalidToken},
+		body:       `{"refresh_token":"abc.def"}`,
+		statusCode: 401,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("db error")},
+		body:       `{"refresh_token":"abc.def"}`,
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &UserHandler{UserUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Refresh(rec, httptest.NewRequest("POST", "/refresh", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestUserHandler_Logout(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    user.Usecase
+		statusCode int
+	}{
+		{name: "Success case", usecase: &mocks.MockUsecase{}, statusCode: 200},
+		{name: "invalid token", usecase: &mocks.MockUsecase{Error: core.ErrInvalidToken}, statusCode: 401},
+		{name: "usecase error", usecase: &mocks.MockUsecase{Error: errors.New("db error")}, statusCode: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &UserHandler{UserUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/logout", nil)
+			req.Header.Set("Authorization", "Bearer access")
+			h.Logout(rec, req)
+			if rec.Code != tt.statusCode {
+				t.Errorf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
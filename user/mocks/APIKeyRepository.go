@@ -0,0 +1,83 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockAPIKeyRepository implements inerface user.APIKeyRepository
+type MockAPIKeyRepository struct {
+	Error error
+	Keys  map[string]*models.APIKey
+}
+
+//Add stores a copy of the key in Keys
+func (m *MockAPIKeyRepository) Add(ctx context.Context, k *models.APIKey) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	if m.Keys == nil {
+		m.Keys = map[string]*models.APIKey{}
+	}
+	stored := *k
+	m.Keys[k.ID] = &stored
+	return nil
+}
+
+//Get returns a copy of the key of Keys with the id
+func (m *MockAPIKeyRepository) Get(ctx context.Context, id string) (*models.APIKey, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	k, ok := m.Keys[id]
+	if !ok {
+		return nil, core.ErrRecordNotFound
+	}
+	found := *k
+	return &found, nil
+}
+
+//List returns the keys of Keys of the user
+func (m *MockAPIKeyRepository) List(ctx context.Context, userID int) ([]*models.APIKey, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	keys := []*models.APIKey{}
+	for _, k := range m.Keys {
+		if k.UserID == userID {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+//Revoke sets RevokedAt on the stored key of the user
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, userID int, id string) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	k, ok := m.Keys[id]
+	if !ok || k.UserID != userID {
+		return core.ErrRecordNotFound
+	}
+	if k.RevokedAt == nil {
+		now := time.Now()
+		k.RevokedAt = &now
+	}
+	return nil
+}
+
+//Touch sets LastUsedAt on the stored key
+func (m *MockAPIKeyRepository) Touch(ctx context.Context, id string) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	if k, ok := m.Keys[id]; ok {
+		now := time.Now()
+		k.LastUsedAt = &now
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockRepository implements inerface user.Repository
+type MockRepository struct {
+	Error error
+	Users []*models.User
+}
+
+//Add appends the user to Users unless the email is taken, every user gets a workspace of the same id
+func (m *MockRepository) Add(ctx context.Context, u *models.User) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	for _, existing := range m.Users {
+		if existing.Email == u.Email {
+			return core.ErrAlreadyExists
+		}
+	}
+	u.ID = len(m.Users) + 1
+	u.WorkspaceID = u.ID
+	m.Users = append(m.Users, u)
+	return nil
+}
+
+//Get returns the user of Users with the id
+func (m *MockRepository) Get(ctx context.Context, id int) (*models.User, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	for _, u := range m.Users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return nil, core.ErrRecordNotFound
+}
+
+//GetByEmail returns the user of Users with the email
+func (m *MockRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	for _, u := range m.Users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, core.ErrRecordNotFound
+}
+
+//SetFeedKey sets the feed key of the user of Users with the id
+func (m *MockRepository) SetFeedKey(ctx context.Context, id int, key string) error {
+	u, err := m.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	u.FeedKey = key
+	return nil
+}
@@ -0,0 +1,67 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockSessionRepository implements inerface user.SessionRepository
+type MockSessionRepository struct {
+	Error    error
+	Sessions map[string]*models.Session
+}
+
+//Add stores a copy of the session in Sessions
+func (m *MockSessionRepository) Add(ctx context.Context, s *models.Session) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	if m.Sessions == nil {
+		m.Sessions = map[string]*models.Session{}
+	}
+	stored := *s
+	m.Sessions[s.ID] = &stored
+	return nil
+}
+
+//Get returns a copy of the session of Sessions with the id
+func (m *MockSessionRepository) Get(ctx context.Context, id string) (*models.Session, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	s, ok := m.Sessions[id]
+	if !ok {
+		return nil, core.ErrRecordNotFound
+	}
+	found := *s
+	return &found, nil
+}
+
+//Rotate replaces the refresh hash of the stored session when it is still oldHash
+func (m *MockSessionRepository) Rotate(ctx context.Context, s *models.Session, oldHash string) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	stored, ok := m.Sessions[s.ID]
+	if !ok || stored.RefreshHash != oldHash || stored.RevokedAt != nil {
+		return core.ErrRecordNotFound
+	}
+	stored.RefreshHash = s.RefreshHash
+	stored.ExpiresAt = s.ExpiresAt
+	return nil
+}
+
+//Revoke sets RevokedAt on the stored session
+func (m *MockSessionRepository) Revoke(ctx context.Context, id string) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	if s, ok := m.Sessions[id]; ok && s.RevokedAt == nil {
+		now := time.Now()
+		s.RevokedAt = &now
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockUsecase implements inerface user.Usecase
+type MockUsecase struct {
+	Error  error
+	User   *models.User
+	Tokens *models.TokenPair
+	Keys   []*models.APIKey
+	// Feed is the calendar feed key of every user
+	Feed string
+}
+
+//Register returns User
+func (m *MockUsecase) Register(ctx context.Context, c *models.Credentials) (*models.User, error) {
+	return m.User, m.Error
+}
+
+//Login returns Tokens
+func (m *MockUsecase) Login(ctx context.Context, c *models.Credentials) (*models.TokenPair, error) {
+	return m.Tokens, m.Error
+}
+
+//Refresh returns Tokens
+func (m *MockUsecase) Refresh(ctx context.Context, refreshToken string) (*models.TokenPair, error) {
+	return m.Tokens, m.Error
+}
+
+//Logout returns Error
+func (m *MockUsecase) Logout(ctx context.Context, accessToken string) error {
+	return m.Error
+}
+
+//Authenticate returns User
+func (m *MockUsecase) Authenticate(ctx context.Context, accessToken string) (*models.User, error) {
+	return m.User, m.Error
+}
+
+//CreateKey sets the id and the key of the API key
+func (m *MockUsecase) CreateKey(ctx context.Context, k *models.APIKey) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	k.ID = "abc"
+	k.Key = "tdk_abc.secret"
+	return nil
+}
+
+//ListKeys returns Keys
+func (m *MockUsecase) ListKeys(ctx context.Context) ([]*models.APIKey, error) {
+	return m.Keys, m.Error
+}
+
+//RevokeKey API key
+func (m *MockUsecase) RevokeKey(ctx context.Context, id string) error {
+	return m.Error
+}
+
+//FeedKey returns Feed
+func (m *MockUsecase) FeedKey(ctx context.Context, id int) (string, error) {
+	return m.Feed, m.Error
+}
+
+//RotateFeedKey replaces Feed
+func (m *MockUsecase) RotateFeedKey(ctx context.Context) (string, error) {
+	if m.Error != nil {
+		return "", m.Error
+	}
+	m.Feed += "-rotated"
+	return m.Feed, nil
+}
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Repository represents user's interface
+type Repository interface {
+	// Add stores the user in a new workspace, core.ErrAlreadyExists is returned when the email is taken
+	Add(context.Context, *models.User) error
+	Get(ctx context.Context, id int) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	// SetFeedKey replaces the calendar feed key of the user
+	SetFeedKey(ctx context.Context, id int, key string) error
+}
+
+//SessionRepository represents the interface of the login sessions
+type SessionRepository interface {
+	Add(context.Context, *models.Session) error
+	Get(ctx context.Context, id string) (*models.Session, error)
+	// Rotate replaces the refresh token of the session when its hash is still oldHash
+	// and the session is not revoked, core.ErrRecordNotFound is returned otherwise
+	Rotate(ctx context.Context, s *models.Session, oldHash string) error
+	// Revoke ends the session, revoking a revoked session is a no-op
+	Revoke(ctx context.Context, id string) error
+}
+
+//APIKeyRepository represents the interface of the API keys
+type APIKeyRepository interface {
+	Add(context.Context, *models.APIKey) error
+	Get(ctx context.Context, id string) (*models.APIKey, error)
+	// List returns the keys of the user, revoked ones included, newest first
+	List(ctx context.Context, userID int) ([]*models.APIKey, error)
+	// Revoke revokes the key of the user, core.ErrRecordNotFound is returned
+	// when the user has no such key. Revoking a revoked key is a no-op
+	Revoke(ctx context.Context, userID int, id string) error
+	// Touch records the use of the key, at most once a minute
+	Touch(ctx context.Context, id string) error
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
+	"github.com/pratheeshm/todo-golang/user"
+)
+
+const apiKeyColumns = "id_key, id_user, id_workspace, name, scope, secret_hash, last_used_at, revoked_at, created_at"
+
+type postgresAPIKeyRepository struct {
+	*sql.DB
+}
+
+// NewPostgresAPIKeyRepository will create an object that represent the user.APIKeyRepository interface
+func NewPostgresAPIKeyRepository(db *sql.DB) user.APIKeyRepository {
+	return &postgresAPIKeyRepository{db}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAPIKey(s scanner) (*models.APIKey, error) {
+	k := &models.APIKey{}
+	err := s.Scan(&k.ID, &k.UserID, &k.WorkspaceID, &k.Name, &k.Scope, &k.SecretHash, &k.LastUsedAt, &k.RevokedAt, &k.CreatedAt)
+	return k, err
+}
+
+func (p *postgresAPIKeyRepository) Add(ctx context.Context, k *models.APIKey) error {
+	return transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "INSERT INTO api_key(id_key, id_user, id_workspace, name, scope, secret_hash) VALUES ($1, $2, $3, $4, $5, $6) RETURNING created_at",
+		k.ID, k.UserID, k.WorkspaceID, k.Name, k.Scope, k.SecretHash).Scan(&k.CreatedAt)
+}
+
+func (p *postgresAPIKeyRepository) Get(ctx context.Context, id string) (*models.APIKey, error) {
+	row := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "SELECT "+apiKeyColumns+" FROM api_key WHERE id_key = $1", id)
+	k, err := scanAPIKey(row)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (p *postgresAPIKeyRepository) List(ctx context.Context, userID int) ([]*models.APIKey, error) {
+	keys := make([]*models.APIKey, 0)
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT "+apiKeyColumns+" FROM api_key WHERE id_user = $1 ORDER BY created_at DESC, id_key", userID)
+	if err != nil {
+		return keys, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return []*models.APIKey{}, err
+		}
+		keys = append(keys, k)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.APIKey{}, err
+	}
+	return keys, nil
+}
+
+func (p *postgresAPIKeyRepository) Revoke(ctx context.Context, userID int, id string) error {
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "UPDATE api_key SET revoked_at = COALESCE(revoked_at, now()) WHERE id_key = $1 AND id_user = $2", id, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresAPIKeyRepository) Touch(ctx context.Context, id string) error {
+	_, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "UPDATE api_key SET last_used_at = now() WHERE id_key = $1 AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')", id)
+	return err
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+var apiKeyRowColumns = []string{"id_key", "id_user", "id_workspace", "name", "scope", "secret_hash", "last_used_at", "revoked_at", "created_at"}
+
+func Test_postgresAPIKeyRepository_Add(t *testing.T) {
+	query := "INSERT INTO api_key(id_key, id_user, id_workspace, name, scope, secret_hash) VALUES ($1, $2, $3, $4, $5, $6) RETURNING created_at"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	k := &models.APIKey{ID: "abc", UserID: 1, WorkspaceID: 4, Name: "ci", Scope: models.ScopeReadWrite, SecretHash: "hash"}
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("abc", 1, 4, "ci", models.ScopeReadWrite, "hash").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(at))
+	if err := NewPostgresAPIKeyRepository(db).Add(context.Background(), k); err != nil {
+		t.Fatalf("postgresAPIKeyRepository.Add() error = %v", err)
+	}
+	if !k.CreatedAt.Equal(at) {
+		t.Errorf("postgresAPIKeyRepository.Add() created_at = %v, want %v", k.CreatedAt, at)
+	}
+}
+
+func Test_postgresAPIKeyRepository_Get(t *testing.T) {
+	query := "SELECT id_key, id_user, id_workspace, name, scope, secret_hash, last_used_at, revoked_at, created_at FROM api_key WHERE id_key = $1"
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *models.APIKey
+		wantErr error
+	}{{
+		name: "Normal Case 1: used key",
+		rows: sqlmock.NewRows(apiKeyRowColumns).AddRow("abc", 1, 4, "ci", "read-only", "hash", at, nil, at),
+		want: &models.APIKey{ID: "abc", UserID: 1, WorkspaceID: 4, Name: "ci", Scope: models.ScopeReadOnly,
+			SecretHash: "hash", LastUsedAt: &at, CreatedAt: at},
+	}, {
+		name:    "key not found",
+		rows:    sqlmock.NewRows(apiKeyRowColumns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("abc").WillReturnRows(tt.rows)
+			got, err := NewPostgresAPIKeyRepository(db).Get(context.Background(), "abc")
+			if err != tt.wantErr {
+				t.Fatalf("postgresAPIKeyRepository.Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postgresAPIKeyRepository.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresAPIKeyRepository_List(t *testing.T) {
+	query := "SELECT id_key, id_user, id_workspace, name, scope, secret_hash, last_used_at, revoked_at, created_at FROM api_key WHERE id_user = $1 ORDER BY created_at DESC, id_key"
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbErr   error
+		want    int
+		wantErr bool
+	}{{
+		name: "Normal Case 1: keys of the user",
+		rows: sqlmock.NewRows(apiKeyRowColumns).
+			AddRow("abc", 1, 4, "ci", "read-only", "hash", nil, nil, at).
+			AddRow("def", 1, 4, "bot", "admin", "hash", at, at, at),
+		want: 2,
+	}, {
+		name:    "db error",
+		dbErr:   errors.New("db error"),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			exp := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1)
+			if tt.dbErr != nil {
+				exp.WillReturnError(tt.dbErr)
+			} else {
+				exp.WillReturnRows(tt.rows)
+			}
+			got, err := NewPostgresAPIKeyRepository(db).List(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("postgresAPIKeyRepository.List() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("postgresAPIKeyRepository.List() = %d keys, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresAPIKeyRepository_Revoke(t *testing.T) {
+	query := "UPDATE api_key SET revoked_at = COALESCE(revoked_at, now()) WHERE id_key = $1 AND id_user = $2"
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{{
+		name: "Normal Case 1: key revoked",
+		rows: 1,
+	}, {
+		name:    "key of another user",
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs("abc", 1).WillReturnResult(sqlmock.NewResult(0, tt.rows))
+			if err := NewPostgresAPIKeyRepository(db).Revoke(context.Background(), 1, "abc"); err != tt.wantErr {
+				t.Errorf("postgresAPIKeyRepository.Revoke() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresAPIKeyRepository_Touch(t *testing.T) {
+	query := "UPDATE api_key SET last_used_at = now() WHERE id_key = $1 AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs("abc").WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := NewPostgresAPIKeyRepository(db).Touch(context.Background(), "abc"); err != nil {
+		t.Errorf("postgresAPIKeyRepository.Touch() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
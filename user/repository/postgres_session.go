@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
+	"github.com/pratheeshm/todo-golang/user"
+)
+
+type postgresSessionRepository struct {
+	*sql.DB
+}
+
+// NewPostgresSessionRepository will create an object that represent the user.SessionRepository interface
+func NewPostgresSessionRepository(db *sql.DB) user.SessionRepository {
+	return &postgresSessionRepository{db}
+}
+
+func (p *postgresSessionRepository) Add(ctx context.Context, s *models.Session) error {
+	return transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "INSERT INTO user_session(id_session, id_user, refresh_hash, expires_at) VALUES ($1, $2, $3, $4) RETURNING created_at",
+		s.ID, s.UserID, s.RefreshHash, s.ExpiresAt).Scan(&s.CreatedAt)
+}
+
+func (p *postgresSessionRepository) Get(ctx context.Context, id string) (*models.Session, error) {
+	s := &models.Session{}
+	err := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "SELECT id_session, id_user, refresh_hash, expires_at, revoked_at, created_at FROM user_session WHERE id_session = $1",
+		id).Scan(&s.ID, &s.UserID, &s.RefreshHash, &s.ExpiresAt, &s.RevokedAt, &s.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (p *postgresSessionRepository) Rotate(ctx context.Context, s *models.Session, oldHash string) error {
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "UPDATE user_session SET refresh_hash = $1, expires_at = $2 WHERE id_session = $3 AND refresh_hash = $4 AND revoked_at IS NULL",
+		s.RefreshHash, s.ExpiresAt, s.ID, oldHash)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresSessionRepository) Revoke(ctx context.Context, id string) error {
+	_, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "UPDATE user_session SET revoked_at = now() WHERE id_session = $1 AND revoked_at IS NULL", id)
+	return err
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func Test_postgresSessionRepository_Add(t *testing.T) {
+	query := "INSERT INTO user_session(id_session, id_user, refresh_hash, expires_at) VALUES ($1, $2, $3, $4) RETURNING created_at"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	s := &models.Session{ID: "abc", UserID: 1, RefreshHash: "hash", ExpiresAt: at}
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("abc", 1, "hash", at).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(at))
+	if err := NewPostgresSessionRepository(db).Add(context.Background(), s); err != nil {
+		t.Fatalf("postgresSessionRepository.Add() error = %v", err)
+	}
+	if !s.CreatedAt.Equal(at) {
+		t.Errorf("postgresSessionRepository.Add() created_at = %v, want %v", s.CreatedAt, at)
+	}
+}
+
+func Test_postgresSessionRepository_Get(t *testing.T) {
+	query := "SELECT id_session, id_user, refresh_hash, expires_at, revoked_at, created_at FROM user_session WHERE id_session = $1"
+	columns := []string{"id_session", "id_user", "refresh_hash", "expires_at", "revoked_at", "created_at"}
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *models.Session
+		wantErr error
+	}{{
+		name: "Normal Case 1: revoked session",
+		rows: sqlmock.NewRows(columns).AddRow("abc", 1, "hash", at, at, at),
+		want: &models.Session{ID: "abc", UserID: 1, RefreshHash: "hash", ExpiresAt: at, RevokedAt: &at, CreatedAt: at},
+	}, {
+		name:    "session not found",
+		rows:    sqlmock.NewRows(columns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("abc").WillReturnRows(tt.rows)
+			got, err := NewPostgresSessionRepository(db).Get(context.Background(), "abc")
+			if err != tt.wantErr {
+				t.Fatalf("postgresSessionRepository.Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postgresSessionRepository.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresSessionRepository_Rotate(t *testing.T) {
+	query := "UPDATE user_session SET refresh_hash = $1, expires_at = $2 WHERE id_session = $3 AND refresh_hash = $4 AND revoked_at IS NULL"
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{{
+		name: "Normal Case 1: token rotated",
+		rows: 1,
+	}, {
+		name:    "token already rotated",
+		rows:    0,
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs("new", at, "abc", "old").
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+			s := &models.Session{ID: "abc", RefreshHash: "new", ExpiresAt: at}
+			if err := NewPostgresSessionRepository(db).Rotate(context.Background(), s, "old"); err != tt.wantErr {
+				t.Errorf("postgresSessionRepository.Rotate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresSessionRepository_Revoke(t *testing.T) {
+	query := "UPDATE user_session SET revoked_at = now() WHERE id_session = $1 AND revoked_at IS NULL"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs("abc").WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := NewPostgresSessionRepository(db).Revoke(context.Background(), "abc"); err != nil {
+		t.Errorf("postgresSessionRepository.Revoke() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
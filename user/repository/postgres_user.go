@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
+	"github.com/pratheeshm/todo-golang/user"
+)
+
+// uniqueViolation is the postgres error code of a duplicate key
+const uniqueViolation = "23505"
+
+type postgresUserRepository struct {
+	*sql.DB
+}
+
+// NewPostgresUserRepository will create an object that represent the user.Repository interface
+func NewPostgresUserRepository(db *sql.DB) user.Repository {
+	return &postgresUserRepository{db}
+}
+
+// Add creates the workspace of the user along with it, both are named after the email.
+// The user is the admin of its workspace
+func (p *postgresUserRepository) Add(ctx context.Context, u *models.User) error {
+	err := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "WITH w AS (INSERT INTO workspace(name) VALUES ($1) RETURNING id_workspace), u AS (INSERT INTO app_user(email, password_hash, id_workspace) SELECT $1, $2, id_workspace FROM w RETURNING id_user, id_workspace, created_at), g AS (INSERT INTO role_grant(id_workspace, id_user, role) SELECT id_workspace, id_user, $3 FROM u) SELECT id_user, id_workspace, created_at FROM u",
+		u.Email, u.PasswordHash, models.RoleAdmin).Scan(&u.ID, &u.WorkspaceID, &u.CreatedAt)
+	if perr, ok := err.(*pq.Error); ok && perr.Code == uniqueViolation {
+		return core.ErrAlreadyExists
+	}
+	return err
+}
+
+func (p *postgresUserRepository) Get(ctx context.Context, id int) (*models.User, error) {
+	return p.get(ctx, "id_user", id)
+}
+
+func (p *postgresUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	return p.get(ctx, "email", email)
+}
+
+// get returns the user whose column equals value, column is never user input
+func (p *postgresUserRepository) get(ctx context.Context, column string, value interface{}) (*models.User, error) {
+	u := &models.User{}
+	err := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "SELECT id_user, email, password_hash, id_workspace, feed_key, created_at FROM app_user WHERE "+column+" = $1",
+		value).Scan(&u.ID, &u.Email, &u.PasswordHash, &u.WorkspaceID, &u.FeedKey, &u.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (p *postgresUserRepository) SetFeedKey(ctx context.Context, id int, key string) error {
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "UPDATE app_user SET feed_key = $1 WHERE id_user = $2", key, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return nil
+}
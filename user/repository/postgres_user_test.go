@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+func Test_postgresUserRepository_Add(t *testing.T) {
+	query := "WITH w AS (INSERT INTO workspace(name) VALUES ($1) RETURNING id_workspace), u AS (INSERT INTO app_user(email, password_hash, id_workspace) SELECT $1, $2, id_workspace FROM w RETURNING id_user, id_workspace, created_at), g AS (INSERT INTO role_grant(id_workspace, id_user, role) SELECT id_workspace, id_user, $3 FROM u) SELECT id_user, id_workspace, created_at FROM u"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		err     error
+		wantID  int
+		wantWS  int
+		wantErr error
+	}{{
+		name:   "Normal Case 1: user stored",
+		rows:   sqlmock.NewRows([]string{"id_user", "id_workspace", "created_at"}).AddRow(7, 4, time.Time{}),
+		wantID: 7,
+		wantWS: 4,
+	}, {
+		name:    "email taken",
+		err:     &pq.Error{Code: uniqueViolation},
+		wantErr: core.ErrAlreadyExists,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			expect := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("alice@example.com", "hash", models.RoleAdmin)
+			if tt.err != nil {
+				expect.WillReturnError(tt.err)
+			} else {
+				expect.WillReturnRows(tt.rows)
+			}
+			u := &models.User{Email: "alice@example.com", PasswordHash: "hash"}
+			if err := NewPostgresUserRepository(db).Add(context.Background(), u); err != tt.wantErr {
+				t.Fatalf("postgresUserRepository.Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if u.ID != tt.wantID || u.WorkspaceID != tt.wantWS {
+				t.Errorf("postgresUserRepository.Add() id = %d workspace = %d, want %d %d", u.ID, u.WorkspaceID, tt.wantID, tt.wantWS)
+			}
+		})
+	}
+}
+
+func Test_postgresUserRepository_Get(t *testing.T) {
+	columns := []string{"id_user", "email", "password_hash", "id_workspace", "feed_key", "created_at"}
+	tests := []struct {
+		name    string
+		query   string
+		get     func(ctx context.Context, r *postgresUserRepository) (*models.User, error)
+		arg     interface{}
+		rows    *sqlmock.Rows
+		err     error
+		want    *models.User
+		wantErr error
+	}{{
+		name:  "Normal Case 1: by email",
+		query: "SELECT id_user, email, password_hash, id_workspace, feed_key, created_at FROM app_user WHERE email = $1",
+		get: func(ctx context.Context, r *postgresUserRepository) (*models.User, error) {
+			return r.GetByEmail(ctx, "alice@example.com")
+		},
+		arg:  "alice@example.com",
+		rows: sqlmock.NewRows(columns).AddRow(1, "alice@example.com", "hash", 4, "", time.Time{}),
+		want: &models.User{ID: 1, Email: "alice@example.com", PasswordHash: "hash", WorkspaceID: 4},
+	}, {
+		name:  "by id",
+		query: "SELECT id_user, email, password_hash, id_workspace, feed_key, created_at FROM app_user WHERE id_user = $1",
+		get: func(ctx context.Context, r *postgresUserRepository) (*models.User, error) {
+			return r.Get(ctx, 1)
+		},
+		arg:  1,
+		rows: sqlmock.NewRows(columns).AddRow(1, "alice@example.com", "hash", 4, "key", time.Time{}),
+		want: &models.User{ID: 1, Email: "alice@example.com", PasswordHash: "hash", WorkspaceID: 4, FeedKey: "key"},
+	}, {
+		name:  "user not found",
+		query: "SELECT id_user, email, password_hash, id_workspace, feed_key, created_at FROM app_user WHERE email = $1",
+		get: func(ctx context.Context, r *postgresUserRepository) (*models.User, error) {
+			return r.GetByEmail(ctx, "bob@example.com")
+		},
+		arg:     "bob@example.com",
+		rows:    sqlmock.NewRows(columns),
+		wantErr: core.ErrRecordNotFound,
+	}, {
+		name:  "db error",
+		query: "SELECT id_user, email, password_hash, id_workspace, feed_key, created_at FROM app_user WHERE email = $1",
+		get: func(ctx context.Context, r *postgresUserRepository) (*models.User, error) {
+			return r.GetByEmail(ctx, "bob@example.com")
+		},
+		arg:     "bob@example.com",
+		err:     errors.New("db error"),
+		wantErr: errors.New("db error"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			expect := mock.ExpectQuery(regexp.QuoteMeta(tt.query)).WithArgs(tt.arg)
+			if tt.err != nil {
+				expect.WillReturnError(tt.err)
+			} else {
+				expect.WillReturnRows(tt.rows)
+			}
+			got, err := tt.get(context.Background(), &postgresUserRepository{db})
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("postgresUserRepository.Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postgresUserRepository.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresUserRepository_SetFeedKey(t *testing.T) {
+	query := "UPDATE app_user SET feed_key = $1 WHERE id_user = $2"
+	tests := []struct {
+		name    string
+		result  driver.Result
+		err     error
+		wantErr error
+	}{
+		{name: "Normal Case 1", result: sqlmock.NewResult(0, 1)},
+		{name: "user not found", result: sqlmock.NewResult(0, 0), wantErr: core.ErrRecordNotFound},
+		{name: "db error", err: errors.New("db error"), wantErr: errors.New("db error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			expect := mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs("key", 1)
+			if tt.err != nil {
+				expect.WillReturnError(tt.err)
+			} else {
+				expect.WillReturnResult(tt.result)
+			}
+			err = (&postgresUserRepository{db}).SetFeedKey(context.Background(), 1, "key")
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("postgresUserRepository.SetFeedKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package user
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Usecase represents user's usecases
+type Usecase interface {
+	// Register creates the user, core.ErrAlreadyExists is returned when the email is taken
+	Register(context.Context, *models.Credentials) (*models.User, error)
+	// Login starts a session, core.ErrInvalidCredentials is returned when
+	// the email is unknown or the password does not match
+	Login(context.Context, *models.Credentials) (*models.TokenPair, error)
+	// Refresh exchanges a refresh token for a new pair, the old refresh token
+	// can not be used again. core.ErrInvalidToken is returned for a bad token
+	Refresh(ctx context.Context, refreshToken string) (*models.TokenPair, error)
+	// Logout revokes the session of the access token along with its refresh token
+	Logout(ctx context.Context, accessToken string) error
+	// Authenticate returns the user of a valid access token or API key,
+	// core.ErrInvalidToken is returned otherwise
+	Authenticate(ctx context.Context, accessToken string) (*models.User, error)
+	// CreateKey creates an API key of the user of the context on the workspace of
+	// the context, k.Key is the only copy of the secret. API keys can not create keys
+	CreateKey(ctx context.Context, k *models.APIKey) error
+	// ListKeys returns the API keys of the user of the context
+	ListKeys(context.Context) ([]*models.APIKey, error)
+	// RevokeKey revokes an API key of the user of the context
+	RevokeKey(ctx context.Context, id string) error
+	// FeedKey returns the calendar feed key of the user, it is empty until
+	// RotateFeedKey first creates one
+	FeedKey(ctx context.Context, id int) (string, error)
+	// RotateFeedKey gives the user of the context a new calendar feed key and
+	// returns it, the feed urls signed with the former one stop working
+	RotateFeedKey(context.Context) (string, error)
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/sirupsen/logrus"
+)
+
+// apiKeyPrefix tells API keys apart from access tokens, a key reads
+// "tdk_<id>.<secret>" where only the hash of the secret is stored
+const apiKeyPrefix = "tdk_"
+
+// keyOwner returns the user of ctx, who must have logged in to manage API keys
+func keyOwner(ctx context.Context) (*models.User, error) {
+	u, ok := core.UserFromContext(ctx)
+	if !ok || u.Scope != "" {
+		return nil, core.ErrForbidden
+	}
+	return u, nil
+}
+
+func (uu *userUsecase) CreateKey(ctx context.Context, k *models.APIKey) error {
+	u, err := keyOwner(ctx)
+	if err != nil {
+		return err
+	}
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if k.ID, err = randomHex(16); err != nil {
+		return err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return err
+	}
+	k.UserID = u.ID
+	k.WorkspaceID = workspace
+	k.SecretHash = hashToken(secret)
+	if err := uu.keyRepo.Add(ctx, k); err != nil {
+		return err
+	}
+	k.Key = apiKeyPrefix + k.ID + "." + secret
+	return nil
+}
+
+func (uu *userUsecase) ListKeys(ctx context.Context) ([]*models.APIKey, error) {
+	u, err := keyOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return uu.keyRepo.List(ctx, u.ID)
+}
+
+func (uu *userUsecase) RevokeKey(ctx context.Context, id string) error {
+	u, err := keyOwner(ctx)
+	if err != nil {
+		return err
+	}
+	return uu.keyRepo.Revoke(ctx, u.ID, id)
+}
+
+// authenticateKey returns the user of an API key, scoped to the workspace of the key
+func (uu *userUsecase) authenticateKey(ctx context.Context, key string) (*models.User, error) {
+	parts := strings.SplitN(strings.TrimPrefix(key, apiKeyPrefix), ".", 2)
+	if len(parts) != 2 {
+		return nil, core.ErrInvalidToken
+	}
+	k, err := uu.keyRepo.Get(ctx, parts[0])
+	if err == core.ErrRecordNotFound {
+		return nil, core.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if k.RevokedAt != nil || subtle.ConstantTimeCompare([]byte(hashToken(parts[1])), []byte(k.SecretHash)) != 1 {
+		return nil, core.ErrInvalidToken
+	}
+	if err := uu.keyRepo.Touch(ctx, k.ID); err != nil {
+		// the last use is informative, it does not fail the request
+		logrus.Error(err)
+	}
+	return &models.User{ID: k.UserID, WorkspaceID: k.WorkspaceID, Scope: k.Scope}, nil
+}
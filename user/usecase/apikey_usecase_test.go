@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/user/mocks"
+)
+
+func Test_userUsecase_CreateKey(t *testing.T) {
+	uu := newTestUsecase(&mocks.MockRepository{}, &mocks.MockSessionRepository{})
+	keys := uu.keyRepo.(*mocks.MockAPIKeyRepository)
+	ctx := core.WithTenant(core.WithUser(context.Background(), &models.User{ID: 1, WorkspaceID: 7}), 7)
+	k := &models.APIKey{Name: "ci", Scope: models.ScopeReadOnly}
+	if err := uu.CreateKey(ctx, k); err != nil {
+		t.Fatalf("userUsecase.CreateKey() error = %v", err)
+	}
+	if !strings.HasPrefix(k.Key, apiKeyPrefix+k.ID+".") || len(k.ID) != 32 {
+		t.Fatalf("unexpected key %q", k.Key)
+	}
+	if stored := keys.Keys[k.ID]; stored.SecretHash == "" || strings.Contains(k.Key, stored.SecretHash) {
+		t.Errorf("only the hash of the secret must be stored, got %q", stored.SecretHash)
+	}
+	u, err := uu.Authenticate(context.Background(), k.Key)
+	if err != nil {
+		t.Fatalf("userUsecase.Authenticate() error = %v", err)
+	}
+	if u.ID != 1 || u.WorkspaceID != 7 || u.Scope != models.ScopeReadOnly {
+		t.Errorf("userUsecase.Authenticate() = %+v", u)
+	}
+	if keys.Keys[k.ID].LastUsedAt == nil {
+		t.Errorf("expected the last use of the key to be recorded")
+	}
+
+	// an API key can not create more keys
+	if err := uu.CreateKey(core.WithUser(ctx, u), &models.APIKey{Name: "ci"}); err != core.ErrForbidden {
+		t.Errorf("userUsecase.CreateKey() error = %v, wantErr %v", err, core.ErrForbidden)
+	}
+	if err := uu.CreateKey(context.Background(), &models.APIKey{Name: "ci"}); err != core.ErrForbidden {
+		t.Errorf("userUsecase.CreateKey() error = %v, wantErr %v", err, core.ErrForbidden)
+	}
+	if err := uu.CreateKey(core.WithUser(context.Background(), &models.User{ID: 1}), &models.APIKey{Name: "ci"}); err != core.ErrNoTenant {
+		t.Errorf("userUsecase.CreateKey() error = %v, wantErr %v", err, core.ErrNoTenant)
+	}
+}
+
+func Test_userUsecase_authenticateKey(t *testing.T) {
+	uu := newTestUsecase(&mocks.MockRepository{}, &mocks.MockSessionRepository{})
+	ctx := core.WithTenant(core.WithUser(context.Background(), &models.User{ID: 1}), 7)
+	live := &models.APIKey{Name: "live", Scope: models.ScopeReadWrite}
+	revoked := &models.APIKey{Name: "revoked", Scope: models.ScopeReadWrite}
+	for _, k := range []*models.APIKey{live, revoked} {
+		if err := uu.CreateKey(ctx, k); err != nil {
+			t.Fatalf("userUsecase.CreateKey() error = %v", err)
+		}
+	}
+	if err := uu.RevokeKey(ctx, revoked.ID); err != nil {
+		t.Fatalf("userUsecase.RevokeKey() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		key     string
+		wantErr error
+	}{
+		{name: "Normal Case1: live key", key: live.Key},
+		{name: "revoked key", key: revoked.Key, wantErr: core.ErrInvalidToken},
+		{name: "wrong secret", key: apiKeyPrefix + live.ID + ".abc", wantErr: core.ErrInvalidToken},
+		{name: "unknown key", key: apiKeyPrefix + "abc.def", wantErr: core.ErrInvalidToken},
+		{name: "no secret", key: apiKeyPrefix + live.ID, wantErr: core.ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := uu.Authenticate(context.Background(), tt.key); err != tt.wantErr {
+				t.Errorf("userUsecase.Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_Keys_ofAnotherUser(t *testing.T) {
+	uu := newTestUsecase(&mocks.MockRepository{}, &mocks.MockSessionRepository{})
+	alice := core.WithTenant(core.WithUser(context.Background(), &models.User{ID: 1}), 7)
+	bob := core.WithTenant(core.WithUser(context.Background(), &models.User{ID: 2}), 7)
+	k := &models.APIKey{Name: "ci", Scope: models.ScopeAdmin}
+	if err := uu.CreateKey(alice, k); err != nil {
+		t.Fatalf("userUsecase.CreateKey() error = %v", err)
+	}
+	if keys, err := uu.ListKeys(bob); err != nil || len(keys) != 0 {
+		t.Errorf("userUsecase.ListKeys() = %v, %v, want no keys", keys, err)
+	}
+	if err := uu.RevokeKey(bob, k.ID); err != core.ErrRecordNotFound {
+		t.Errorf("userUsecase.RevokeKey() error = %v, wantErr %v", err, core.ErrRecordNotFound)
+	}
+	if keys, err := uu.ListKeys(alice); err != nil || len(keys) != 1 {
+		t.Errorf("userUsecase.ListKeys() = %v, %v, want the key", keys, err)
+	}
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+func (uu *userUsecase) FeedKey(ctx context.Context, id int) (string, error) {
+	u, err := uu.userRepo.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return u.FeedKey, nil
+}
+
+func (uu *userUsecase) RotateFeedKey(ctx context.Context) (string, error) {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	key, err := randomHex(32)
+	if err != nil {
+		return "", err
+	}
+	if err := uu.userRepo.SetFeedKey(ctx, u.ID, key); err != nil {
+		return "", err
+	}
+	return key, nil
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/user/mocks"
+)
+
+func Test_userUsecase_RotateFeedKey(t *testing.T) {
+	uu := newTestUsecase(&mocks.MockRepository{Users: []*models.User{{ID: 1, Email: "alice@example.com"}}}, &mocks.MockSessionRepository{})
+	ctx := core.WithUser(context.Background(), &models.User{ID: 1})
+	if key, err := uu.FeedKey(ctx, 1); err != nil || key != "" {
+		t.Fatalf("userUsecase.FeedKey() = %q, %v, want no key yet", key, err)
+	}
+	first, err := uu.RotateFeedKey(ctx)
+	if err != nil || len(first) != 64 {
+		t.Fatalf("userUsecase.RotateFeedKey() = %q, %v", first, err)
+	}
+	second, err := uu.RotateFeedKey(ctx)
+	if err != nil || second == first {
+		t.Fatalf("userUsecase.RotateFeedKey() = %q, %v, want a new key", second, err)
+	}
+	if key, err := uu.FeedKey(ctx, 1); err != nil || key != second {
+		t.Errorf("userUsecase.FeedKey() = %q, %v, want %q", key, err, second)
+	}
+	if _, err := uu.FeedKey(ctx, 2); err != core.ErrRecordNotFound {
+		t.Errorf("userUsecase.FeedKey() error = %v, wantErr %v", err, core.ErrRecordNotFound)
+	}
+	if _, err := uu.RotateFeedKey(context.Background()); err != core.ErrForbidden {
+		t.Errorf("userUsecase.RotateFeedKey() error = %v, wantErr %v", err, core.ErrForbidden)
+	}
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+// jwtHeader is the encoded header of every token, only HS256 is issued and accepted
+var jwtHeader = encodeSegment([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// claims of the access tokens
+type claims struct {
+	Subject   string `json:"sub"`
+	Email     string `json:"email"`
+	Workspace int    `json:"wid"`
+	Session   string `json:"sid"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func sign(secret []byte, unsigned string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
+
+// signToken returns the compact serialization of a JWT carrying c
+func signToken(secret []byte, c *claims) (string, error) {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	unsigned := jwtHeader + "." + encodeSegment(payload)
+	return unsigned + "." + encodeSegment(sign(secret, unsigned)), nil
+}
+
+// parseToken checks the signature and the expiry of token and returns its claims,
+// core.ErrInvalidToken is returned for any token that is not valid at now
+func parseToken(secret []byte, token string, now time.Time) (*claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, core.ErrInvalidToken
+	}
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, core.ErrInvalidToken
+	}
+	h := struct {
+		Alg string `json:"alg"`
+	}{}
+	// the algorithm is fixed, a token asking for another one (or "none") is rejected
+	if err := json.Unmarshal(header, &h); err != nil || h.Alg != "HS256" {
+		return nil, core.ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(secret, parts[0]+"."+parts[1])) {
+		return nil, core.ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, core.ErrInvalidToken
+	}
+	c := &claims{}
+	if err := json.Unmarshal(payload, c); err != nil {
+		return nil, core.ErrInvalidToken
+	}
+	if now.Unix() >= c.ExpiresAt {
+		return nil, core.ErrInvalidToken
+	}
+	return c, nil
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+)
+
+func Test_parseToken(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	c := &claims{Subject: "1", Email: "alice@example.com", Session: "abc", IssuedAt: now.Unix(), ExpiresAt: now.Add(time.Minute).Unix()}
+	token, err := signToken(secret, c)
+	if err != nil {
+		t.Fatalf("signToken() error = %v", err)
+	}
+	parts := strings.Split(token, ".")
+	tests := []struct {
+		name    string
+		secret  []byte
+		token   string
+		now     time.Time
+		want    *claims
+		wantErr error
+	}{{
+		name:   "Normal Case 1: valid token",
+		secret: secret,
+		token:  token,
+		now:    now,
+		want:   c,
+	}, {
+		name:    "expired",
+		secret:  secret,
+		token:   token,
+		now:     now.Add(time.Minute),
+		wantErr: core.ErrInvalidToken,
+	}, {
+		name:    "signed with another secret",
+		secret:  []byte("other"),
+		token:   token,
+		now:     now,
+		wantErr: core.ErrInvalidToken,
+	}, {
+		name:    "tampered payload",
+		secret:  secret,
+		token:   parts[0] + "." + encodeSegment([]byte(`{"sub":"2","sid":"abc","exp":9999999999}`)) + "." + parts[2],
+		now:     now,
+		wantErr: core.ErrInvalidToken,
+	}, {
+		name:    "alg none",
+		secret:  secret,
+		token:   encodeSegment([]byte(`{"alg":"none"}`)) + "." + parts[1] + ".",
+		now:     now,
+		wantErr: core.ErrInvalidToken,
+	}, {
+		name:    "malformed",
+		secret:  secret,
+		token:   "abc",
+		now:     now,
+		wantErr: core.ErrInvalidToken,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseToken(tt.secret, tt.token, tt.now)
+			if err != tt.wantErr {
+				t.Fatalf("parseToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
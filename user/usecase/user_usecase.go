@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/user"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type userUsecase struct {
+	userRepo    user.Repository
+	sessionRepo user.SessionRepository
+	keyRepo     user.APIKeyRepository
+	secret      []byte
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
+	cost        int
+	dummy       struct {
+		once sync.Once
+		hash []byte
+	}
+}
+
+// NewUserUsecase will create new a userUsecase object representation of user.Usecase interface.
+// Access tokens are signed with secret and live for accessTTL, refresh tokens for refreshTTL
+func NewUserUsecase(ur user.Repository, sr user.SessionRepository, kr user.APIKeyRepository, secret []byte, accessTTL, refreshTTL time.Duration) user.Usecase {
+	return &userUsecase{
+		userRepo:    ur,
+		sessionRepo: sr,
+		keyRepo:     kr,
+		secret:      secret,
+		accessTTL:   accessTTL,
+		refreshTTL:  refreshTTL,
+		cost:        bcrypt.DefaultCost,
+	}
+}
+
+// normalizeEmail makes the lookup of an email case insensitive
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (uu *userUsecase) Register(ctx context.Context, c *models.Credentials) (*models.User, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(c.Password), uu.cost)
+	if err != nil {
+		return nil, err
+	}
+	u := &models.User{Email: normalizeEmail(c.Email), PasswordHash: string(hash)}
+	if err := uu.userRepo.Add(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (uu *userUsecase) Login(ctx context.Context, c *models.Credentials) (*models.TokenPair, error) {
+	u, err := uu.userRepo.GetByEmail(ctx, normalizeEmail(c.Email))
+	if err == core.ErrRecordNotFound {
+		// compare anyway so that unknown emails can not be told apart by the response time
+		uu.dummy.once.Do(func() {
+			uu.dummy.hash, _ = bcrypt.GenerateFromPassword([]byte("not a password"), uu.cost)
+		})
+		bcrypt.CompareHashAndPassword(uu.dummy.hash, []byte(c.Password))
+		return nil, core.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(c.Password)); err != nil {
+		return nil, core.ErrInvalidCredentials
+	}
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	s := &models.Session{ID: id, UserID: u.ID}
+	refresh, err := uu.newRefreshToken(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := uu.sessionRepo.Add(ctx, s); err != nil {
+		return nil, err
+	}
+	return uu.tokens(u, s, refresh)
+}
+
+// newRefreshToken sets a new refresh token on s and returns it, the token is
+// made of the session id and a random secret of which only the hash is kept
+func (uu *userUsecase) newRefreshToken(s *models.Session) (string, error) {
+	secret, err := randomHex(32)
+	if err != nil {
+		return "", err
+	}
+	s.RefreshHash = hashToken(secret)
+	s.ExpiresAt = time.Now().Add(uu.refreshTTL)
+	return s.ID + "." + secret, nil
+}
+
+func (uu *userUsecase) tokens(u *models.User, s *models.Session, refresh string) (*models.TokenPair, error) {
+	now := time.Now()
+	access, err := signToken(uu.secret, &claims{
+		Subject:   strconv.Itoa(u.ID),
+		Email:     u.Email,
+		Workspace: u.WorkspaceID,
+		Session:   s.ID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(uu.accessTTL).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    models.TokenTypeBearer,
+		ExpiresIn:    int64(uu.accessTTL / time.Second),
+	}, nil
+}
+
+// liveSession returns the session of id when it is neither revoked nor expired
+func (uu *userUsecase) liveSession(ctx context.Context, id string) (*models.Session, error) {
+	s, err := uu.sessionRepo.Get(ctx, id)
+	if err == core.ErrRecordNotFound {
+		return nil, core.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.RevokedAt != nil || !time.Now().Before(s.ExpiresAt) {
+		return nil, core.ErrInvalidToken
+	}
+	return s, nil
+}
+
+func (uu *userUsecase) Refresh(ctx context.Context, refreshToken string) (*models.TokenPair, error) {
+	parts := strings.SplitN(refreshToken, ".", 2)
+	if len(parts) != 2 {
+		return nil, core.ErrInvalidToken
+	}
+	s, err := uu.liveSession(ctx, parts[0])
+	if err != nil {
+		return nil, err
+	}
+	oldHash := hashToken(parts[1])
+	if subtle.ConstantTimeCompare([]byte(oldHash), []byte(s.RefreshHash)) != 1 {
+		// an already rotated token is being replayed, it may have been stolen so the session ends
+		if err := uu.sessionRepo.Revoke(ctx, s.ID); err != nil {
+			return nil, err
+		}
+		return nil, core.ErrInvalidToken
+	}
+	u, err := uu.userRepo.Get(ctx, s.UserID)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := uu.newRefreshToken(s)
+	if err != nil {
+		return nil, err
+	}
+	err = uu.sessionRepo.Rotate(ctx, s, oldHash)
+	if err == core.ErrRecordNotFound {
+		// a concurrent refresh won the race
+		return nil, core.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return uu.tokens(u, s, refresh)
+}
+
+func (uu *userUsecase) Logout(ctx context.Context, accessToken string) error {
+	c, err := parseToken(uu.secret, accessToken, time.Now())
+	if err != nil {
+		return err
+	}
+	return uu.sessionRepo.Revoke(ctx, c.Session)
+}
+
+func (uu *userUsecase) Authenticate(ctx context.Context, accessToken string) (*models.User, error) {
+	if strings.HasPrefix(accessToken, apiKeyPrefix) {
+		return uu.authenticateKey(ctx, accessToken)
+	}
+	c, err := parseToken(uu.secret, accessToken, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	id, err := strconv.Atoi(c.Subject)
+	if err != nil {
+		return nil, core.ErrInvalidToken
+	}
+	// the session is checked so that a logout takes effect before the access token expires
+	s, err := uu.liveSession(ctx, c.Session)
+	if err != nil {
+		return nil, err
+	}
+	if s.UserID != id {
+		return nil, core.ErrInvalidToken
+	}
+	return &models.User{ID: id, Email: c.Email, WorkspaceID: c.Workspace}, nil
+}
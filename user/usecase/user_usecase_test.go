@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/user"
+	"github.com/pratheeshm/todo-golang/user/mocks"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newTestUsecase keeps the bcrypt cost low, hashing at the default cost slows the tests down
+func newTestUsecase(ur *mocks.MockRepository, sr *mocks.MockSessionRepository) *userUsecase {
+	uu := NewUserUsecase(ur, sr, &mocks.MockAPIKeyRepository{}, []byte("secret"), time.Minute, time.Hour).(*userUsecase)
+	uu.cost = bcrypt.MinCost
+	return uu
+}
+
+var alice = &models.Credentials{Email: "Alice@Example.com", Password: "correct horse"}
+
+func TestNewUserUsecase(t *testing.T) {
+	ur := &mocks.MockRepository{}
+	sr := &mocks.MockSessionRepository{}
+	kr := &mocks.MockAPIKeyRepository{}
+	want := &userUsecase{userRepo: ur, sessionRepo: sr, keyRepo: kr, secret: []byte("secret"),
+		accessTTL: time.Minute, refreshTTL: time.Hour, cost: bcrypt.DefaultCost}
+	var got user.Usecase = NewUserUsecase(ur, sr, kr, []byte("secret"), time.Minute, time.Hour)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewUserUsecase() = %v, want %v", got, want)
+	}
+}
+
+func Test_userUsecase_Register(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    *mocks.MockRepository
+		wantErr error
+	}{{
+		name: "Normal Case1: user registered",
+		repo: &mocks.MockRepository{},
+	}, {
+		name:    "email taken",
+		repo:    &mocks.MockRepository{Users: []*models.User{{ID: 1, Email: "alice@example.com"}}},
+		wantErr: core.ErrAlreadyExists,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uu := newTestUsecase(tt.repo, &mocks.MockSessionRepository{})
+			got, err := uu.Register(context.Background(), alice)
+			if err != tt.wantErr {
+				t.Fatalf("userUsecase.Register() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Email != "alice@example.com" {
+				t.Errorf("email should be normalized, got %q", got.Email)
+			}
+			if bcrypt.CompareHashAndPassword([]byte(got.PasswordHash), []byte(alice.Password)) != nil {
+				t.Errorf("password hash does not match the password")
+			}
+		})
+	}
+}
+
+func Test_userUsecase_Login(t *testing.T) {
+	tests := []struct {
+		name    string
+		creds   *models.Credentials
+		wantErr error
+	}{{
+		name:  "Normal Case1: login",
+		creds: &models.Credentials{Email: "alice@example.com ", Password: alice.Password},
+	}, {
+		name:    "wrong password",
+		creds:   &models.Credentials{Email: alice.Email, Password: "wrong password"},
+		wantErr: core.ErrInvalidCredentials,
+	}, {
+		name:    "unknown email",
+		creds:   &models.Credentials{Email: "bob@example.com", Password: alice.Password},
+		wantErr: core.ErrInvalidCredentials,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions := &mocks.MockSessionRepository{}
+			uu := newTestUsecase(&mocks.MockRepository{}, sessions)
+			if _, err := uu.Register(context.Background(), alice); err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			tokens, err := uu.Login(context.Background(), tt.creds)
+			if err != tt.wantErr {
+				t.Fatalf("userUsecase.Login() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(sessions.Sessions) != 0 {
+					t.Errorf("no session should be started, got %v", sessions.Sessions)
+				}
+				return
+			}
+			if tokens.TokenType != models.TokenTypeBearer || tokens.ExpiresIn != 60 {
+				t.Errorf("userUsecase.Login() = %v", tokens)
+			}
+			u, err := uu.Authenticate(context.Background(), tokens.AccessToken)
+			if err != nil {
+				t.Fatalf("userUsecase.Authenticate() error = %v", err)
+			}
+			if want := (&models.User{ID: 1, Email: "alice@example.com", WorkspaceID: 1}); !reflect.DeepEqual(u, want) {
+				t.Errorf("userUsecase.Authenticate() = %v, want %v", u, want)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_Refresh(t *testing.T) {
+	ctx := context.Background()
+	sessions := &mocks.MockSessionRepository{}
+	uu := newTestUsecase(&mocks.MockRepository{}, sessions)
+	if _, err := uu.Register(ctx, alice); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	first, err := uu.Login(ctx, alice)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	second, err := uu.Refresh(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("userUsecase.Refresh() error = %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Errorf("refresh token should be rotated")
+	}
+	if _, err := uu.Authenticate(ctx, second.AccessToken); err != nil {
+		t.Errorf("new access token should be valid, got %v", err)
+	}
+	// replaying the rotated token ends the session
+	if _, err := uu.Refresh(ctx, first.RefreshToken); err != core.ErrInvalidToken {
+		t.Fatalf("userUsecase.Refresh() error = %v, wantErr %v", err, core.ErrInvalidToken)
+	}
+	if _, err := uu.Refresh(ctx, second.RefreshToken); err != core.ErrInvalidToken {
+		t.Errorf("session should be revoked after a replay, got %v", err)
+	}
+	if _, err := uu.Authenticate(ctx, second.AccessToken); err != core.ErrInvalidToken {
+		t.Errorf("access token of a revoked session should be rejected, got %v", err)
+	}
+	for _, token := range []string{"", "abc", "unknown." + strings.Repeat("0", 64)} {
+		if _, err := uu.Refresh(ctx, token); err != core.ErrInvalidToken {
+			t.Errorf("userUsecase.Refresh(%q) error = %v, wantErr %v", token, err, core.ErrInvalidToken)
+		}
+	}
+}
+
+func Test_userUsecase_Logout(t *testing.T) {
+	ctx := context.Background()
+	uu := newTestUsecase(&mocks.MockRepository{}, &mocks.MockSessionRepository{})
+	if _, err := uu.Register(ctx, alice); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	tokens, err := uu.Login(ctx, alice)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if err := uu.Logout(ctx, tokens.AccessToken); err != nil {
+		t.Fatalf("userUsecase.Logout() error = %v", err)
+	}
+	if _, err := uu.Authenticate(ctx, tokens.AccessToken); err != core.ErrInvalidToken {
+		t.Errorf("access token should be revoked, got %v", err)
+	}
+	if _, err := uu.Refresh(ctx, tokens.RefreshToken); err != core.ErrInvalidToken {
+		t.Errorf("refresh token should be revoked, got %v", err)
+	}
+	if err := uu.Logout(ctx, "abc"); err != core.ErrInvalidToken {
+		t.Errorf("userUsecase.Logout() error = %v, wantErr %v", err, core.ErrInvalidToken)
+	}
+}
+
+func Test_userUsecase_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	sessions := &mocks.MockSessionRepository{Sessions: map[string]*models.Session{
+		"live":    {ID: "live", UserID: 1, ExpiresAt: now.Add(time.Hour)},
+		"expired": {ID: "expired", UserID: 1, ExpiresAt: now.Add(-time.Second)},
+	}}
+	uu := newTestUsecase(&mocks.MockRepository{}, sessions)
+	token := func(sub, sid string) string {
+		s, _ := signToken(uu.secret, &claims{Subject: sub, Session: sid, ExpiresAt: now.Add(time.Minute).Unix()})
+		return s
+	}
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "Normal Case1: live session", token: token("1", "live")},
+		{name: "expired session", token: token("1", "expired"), wantErr: core.ErrInvalidToken},
+		{name: "unknown session", token: token("1", "unknown"), wantErr: core.ErrInvalidToken},
+		{name: "session of another user", token: token("2", "live"), wantErr: core.ErrInvalidToken},
+		{name: "bad subject", token: token("alice", "live"), wantErr: core.ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := uu.Authenticate(ctx, tt.token); err != tt.wantErr {
+				t.Errorf("userUsecase.Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	sessions.Error = errors.New("db error")
+	if _, err := uu.Authenticate(ctx, token("1", "live")); err != sessions.Error {
+		t.Errorf("userUsecase.Authenticate() error = %v, wantErr %v", err, sessions.Error)
+	}
+}
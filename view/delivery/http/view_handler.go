@@ -0,0 +1,201 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/view"
+	"github.com/sirupsen/logrus"
+)
+
+//ViewHandler represents http handler for saved views
+type ViewHandler struct {
+	ViewUsecase view.Usecase
+}
+
+// NewViewHandler will initialize the views/ resources endpoint
+func NewViewHandler(vu view.Usecase) nethttp.Handler {
+	r := chi.NewMux()
+	viewHandler := &ViewHandler{
+		ViewUsecase: vu,
+	}
+	r.Get("/", viewHandler.List)
+	r.Post("/", viewHandler.Add)
+	r.Get("/{id:[0-9]+}", viewHandler.Get)
+	r.Put("/{id:[0-9]+}", viewHandler.Edit)
+	r.Delete("/{id:[0-9]+}", viewHandler.Delete)
+	r.Get("/{id:[0-9]+}/tasks", viewHandler.Tasks)
+	return r
+}
+
+func writeJSON(w nethttp.ResponseWriter, status int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	res, _ := json.Marshal(body)
+	w.Write(res)
+}
+
+// writeError answers the errors shared by the view endpoints
+func writeError(w nethttp.ResponseWriter, err error, v *models.View) {
+	switch err {
+	case core.ErrForbidden:
+		w.WriteHeader(nethttp.StatusForbidden)
+		w.Write([]byte("forbidden"))
+	case core.ErrRecordNotFound:
+		w.WriteHeader(nethttp.StatusNotFound)
+		w.Write([]byte("view not found"))
+	case core.ErrInvalidView:
+		writeJSON(w, nethttp.StatusUnprocessableEntity, map[string]interface{}{
+			"message": "failure",
+			"problem": v.Problem,
+		})
+	default:
+		logrus.Error(err)
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		w.Write([]byte("internal server error"))
+	}
+}
+
+// decodeView reads and validates the view of the request body
+func decodeView(w nethttp.ResponseWriter, r *nethttp.Request) (*models.View, bool) {
+	v := &models.View{}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("Can not decode body"))
+		return nil, false
+	}
+	validate := validator.New()
+	if err := validate.Struct(v); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return nil, false
+	}
+	return v, true
+}
+
+//List handler returns the views of the user along with the ones shared with project
+func (h *ViewHandler) List(w nethttp.ResponseWriter, r *nethttp.Request) {
+	f := &models.ViewFilter{Project: r.URL.Query().Get("project")}
+	validate := validator.New()
+	if err := validate.Struct(f); err != nil {
+		w.WriteHeader(nethttp.StatusBadRequest)
+		w.Write([]byte("validation error"))
+		return
+	}
+	views, err := h.ViewUsecase.List(r.Context(), f)
+	if err != nil {
+		writeError(w, err, nil)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"views":   views,
+	})
+}
+
+//Add view handler
+func (h *ViewHandler) Add(w nethttp.ResponseWriter, r *nethttp.Request) {
+	v, ok := decodeView(w, r)
+	if !ok {
+		return
+	}
+	if err := h.ViewUsecase.Add(r.Context(), v); err != nil {
+		writeError(w, err, v)
+		return
+	}
+	writeJSON(w, nethttp.StatusCreated, map[string]interface{}{
+		"message": "success",
+		"view":    v,
+	})
+}
+
+//Get view handler
+func (h *ViewHandler) Get(w nethttp.ResponseWriter, r *nethttp.Request) {
+	id, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	v, err := h.ViewUsecase.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, err, nil)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"view":    v,
+	})
+}
+
+//Edit view handler replaces the view
+func (h *ViewHandler) Edit(w nethttp.ResponseWriter, r *nethttp.Request) {
+	v, ok := decodeView(w, r)
+	if !ok {
+		return
+	}
+	v.ID, _ = strconv.Atoi(chi.URLParam(r, "id"))
+	if err := h.ViewUsecase.Edit(r.Context(), v); err != nil {
+		writeError(w, err, v)
+		return
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"view":    v,
+	})
+}
+
+//Delete view handler
+func (h *ViewHandler) Delete(w nethttp.ResponseWriter, r *nethttp.Request) {
+	id, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	if err := h.ViewUsecase.Delete(r.Context(), id); err != nil {
+		writeError(w, err, nil)
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+	w.Write([]byte("success"))
+}
+
+// project keeps the columns of the task, every field when columns is empty
+func project(t *models.Task, columns []string) (interface{}, error) {
+	if len(columns) == 0 {
+		return t, nil
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	row := make(map[string]json.RawMessage, len(columns))
+	for _, c := range columns {
+		if v, ok := fields[c]; ok {
+			row[c] = v
+		}
+	}
+	return row, nil
+}
+
+//Tasks handler runs the stored query of the view and returns the tasks with the columns of the view
+func (h *ViewHandler) Tasks(w nethttp.ResponseWriter, r *nethttp.Request) {
+	id, _ := strconv.Atoi(chi.URLParam(r, "id"))
+	v, tasks, err := h.ViewUsecase.Tasks(r.Context(), id)
+	if err != nil {
+		writeError(w, err, v)
+		return
+	}
+	rows := make([]interface{}, len(tasks))
+	for i, t := range tasks {
+		if rows[i], err = project(t, v.Columns); err != nil {
+			writeError(w, err, v)
+			return
+		}
+	}
+	writeJSON(w, nethttp.StatusOK, map[string]interface{}{
+		"message": "success",
+		"view":    v,
+		"tasks":   rows,
+	})
+}
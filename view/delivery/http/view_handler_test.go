@@ -0,0 +1,208 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/view"
+	"github.com/pratheeshm/todo-golang/view/mocks"
+)
+
+func withID(r *nethttp.Request, id string) *nethttp.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestNewViewHandler(t *testing.T) {
+	h := NewViewHandler(&mocks.MockUsecase{View: &models.View{ID: 1}})
+	tests := []struct {
+		method     string
+		url        string
+		statusCode int
+	}{
+		{"GET", "/", 200},
+		{"GET", "/1", 200},
+		{"GET", "/1/tasks", 200},
+		{"DELETE", "/1", 200},
+		{"GET", "/abc", 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, nil))
+			if rec.Code != tt.statusCode {
+				t.Errorf("got statuscode %d but expected %d", rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestViewHandler_Add(t *testing.T) {
+	body := `{"name":"my bugs","filter":"tag:bug","columns":["title"]}`
+	tests := []struct {
+		name       string
+		usecase    view.Usecase
+		body       string
+		statusCode int
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{},
+		body:       body,
+		statusCode: 201,
+	}, {
+		name:       "view does not compile",
+		usecase:    &mocks.MockUsecase{Error: core.ErrInvalidView},
+		body:       body,
+		statusCode: 422,
+	}, {
+		name:       "missing name",
+		usecase:    &mocks.MockUsecase{},
+		body:       `{"filter":"tag:bug"}`,
+		statusCode: 400,
+	}, {
+		name:       "body parse error",
+		usecase:    &mocks.MockUsecase{},
+		body:       `[]`,
+		statusCode: 400,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("Usecase.Error()")},
+		body:       body,
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &ViewHandler{ViewUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Add(rec, httptest.NewRequest("POST", "/views", bytes.NewBufferString(tt.body)))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestViewHandler_Edit(t *testing.T) {
+	body := `{"name":"my bugs","filter":"tag:bug"}`
+	tests := []struct {
+		name       string
+		usecase    view.Usecase
+		statusCode int
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{},
+		statusCode: 200,
+	}, {
+		name:       "view not found",
+		usecase:    &mocks.MockUsecase{Error: core.ErrRecordNotFound},
+		statusCode: 404,
+	}, {
+		name:       "view of someone else",
+		usecase:    &mocks.MockUsecase{Error: core.ErrForbidden},
+		statusCode: 403,
+	}, {
+		name:       "view does not compile",
+		usecase:    &mocks.MockUsecase{Error: core.ErrInvalidView},
+		statusCode: 422,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &ViewHandler{ViewUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Edit(rec, withID(httptest.NewRequest("PUT", "/views/1", bytes.NewBufferString(body)), "1"))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestViewHandler_List(t *testing.T) {
+	tests := []struct {
+		name       string
+		usecase    view.Usecase
+		url        string
+		statusCode int
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{Views: []*models.View{{ID: 1}}},
+		url:        "/views?project=web",
+		statusCode: 200,
+	}, {
+		name:       "project too long",
+		usecase:    &mocks.MockUsecase{},
+		url:        "/views?project=" + strings.Repeat("a", 51),
+		statusCode: 400,
+	}, {
+		name:       "usecase error",
+		usecase:    &mocks.MockUsecase{Error: errors.New("Usecase.Error()")},
+		url:        "/views",
+		statusCode: 500,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &ViewHandler{ViewUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.List(rec, httptest.NewRequest("GET", tt.url, nil))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestViewHandler_Tasks(t *testing.T) {
+	tasks := []*models.Task{{ID: 1, Title: "Fix login", Status: "todo", Priority: 2}}
+	tests := []struct {
+		name       string
+		usecase    view.Usecase
+		statusCode int
+		wantRow    map[string]interface{}
+	}{{
+		name:       "Success case",
+		usecase:    &mocks.MockUsecase{View: &models.View{ID: 1, Columns: []string{"id_task", "title"}}, TaskList: tasks},
+		statusCode: 200,
+		wantRow:    map[string]interface{}{"id_task": float64(1), "title": "Fix login"},
+	}, {
+		name:       "stored view no longer compiles",
+		usecase:    &mocks.MockUsecase{View: &models.View{ID: 1, Problem: "filter: position 1: unknown field"}, Error: core.ErrInvalidView},
+		statusCode: 422,
+	}, {
+		name:       "view not found",
+		usecase:    &mocks.MockUsecase{Error: core.ErrRecordNotFound},
+		statusCode: 404,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &ViewHandler{ViewUsecase: tt.usecase}
+			rec := httptest.NewRecorder()
+			h.Tasks(rec, withID(httptest.NewRequest("GET", "/views/1/tasks", nil), "1"))
+			if rec.Code != tt.statusCode {
+				t.Fatalf("Test - %s , got statuscode %d but expected %d", tt.name, rec.Code, tt.statusCode)
+			}
+			if tt.wantRow == nil {
+				return
+			}
+			res := struct {
+				Tasks []map[string]interface{} `json:"tasks"`
+			}{}
+			if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+				t.Fatalf("Can not decode body: %v", err)
+			}
+			if len(res.Tasks) != 1 || len(res.Tasks[0]) != len(tt.wantRow) ||
+				res.Tasks[0]["title"] != tt.wantRow["title"] || res.Tasks[0]["id_task"] != tt.wantRow["id_task"] {
+				t.Errorf("got tasks %v, want the row %v", res.Tasks, tt.wantRow)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockRepository implements inerface view.Repository
+type MockRepository struct {
+	Error error
+	Views []*models.View
+}
+
+func (m *MockRepository) find(id int) int {
+	for i, v := range m.Views {
+		if v.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+//Add appends the view to Views
+func (m *MockRepository) Add(ctx context.Context, v *models.View) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	v.ID = len(m.Views) + 1
+	m.Views = append(m.Views, v)
+	return nil
+}
+
+//Get returns the view of Views with the id
+func (m *MockRepository) Get(ctx context.Context, id int) (*models.View, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	if i := m.find(id); i >= 0 {
+		return m.Views[i], nil
+	}
+	return nil, core.ErrRecordNotFound
+}
+
+//List returns Views
+func (m *MockRepository) List(ctx context.Context, f *models.ViewFilter) ([]*models.View, error) {
+	return m.Views, m.Error
+}
+
+//Edit replaces the view of Views with the same id
+func (m *MockRepository) Edit(ctx context.Context, v *models.View) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	i := m.find(v.ID)
+	if i < 0 {
+		return core.ErrRecordNotFound
+	}
+	m.Views[i] = v
+	return nil
+}
+
+//Delete removes the view of Views with the id
+func (m *MockRepository) Delete(ctx context.Context, id int) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	i := m.find(id)
+	if i < 0 {
+		return core.ErrRecordNotFound
+	}
+	m.Views = append(m.Views[:i], m.Views[i+1:]...)
+	return nil
+}
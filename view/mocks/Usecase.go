@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//MockUsecase implements inerface view.Usecase
+type MockUsecase struct {
+	Error    error
+	View     *models.View
+	Views    []*models.View
+	TaskList []*models.Task
+}
+
+//Add returns Error
+func (m *MockUsecase) Add(ctx context.Context, v *models.View) error {
+	return m.Error
+}
+
+//Get returns View
+func (m *MockUsecase) Get(ctx context.Context, id int) (*models.View, error) {
+	return m.View, m.Error
+}
+
+//List returns Views
+func (m *MockUsecase) List(ctx context.Context, f *models.ViewFilter) ([]*models.View, error) {
+	return m.Views, m.Error
+}
+
+//Edit returns Error
+func (m *MockUsecase) Edit(ctx context.Context, v *models.View) error {
+	return m.Error
+}
+
+//Delete returns Error
+func (m *MockUsecase) Delete(ctx context.Context, id int) error {
+	return m.Error
+}
+
+//Tasks returns View and TaskList
+func (m *MockUsecase) Tasks(ctx context.Context, id int) (*models.View, []*models.Task, error) {
+	return m.View, m.TaskList, m.Error
+}
@@ -0,0 +1,18 @@
+package view
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Repository represents saved view's interface
+type Repository interface {
+	Add(context.Context, *models.View) error
+	Get(context.Context, int) (*models.View, error)
+	// List returns the views of the owner and the ones shared with the project of the filter
+	List(context.Context, *models.ViewFilter) ([]*models.View, error)
+	// Edit replaces the view, its owner aside
+	Edit(context.Context, *models.View) error
+	Delete(context.Context, int) error
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/transaction"
+	"github.com/pratheeshm/todo-golang/view"
+)
+
+const viewColumns = "id_view, name, id_owner, project, filter, sort, columns, created_at, updated_at"
+
+type postgresViewRepository struct {
+	*sql.DB
+}
+
+// NewPostgresViewRepository will create an object that represent the view.Repository interface
+func NewPostgresViewRepository(db *sql.DB) view.Repository {
+	return &postgresViewRepository{db}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanView(s scanner) (*models.View, error) {
+	v := &models.View{}
+	err := s.Scan(&v.ID, &v.Name, &v.OwnerID, &v.Project, &v.Filter, &v.Sort,
+		pq.Array(&v.Columns), &v.CreatedAt, &v.UpdatedAt)
+	return v, err
+}
+
+// columns never returns nil so that the column stays an empty array
+func columns(v *models.View) []string {
+	if v.Columns == nil {
+		return []string{}
+	}
+	return v.Columns
+}
+
+func (p *postgresViewRepository) Add(ctx context.Context, v *models.View) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "INSERT INTO task_view(name, id_owner, project, filter, sort, columns, id_workspace) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id_view, created_at, updated_at",
+		v.Name, v.OwnerID, v.Project, v.Filter, v.Sort, pq.Array(columns(v)), workspace).Scan(&v.ID, &v.CreatedAt, &v.UpdatedAt)
+}
+
+func (p *postgresViewRepository) Get(ctx context.Context, id int) (*models.View, error) {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "SELECT "+viewColumns+" FROM task_view WHERE id_view = $1 AND id_workspace = $2", id, workspace)
+	v, err := scanView(row)
+	if err == sql.ErrNoRows {
+		return nil, core.ErrRecordNotFound
+	}
+	return v, err
+}
+
+func (p *postgresViewRepository) List(ctx context.Context, filter *models.ViewFilter) ([]*models.View, error) {
+	views := make([]*models.View, 0)
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return views, err
+	}
+	rows, err := transaction.Conn(ctx, p.DB).QueryContext(ctx, "SELECT "+viewColumns+" FROM task_view WHERE id_workspace = $1 AND (id_owner = $2 OR (project <> '' AND project = $3)) ORDER BY name, id_view",
+		workspace, filter.OwnerID, filter.Project)
+	if err != nil {
+		return views, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		v, err := scanView(rows)
+		if err != nil {
+			return []*models.View{}, err
+		}
+		views = append(views, v)
+	}
+	if err = rows.Err(); err != nil {
+		return []*models.View{}, err
+	}
+	return views, nil
+}
+
+func (p *postgresViewRepository) Edit(ctx context.Context, v *models.View) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	err = transaction.Conn(ctx, p.DB).QueryRowContext(ctx, "UPDATE task_view SET name = $1, project = $2, filter = $3, sort = $4, columns = $5, updated_at = now() WHERE id_view = $6 AND id_workspace = $7 RETURNING id_owner, created_at, updated_at",
+		v.Name, v.Project, v.Filter, v.Sort, pq.Array(columns(v)), v.ID, workspace).Scan(&v.OwnerID, &v.CreatedAt, &v.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
+
+func (p *postgresViewRepository) Delete(ctx context.Context, id int) error {
+	workspace, err := core.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := transaction.Conn(ctx, p.DB).ExecContext(ctx, "DELETE FROM task_view WHERE id_view = $1 AND id_workspace = $2", id, workspace)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return core.ErrRecordNotFound
+	}
+	return err
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pratheeshm/todo-golang/core"
+	coremocks "github.com/pratheeshm/todo-golang/core/mocks"
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+var viewRowColumns = []string{"id_view", "name", "id_owner", "project", "filter", "sort", "columns", "created_at", "updated_at"}
+
+func Test_postgresViewRepository_Add(t *testing.T) {
+	query := "INSERT INTO task_view(name, id_owner, project, filter, sort, columns, id_workspace) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id_view, created_at, updated_at"
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	v := &models.View{Name: "my bugs", OwnerID: 3, Filter: "tag:bug", Sort: "-priority"}
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("my bugs", 3, "", "tag:bug", "-priority", sqlmock.AnyArg(), coremocks.Tenant).
+		WillReturnRows(mock.NewRows([]string{"id_view", "created_at", "updated_at"}).AddRow(3, time.Time{}, time.Time{}))
+	if err := NewPostgresViewRepository(db).Add(coremocks.TenantContext(), v); err != nil {
+		t.Fatalf("postgresViewRepository.Add() error = %v", err)
+	}
+	if v.ID != 3 {
+		t.Errorf("postgresViewRepository.Add() id = %d, want 3", v.ID)
+	}
+}
+
+func Test_postgresViewRepository_Get(t *testing.T) {
+	query := "SELECT id_view, name, id_owner, project, filter, sort, columns, created_at, updated_at FROM task_view WHERE id_view = $1 AND id_workspace = $2"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *models.View
+		wantErr error
+	}{{
+		name: "Normal Case 1: view found",
+		rows: sqlmock.NewRows(viewRowColumns).
+			AddRow(1, "my bugs", 3, "web", "tag:bug", "", "{title,status}", time.Time{}, time.Time{}),
+		want: &models.View{ID: 1, Name: "my bugs", OwnerID: 3, Project: "web", Filter: "tag:bug",
+			Columns: []string{"title", "status"}},
+	}, {
+		name:    "view not found",
+		rows:    sqlmock.NewRows(viewRowColumns),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant).WillReturnRows(tt.rows)
+			got, err := NewPostgresViewRepository(db).Get(coremocks.TenantContext(), 1)
+			if err != tt.wantErr {
+				t.Fatalf("postgresViewRepository.Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("postgresViewRepository.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresViewRepository_List(t *testing.T) {
+	query := "SELECT id_view, name, id_owner, project, filter, sort, columns, created_at, updated_at FROM task_view WHERE id_workspace = $1 AND (id_owner = $2 OR (project <> '' AND project = $3)) ORDER BY name, id_view"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		dbErr   error
+		want    int
+		wantErr bool
+	}{{
+		name: "Normal Case 1: own and shared views",
+		rows: sqlmock.NewRows(viewRowColumns).
+			AddRow(1, "my bugs", 3, "", "tag:bug", "", "{}", time.Time{}, time.Time{}).
+			AddRow(2, "release", 5, "web", "due<2026-11-01", "due", "{}", time.Time{}, time.Time{}),
+		want: 2,
+	}, {
+		name:    "db error",
+		dbErr:   errors.New("db error"),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			exp := mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(coremocks.Tenant, 3, "web")
+			if tt.dbErr != nil {
+				exp.WillReturnError(tt.dbErr)
+			} else {
+				exp.WillReturnRows(tt.rows)
+			}
+			got, err := NewPostgresViewRepository(db).List(coremocks.TenantContext(), &models.ViewFilter{OwnerID: 3, Project: "web"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("postgresViewRepository.List() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("postgresViewRepository.List() = %d views, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_postgresViewRepository_Edit(t *testing.T) {
+	query := "UPDATE task_view SET name = $1, project = $2, filter = $3, sort = $4, columns = $5, updated_at = now() WHERE id_view = $6 AND id_workspace = $7 RETURNING id_owner, created_at, updated_at"
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr error
+	}{{
+		name: "Normal Case 1: view updated",
+		rows: sqlmock.NewRows([]string{"id_owner", "created_at", "updated_at"}).AddRow(3, time.Time{}, time.Time{}),
+	}, {
+		name:    "view not found",
+		rows:    sqlmock.NewRows([]string{"id_owner", "created_at", "updated_at"}),
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(query)).
+				WithArgs("my bugs", "", "tag:bug", "", sqlmock.AnyArg(), 1, coremocks.Tenant).WillReturnRows(tt.rows)
+			v := &models.View{ID: 1, Name: "my bugs", Filter: "tag:bug"}
+			if err := NewPostgresViewRepository(db).Edit(coremocks.TenantContext(), v); err != tt.wantErr {
+				t.Errorf("postgresViewRepository.Edit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_postgresViewRepository_Delete(t *testing.T) {
+	query := "DELETE FROM task_view WHERE id_view = $1 AND id_workspace = $2"
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{{
+		name:     "Normal Case 1: view deleted",
+		affected: 1,
+	}, {
+		name:    "view not found",
+		wantErr: core.ErrRecordNotFound,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+			mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(1, coremocks.Tenant).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if err := NewPostgresViewRepository(db).Delete(coremocks.TenantContext(), 1); err != tt.wantErr {
+				t.Errorf("postgresViewRepository.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
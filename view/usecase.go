@@ -0,0 +1,23 @@
+package view
+
+import (
+	"context"
+
+	"github.com/pratheeshm/todo-golang/models"
+)
+
+//Usecase represents saved view's usecases, they act for the user of the context
+type Usecase interface {
+	// Add stores the view owned by the user, core.ErrInvalidView is returned when it does not compile
+	Add(context.Context, *models.View) error
+	Get(context.Context, int) (*models.View, error)
+	// List returns the views of the user along with the ones shared with the project of the filter
+	List(context.Context, *models.ViewFilter) ([]*models.View, error)
+	// Edit replaces the view, core.ErrInvalidView is returned when it does not compile.
+	// Only the owner of the view or an admin can edit or delete it, core.ErrForbidden otherwise
+	Edit(context.Context, *models.View) error
+	Delete(context.Context, int) error
+	// Tasks runs the stored query of the view. When it no longer compiles
+	// core.ErrInvalidView is returned along with the view telling why
+	Tasks(ctx context.Context, id int) (*models.View, []*models.Task, error)
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/pratheeshm/todo-golang/access"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/filter"
+	"github.com/pratheeshm/todo-golang/models"
+	"github.com/pratheeshm/todo-golang/task"
+	"github.com/pratheeshm/todo-golang/view"
+)
+
+type viewUsecase struct {
+	viewRepo      view.Repository
+	taskUsecase   task.Usecase
+	accessUsecase access.Usecase
+}
+
+// NewViewUsecase will create new a viewUsecase object representation of view.Usecase interface.
+// Views belong to the user who saved them and are read by the readers of their project when
+// shared. Sharing a view takes a member of the project, only its owner or an admin changes it
+func NewViewUsecase(vr view.Repository, tu task.Usecase, au access.Usecase) view.Usecase {
+	return &viewUsecase{
+		viewRepo:      vr,
+		taskUsecase:   tu,
+		accessUsecase: au,
+	}
+}
+
+// visible tells whether the user reads the view: its own ones and the ones shared with a project it reads
+func visible(p *access.Policy, u *models.User, v *models.View) bool {
+	return v.OwnerID == u.ID || v.Project != "" && p.Can(access.ActionRead, v.Project, 0)
+}
+
+// allowSave checks that the user can store the view, a private one takes a reader
+// of the workspace and sharing it with a project takes a member of the project
+func allowSave(p *access.Policy, v *models.View) error {
+	action := access.ActionRead
+	if v.Project != "" {
+		action = access.ActionWrite
+	}
+	if !p.Can(action, v.Project, 0) {
+		return core.ErrForbidden
+	}
+	return nil
+}
+
+// change returns the stored view when the user of ctx may change it, being its owner or an admin
+func (vu *viewUsecase) change(ctx context.Context, id int) (*access.Policy, *models.View, error) {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	p, err := vu.accessUsecase.Policy(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	old, err := vu.viewRepo.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if old.OwnerID != u.ID && !p.Can(access.ActionManage, old.Project, 0) {
+		return nil, nil, core.ErrForbidden
+	}
+	return p, old, nil
+}
+
+// taskColumns returns the json names of the fields of models.Task, the columns a view can show
+func taskColumns() map[string]bool {
+	columns := map[string]bool{}
+	t := reflect.TypeOf(models.Task{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.SplitN(t.Field(i).Tag.Get("json"), ",", 2)[0]
+		if name != "" && name != "-" {
+			columns[name] = true
+		}
+	}
+	return columns
+}
+
+// problem tells why the view does not compile against the current task model, if it does not
+func problem(v *models.View) string {
+	if _, err := filter.Parse(v.Filter); err != nil {
+		return "filter: " + err.Error()
+	}
+	if _, err := filter.ParseSort(v.Sort); err != nil {
+		return "sort: " + err.Error()
+	}
+	known := taskColumns()
+	for _, c := range v.Columns {
+		if !known[c] {
+			return "columns: unknown column " + c
+		}
+	}
+	return ""
+}
+
+func (vu *viewUsecase) Add(ctx context.Context, v *models.View) error {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return err
+	}
+	p, err := vu.accessUsecase.Policy(ctx)
+	if err != nil {
+		return err
+	}
+	if err := allowSave(p, v); err != nil {
+		return err
+	}
+	if v.Problem = problem(v); v.Problem != "" {
+		return core.ErrInvalidView
+	}
+	v.OwnerID = u.ID
+	return vu.viewRepo.Add(ctx, v)
+}
+
+func (vu *viewUsecase) Get(ctx context.Context, id int) (*models.View, error) {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, err := vu.accessUsecase.Policy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v, err := vu.viewRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !visible(p, u, v) {
+		return nil, core.ErrForbidden
+	}
+	v.Problem = problem(v)
+	return v, nil
+}
+
+func (vu *viewUsecase) List(ctx context.Context, f *models.ViewFilter) ([]*models.View, error) {
+	u, err := core.RequireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, err := vu.accessUsecase.Policy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	f.OwnerID = u.ID
+	stored, err := vu.viewRepo.List(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]*models.View, 0, len(stored))
+	for _, v := range stored {
+		if !visible(p, u, v) {
+			continue
+		}
+		v.Problem = problem(v)
+		views = append(views, v)
+	}
+	return views, nil
+}
+
+func (vu *viewUsecase) Edit(ctx context.Context, v *models.View) error {
+	p, old, err := vu.change(ctx, v.ID)
+	if err != nil {
+		return err
+	}
+	if err := allowSave(p, v); err != nil {
+		return err
+	}
+	if v.Problem = problem(v); v.Problem != "" {
+		return core.ErrInvalidView
+	}
+	v.OwnerID = old.OwnerID
+	return vu.viewRepo.Edit(ctx, v)
+}
+
+func (vu *viewUsecase) Delete(ctx context.Context, id int) error {
+	if _, _, err := vu.change(ctx, id); err != nil {
+		return err
+	}
+	return vu.viewRepo.Delete(ctx, id)
+}
+
+func (vu *viewUsecase) Tasks(ctx context.Context, id int) (*models.View, []*models.Task, error) {
+	v, err := vu.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v.Problem != "" {
+		return v, nil, core.ErrInvalidView
+	}
+	// a shared view only lists the tasks of its project
+	tasks, err := vu.taskUsecase.List(ctx, &models.TaskFilter{Query: v.Filter, Sort: v.Sort, Project: v.Project})
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, tasks, nil
+}
@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	accessmocks "github.com/pratheeshm/todo-golang/access/mocks"
+	"github.com/pratheeshm/todo-golang/core"
+	"github.com/pratheeshm/todo-golang/models"
+	taskmocks "github.com/pratheeshm/todo-golang/task/mocks"
+	"github.com/pratheeshm/todo-golang/view/mocks"
+)
+
+func TestNewViewUsecase(t *testing.T) {
+	vr, tu, au := &mocks.MockRepository{}, &taskmocks.MockUsecase{}, &accessmocks.MockUsecase{}
+	want := &viewUsecase{viewRepo: vr, taskUsecase: tu, accessUsecase: au}
+	if got := NewViewUsecase(vr, tu, au); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewViewUsecase() = %v, want %v", got, want)
+	}
+}
+
+func Test_problem(t *testing.T) {
+	tests := []struct {
+		name string
+		view *models.View
+		want string
+	}{{
+		name: "Normal Case1: view compiles",
+		view: &models.View{Filter: "status:todo AND tag:bug", Sort: "-priority,due", Columns: []string{"id_task", "title", "due_date"}},
+	}, {
+		name: "filter no longer compiles",
+		view: &models.View{Filter: "assignee:bob"},
+		want: `filter: position 1: unknown field "assignee"`,
+	}, {
+		name: "unknown sort",
+		view: &models.View{Sort: "priority,-tag"},
+		want: `sort: position 10: can not sort on "-tag"`,
+	}, {
+		name: "column removed from the task model",
+		view: &models.View{Columns: []string{"title", "estimate"}},
+		want: "columns: unknown column estimate",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := problem(tt.view); got != tt.want {
+				t.Errorf("problem() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// views are the ones of the user of accessmocks.GrantedContext, view 2 no longer
+// compiles, view 3 is shared with web by user 5 and view 4 is private to user 5
+func views() []*models.View {
+	return []*models.View{
+		{ID: 1, Name: "my bugs", OwnerID: accessmocks.UserID, Filter: "tag:bug"},
+		{ID: 2, Name: "old", OwnerID: accessmocks.UserID, Filter: "colour:red"},
+		{ID: 3, Name: "release", OwnerID: 5, Project: "web", Filter: "tag:release"},
+		{ID: 4, Name: "private", OwnerID: 5},
+	}
+}
+
+func Test_viewUsecase_Add(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		ctx     context.Context
+		view    *models.View
+		wantErr error
+	}{
+		{name: "Normal Case1: view stored", role: models.RoleViewer, ctx: accessmocks.GrantedContext(),
+			view: &models.View{Name: "my bugs", Filter: "tag:bug"}},
+		{name: "owner comes from the context", role: models.RoleViewer, ctx: accessmocks.GrantedContext(),
+			view: &models.View{Name: "my bugs", OwnerID: 5}},
+		{name: "shared by a member", role: models.RoleMember, ctx: accessmocks.GrantedContext(),
+			view: &models.View{Name: "release", Project: "web"}},
+		{name: "viewer can not share", role: models.RoleViewer, ctx: accessmocks.GrantedContext(),
+			view: &models.View{Name: "release", Project: "web"}, wantErr: core.ErrForbidden},
+		{name: "outsider", ctx: accessmocks.GrantedContext(),
+			view: &models.View{Name: "my bugs"}, wantErr: core.ErrForbidden},
+		{name: "no user", role: models.RoleViewer, ctx: core.WithTenant(context.Background(), accessmocks.Workspace),
+			view: &models.View{Name: "my bugs"}, wantErr: core.ErrForbidden},
+		{name: "invalid filter", role: models.RoleViewer, ctx: accessmocks.GrantedContext(),
+			view: &models.View{Name: "my bugs", Filter: "tag:"}, wantErr: core.ErrInvalidView},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockRepository{}
+			vu := NewViewUsecase(repo, &taskmocks.MockUsecase{}, accessmocks.RoleUsecase(tt.role))
+			if err := vu.Add(tt.ctx, tt.view); err != tt.wantErr {
+				t.Fatalf("viewUsecase.Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			stored := len(repo.Views) == 1
+			if stored != (tt.wantErr == nil) {
+				t.Errorf("viewUsecase.Add() stored = %v", stored)
+			}
+			if stored && repo.Views[0].OwnerID != accessmocks.UserID {
+				t.Errorf("viewUsecase.Add() owner = %d, want %d", repo.Views[0].OwnerID, accessmocks.UserID)
+			}
+		})
+	}
+}
+
+func Test_viewUsecase_Get(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		id      int
+		wantErr error
+	}{
+		{"Normal Case1: own view", models.RoleViewer, 1, nil},
+		{"view shared with a project the user reads", models.RoleViewer, 3, nil},
+		{"private view of someone else", models.RoleViewer, 4, core.ErrForbidden},
+		{"shared view without access to the project", "", 3, core.ErrForbidden},
+		{"view not found", models.RoleViewer, 42, core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vu := NewViewUsecase(&mocks.MockRepository{Views: views()}, &taskmocks.MockUsecase{}, accessmocks.RoleUsecase(tt.role))
+			if _, err := vu.Get(accessmocks.GrantedContext(), tt.id); err != tt.wantErr {
+				t.Errorf("viewUsecase.Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_viewUsecase_Edit(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		view    *models.View
+		wantErr error
+	}{
+		{name: "Normal Case1: owner renames the view", role: models.RoleViewer,
+			view: &models.View{ID: 1, Name: "bugs"}},
+		{name: "admin edits the view of someone else", role: models.RoleAdmin,
+			view: &models.View{ID: 4, Name: "renamed"}},
+		{name: "member can not edit the view of someone else", role: models.RoleMember,
+			view: &models.View{ID: 3, Name: "renamed", Project: "web"}, wantErr: core.ErrForbidden},
+		{name: "viewer can not share its view", role: models.RoleViewer,
+			view: &models.View{ID: 1, Name: "bugs", Project: "web"}, wantErr: core.ErrForbidden},
+		{name: "view does not compile", role: models.RoleViewer,
+			view: &models.View{ID: 1, Name: "bugs", Sort: "colour"}, wantErr: core.ErrInvalidView},
+		{name: "view not found", role: models.RoleAdmin,
+			view: &models.View{ID: 42, Name: "bugs"}, wantErr: core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockRepository{Views: views()}
+			owner := 0
+			for _, v := range repo.Views {
+				if v.ID == tt.view.ID {
+					owner = v.OwnerID
+				}
+			}
+			vu := NewViewUsecase(repo, &taskmocks.MockUsecase{}, accessmocks.RoleUsecase(tt.role))
+			if err := vu.Edit(accessmocks.GrantedContext(), tt.view); err != tt.wantErr {
+				t.Fatalf("viewUsecase.Edit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && tt.view.OwnerID != owner {
+				t.Errorf("viewUsecase.Edit() owner = %d, want %d", tt.view.OwnerID, owner)
+			}
+		})
+	}
+}
+
+func Test_viewUsecase_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		id      int
+		wantErr error
+	}{
+		{"Normal Case1: owner deletes the view", models.RoleViewer, 1, nil},
+		{"admin deletes the view of someone else", models.RoleAdmin, 4, nil},
+		{"member can not delete the view of someone else", models.RoleMember, 3, core.ErrForbidden},
+		{"view not found", models.RoleAdmin, 42, core.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mocks.MockRepository{Views: views()}
+			vu := NewViewUsecase(repo, &taskmocks.MockUsecase{}, accessmocks.RoleUsecase(tt.role))
+			if err := vu.Delete(accessmocks.GrantedContext(), tt.id); err != tt.wantErr {
+				t.Fatalf("viewUsecase.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if deleted := len(repo.Views) == 3; deleted != (tt.wantErr == nil) {
+				t.Errorf("viewUsecase.Delete() deleted = %v", deleted)
+			}
+		})
+	}
+}
+
+func Test_viewUsecase_List(t *testing.T) {
+	repo := &mocks.MockRepository{Views: views()}
+	vu := NewViewUsecase(repo, &taskmocks.MockUsecase{}, accessmocks.RoleUsecase(models.RoleViewer))
+	f := &models.ViewFilter{Project: "web"}
+	got, err := vu.List(accessmocks.GrantedContext(), f)
+	if err != nil {
+		t.Fatalf("viewUsecase.List() error = %v", err)
+	}
+	if f.OwnerID != accessmocks.UserID {
+		t.Errorf("viewUsecase.List() owner = %d, want %d", f.OwnerID, accessmocks.UserID)
+	}
+	// the private view of user 5 is left out
+	if len(got) != 3 || got[0].Problem != "" || got[1].Problem == "" {
+		t.Errorf("viewUsecase.List() = %v", got)
+	}
+	if _, err := vu.List(core.WithTenant(context.Background(), accessmocks.Workspace), f); err != core.ErrForbidden {
+		t.Errorf("viewUsecase.List() without user error = %v", err)
+	}
+}
+
+func Test_viewUsecase_Tasks(t *testing.T) {
+	tasks := []*models.Task{{ID: 1, Title: "Fix login", Status: "todo", Tags: []string{"bug"}}}
+	tests := []struct {
+		name       string
+		tasks      *taskmocks.MockUsecase
+		id         int
+		want       []*models.Task
+		wantFilter *models.TaskFilter
+		wantErr    error
+	}{{
+		name:       "Normal Case1: tasks of the view",
+		tasks:      &taskmocks.MockUsecase{Tasks: tasks},
+		id:         1,
+		want:       tasks,
+		wantFilter: &models.TaskFilter{Query: "tag:bug"},
+	}, {
+		name:       "shared view lists the tasks of its project",
+		tasks:      &taskmocks.MockUsecase{Tasks: tasks},
+		id:         3,
+		want:       tasks,
+		wantFilter: &models.TaskFilter{Query: "tag:release", Project: "web"},
+	}, {
+		name:    "stored filter no longer compiles",
+		tasks:   &taskmocks.MockUsecase{Tasks: tasks},
+		id:      2,
+		wantErr: core.ErrInvalidView,
+	}, {
+		name:    "private view of someone else",
+		tasks:   &taskmocks.MockUsecase{Tasks: tasks},
+		id:      4,
+		wantErr: core.ErrForbidden,
+	}, {
+		name:    "view not found",
+		tasks:   &taskmocks.MockUsecase{Tasks: tasks},
+		id:      42,
+		wantErr: core.ErrRecordNotFound,
+	}, {
+		name:  "task usecase error",
+		tasks: &taskmocks.MockUsecase{Error: errors.New("db error")},
+		id:    1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vu := NewViewUsecase(&mocks.MockRepository{Views: views()}, tt.tasks, accessmocks.RoleUsecase(models.RoleViewer))
+			v, got, err := vu.Tasks(accessmocks.GrantedContext(), tt.id)
+			if tt.tasks.Error != nil {
+				if err != tt.tasks.Error {
+					t.Fatalf("viewUsecase.Tasks() error = %v, want %v", err, tt.tasks.Error)
+				}
+				return
+			}
+			if err != tt.wantErr {
+				t.Fatalf("viewUsecase.Tasks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == core.ErrInvalidView && (v == nil || v.Problem == "") {
+				t.Errorf("viewUsecase.Tasks() should tell why the view is invalid, got %v", v)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("viewUsecase.Tasks() = %v, want %v", got, tt.want)
+			}
+			if tt.wantFilter != nil && !reflect.DeepEqual(tt.tasks.Filter, tt.wantFilter) {
+				t.Errorf("viewUsecase.Tasks() filter = %+v, want %+v", tt.tasks.Filter, tt.wantFilter)
+			}
+		})
+	}
+}